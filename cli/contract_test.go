@@ -82,6 +82,40 @@ func TestCalcHash(t *testing.T) {
 	})
 }
 
+func TestGenerateRPCWrapper(t *testing.T) {
+	e := newExecutor(t, false)
+
+	manifestPath := "./testdata/verify.manifest.json"
+	outPath := path.Join(os.TempDir(), "neogo.rpcwrapper.go")
+	t.Cleanup(func() {
+		os.Remove(outPath)
+	})
+
+	cmd := []string{"neo-go", "contract", "generate-rpcwrapper"}
+	t.Run("no manifest", func(t *testing.T) {
+		e.RunWithError(t, cmd...)
+	})
+	t.Run("invalid manifest path", func(t *testing.T) {
+		e.RunWithError(t, append(cmd, "--manifest", "./testdata/verify.manifest.json123")...)
+	})
+	t.Run("invalid hash", func(t *testing.T) {
+		e.RunWithError(t, append(cmd, "--manifest", manifestPath, "--hash", "xxx")...)
+	})
+	t.Run("invalid package", func(t *testing.T) {
+		e.RunWithError(t, append(cmd, "--manifest", manifestPath, "--package", "func")...)
+	})
+
+	h := random.Uint160()
+	e.Run(t, append(cmd, "--manifest", manifestPath, "--hash", h.StringLE(), "--out", outPath)...)
+	data, err := ioutil.ReadFile(outPath)
+	require.NoError(t, err)
+	src := string(data)
+	require.True(t, strings.Contains(src, "package verify\n"))
+	require.True(t, strings.Contains(src, "func (c *Contract) Verify() (util.Uint256, error) {"))
+	require.True(t, strings.Contains(src, "func (c *Contract) VerifyTest() (*result.Invoke, error) {"))
+	require.True(t, strings.Contains(src, "func ParseHelloWorldEvent(ev *state.NotificationEvent) (*HelloWorldEvent, error) {"))
+}
+
 func TestContractInitAndCompile(t *testing.T) {
 	tmpDir := path.Join(os.TempDir(), "neogo.inittest")
 	require.NoError(t, os.Mkdir(tmpDir, os.ModePerm))
@@ -197,11 +231,19 @@ func TestComlileAndInvokeFunction(t *testing.T) {
 		"--rpc-endpoint", "http://"+e.RPC.Addr,
 		"--in", nefName, "--", address.Uint160ToString(util.Uint160{1, 2, 3}))
 
-	e.In.WriteString("one\r")
-	e.Run(t, "neo-go", "contract", "deploy",
-		"--rpc-endpoint", "http://"+e.RPC.Addr,
+	deployCmd := []string{"neo-go", "contract", "deploy",
+		"--rpc-endpoint", "http://" + e.RPC.Addr,
 		"--wallet", validatorWallet, "--address", validatorAddr,
-		"--in", nefName, "--manifest", manifestName)
+		"--in", nefName, "--manifest", manifestName}
+	t.Run("bad data", func(t *testing.T) {
+		e.RunWithError(t, append(deployCmd, "bad:data")...)
+	})
+	t.Run("several data parameters", func(t *testing.T) {
+		e.RunWithError(t, append(deployCmd, "1", "2")...)
+	})
+
+	e.In.WriteString("one\r")
+	e.Run(t, append(deployCmd, "[", "key1", "12", "]")...)
 
 	line, err := e.Out.ReadString('\n')
 	require.NoError(t, err)
@@ -460,6 +502,13 @@ func TestContractInspect(t *testing.T) {
 		e.Run(t, append(cmd, "--in", nefName)...)
 		require.True(t, strings.Contains(e.Out.String(), "SYSCALL"))
 	})
+	t.Run("with manifest", func(t *testing.T) {
+		e.RunWithError(t, append(cmd, "--in", nefName, "--manifest", path.Join(tmpDir, "not.exists"))...)
+		e.Run(t, append(cmd, "--in", nefName, "--manifest", manifestName)...)
+		out := e.Out.String()
+		require.True(t, strings.Contains(out, "getValue:"))
+		require.True(t, strings.Contains(out, "update:"))
+	})
 }
 
 func TestCompileExamples(t *testing.T) {
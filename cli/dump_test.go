@@ -62,4 +62,6 @@ func TestDBRestore(t *testing.T) {
 	d2, err := ioutil.ReadFile(dumpPath)
 	require.NoError(t, err)
 	require.Equal(t, d1, d2, "dumps differ")
+
+	e.Run(t, "neo-go", "db", "check", "--unittest", "--config-path", tmpDir)
 }
@@ -19,16 +19,18 @@ const validUntilBlockIncrement = 50
 func InitAndSave(tx *transaction.Transaction, acc *wallet.Account, filename string) error {
 	// avoid fast transaction expiration
 	tx.ValidUntilBlock += validUntilBlockIncrement
-	priv := acc.PrivateKey()
-	pub := priv.PublicKey()
-	sign := priv.Sign(tx.GetSignedPart())
 	scCtx := context.NewParameterContext("Neo.Core.ContractTransaction", tx.Network, tx)
-	h, err := address.StringToUint160(acc.Address)
-	if err != nil {
-		return fmt.Errorf("invalid address: %s", acc.Address)
-	}
-	if err := scCtx.AddSignature(h, acc.Contract, pub, sign); err != nil {
-		return fmt.Errorf("can't add signature: %w", err)
+	// Watch-only accounts can't sign, so the transaction is saved without
+	// signatures to be signed elsewhere.
+	if priv := acc.PrivateKey(); priv != nil {
+		h, err := address.StringToUint160(acc.Address)
+		if err != nil {
+			return fmt.Errorf("invalid address: %s", acc.Address)
+		}
+		sign := priv.Sign(tx.GetSignedPart())
+		if err := scCtx.AddSignature(h, acc.Contract, priv.PublicKey(), sign); err != nil {
+			return fmt.Errorf("can't add signature: %w", err)
+		}
 	}
 	return Save(scCtx, filename)
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"syscall"
 
 	"github.com/nspcc-dev/neo-go/cli/options"
 	"github.com/nspcc-dev/neo-go/pkg/config"
@@ -12,6 +13,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/chaindump"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/network"
 	"github.com/nspcc-dev/neo-go/pkg/network/metrics"
@@ -64,6 +66,38 @@ func NewCommands() []cli.Command {
 			Usage: "directory for storing JSON dumps",
 		},
 	)
+	var cfgSnapshotOutFlags = make([]cli.Flag, len(cfgFlags))
+	copy(cfgSnapshotOutFlags, cfgFlags)
+	cfgSnapshotOutFlags = append(cfgSnapshotOutFlags,
+		cli.StringFlag{
+			Name:  "out, o",
+			Usage: "Output file (stdout if not given)",
+		},
+	)
+	var cfgSnapshotInFlags = make([]cli.Flag, len(cfgFlags))
+	copy(cfgSnapshotInFlags, cfgFlags)
+	cfgSnapshotInFlags = append(cfgSnapshotInFlags,
+		cli.StringFlag{
+			Name:  "in, i",
+			Usage: "Input file (stdin if not given)",
+		},
+		cli.StringSliceFlag{
+			Name:  "state-validators",
+			Usage: "hex-encoded public keys of state validators designated at snapshot height (from a trusted source) to check state root signature against",
+		},
+		cli.BoolFlag{
+			Name:  "skip-root-verification",
+			Usage: "don't check state root signatures (only use with trusted snapshots)",
+		},
+	)
+	var cfgCheckFlags = make([]cli.Flag, len(cfgFlags))
+	copy(cfgCheckFlags, cfgFlags)
+	cfgCheckFlags = append(cfgCheckFlags,
+		cli.BoolFlag{
+			Name:  "repair",
+			Usage: "re-derive broken secondary indexes (transaction attributes index and transfer logs)",
+		},
+	)
 	return []cli.Command{
 		{
 			Name:   "node",
@@ -87,6 +121,30 @@ func NewCommands() []cli.Command {
 					Action: restoreDB,
 					Flags:  cfgCountInFlags,
 				},
+				{
+					Name:   "check",
+					Usage:  "check database integrity",
+					Action: checkDB,
+					Flags:  cfgCheckFlags,
+				},
+				{
+					Name:  "snapshot",
+					Usage: "state snapshot manipulations",
+					Subcommands: []cli.Command{
+						{
+							Name:   "create",
+							Usage:  "create snapshot of the current chain state",
+							Action: createSnapshot,
+							Flags:  cfgSnapshotOutFlags,
+						},
+						{
+							Name:   "apply",
+							Usage:  "apply state snapshot to an empty database",
+							Action: applySnapshot,
+							Flags:  cfgSnapshotInFlags,
+						},
+					},
+				},
 			},
 		},
 	}
@@ -295,6 +353,124 @@ func restoreDB(ctx *cli.Context) error {
 	return nil
 }
 
+func checkDB(ctx *cli.Context) error {
+	cfg, err := getConfigFromContext(ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	log, err := handleLoggingParams(ctx, cfg.ApplicationConfiguration)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	chain, prometheus, pprof, err := initBCWithMetrics(cfg, log)
+	if err != nil {
+		return err
+	}
+	defer chain.Close()
+	defer prometheus.ShutDown()
+	defer pprof.ShutDown()
+
+	problems, err := chain.CheckIntegrity(ctx.Bool("repair"))
+	var broken int
+	for _, p := range problems {
+		fmt.Fprintln(ctx.App.Writer, p)
+		if !p.Repaired {
+			broken++
+		}
+	}
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("can't check database: %w", err), 1)
+	}
+	if broken != 0 {
+		return cli.NewExitError(fmt.Errorf("%d problems found", broken), 1)
+	}
+	fmt.Fprintf(ctx.App.Writer, "database is consistent up to block %d\n", chain.BlockHeight())
+	return nil
+}
+
+func createSnapshot(ctx *cli.Context) error {
+	cfg, err := getConfigFromContext(ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	log, err := handleLoggingParams(ctx, cfg.ApplicationConfiguration)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	var outStream = os.Stdout
+	if out := ctx.String("out"); out != "" {
+		outStream, err = os.Create(out)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+	}
+	defer outStream.Close()
+	writer := io.NewBinWriterFromIO(outStream)
+
+	chain, prometheus, pprof, err := initBCWithMetrics(cfg, log)
+	if err != nil {
+		return err
+	}
+	defer chain.Close()
+	defer prometheus.ShutDown()
+	defer pprof.ShutDown()
+
+	if err := chain.CreateStateSnapshot(writer); err != nil {
+		return cli.NewExitError(fmt.Errorf("can't create snapshot: %w", err), 1)
+	}
+	return nil
+}
+
+func applySnapshot(ctx *cli.Context) error {
+	cfg, err := getConfigFromContext(ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	log, err := handleLoggingParams(ctx, cfg.ApplicationConfiguration)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	var validators keys.PublicKeys
+	if !ctx.Bool("skip-root-verification") {
+		for _, s := range ctx.StringSlice("state-validators") {
+			pub, err := keys.NewPublicKeyFromString(s)
+			if err != nil {
+				return cli.NewExitError(fmt.Errorf("invalid state validator key %s: %w", s, err), 1)
+			}
+			validators = append(validators, pub)
+		}
+		if len(validators) == 0 {
+			return cli.NewExitError("state validators must be given unless root verification is skipped", 1)
+		}
+	}
+
+	var inStream = os.Stdin
+	if in := ctx.String("in"); in != "" {
+		inStream, err = os.Open(in)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+	}
+	defer inStream.Close()
+	reader := io.NewBinReaderFromIO(inStream)
+
+	store, err := storage.NewStore(cfg.ApplicationConfiguration.DBConfiguration)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("could not initialize storage: %w", err), 1)
+	}
+	defer store.Close()
+
+	height, err := core.ApplyStateSnapshot(store, cfg.ProtocolConfiguration, reader, validators)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("can't apply snapshot: %w", err), 1)
+	}
+	log.Info("state snapshot applied", zap.Uint32("height", height))
+	return nil
+}
+
 func startServer(ctx *cli.Context) error {
 	cfg, err := getConfigFromContext(ctx)
 	if err != nil {
@@ -329,6 +505,10 @@ func startServer(ctx *cli.Context) error {
 	fmt.Fprintln(ctx.App.Writer, serv.UserAgent)
 	fmt.Fprintln(ctx.App.Writer)
 
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
 	var shutdownErr error
 Main:
 	for {
@@ -337,6 +517,11 @@ Main:
 			shutdownErr = fmt.Errorf("server error: %w", err)
 			cancel()
 
+		case <-hup:
+			if err := rpcServer.ReloadTLS(); err != nil {
+				log.Warn("failed to reload TLS certificate", zap.Error(err))
+			}
+
 		case <-grace.Done():
 			serv.Shutdown()
 			if serverErr := rpcServer.Shutdown(); serverErr != nil {
@@ -0,0 +1,87 @@
+package smartcontract
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/nspcc-dev/neo-go/cli/flags"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/binding"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/urfave/cli"
+)
+
+var generateWrapperCmd = cli.Command{
+	Name:   "generate-rpcwrapper",
+	Usage:  "generate RPC wrapper to use for data reads and transaction submission",
+	Action: contractGenerateWrapper,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "manifest, m",
+			Usage: "Read contract manifest (*.manifest.json) file",
+		},
+		cli.StringFlag{
+			Name:  "out, o",
+			Usage: "Output of the generated wrapper (stdout if not specified)",
+		},
+		cli.StringFlag{
+			Name:  "hash",
+			Usage: "Smart-contract hash (address or Uint160 in LE form)",
+		},
+		cli.StringFlag{
+			Name:  "package",
+			Usage: "Package name of the generated wrapper (lowercased contract name by default)",
+		},
+	},
+}
+
+// contractGenerateWrapper generates typed Go wrapper for the contract described
+// by the given manifest.
+func contractGenerateWrapper(ctx *cli.Context) error {
+	mpath := ctx.String("manifest")
+	if mpath == "" {
+		return cli.NewExitError(errors.New("no manifest file provided"), 1)
+	}
+	manifestBytes, err := ioutil.ReadFile(mpath)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to read manifest file: %w", err), 1)
+	}
+	m := &manifest.Manifest{}
+	err = json.Unmarshal(manifestBytes, m)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to restore manifest file: %w", err), 1)
+	}
+
+	var h util.Uint160
+	if s := ctx.String("hash"); s != "" {
+		h, err = flags.ParseAddress(s)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("invalid contract hash: %w", err), 1)
+		}
+	}
+
+	var w io.Writer = ctx.App.Writer
+	if out := ctx.String("out"); out != "" {
+		f, err := os.Create(out)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("can't create output file: %w", err), 1)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	err = binding.Generate(binding.Config{
+		Manifest: m,
+		Hash:     h,
+		Package:  ctx.String("package"),
+		Output:   w,
+	})
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("error during generation: %w", err), 1)
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package smartcontract
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/cli/flags"
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/urfave/cli"
+)
+
+// contractCall is a call of other contract's method found in the script.
+type contractCall struct {
+	// Offset is an offset of the SYSCALL or CALLT instruction.
+	Offset int
+	// Hash is the called contract hash, it's nil if it can't be determined
+	// statically.
+	Hash *util.Uint160
+	// Method is the called method name, it's empty if it can't be
+	// determined statically.
+	Method string
+}
+
+type instruction struct {
+	op    opcode.Opcode
+	param []byte
+}
+
+// findContractCalls returns all System.Contract.Call invocations and method
+// token calls of the given NEF file. Call targets are only resolved if they're
+// pushed as constants in the way emit package (hash and method right before the
+// syscall) or compiler (hash, method and flags followed by arguments and
+// REVERSE4) do it.
+func findContractCalls(nf *nef.File) ([]contractCall, error) {
+	var (
+		calls   []contractCall
+		history []instruction
+		ctx     = vm.NewContext(nf.Script)
+		callID  = interopnames.ToID([]byte(interopnames.SystemContractCall))
+	)
+	for ctx.NextIP() < len(nf.Script) {
+		op, param, err := ctx.Next()
+		if err != nil {
+			return nil, fmt.Errorf("at %d: %w", ctx.IP(), err)
+		}
+		switch op {
+		case opcode.SYSCALL:
+			if binary.LittleEndian.Uint32(param) == callID {
+				call := contractCall{Offset: ctx.IP()}
+				resolveCallTarget(&call, history)
+				calls = append(calls, call)
+			}
+			history = history[:0]
+		case opcode.CALLT:
+			i := int(binary.LittleEndian.Uint16(param))
+			if i >= len(nf.Tokens) {
+				return nil, fmt.Errorf("at %d: invalid method token index %d", ctx.IP(), i)
+			}
+			h := nf.Tokens[i].Hash
+			calls = append(calls, contractCall{
+				Offset: ctx.IP(),
+				Hash:   &h,
+				Method: nf.Tokens[i].Method,
+			})
+			history = history[:0]
+		default:
+			history = append(history, instruction{op: op, param: param})
+		}
+	}
+	return calls, nil
+}
+
+// resolveCallTarget fills call hash and method using instructions preceding
+// System.Contract.Call syscall.
+func resolveCallTarget(call *contractCall, history []instruction) {
+	n := len(history)
+	if n >= 2 && isHashPush(history[n-1]) && isDataPush(history[n-2]) {
+		h, _ := util.Uint160DecodeBytesBE(history[n-1].param)
+		call.Hash, call.Method = &h, string(history[n-2].param)
+		return
+	}
+	if n == 0 || history[n-1].op != opcode.REVERSE4 {
+		return
+	}
+	for i := 0; i+2 < n-1; i++ {
+		if isHashPush(history[i]) && isDataPush(history[i+1]) && isIntPush(history[i+2]) {
+			h, _ := util.Uint160DecodeBytesBE(history[i].param)
+			call.Hash, call.Method = &h, string(history[i+1].param)
+			return
+		}
+	}
+}
+
+func isDataPush(i instruction) bool {
+	return i.op == opcode.PUSHDATA1 || i.op == opcode.PUSHDATA2 || i.op == opcode.PUSHDATA4
+}
+
+func isHashPush(i instruction) bool {
+	return i.op == opcode.PUSHDATA1 && len(i.param) == util.Uint160Size
+}
+
+func isIntPush(i instruction) bool {
+	return i.op <= opcode.PUSHINT256 || opcode.PUSH0 <= i.op && i.op <= opcode.PUSH16
+}
+
+func checkPermissions(ctx *cli.Context) error {
+	args := ctx.Args()
+	if !args.Present() {
+		return cli.NewExitError(errNoScriptHash, 1)
+	}
+	h, err := flags.ParseAddress(args[0])
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("incorrect script hash: %w", err), 1)
+	}
+
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+
+	c, err := options.GetRPCClient(gctx, ctx)
+	if err != nil {
+		return err
+	}
+	cs, err := c.GetContractStateByHash(h)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to get contract state: %w", err), 1)
+	}
+	calls, err := findContractCalls(&cs.NEF)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to parse contract script: %w", err), 1)
+	}
+
+	var (
+		denied    int
+		manifests = make(map[util.Uint160]*manifest.Manifest)
+	)
+	for _, call := range calls {
+		fmt.Fprintf(ctx.App.Writer, "%d: ", call.Offset)
+		if call.Hash == nil {
+			fmt.Fprintln(ctx.App.Writer, "dynamic call, can't be checked")
+			continue
+		}
+		fmt.Fprintf(ctx.App.Writer, "%s.%s: ", call.Hash.StringLE(), call.Method)
+		m, ok := manifests[*call.Hash]
+		if !ok {
+			target, err := c.GetContractStateByHash(*call.Hash)
+			if err == nil {
+				m = &target.Manifest
+			}
+			manifests[*call.Hash] = m
+		}
+		switch {
+		case m == nil:
+			fmt.Fprintln(ctx.App.Writer, "target contract is not deployed")
+			denied++
+		case call.Method == "":
+			fmt.Fprintln(ctx.App.Writer, "dynamic method, can't be checked")
+		case m.ABI.GetMethod(call.Method, -1) == nil:
+			fmt.Fprintln(ctx.App.Writer, "target contract has no such method")
+			denied++
+		case !cs.Manifest.CanCall(*call.Hash, m, call.Method):
+			fmt.Fprintln(ctx.App.Writer, "DENIED by manifest permissions")
+			denied++
+		default:
+			fmt.Fprintln(ctx.App.Writer, "OK")
+		}
+	}
+	if denied != 0 {
+		return cli.NewExitError(fmt.Errorf("%d of %d calls would fail at runtime", denied, len(calls)), 1)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package smartcontract
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindContractCalls(t *testing.T) {
+	h1 := util.Uint160{1, 2, 3}
+	h2 := util.Uint160{4, 5, 6}
+
+	w := io.NewBufBinWriter()
+	emit.AppCall(w.BinWriter, h1, "transfer", callflag.All, 1, 2)
+	emit.Opcodes(w.BinWriter, opcode.DROP)
+	emit.Instruction(w.BinWriter, opcode.CALLT, []byte{0, 0})
+	// Compiler-style call.
+	emit.Bytes(w.BinWriter, h2.BytesBE())
+	emit.String(w.BinWriter, "symbol")
+	emit.Int(w.BinWriter, int64(callflag.ReadStates))
+	emit.Opcodes(w.BinWriter, opcode.PUSH1, opcode.PUSH1, opcode.PACK, opcode.REVERSE4)
+	emit.Syscall(w.BinWriter, interopnames.SystemContractCall)
+	// Dynamic call target.
+	emit.Opcodes(w.BinWriter, opcode.NEWARRAY0)
+	emit.Int(w.BinWriter, int64(callflag.All))
+	emit.String(w.BinWriter, "method")
+	emit.Opcodes(w.BinWriter, opcode.LDARG0)
+	emit.Syscall(w.BinWriter, interopnames.SystemContractCall)
+	emit.Syscall(w.BinWriter, interopnames.SystemRuntimeGetTime)
+	require.NoError(t, w.Err)
+	script := w.Bytes()
+
+	nf := &nef.File{
+		Tokens: []nef.MethodToken{{Hash: h2, Method: "balanceOf"}},
+		Script: script,
+	}
+	calls, err := findContractCalls(nf)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(calls))
+	require.Equal(t, h1, *calls[0].Hash)
+	require.Equal(t, "transfer", calls[0].Method)
+	require.Equal(t, opcode.SYSCALL, opcode.Opcode(script[calls[0].Offset]))
+	require.Equal(t, h2, *calls[1].Hash)
+	require.Equal(t, "balanceOf", calls[1].Method)
+	require.Equal(t, opcode.CALLT, opcode.Opcode(script[calls[1].Offset]))
+	require.Equal(t, h2, *calls[2].Hash)
+	require.Equal(t, "symbol", calls[2].Method)
+	require.Nil(t, calls[3].Hash)
+
+	t.Run("invalid token", func(t *testing.T) {
+		nf.Tokens = nil
+		_, err := findContractCalls(nf)
+		require.Error(t, err)
+	})
+}
@@ -16,21 +16,17 @@ import (
 	"github.com/nspcc-dev/neo-go/cli/options"
 	"github.com/nspcc-dev/neo-go/cli/paramcontext"
 	"github.com/nspcc-dev/neo-go/pkg/compiler"
-	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
-	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/client"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
-	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
-	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/urfave/cli"
 	"gopkg.in/yaml.v2"
@@ -168,10 +164,14 @@ func NewCommands() []cli.Command {
 				},
 			},
 			{
-				Name:  "deploy",
-				Usage: "deploy a smart contract (.nef with description)",
+				Name:      "deploy",
+				Usage:     "deploy a smart contract (.nef with description)",
+				UsageText: "neo-go contract deploy -r endpoint -w wallet [-a address] [-g gas] --in contract.nef --manifest contract.manifest.json [data]",
 				Description: `Deploys given contract into the chain. The gas parameter is for additional
-   gas to be added as a network fee to prioritize the transaction.
+   gas to be added as a network fee to prioritize the transaction. The data
+   parameter is optional, if given it's passed to contract's _deploy method.
+   It's specified the same way as testinvokefunction arguments are, arrays
+   are also supported.
 `,
 				Action: contractDeploy,
 				Flags:  deployFlags,
@@ -340,8 +340,26 @@ func NewCommands() []cli.Command {
 						Name:  "in, i",
 						Usage: "input file of the program (either .go or .nef)",
 					},
+					cli.StringFlag{
+						Name:  "manifest, m",
+						Usage: "manifest file to split the dump into methods (not needed with --compile)",
+					},
 				},
 			},
+			{
+				Name:      "check-permissions",
+				Usage:     "check deployed contract calls against its manifest permissions",
+				UsageText: "neo-go contract check-permissions -r endpoint scripthash",
+				Description: `Finds all calls of other contracts made by the given deployed contract
+   (System.Contract.Call syscalls with constant targets and method tokens) and
+   checks whether they're allowed by contract manifest permissions and whether
+   called contracts and methods exist. Calls with targets computed at runtime
+   can't be checked and are only listed. Command fails if any of the calls
+   would be denied.
+`,
+				Action: checkPermissions,
+				Flags:  options.RPC,
+			},
 			{
 				Name:   "calc-hash",
 				Usage:  "calculates hash of a contract after deployment",
@@ -361,6 +379,7 @@ func NewCommands() []cli.Command {
 					},
 				},
 			},
+			generateWrapperCmd,
 		},
 	}}
 }
@@ -669,6 +688,20 @@ func parseParams(args []string, calledFromMain bool) (int, []smartcontract.Param
 
 }
 
+// paramToValue converts parsed parameter into a value that can be emitted into
+// a script.
+func paramToValue(p smartcontract.Parameter) interface{} {
+	if p.Type != smartcontract.ArrayType {
+		return p.Value
+	}
+	params := p.Value.([]smartcontract.Parameter)
+	res := make([]interface{}, len(params))
+	for i := range params {
+		res[i] = paramToValue(params[i])
+	}
+	return res
+}
+
 func testInvokeScript(ctx *cli.Context) error {
 	src := ctx.String("in")
 	if len(src) == 0 {
@@ -735,13 +768,19 @@ func inspect(ctx *cli.Context) error {
 	}
 	var (
 		b   []byte
+		m   *manifest.Manifest
 		err error
 	)
 	if compile {
-		b, err = compiler.Compile(in, nil)
+		var di *compiler.DebugInfo
+		b, di, err = compiler.CompileWithDebugInfo(in, nil)
 		if err != nil {
 			return cli.NewExitError(fmt.Errorf("failed to compile: %w", err), 1)
 		}
+		m, err = di.ConvertToManifest(&compiler.Options{})
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to create manifest: %w", err), 1)
+		}
 	} else {
 		f, err := ioutil.ReadFile(in)
 		if err != nil {
@@ -753,9 +792,26 @@ func inspect(ctx *cli.Context) error {
 		}
 		b = nefFile.Script
 	}
+	if mpath := ctx.String("manifest"); mpath != "" {
+		manifestBytes, err := ioutil.ReadFile(mpath)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to read manifest file: %w", err), 1)
+		}
+		m = new(manifest.Manifest)
+		if err := json.Unmarshal(manifestBytes, m); err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to restore manifest file: %w", err), 1)
+		}
+	}
+	var methods map[int]string
+	if m != nil {
+		methods = make(map[int]string, len(m.ABI.Methods))
+		for _, md := range m.ABI.Methods {
+			methods[md.Offset] = md.Name
+		}
+	}
 	v := vm.New()
 	v.LoadScript(b)
-	v.PrintOps(ctx.App.Writer)
+	v.PrintOpsWithMethods(ctx.App.Writer, methods)
 
 	return nil
 }
@@ -808,14 +864,22 @@ func contractDeploy(ctx *cli.Context) error {
 	}
 	gas := flags.Fixed8FromContext(ctx, "gas")
 
+	var data interface{}
+	if ctx.Args().Present() {
+		_, params, err := parseParams(ctx.Args(), true)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("unable to parse 'data' parameter: %w", err), 1)
+		}
+		if len(params) != 1 {
+			return cli.NewExitError("'data' should be represented as a single parameter", 1)
+		}
+		data = paramToValue(params[0])
+	}
+
 	acc, _, err := getAccFromContext(ctx)
 	if err != nil {
 		return err
 	}
-	sender, err := address.StringToUint160(acc.Address)
-	if err != nil {
-		return cli.NewExitError(err, 1)
-	}
 	f, err := ioutil.ReadFile(in)
 	if err != nil {
 		return cli.NewExitError(err, 1)
@@ -844,32 +908,10 @@ func contractDeploy(ctx *cli.Context) error {
 		return err
 	}
 
-	mgmtHash, err := c.GetNativeContractHash(nativenames.Management)
-	if err != nil {
-		return cli.NewExitError(fmt.Errorf("failed to get management contract's hash: %w", err), 1)
-	}
-	buf := io.NewBufBinWriter()
-	emit.AppCall(buf.BinWriter, mgmtHash, "deploy",
-		callflag.ReadStates|callflag.WriteStates|callflag.AllowNotify,
-		f, manifestBytes)
-	if buf.Err != nil {
-		return cli.NewExitError(fmt.Errorf("failed to create deployment script: %w", buf.Err), 1)
-	}
-	txScript := buf.Bytes()
-	// It doesn't require any signers.
-	invRes, err := c.InvokeScript(txScript, nil)
-	if err == nil && invRes.FaultException != "" {
-		err = errors.New(invRes.FaultException)
-	}
-	if err != nil {
-		return cli.NewExitError(fmt.Errorf("failed to test-invoke deployment script: %w", err), 1)
-	}
-
-	txHash, err := c.SignAndPushInvocationTx(txScript, acc, invRes.GasConsumed, gas, nil)
+	hash, txHash, err := c.DeployContract(acc, &nefFile, m, data, int64(gas))
 	if err != nil {
-		return cli.NewExitError(fmt.Errorf("failed to push invocation tx: %w", err), 1)
+		return cli.NewExitError(fmt.Errorf("failed to deploy contract: %w", err), 1)
 	}
-	hash := state.CreateContractHash(sender, nefFile.Checksum, m.Name)
 	fmt.Fprintf(ctx.App.Writer, "Contract: %s\n", hash.StringLE())
 	fmt.Fprintln(ctx.App.Writer, txHash.StringLE())
 	return nil
@@ -111,7 +111,7 @@ func newNEP17Commands() []cli.Command {
 		{
 			Name:      "transfer",
 			Usage:     "transfer NEP17 tokens",
-			UsageText: "transfer --wallet <path> --rpc-endpoint <node> --timeout <time> --from <addr> --to <addr> --token <hash> --amount string",
+			UsageText: "transfer --wallet <path> --rpc-endpoint <node> --timeout <time> --from <addr> --to <addr-or-name> --token <hash> --amount string",
 			Action:    transferNEP17,
 			Flags:     transferFlags,
 		},
@@ -363,7 +363,7 @@ func multiTransferNEP17(ctx *cli.Context) error {
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
-	acc, err := getDecryptedAccount(ctx, wall, from)
+	acc, err := getTransferAccount(ctx, wall, from)
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
@@ -399,9 +399,9 @@ func multiTransferNEP17(ctx *cli.Context) error {
 			}
 		}
 		cache[ss[0]] = token
-		addr, err := address.StringToUint160(ss[1])
+		addr, err := resolveAddress(c, ss[1])
 		if err != nil {
-			return cli.NewExitError(fmt.Errorf("invalid address: '%s'", ss[1]), 1)
+			return cli.NewExitError(fmt.Errorf("invalid address: %w", err), 1)
 		}
 		amount, err := fixedn.FromString(ss[2], int(token.Decimals))
 		if err != nil {
@@ -429,7 +429,7 @@ func transferNEP17(ctx *cli.Context) error {
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
-	acc, err := getDecryptedAccount(ctx, wall, from)
+	acc, err := getTransferAccount(ctx, wall, from)
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
@@ -442,8 +442,10 @@ func transferNEP17(ctx *cli.Context) error {
 		return cli.NewExitError(err, 1)
 	}
 
-	toFlag := ctx.Generic("to").(*flags.Address)
-	to := toFlag.Uint160()
+	to, err := resolveAddress(c, ctx.String("to"))
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("invalid address: %w", err), 1)
+	}
 	token, err := getMatchingToken(ctx, wall, ctx.String("token"))
 	if err != nil {
 		fmt.Fprintln(ctx.App.ErrWriter, "Can't find matching token in the wallet. Querying RPC-node for balances.")
@@ -465,6 +467,29 @@ func transferNEP17(ctx *cli.Context) error {
 	}})
 }
 
+// getTransferAccount returns an account to transfer assets from. Watch-only
+// accounts can only be used when transaction is saved to file (to be signed
+// elsewhere), other accounts are decrypted.
+func getTransferAccount(ctx *cli.Context, wall *wallet.Wallet, from util.Uint160) (*wallet.Account, error) {
+	acc := wall.GetAccount(from)
+	if acc != nil && acc.IsWatchOnly() {
+		if ctx.String("out") == "" {
+			return nil, fmt.Errorf("account %s is watch-only, use --out to save unsigned transaction", acc.Address)
+		}
+		return acc, nil
+	}
+	return getDecryptedAccount(ctx, wall, from)
+}
+
+// resolveAddress parses the given string as an address or script hash and
+// falls back to NameService resolution if it's neither of them.
+func resolveAddress(c *client.Client, s string) (util.Uint160, error) {
+	if u, err := flags.ParseAddress(s); err == nil {
+		return u, nil
+	}
+	return c.ResolveAddress(s)
+}
+
 func signAndSendTransfer(ctx *cli.Context, c *client.Client, acc *wallet.Account, recipients []client.TransferTarget) error {
 	gas := flags.Fixed8FromContext(ctx, "gas")
 
@@ -6,16 +6,10 @@ import (
 	"github.com/nspcc-dev/neo-go/cli/flags"
 	"github.com/nspcc-dev/neo-go/cli/input"
 	"github.com/nspcc-dev/neo-go/cli/options"
-	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
-	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
-	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/client"
-	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/util"
-	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
-	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/urfave/cli"
 )
@@ -72,14 +66,14 @@ func newValidatorCommands() []cli.Command {
 }
 
 func handleRegister(ctx *cli.Context) error {
-	return handleCandidate(ctx, "registerCandidate", 1001*100000000) // registering costs 1000 GAS
+	return handleCandidate(ctx, (*client.Client).RegisterCandidate)
 }
 
 func handleUnregister(ctx *cli.Context) error {
-	return handleCandidate(ctx, "unregisterCandidate", -1)
+	return handleCandidate(ctx, (*client.Client).UnregisterCandidate)
 }
 
-func handleCandidate(ctx *cli.Context, method string, sysGas int64) error {
+func handleCandidate(ctx *cli.Context, send func(*client.Client, *wallet.Account, int64) (util.Uint256, error)) error {
 	wall, err := openWallet(ctx.String("wallet"))
 	if err != nil {
 		return cli.NewExitError(err, 1)
@@ -102,28 +96,7 @@ func handleCandidate(ctx *cli.Context, method string, sysGas int64) error {
 	}
 
 	gas := flags.Fixed8FromContext(ctx, "gas")
-	neoContractHash, err := c.GetNativeContractHash(nativenames.Neo)
-	if err != nil {
-		return err
-	}
-	w := io.NewBufBinWriter()
-	emit.AppCall(w.BinWriter, neoContractHash, method, callflag.States, acc.PrivateKey().PublicKey().Bytes())
-	emit.Opcodes(w.BinWriter, opcode.ASSERT)
-	tx, err := c.CreateTxFromScript(w.Bytes(), acc, sysGas, int64(gas), []client.SignerAccount{{
-		Signer: transaction.Signer{
-			Account: acc.Contract.ScriptHash(),
-			Scopes:  transaction.CalledByEntry,
-		},
-		Account: acc,
-	},
-	})
-	if err != nil {
-		return cli.NewExitError(err, 1)
-	} else if err = acc.SignTx(tx); err != nil {
-		return cli.NewExitError(fmt.Errorf("can't sign tx: %v", err), 1)
-	}
-
-	res, err := c.SendRawTransaction(tx)
+	res, err := send(c, acc, int64(gas))
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
@@ -162,36 +135,8 @@ func handleVote(ctx *cli.Context) error {
 		return cli.NewExitError(err, 1)
 	}
 
-	var pubArg interface{}
-	if pub != nil {
-		pubArg = pub.Bytes()
-	}
-
 	gas := flags.Fixed8FromContext(ctx, "gas")
-	neoContractHash, err := c.GetNativeContractHash(nativenames.Neo)
-	if err != nil {
-		return cli.NewExitError(err, 1)
-	}
-	w := io.NewBufBinWriter()
-	emit.AppCall(w.BinWriter, neoContractHash, "vote", callflag.States, addr.BytesBE(), pubArg)
-	emit.Opcodes(w.BinWriter, opcode.ASSERT)
-
-	tx, err := c.CreateTxFromScript(w.Bytes(), acc, -1, int64(gas), []client.SignerAccount{{
-		Signer: transaction.Signer{
-			Account: acc.Contract.ScriptHash(),
-			Scopes:  transaction.CalledByEntry,
-		},
-		Account: acc,
-	}})
-	if err != nil {
-		return cli.NewExitError(err, 1)
-	}
-
-	if err = acc.SignTx(tx); err != nil {
-		return cli.NewExitError(fmt.Errorf("can't sign tx: %v", err), 1)
-	}
-
-	res, err := c.SendRawTransaction(tx)
+	res, err := c.Vote(acc, pub, int64(gas))
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
@@ -204,6 +149,9 @@ func getDecryptedAccount(ctx *cli.Context, wall *wallet.Wallet, addr util.Uint16
 	if acc == nil {
 		return nil, fmt.Errorf("can't find account for the address: %s", address.Uint160ToString(addr))
 	}
+	if acc.IsWatchOnly() {
+		return nil, fmt.Errorf("account %s is watch-only", acc.Address)
+	}
 
 	if pass, err := input.ReadPassword("Password > "); err != nil {
 		fmt.Println("ERROR", pass, err)
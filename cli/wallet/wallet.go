@@ -10,7 +10,6 @@ import (
 	"github.com/nspcc-dev/neo-go/cli/flags"
 	"github.com/nspcc-dev/neo-go/cli/input"
 	"github.com/nspcc-dev/neo-go/cli/options"
-	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
@@ -50,9 +49,9 @@ var (
 		Name:  "from",
 		Usage: "Address to send an asset from",
 	}
-	toAddrFlag = flags.AddressFlag{
+	toAddrFlag = cli.StringFlag{
 		Name:  "to",
-		Usage: "Address to send an asset to",
+		Usage: "Address or NameService name to send an asset to",
 	}
 	forceFlag = cli.BoolFlag{
 		Name:  "force",
@@ -189,6 +188,19 @@ func NewCommands() []cli.Command {
 					},
 				},
 			},
+			{
+				Name:      "import-watchonly",
+				Usage:     "import watch-only account",
+				UsageText: "import-watchonly --wallet <path> [--name <name>] <address|pubkey>",
+				Action:    importWatchOnly,
+				Flags: []cli.Flag{
+					walletPathFlag,
+					cli.StringFlag{
+						Name:  "name, n",
+						Usage: "Optional account name",
+					},
+				},
+			},
 			{
 				Name:      "import-deployed",
 				Usage:     "import deployed contract",
@@ -259,11 +271,7 @@ func claimGas(ctx *cli.Context) error {
 		return cli.NewExitError(err, 1)
 	}
 
-	neoContractHash, err := c.GetNativeContractHash(nativenames.Neo)
-	if err != nil {
-		return cli.NewExitError(err, 1)
-	}
-	hash, err := c.TransferNEP17(acc, scriptHash, neoContractHash, 0, 0, nil)
+	hash, err := c.ClaimGas(acc, 0)
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
@@ -340,7 +348,7 @@ func exportKeys(ctx *cli.Context) error {
 
 loop:
 	for _, a := range wall.Accounts {
-		if addr != "" && a.Address != addr {
+		if (addr != "" && a.Address != addr) || a.EncryptedWIF == "" {
 			continue
 		}
 
@@ -496,6 +504,37 @@ func importWallet(ctx *cli.Context) error {
 	return nil
 }
 
+// importWatchOnly adds watch-only account for the given address or public key
+// to the wallet. Only accounts imported with public key have verification
+// script, so they can be used to create unsigned transactions.
+func importWatchOnly(ctx *cli.Context) error {
+	wall, err := openWallet(ctx.String("wallet"))
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	defer wall.Close()
+
+	if ctx.NArg() != 1 {
+		return cli.NewExitError("address or public key must be provided", 1)
+	}
+	var acc *wallet.Account
+	arg := ctx.Args().First()
+	if pub, err := keys.NewPublicKeyFromString(arg); err == nil {
+		acc = wallet.NewWatchOnlyAccountFromPublicKey(pub)
+	} else {
+		h, err := flags.ParseAddress(arg)
+		if err != nil {
+			return cli.NewExitError("invalid address or public key", 1)
+		}
+		acc = wallet.NewWatchOnlyAccount(h)
+	}
+	acc.Label = ctx.String("name")
+	if err := addAccountAndSave(wall, acc); err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	return nil
+}
+
 func removeAccount(ctx *cli.Context) error {
 	wall, err := openWallet(ctx.String("wallet"))
 	if err != nil {
@@ -551,6 +590,9 @@ func dumpWallet(ctx *cli.Context) error {
 			return cli.NewExitError(err, 1)
 		}
 		for i := range wall.Accounts {
+			if wall.Accounts[i].IsWatchOnly() {
+				continue
+			}
 			// Just testing the decryption here.
 			err := wall.Accounts[i].Decrypt(pass)
 			if err != nil {
@@ -583,6 +625,12 @@ func dumpKeys(ctx *cli.Context) error {
 
 	hasPrinted := false
 	for _, acc := range accounts {
+		if acc.Contract == nil {
+			if addr != "" {
+				return cli.NewExitError(fmt.Errorf("no verification script for address %s", addr), 1)
+			}
+			continue
+		}
 		pub, ok := vm.ParseSignatureContract(acc.Contract.Script)
 		if ok {
 			if hasPrinted {
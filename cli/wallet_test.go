@@ -426,3 +426,68 @@ func TestWalletConvert(t *testing.T) {
 		require.Equal(t, exp, act)
 	}
 }
+
+func TestWalletWatchOnly(t *testing.T) {
+	e := newExecutor(t, true)
+
+	walletPath := path.Join(os.TempDir(), "watchonly.json")
+	t.Cleanup(func() {
+		os.Remove(walletPath)
+	})
+	e.Run(t, "neo-go", "wallet", "init", "--wallet", walletPath)
+
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	t.Run("invalid argument", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "import-watchonly", "--wallet", walletPath, "kek")
+	})
+	e.Run(t, "neo-go", "wallet", "import-watchonly", "--wallet", walletPath, "--name", "addr", priv.Address())
+	e.Run(t, "neo-go", "wallet", "import-watchonly", "--wallet", walletPath,
+		hex.EncodeToString(validatorPriv.PublicKey().Bytes()))
+
+	w, err := wallet.NewWalletFromFile(walletPath)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(w.Accounts))
+	require.True(t, w.Accounts[0].IsWatchOnly())
+	require.Equal(t, "addr", w.Accounts[0].Label)
+	require.Nil(t, w.Accounts[0].Contract)
+	acc := w.GetAccount(validatorHash)
+	require.NotNil(t, acc)
+	require.True(t, acc.IsWatchOnly())
+	w.Close()
+
+	t.Run("balance", func(t *testing.T) {
+		e.Run(t, "neo-go", "wallet", "nep17", "balance",
+			"--rpc-endpoint", "http://"+e.RPC.Addr,
+			"--wallet", walletPath, "--address", validatorAddr, "--token", "NEO")
+		b, _ := e.Chain.GetGoverningTokenBalance(validatorHash)
+		e.checkNextLine(t, "^\\s*Account\\s+"+validatorAddr)
+		e.checkNextLine(t, "^\\s*NEO:\\s+NeoToken \\("+e.Chain.GoverningTokenHash().StringLE()+"\\)")
+		e.checkNextLine(t, "^\\s*Amount\\s*:\\s*"+b.String()+"$")
+	})
+
+	args := []string{"neo-go", "wallet", "nep17", "transfer",
+		"--rpc-endpoint", "http://" + e.RPC.Addr,
+		"--wallet", walletPath, "--from", validatorAddr,
+		"--to", priv.Address(), "--token", "NEO", "--amount", "1"}
+	t.Run("transfer without --out", func(t *testing.T) {
+		e.RunWithError(t, args...)
+	})
+
+	txPath := path.Join(os.TempDir(), "watchonlytx.json")
+	t.Cleanup(func() {
+		os.Remove(txPath)
+	})
+	e.Run(t, append(args, "--out", txPath)...)
+	e.checkNextLine(t, "^[0-9a-f]{64}$")
+
+	e.In.WriteString("one\r")
+	e.Run(t, "neo-go", "wallet", "sign",
+		"--rpc-endpoint", "http://"+e.RPC.Addr,
+		"--wallet", validatorWallet, "--address", validatorAddr,
+		"--in", txPath)
+	e.checkTxPersisted(t)
+
+	b, _ := e.Chain.GetGoverningTokenBalance(priv.GetScriptHash())
+	require.Equal(t, big.NewInt(1), b)
+}
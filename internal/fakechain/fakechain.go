@@ -9,9 +9,11 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/blockchainer"
 	"github.com/nspcc-dev/neo-go/pkg/core/blockchainer/services"
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
 	"github.com/nspcc-dev/neo-go/pkg/core/native"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto"
@@ -90,11 +92,21 @@ func (*FakeChain) IsExtensibleAllowed(uint160 util.Uint160) bool {
 	return true
 }
 
+// IsTracked implements Blockchainer interface.
+func (*FakeChain) IsTracked(util.Uint160) bool {
+	return true
+}
+
 // GetNatives implements blockchainer.Blockchainer interface.
 func (*FakeChain) GetNatives() []state.NativeContract {
 	panic("TODO")
 }
 
+// GetNativeMethods implements blockchainer.Blockchainer interface.
+func (*FakeChain) GetNativeMethods(util.Uint160) []state.NativeMethod {
+	panic("TODO")
+}
+
 // GetNotaryDepositExpiration implements Blockchainer interface.
 func (chain *FakeChain) GetNotaryDepositExpiration(acc util.Uint160) uint32 {
 	if chain.NotaryDepositExpiration != 0 {
@@ -149,6 +161,11 @@ func (chain *FakeChain) RegisterPostBlock(f func(blockchainer.Blockchainer, *mem
 	chain.PostBlock = append(chain.PostBlock, f)
 }
 
+// RemoveWatched implements Blockchainer interface.
+func (chain *FakeChain) RemoveWatched(...util.Uint160) error {
+	return errors.New("light wallet mode is not supported")
+}
+
 // GetConfig implements Blockchainer interface.
 func (chain *FakeChain) GetConfig() config.ProtocolConfiguration {
 	return chain.ProtocolConfiguration
@@ -182,6 +199,11 @@ func (chain *FakeChain) AddBlock(block *block.Block) error {
 	return nil
 }
 
+// AddWatched implements Blockchainer interface.
+func (chain *FakeChain) AddWatched(...util.Uint160) error {
+	return errors.New("light wallet mode is not supported")
+}
+
 // BlockHeight implements Feer interface.
 func (chain *FakeChain) BlockHeight() uint32 {
 	return atomic.LoadUint32(&chain.Blockheight)
@@ -225,6 +247,11 @@ func (chain *FakeChain) GetContractScriptHash(id int32) (util.Uint160, error) {
 	panic("TODO")
 }
 
+// GetDesignatedByRole implements Blockchainer interface.
+func (chain *FakeChain) GetDesignatedByRole(r noderoles.Role, index uint32) (keys.PublicKeys, uint32, error) {
+	panic("TODO")
+}
+
 // GetNativeContractScriptHash implements Blockchainer interface.
 func (chain *FakeChain) GetNativeContractScriptHash(name string) (util.Uint160, error) {
 	panic("TODO")
@@ -254,6 +281,16 @@ func (chain *FakeChain) ForEachNEP17Transfer(util.Uint160, func(*state.NEP17Tran
 	panic("TODO")
 }
 
+// ForEachNEP11Transfer implements Blockchainer interface.
+func (chain *FakeChain) ForEachNEP11Transfer(util.Uint160, func(*state.NEP11Transfer) (bool, error)) error {
+	panic("TODO")
+}
+
+// GetNEP11Balances implements Blockchainer interface.
+func (chain *FakeChain) GetNEP11Balances(util.Uint160) *state.NEP11Balances {
+	panic("TODO")
+}
+
 // GetNEP17Balances implements Blockchainer interface.
 func (chain *FakeChain) GetNEP17Balances(util.Uint160) *state.NEP17Balances {
 	panic("TODO")
@@ -264,6 +301,11 @@ func (chain *FakeChain) GetValidators() ([]*keys.PublicKey, error) {
 	panic("TODO")
 }
 
+// GetWatched implements Blockchainer interface.
+func (chain *FakeChain) GetWatched() ([]util.Uint160, error) {
+	return nil, errors.New("light wallet mode is not supported")
+}
+
 // GetStandByCommittee implements Blockchainer interface.
 func (chain *FakeChain) GetStandByCommittee() keys.PublicKeys {
 	panic("TODO")
@@ -289,8 +331,18 @@ func (chain *FakeChain) GetStorageItem(id int32, key []byte) state.StorageItem {
 	panic("TODO")
 }
 
+// GetTestHistoricVM implements Blockchainer interface.
+func (chain *FakeChain) GetTestHistoricVM(t trigger.Type, tx *transaction.Transaction, b *block.Block, root util.Uint256) (*vm.VM, dao.DAO, error) {
+	panic("TODO")
+}
+
 // GetTestVM implements Blockchainer interface.
-func (chain *FakeChain) GetTestVM(t trigger.Type, tx *transaction.Transaction, b *block.Block) *vm.VM {
+func (chain *FakeChain) GetTestVM(t trigger.Type, tx *transaction.Transaction, b *block.Block) (*vm.VM, dao.DAO) {
+	panic("TODO")
+}
+
+// GetTestVMWithOverride implements Blockchainer interface.
+func (chain *FakeChain) GetTestVMWithOverride(t trigger.Type, tx *transaction.Transaction, b *block.Block, ov *state.Override) (*vm.VM, dao.DAO, error) {
 	panic("TODO")
 }
 
@@ -299,6 +351,11 @@ func (chain *FakeChain) GetStorageItems(id int32) (map[string]state.StorageItem,
 	panic("TODO")
 }
 
+// GetStorageItemsWithPrefix implements Blockchainer interface.
+func (chain *FakeChain) GetStorageItemsWithPrefix(id int32, prefix []byte) (map[string]state.StorageItem, error) {
+	panic("TODO")
+}
+
 // CurrentHeaderHash implements Blockchainer interface.
 func (chain *FakeChain) CurrentHeaderHash() util.Uint256 {
 	return util.Uint256{}
@@ -329,11 +386,31 @@ func (chain *FakeChain) GetTransaction(h util.Uint256) (*transaction.Transaction
 	return nil, 0, errors.New("not found")
 }
 
+// GetTransactionsByAttribute implements Blockchainer interface.
+func (chain *FakeChain) GetTransactionsByAttribute(*transaction.Attribute, int, int) ([]util.Uint256, error) {
+	panic("TODO")
+}
+
 // GetMemPool implements Blockchainer interface.
 func (chain *FakeChain) GetMemPool() *mempool.Pool {
 	return chain.Pool
 }
 
+// GetMaxBlockSize implements Blockchainer interface.
+func (chain *FakeChain) GetMaxBlockSize() uint32 {
+	return chain.MaxBlockSize
+}
+
+// GetMaxBlockSystemFee implements Blockchainer interface.
+func (chain *FakeChain) GetMaxBlockSystemFee() int64 {
+	return chain.MaxBlockSystemFee
+}
+
+// GetMaxTransactionsPerBlock implements Blockchainer interface.
+func (chain *FakeChain) GetMaxTransactionsPerBlock() uint16 {
+	return chain.MaxTransactionsPerBlock
+}
+
 // GetGoverningTokenBalance implements Blockchainer interface.
 func (chain *FakeChain) GetGoverningTokenBalance(acc util.Uint160) (*big.Int, uint32) {
 	panic("TODO")
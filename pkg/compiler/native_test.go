@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
 	"github.com/nspcc-dev/neo-go/pkg/core/native"
@@ -30,7 +31,7 @@ import (
 )
 
 func TestContractHashes(t *testing.T) {
-	cs := native.NewContracts(true, map[string][]uint32{})
+	cs := native.NewContracts(config.ProtocolConfiguration{P2PSigExtensions: true})
 	require.Equal(t, []byte(neo.Hash), cs.NEO.Hash.BytesBE())
 	require.Equal(t, []byte(gas.Hash), cs.GAS.Hash.BytesBE())
 	require.Equal(t, []byte(oracle.Hash), cs.Oracle.Hash.BytesBE())
@@ -95,7 +96,13 @@ type nativeTestCase struct {
 
 // Here we test that corresponding method does exist, is invoked and correct value is returned.
 func TestNativeHelpersCompile(t *testing.T) {
-	cs := native.NewContracts(true, map[string][]uint32{})
+	cs := native.NewContracts(config.ProtocolConfiguration{
+		P2PSigExtensions:   true,
+		PolicyBlockLimits:  true,
+		DesignationEvent:   true,
+		VoterRewardMethods: true,
+		NotaryEvents:       true,
+	})
 	u160 := `interop.Hash160("aaaaaaaaaaaaaaaaaaaa")`
 	u256 := `interop.Hash256("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")`
 	pub := `interop.PublicKey("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")`
@@ -111,6 +118,7 @@ func TestNativeHelpersCompile(t *testing.T) {
 		{"getCandidates", nil},
 		{"getCommittee", nil},
 		{"getGasPerBlock", nil},
+		{"getGasPerVote", []string{pub, "123"}},
 		{"getNextBlockValidators", nil},
 		{"getRegisterPrice", nil},
 		{"registerCandidate", []string{pub}},
@@ -118,6 +126,7 @@ func TestNativeHelpersCompile(t *testing.T) {
 		{"setRegisterPrice", []string{"10"}},
 		{"vote", []string{u160, pub}},
 		{"unclaimedGas", []string{u160, "123"}},
+		{"unclaimedVoterGas", []string{u160, "123"}},
 		{"unregisterCandidate", []string{pub}},
 	}, nep17TestCases...))
 	runNativeTestCases(t, cs.GAS.ContractMD, "gas", nep17TestCases)
@@ -134,10 +143,16 @@ func TestNativeHelpersCompile(t *testing.T) {
 		{"blockAccount", []string{u160}},
 		{"getExecFeeFactor", nil},
 		{"getFeePerByte", nil},
+		{"getMaxBlockSize", nil},
+		{"getMaxBlockSystemFee", nil},
+		{"getMaxTransactionsPerBlock", nil},
 		{"getStoragePrice", nil},
 		{"isBlocked", []string{u160}},
 		{"setExecFeeFactor", []string{"42"}},
 		{"setFeePerByte", []string{"42"}},
+		{"setMaxBlockSize", []string{"42"}},
+		{"setMaxBlockSystemFee", []string{"42"}},
+		{"setMaxTransactionsPerBlock", []string{"42"}},
 		{"setStoragePrice", []string{"42"}},
 		{"unblockAccount", []string{u160}},
 	})
@@ -195,6 +210,7 @@ func TestNativeHelpersCompile(t *testing.T) {
 	runNativeTestCases(t, cs.Crypto.ContractMD, "crypto", []nativeTestCase{
 		{"sha256", []string{"[]byte{1, 2, 3}"}},
 		{"ripemd160", []string{"[]byte{1, 2, 3}"}},
+		{"murmur32", []string{"[]byte{1, 2, 3}", "123"}},
 		{"verifyWithECDsa", []string{"[]byte{1, 2, 3}", pub, sig, "crypto.Secp256k1"}},
 	})
 	runNativeTestCases(t, cs.Std.ContractMD, "std", []nativeTestCase{
@@ -208,6 +224,10 @@ func TestNativeHelpersCompile(t *testing.T) {
 		{"base58Decode", []string{"[]byte{1, 2, 3}"}},
 		{"itoa", []string{"4", "10"}},
 		{"atoi", []string{`"4"`, "10"}},
+		{"memoryCompare", []string{"[]byte{1}", "[]byte{2}"}},
+		{"memorySearch", []string{"[]byte{1}", "[]byte{2}"}},
+		{"memorySearchIndex", []string{"[]byte{1}", "[]byte{2}", "3"}},
+		{"memorySearchLastIndex", []string{"[]byte{1}", "[]byte{2}", "3"}},
 	})
 }
 
@@ -221,8 +241,22 @@ func runNativeTestCases(t *testing.T, ctr interop.ContractMD, name string, testC
 	})
 }
 
+// nativeOverloads maps interop wrapper names of overloaded native methods to
+// the native method name and the number of its parameters.
+var nativeOverloads = map[string]struct {
+	method     string
+	paramCount int
+}{
+	"memorySearchIndex":     {"memorySearch", 3},
+	"memorySearchLastIndex": {"memorySearch", 4},
+}
+
 func runNativeTestCase(t *testing.T, ctr interop.ContractMD, name, method string, params ...string) {
-	md, ok := ctr.GetMethod(strings.TrimSuffix(method, "WithData"), len(params))
+	nativeMethod, paramCount := strings.TrimSuffix(method, "WithData"), len(params)
+	if m, ok := nativeOverloads[method]; ok {
+		nativeMethod, paramCount = m.method, m.paramCount
+	}
+	md, ok := ctr.GetMethod(nativeMethod, paramCount)
 	require.True(t, ok)
 
 	isVoid := md.MD.ReturnType == smartcontract.VoidType
@@ -0,0 +1,11 @@
+package config
+
+// LightWallet contains light wallet backend mode configuration. In this mode
+// the node only keeps NEP17/NEP11 balances, transfer logs and application
+// logs related to the set of watched addresses and contracts.
+type LightWallet struct {
+	Enabled bool `yaml:"Enabled"`
+	// Watched is a list of addresses or LE script hashes that are always
+	// watched in addition to the ones added at runtime.
+	Watched []string `yaml:"Watched"`
+}
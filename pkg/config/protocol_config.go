@@ -9,15 +9,35 @@ type (
 	ProtocolConfiguration struct {
 		Magic       netmode.Magic `yaml:"Magic"`
 		MemPoolSize int           `yaml:"MemPoolSize"`
+		// MemPoolSenderLimit is the maximum number of transactions a single
+		// sender can have in the memory pool at once, zero means no limit.
+		MemPoolSenderLimit int `yaml:"MemPoolSenderLimit"`
+		// MemPoolMaxBytes is the maximum total size of transactions in the
+		// memory pool in bytes, zero means no limit (only MemPoolSize is
+		// used then).
+		MemPoolMaxBytes int `yaml:"MemPoolMaxBytes"`
+		// MemPoolPostponedSize is the number of completed transactions with
+		// NotValidBefore height in the future the memory pool can hold until
+		// they become valid, such transactions are rejected if it's zero.
+		MemPoolPostponedSize int `yaml:"MemPoolPostponedSize"`
+		// MemPoolMaxAge is the number of blocks transaction can stay in the
+		// memory pool for, older ones are evicted. Zero means no limit.
+		MemPoolMaxAge uint32 `yaml:"MemPoolMaxAge"`
 		// P2PNotaryRequestPayloadPoolSize specifies the memory pool size for P2PNotaryRequestPayloads.
 		// It is valid only if P2PSigExtensions are enabled.
 		P2PNotaryRequestPayloadPoolSize int `yaml:"P2PNotaryRequestPayloadPoolSize"`
+		// MemPoolFallbackSize is the memory pool quota for notary fallback
+		// transactions, they don't compete with regular transactions if it's
+		// set. It is valid only if P2PSigExtensions are enabled.
+		MemPoolFallbackSize int `yaml:"MemPoolFallbackSize"`
 		// KeepOnlyLatestState specifies if MPT should only store latest state.
 		// If true, DB size will be smaller, but older roots won't be accessible.
 		// This value should remain the same for the same database.
 		KeepOnlyLatestState bool `yaml:"KeepOnlyLatestState"`
 		// RemoveUntraceableBlocks specifies if old blocks should be removed.
 		RemoveUntraceableBlocks bool `yaml:"RemoveUntraceableBlocks"`
+		// LightWallet enables light wallet backend mode.
+		LightWallet LightWallet `yaml:"LightWallet"`
 		// MaxBlockSize is the maximum block size in bytes.
 		MaxBlockSize uint32 `yaml:"MaxBlockSize"`
 		// MaxBlockSystemFee is the maximum overall system fee per block.
@@ -28,6 +48,29 @@ type (
 		MaxTransactionsPerBlock uint16 `yaml:"MaxTransactionsPerBlock"`
 		// NativeUpdateHistories is the list of histories of native contracts updates.
 		NativeUpdateHistories map[string][]uint32 `yaml:"NativeActivations"`
+		// OracleResponseRefund enables refunding GAS left unused by oracle
+		// response transactions to the requesters. It changes Oracle contract
+		// behaviour and must be the same for all nodes of the network.
+		OracleResponseRefund bool `yaml:"OracleResponseRefund"`
+		// PolicyBlockLimits makes MaxBlockSize, MaxBlockSystemFee and
+		// MaxTransactionsPerBlock values adjustable by committee via Policy
+		// contract, configured values are only used as initial ones then. It
+		// changes Policy contract and must be the same for all nodes of the
+		// network.
+		PolicyBlockLimits bool `yaml:"PolicyBlockLimits"`
+		// DesignationEvent makes RoleManagement contract emit Designation
+		// notification when nodes are designated for some role. It changes
+		// RoleManagement contract and must be the same for all nodes of the
+		// network.
+		DesignationEvent bool `yaml:"DesignationEvent"`
+		// VoterRewardMethods enables unclaimedVoterGas and getGasPerVote
+		// methods of NEO contract. It changes NEO contract and must be the
+		// same for all nodes of the network.
+		VoterRewardMethods bool `yaml:"VoterRewardMethods"`
+		// NotaryEvents makes Notary contract emit notifications on deposit
+		// changes and expiration. It changes Notary contract and must be the
+		// same for all nodes of the network.
+		NotaryEvents bool `yaml:"NotaryEvents"`
 		// P2PSigExtensions enables additional signature-related logic.
 		P2PSigExtensions bool `yaml:"P2PSigExtensions"`
 		// ReservedAttributes allows to have reserved attributes range for experimental or private purposes.
@@ -44,5 +87,14 @@ type (
 		VerifyBlocks bool `yaml:"VerifyBlocks"`
 		// Whether to verify transactions in received blocks.
 		VerifyTransactions bool `yaml:"VerifyTransactions"`
+		// ZeroFeeMemPoolSize is the memory pool quota for transactions from
+		// ZeroFeeSenders, they don't compete with regular transactions.
+		ZeroFeeMemPoolSize int `yaml:"ZeroFeeMemPoolSize"`
+		// ZeroFeeSenders is a list of addresses or LE script hashes of
+		// senders whose transactions are exempted from fee per byte policy,
+		// it's mostly useful for private networks running with zero fees.
+		// It changes transaction validity rules and must be the same for all
+		// nodes of the network.
+		ZeroFeeSenders []string `yaml:"ZeroFeeSenders"`
 	}
 )
@@ -438,9 +438,9 @@ func (s *service) verifyBlock(b block.Block) bool {
 	}
 
 	size := coreb.GetExpectedBlockSize()
-	if size > int(s.ProtocolConfiguration.MaxBlockSize) {
-		s.log.Warn("proposed block size exceeds config MaxBlockSize",
-			zap.Uint32("max size allowed", s.ProtocolConfiguration.MaxBlockSize),
+	if maxBlockSize := s.Chain.GetMaxBlockSize(); size > int(maxBlockSize) {
+		s.log.Warn("proposed block size exceeds MaxBlockSize",
+			zap.Uint32("max size allowed", maxBlockSize),
 			zap.Int("block size", size))
 		return false
 	}
@@ -472,9 +472,9 @@ func (s *service) verifyBlock(b block.Block) bool {
 		}
 	}
 
-	maxBlockSysFee := s.ProtocolConfiguration.MaxBlockSystemFee
+	maxBlockSysFee := s.Chain.GetMaxBlockSystemFee()
 	if fee > maxBlockSysFee {
-		s.log.Warn("proposed block system fee exceeds config MaxBlockSystemFee",
+		s.log.Warn("proposed block system fee exceeds MaxBlockSystemFee",
 			zap.Int("max system fee allowed", int(maxBlockSysFee)),
 			zap.Int("block system fee", int(fee)))
 		return false
@@ -506,8 +506,8 @@ func (s *service) verifyRequest(p payload.ConsensusPayload) error {
 			return fmt.Errorf("%w: %s != %s", errInvalidStateRoot, sr.Root, req.stateRoot)
 		}
 	}
-	if len(req.TransactionHashes()) > int(s.ProtocolConfiguration.MaxTransactionsPerBlock) {
-		return fmt.Errorf("%w: max = %d, got %d", errInvalidTransactionsCount, s.ProtocolConfiguration.MaxTransactionsPerBlock, len(req.TransactionHashes()))
+	if maxTx := s.Chain.GetMaxTransactionsPerBlock(); len(req.TransactionHashes()) > int(maxTx) {
+		return fmt.Errorf("%w: max = %d, got %d", errInvalidTransactionsCount, maxTx, len(req.TransactionHashes()))
 	}
 	// Save lastProposal for getVerified().
 	s.lastProposal = req.transactionHashes
@@ -593,10 +593,12 @@ func (s *service) getVerifiedTx() []block.Transaction {
 		}
 
 		if len(txx) < len(s.lastProposal)/2 {
-			txx = pool.GetVerifiedTransactions()
+			txx = s.getMostPrioritizedTx(pool)
+		} else {
+			pool.SortTransactions(txx)
 		}
 	} else {
-		txx = pool.GetVerifiedTransactions()
+		txx = s.getMostPrioritizedTx(pool)
 	}
 
 	if len(txx) > 0 {
@@ -611,6 +613,13 @@ func (s *service) getVerifiedTx() []block.Transaction {
 	return res
 }
 
+// getMostPrioritizedTx returns pooled transactions that can fit into a block
+// according to MaxTransactionsPerBlock setting, so that the whole pool is not
+// copied.
+func (s *service) getMostPrioritizedTx(pool *mempool.Pool) []*transaction.Transaction {
+	return pool.GetVerifiedTransactionsPage(0, int(s.Config.Chain.GetMaxTransactionsPerBlock()))
+}
+
 func (s *service) getValidators(txes ...block.Transaction) []crypto.PublicKey {
 	var (
 		pKeys []*keys.PublicKey
@@ -62,6 +62,7 @@ func (b *Block) ComputeMerkleRoot() util.Uint256 {
 // RebuildMerkleRoot rebuilds the merkleroot of the block.
 func (b *Block) RebuildMerkleRoot() {
 	b.MerkleRoot = b.ComputeMerkleRoot()
+	b.DirtyHash()
 }
 
 // NewBlockFromTrimmedBytes returns a new block from trimmed data.
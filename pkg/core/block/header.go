@@ -73,7 +73,9 @@ type baseAux struct {
 	Witnesses     []transaction.Witness `json:"witnesses"`
 }
 
-// Hash returns the hash of the block.
+// Hash returns the hash of the block. It's calculated once and then cached, so
+// if any of the hashable fields are changed after that, DirtyHash must be
+// called to invalidate the cache.
 func (b *Header) Hash() util.Uint256 {
 	if b.hash.Equals(util.Uint256{}) {
 		b.createHash()
@@ -89,6 +91,13 @@ func (b *Header) GetSignedHash() util.Uint256 {
 	return b.verificationHash
 }
 
+// DirtyHash invalidates cached block hashes, so that they're recalculated on
+// the next Hash or GetSignedHash call.
+func (b *Header) DirtyHash() {
+	b.hash = util.Uint256{}
+	b.verificationHash = util.Uint256{}
+}
+
 // DecodeBinary implements Serializable interface.
 func (b *Header) DecodeBinary(br *io.BinReader) {
 	b.decodeHashableFields(br)
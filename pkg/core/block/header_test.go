@@ -52,3 +52,17 @@ func TestHeaderEncodeDecode(t *testing.T) {
 		testHeaderEncodeDecode(t, true)
 	})
 }
+
+func TestHeaderDirtyHash(t *testing.T) {
+	header := Header{Index: 1}
+	h, sh := header.Hash(), header.GetSignedHash()
+
+	header.Index++
+	assert.Equal(t, h, header.Hash())
+	assert.Equal(t, sh, header.GetSignedHash())
+
+	header.DirtyHash()
+	assert.NotEqual(t, h, header.Hash())
+	assert.NotEqual(t, sh, header.GetSignedHash())
+	assert.Equal(t, (&Header{Index: 2}).Hash(), header.Hash())
+}
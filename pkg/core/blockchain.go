@@ -19,6 +19,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/contract"
 	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
 	"github.com/nspcc-dev/neo-go/pkg/core/native"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
@@ -28,6 +29,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/crypto"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
@@ -43,7 +45,7 @@ import (
 // Tuning parameters.
 const (
 	headerBatchCount = 2000
-	version          = "0.1.0"
+	version          = "0.1.1"
 
 	defaultMemPoolSize                     = 50000
 	defaultP2PNotaryRequestPayloadPoolSize = 1000
@@ -72,6 +74,9 @@ var (
 	// conflicts with other transaction in the chain or pool according to
 	// Conflicts attribute.
 	ErrHasConflicts = errors.New("has conflicts")
+	// ErrBlockLimitExceeded is returned when trying to add block that
+	// exceeds block limits set by Policy contract.
+	ErrBlockLimitExceeded = errors.New("block limit exceeded")
 )
 var (
 	persistInterval = 1 * time.Second
@@ -123,6 +128,9 @@ type Blockchain struct {
 
 	memPool *mempool.Pool
 
+	// zeroFeeSenders is a set of senders exempted from fee per byte policy.
+	zeroFeeSenders map[util.Uint160]bool
+
 	// postBlock is a set of callback methods which should be run under the Blockchain lock after new block is persisted.
 	// Block's transactions are passed via mempool.
 	postBlock []func(blockchainer.Blockchainer, *mempool.Pool, *block.Block)
@@ -135,6 +143,10 @@ type Blockchain struct {
 
 	contracts native.Contracts
 
+	// customInterops is a sorted list of interops registered by
+	// RegisterInterops in addition to the standard ones.
+	customInterops []interop.Function
+
 	extensible atomic.Value
 
 	// defaultBlockWitness stores transaction.Witness with m out of n multisig,
@@ -147,6 +159,18 @@ type Blockchain struct {
 	events  chan bcEvent
 	subCh   chan interface{}
 	unsubCh chan interface{}
+
+	// Asynchronous transfer log writer, transferLogHeight is the index of
+	// the last block processed by it, transferLogErr holds the error it
+	// stopped with.
+	transferLogCh     chan transferLogBatch
+	transferLogDone   chan struct{}
+	transferLogHeight uint32
+	transferLogErr    atomic.Value
+
+	// watched is a set of script hashes tracked in light wallet mode, it's
+	// nil if this mode is disabled.
+	watched *watchList
 }
 
 // bcEvent is an internal event generated by the Blockchain and then
@@ -174,6 +198,10 @@ func NewBlockchain(s storage.Store, cfg config.ProtocolConfiguration, log *zap.L
 		cfg.P2PNotaryRequestPayloadPoolSize = defaultP2PNotaryRequestPayloadPoolSize
 		log.Info("P2PNotaryRequestPayloadPool size is not set or wrong, setting default value", zap.Int("P2PNotaryRequestPayloadPoolSize", cfg.P2PNotaryRequestPayloadPoolSize))
 	}
+	if len(cfg.ZeroFeeSenders) != 0 && cfg.ZeroFeeMemPoolSize <= 0 {
+		cfg.ZeroFeeMemPoolSize = cfg.MemPoolSize
+		log.Info("ZeroFeeMemPoolSize is not set or wrong, using MemPoolSize value", zap.Int("ZeroFeeMemPoolSize", cfg.ZeroFeeMemPoolSize))
+	}
 	if cfg.MaxBlockSize == 0 {
 		cfg.MaxBlockSize = defaultMaxBlockSize
 		log.Info("MaxBlockSize is not set or wrong, setting default value", zap.Uint32("MaxBlockSize", cfg.MaxBlockSize))
@@ -204,14 +232,53 @@ func NewBlockchain(s storage.Store, cfg config.ProtocolConfiguration, log *zap.L
 		dao:         dao.NewSimple(s, cfg.Magic, cfg.StateRootInHeader),
 		stopCh:      make(chan struct{}),
 		runToExitCh: make(chan struct{}),
-		memPool:     mempool.New(cfg.MemPoolSize, 0, false),
+		memPool:     mempool.New(cfg.MemPoolSize, 0, true),
 		sbCommittee: committee,
 		log:         log,
 		events:      make(chan bcEvent),
 		subCh:       make(chan interface{}),
 		unsubCh:     make(chan interface{}),
 
-		contracts: *native.NewContracts(cfg.P2PSigExtensions, cfg.NativeUpdateHistories),
+		transferLogCh:   make(chan transferLogBatch, transferLogQueueSize),
+		transferLogDone: make(chan struct{}),
+
+		contracts: *native.NewContracts(cfg),
+	}
+
+	if cfg.MemPoolSenderLimit > 0 {
+		bc.memPool.SetSenderLimit(cfg.MemPoolSenderLimit)
+	}
+	if cfg.P2PSigExtensions && cfg.MemPoolFallbackSize > 0 {
+		bc.memPool.SetFallbackPool(bc.isFallbackTx, cfg.MemPoolFallbackSize, nil)
+	}
+	if cfg.MemPoolMaxBytes > 0 {
+		bc.memPool.SetMaxBytes(cfg.MemPoolMaxBytes)
+	}
+	if cfg.MemPoolPostponedSize > 0 {
+		bc.memPool.SetPostponedCapacity(cfg.MemPoolPostponedSize)
+	}
+	if cfg.MemPoolMaxAge > 0 {
+		bc.memPool.SetMaxAge(cfg.MemPoolMaxAge)
+	}
+	if len(cfg.ZeroFeeSenders) != 0 {
+		bc.zeroFeeSenders = make(map[util.Uint160]bool, len(cfg.ZeroFeeSenders))
+		for _, addr := range cfg.ZeroFeeSenders {
+			h, err := address.StringToUint160(addr)
+			if err != nil {
+				h, err = util.Uint160DecodeStringLE(addr)
+				if err != nil {
+					return nil, fmt.Errorf("invalid zero fee sender address or script hash %s: %w", addr, err)
+				}
+			}
+			bc.zeroFeeSenders[h] = true
+		}
+		bc.memPool.SetFeeExemption(bc.isZeroFeeTx, cfg.ZeroFeeMemPoolSize)
+	}
+	if cfg.LightWallet.Enabled {
+		bc.watched, err = newWatchList(bc.dao, cfg.LightWallet)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	bc.stateRoot = stateroot.NewModule(bc, bc.log, bc.dao.Store)
@@ -342,6 +409,11 @@ func (bc *Blockchain) init() error {
 		return fmt.Errorf("can't init cache for Management native contract: %w", err)
 	}
 
+	err = bc.catchUpTransferLog(bHeight)
+	if err != nil {
+		return fmt.Errorf("can't restore transfer log: %w", err)
+	}
+
 	return bc.updateExtensibleWhitelist(bHeight)
 }
 
@@ -351,6 +423,8 @@ func (bc *Blockchain) Run() {
 	persistTimer := time.NewTimer(persistInterval)
 	defer func() {
 		persistTimer.Stop()
+		close(bc.transferLogCh)
+		<-bc.transferLogDone
 		if err := bc.persist(); err != nil {
 			bc.log.Warn("failed to persist", zap.Error(err))
 		}
@@ -360,6 +434,7 @@ func (bc *Blockchain) Run() {
 		close(bc.runToExitCh)
 	}()
 	go bc.notificationDispatcher()
+	go bc.transferLogWriter()
 	for {
 		select {
 		case <-bc.stopCh:
@@ -485,6 +560,7 @@ func (bc *Blockchain) Close() {
 	close(bc.stopCh)
 	<-bc.runToExitCh
 	bc.addLock.Unlock()
+	bc.memPool.StopSubscriptions()
 }
 
 // AddBlock accepts successive block for the Blockchain, verifies it and
@@ -520,6 +596,11 @@ func (bc *Blockchain) AddBlock(block *block.Block) error {
 		if !block.MerkleRoot.Equals(merkle) {
 			return errors.New("invalid block: MerkleRoot mismatch")
 		}
+		if bc.config.PolicyBlockLimits {
+			if err := bc.verifyBlockLimits(block); err != nil {
+				return err
+			}
+		}
 		mp = mempool.New(len(block.Transactions), 0, false)
 		for _, tx := range block.Transactions {
 			var err error
@@ -643,6 +724,7 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 	cache := dao.NewCached(bc.dao)
 	writeBuf := io.NewBufBinWriter()
 	appExecResults := make([]*state.AppExecResult, 0, 2+len(block.Transactions))
+	transfers := transferLogBatch{index: block.Index}
 	if err := cache.StoreAsBlock(block, writeBuf); err != nil {
 		return err
 	}
@@ -653,22 +735,27 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 	}
 	writeBuf.Reset()
 
-	aer, err := bc.runPersist(bc.contracts.GetPersistScript(), block, cache, trigger.OnPersist)
+	aer, err := bc.runPersist(bc.contracts.GetPersistScript(), block, cache, trigger.OnPersist, &transfers)
 	if err != nil {
 		return fmt.Errorf("onPersist failed: %w", err)
 	}
 	appExecResults = append(appExecResults, aer)
-	err = cache.PutAppExecResult(aer, writeBuf)
-	if err != nil {
-		return fmt.Errorf("failed to store onPersist exec result: %w", err)
+	if bc.isExecutionTracked(cache, aer, block, nil) {
+		err = cache.PutAppExecResult(aer, writeBuf)
+		if err != nil {
+			return fmt.Errorf("failed to store onPersist exec result: %w", err)
+		}
+		writeBuf.Reset()
 	}
-	writeBuf.Reset()
 
 	for _, tx := range block.Transactions {
 		if err := cache.StoreAsTransaction(tx, block.Index, writeBuf); err != nil {
 			return err
 		}
 		writeBuf.Reset()
+		if err := cache.StoreTransactionAttributes(tx, block.Index); err != nil {
+			return fmt.Errorf("failed to index attributes of transaction %s: %w", tx.Hash().StringLE(), err)
+		}
 
 		systemInterop := bc.newInteropContext(trigger.Application, cache, block, tx)
 		v := systemInterop.SpawnVM()
@@ -678,6 +765,7 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 		v.GasLimit = tx.SystemFee
 
 		err := v.Run()
+		bc.contracts.Oracle.AddResponseGasConsumed(tx, v.GasConsumed())
 		var faultException string
 		if !v.HasFailed() {
 			_, err := systemInterop.DAO.Persist()
@@ -685,7 +773,7 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 				return fmt.Errorf("failed to persist invocation results: %w", err)
 			}
 			for j := range systemInterop.Notifications {
-				bc.handleNotification(&systemInterop.Notifications[j], cache, block, tx.Hash())
+				bc.handleNotification(&systemInterop.Notifications[j], cache, block, tx.Hash(), &transfers)
 			}
 		} else {
 			bc.log.Warn("contract invocation failed",
@@ -706,11 +794,13 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 			},
 		}
 		appExecResults = append(appExecResults, aer)
-		err = cache.PutAppExecResult(aer, writeBuf)
-		if err != nil {
-			return fmt.Errorf("failed to store tx exec result: %w", err)
+		if bc.isExecutionTracked(cache, aer, block, tx) {
+			err = cache.PutAppExecResult(aer, writeBuf)
+			if err != nil {
+				return fmt.Errorf("failed to store tx exec result: %w", err)
+			}
+			writeBuf.Reset()
 		}
-		writeBuf.Reset()
 
 		if bc.config.P2PSigExtensions {
 			for _, attr := range tx.GetAttributes(transaction.ConflictsT) {
@@ -725,16 +815,18 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 		}
 	}
 
-	aer, err = bc.runPersist(bc.contracts.GetPostPersistScript(), block, cache, trigger.PostPersist)
+	aer, err = bc.runPersist(bc.contracts.GetPostPersistScript(), block, cache, trigger.PostPersist, &transfers)
 	if err != nil {
 		return fmt.Errorf("postPersist failed: %w", err)
 	}
 	appExecResults = append(appExecResults, aer)
-	err = cache.AppendAppExecResult(aer, writeBuf)
-	if err != nil {
-		return fmt.Errorf("failed to store postPersist exec result: %w", err)
+	if bc.isExecutionTracked(cache, aer, block, nil) {
+		err = cache.AppendAppExecResult(aer, writeBuf)
+		if err != nil {
+			return fmt.Errorf("failed to store postPersist exec result: %w", err)
+		}
+		writeBuf.Reset()
 	}
-	writeBuf.Reset()
 
 	d := cache.DAO.(*dao.Simple)
 	b := d.GetMPTBatch()
@@ -770,16 +862,30 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 
 	bc.topBlock.Store(block)
 	atomic.StoreUint32(&bc.blockHeight, block.Index)
-	bc.memPool.RemoveStale(func(tx *transaction.Transaction) bool { return bc.IsTxStillRelevant(tx, txpool, false) }, bc)
+	bc.memPool.RemoveStaleWithReason(func(tx *transaction.Transaction) (bool, mempool.RemovalReason) {
+		return bc.isPooledTxStillRelevant(tx, txpool)
+	}, bc)
+	postponed := bc.memPool.ReleasePostponed(block.Index)
 	for _, f := range bc.postBlock {
 		f(bc, txpool, block)
 	}
-	if err := bc.updateExtensibleWhitelist(block.Index); err != nil {
-		bc.lock.Unlock()
+	err = bc.updateExtensibleWhitelist(block.Index)
+	bc.lock.Unlock()
+
+	// Released transactions are verified without holding the chain lock, so
+	// that a large batch of them doesn't stall the chain.
+	for _, tx := range postponed {
+		if err := bc.PoolTx(tx); err != nil {
+			bc.log.Debug("postponed transaction is dropped",
+				zap.String("hash", tx.Hash().StringLE()),
+				zap.Error(err))
+		}
+	}
+	if err != nil {
 		return err
 	}
-	bc.lock.Unlock()
 
+	bc.transferLogCh <- transfers
 	updateBlockHeightMetric(block.Index)
 	// Genesis block is stored when Blockchain is not yet running, so there
 	// is no one to read this event. And it doesn't make much sense as event
@@ -852,7 +958,7 @@ func (bc *Blockchain) IsExtensibleAllowed(u util.Uint160) bool {
 	return n < len(us)
 }
 
-func (bc *Blockchain) runPersist(script []byte, block *block.Block, cache *dao.Cached, trig trigger.Type) (*state.AppExecResult, error) {
+func (bc *Blockchain) runPersist(script []byte, block *block.Block, cache *dao.Cached, trig trigger.Type, transfers *transferLogBatch) (*state.AppExecResult, error) {
 	systemInterop := bc.newInteropContext(trig, cache, block, nil)
 	v := systemInterop.SpawnVM()
 	v.LoadScriptWithFlags(script, callflag.All)
@@ -863,7 +969,7 @@ func (bc *Blockchain) runPersist(script []byte, block *block.Block, cache *dao.C
 		return nil, fmt.Errorf("can't save changes: %w", err)
 	}
 	for i := range systemInterop.Notifications {
-		bc.handleNotification(&systemInterop.Notifications[i], cache, block, block.Hash())
+		bc.handleNotification(&systemInterop.Notifications[i], cache, block, block.Hash(), transfers)
 	}
 	return &state.AppExecResult{
 		Container: block.Hash(), // application logs can be retrieved by block hash
@@ -877,13 +983,57 @@ func (bc *Blockchain) runPersist(script []byte, block *block.Block, cache *dao.C
 	}, nil
 }
 
-func (bc *Blockchain) handleNotification(note *state.NotificationEvent, d *dao.Cached, b *block.Block, h util.Uint256) {
-	if note.Name != "Transfer" {
+// handleNotification updates NEP17 or NEP11 balances if the notification
+// given is a NEP17 or NEP11 `Transfer` event and adds it to the list of
+// transfers to be logged.
+func (bc *Blockchain) handleNotification(note *state.NotificationEvent, d *dao.Cached, b *block.Block, h util.Uint256, transfers *transferLogBatch) {
+	if transfer := bc.getNEP17Transfer(d, note, b, h); transfer != nil {
+		bc.processNEP17Transfer(d, transfer)
+		transfers.transfers = append(transfers.transfers, *transfer)
 		return
 	}
+	if transfer := bc.getNEP11Transfer(d, note, b, h); transfer != nil {
+		bc.processNEP11Transfer(d, transfer)
+		transfers.nep11Transfers = append(transfers.nep11Transfers, *transfer)
+	}
+}
+
+// getNEP17Transfer returns NEP17 transfer described by the notification given
+// or nil if it's not a valid NEP17 `Transfer` event. Amount of the transfer
+// returned is the number of tokens transferred.
+func (bc *Blockchain) getNEP17Transfer(d dao.DAO, note *state.NotificationEvent, b *block.Block, h util.Uint256) *state.NEP17Transfer {
+	transfer, _ := bc.parseTransferEvent(d, note, b, h, 3)
+	return transfer
+}
+
+// getNEP11Transfer returns NEP11 transfer described by the notification given
+// or nil if it's not a valid NEP11 `Transfer` event.
+func (bc *Blockchain) getNEP11Transfer(d dao.DAO, note *state.NotificationEvent, b *block.Block, h util.Uint256) *state.NEP11Transfer {
+	transfer, arr := bc.parseTransferEvent(d, note, b, h, 4)
+	if transfer == nil {
+		return nil
+	}
+	id, ok := arr[3].Value().([]byte)
+	if !ok {
+		return nil
+	}
+	return &state.NEP11Transfer{
+		NEP17Transfer: *transfer,
+		ID:            id,
+	}
+}
+
+// parseTransferEvent parses common part of NEP17 and NEP11 `Transfer` events
+// (from, to and amount) with the given number of parameters, it returns nil
+// if the notification is not a valid `Transfer` event. Notification
+// parameters are also returned for further processing.
+func (bc *Blockchain) parseTransferEvent(d dao.DAO, note *state.NotificationEvent, b *block.Block, h util.Uint256, paramCount int) (*state.NEP17Transfer, []stackitem.Item) {
+	if note.Name != "Transfer" {
+		return nil, nil
+	}
 	arr, ok := note.Item.Value().([]stackitem.Item)
-	if !ok || len(arr) != 3 {
-		return
+	if !ok || len(arr) != paramCount {
+		return nil, nil
 	}
 	var from []byte
 	fromValue := arr[0].Value()
@@ -891,7 +1041,7 @@ func (bc *Blockchain) handleNotification(note *state.NotificationEvent, d *dao.C
 	if fromValue != nil {
 		from, ok = fromValue.([]byte)
 		if !ok {
-			return
+			return nil, nil
 		}
 	}
 	var to []byte
@@ -900,116 +1050,111 @@ func (bc *Blockchain) handleNotification(note *state.NotificationEvent, d *dao.C
 	if toValue != nil {
 		to, ok = toValue.([]byte)
 		if !ok {
-			return
+			return nil, nil
 		}
 	}
 	amount, ok := arr[2].Value().(*big.Int)
 	if !ok {
 		bs, ok := arr[2].Value().([]byte)
 		if !ok {
-			return
+			return nil, nil
 		}
 		amount = bigint.FromBytes(bs)
 	}
-	bc.processNEP17Transfer(d, h, b, note.ScriptHash, from, to, amount)
-}
-
-func parseUint160(addr []byte) util.Uint160 {
-	if u, err := util.Uint160DecodeBytesBE(addr); err == nil {
-		return u
-	}
-	return util.Uint160{}
-}
-
-func (bc *Blockchain) processNEP17Transfer(cache *dao.Cached, h util.Uint256, b *block.Block, sc util.Uint160, from, to []byte, amount *big.Int) {
-	toAddr := parseUint160(to)
-	fromAddr := parseUint160(from)
 	var id int32
-	nativeContract := bc.contracts.ByHash(sc)
+	nativeContract := bc.contracts.ByHash(note.ScriptHash)
 	if nativeContract != nil {
 		id = nativeContract.Metadata().ID
 	} else {
-		assetContract, err := bc.contracts.Management.GetContract(cache, sc)
+		assetContract, err := bc.contracts.Management.GetContract(d, note.ScriptHash)
 		if err != nil {
-			return
+			return nil, nil
 		}
 		id = assetContract.ID
 	}
-	transfer := &state.NEP17Transfer{
+	return &state.NEP17Transfer{
 		Asset:     id,
-		From:      fromAddr,
-		To:        toAddr,
+		From:      parseUint160(from),
+		To:        parseUint160(to),
+		Amount:    *amount,
 		Block:     b.Index,
 		Timestamp: b.Timestamp,
 		Tx:        h,
+	}, arr
+}
+
+func parseUint160(addr []byte) util.Uint160 {
+	if u, err := util.Uint160DecodeBytesBE(addr); err == nil {
+		return u
 	}
-	if !fromAddr.Equals(util.Uint160{}) {
-		balances, err := cache.GetNEP17Balances(fromAddr)
+	return util.Uint160{}
+}
+
+// processNEP17Transfer updates balances of the accounts participating in the
+// transfer. NEO and GAS balances are used by native contracts, so they're
+// always tracked even in light wallet mode.
+func (bc *Blockchain) processNEP17Transfer(cache *dao.Cached, transfer *state.NEP17Transfer) {
+	isNative := transfer.Asset == bc.contracts.NEO.ID || transfer.Asset == bc.contracts.GAS.ID
+	if !transfer.From.Equals(util.Uint160{}) && (isNative || bc.IsTracked(transfer.From)) {
+		balances, err := cache.GetNEP17Balances(transfer.From)
 		if err != nil {
 			return
 		}
-		bs := balances.Trackers[id]
-		bs.Balance = *new(big.Int).Sub(&bs.Balance, amount)
-		bs.LastUpdatedBlock = b.Index
-		balances.Trackers[id] = bs
-		transfer.Amount = *new(big.Int).Sub(&transfer.Amount, amount)
-		balances.NewBatch, err = cache.AppendNEP17Transfer(fromAddr,
-			balances.NextTransferBatch, balances.NewBatch, transfer)
-		if err != nil {
+		bs := balances.Trackers[transfer.Asset]
+		bs.Balance = *new(big.Int).Sub(&bs.Balance, &transfer.Amount)
+		bs.LastUpdatedBlock = transfer.Block
+		balances.Trackers[transfer.Asset] = bs
+		if err := cache.PutNEP17Balances(transfer.From, balances); err != nil {
 			return
 		}
-		if balances.NewBatch {
-			balances.NextTransferBatch++
+	}
+	if !transfer.To.Equals(util.Uint160{}) && (isNative || bc.IsTracked(transfer.To)) {
+		balances, err := cache.GetNEP17Balances(transfer.To)
+		if err != nil {
+			return
 		}
-		if err := cache.PutNEP17Balances(fromAddr, balances); err != nil {
+		bs := balances.Trackers[transfer.Asset]
+		bs.Balance = *new(big.Int).Add(&bs.Balance, &transfer.Amount)
+		bs.LastUpdatedBlock = transfer.Block
+		balances.Trackers[transfer.Asset] = bs
+		if err := cache.PutNEP17Balances(transfer.To, balances); err != nil {
 			return
 		}
 	}
-	if !toAddr.Equals(util.Uint160{}) {
-		balances, err := cache.GetNEP17Balances(toAddr)
+}
+
+// processNEP11Transfer updates NEP11 balances of the accounts participating
+// in the transfer.
+func (bc *Blockchain) processNEP11Transfer(cache *dao.Cached, transfer *state.NEP11Transfer) {
+	if !transfer.From.Equals(util.Uint160{}) && bc.IsTracked(transfer.From) {
+		balances, err := cache.GetNEP11Balances(transfer.From)
 		if err != nil {
 			return
 		}
-		bs := balances.Trackers[id]
-		bs.Balance = *new(big.Int).Add(&bs.Balance, amount)
-		bs.LastUpdatedBlock = b.Index
-		balances.Trackers[id] = bs
-
-		transfer.Amount = *amount
-		balances.NewBatch, err = cache.AppendNEP17Transfer(toAddr,
-			balances.NextTransferBatch, balances.NewBatch, transfer)
-		if err != nil {
+		balances.Add(transfer.Asset, transfer.ID, new(big.Int).Neg(&transfer.Amount), transfer.Block)
+		if err := cache.PutNEP11Balances(transfer.From, balances); err != nil {
 			return
 		}
-		if balances.NewBatch {
-			balances.NextTransferBatch++
+	}
+	if !transfer.To.Equals(util.Uint160{}) && bc.IsTracked(transfer.To) {
+		balances, err := cache.GetNEP11Balances(transfer.To)
+		if err != nil {
+			return
 		}
-		if err := cache.PutNEP17Balances(toAddr, balances); err != nil {
+		balances.Add(transfer.Asset, transfer.ID, &transfer.Amount, transfer.Block)
+		if err := cache.PutNEP11Balances(transfer.To, balances); err != nil {
 			return
 		}
 	}
 }
 
-// ForEachNEP17Transfer executes f for each nep17 transfer in log.
-func (bc *Blockchain) ForEachNEP17Transfer(acc util.Uint160, f func(*state.NEP17Transfer) (bool, error)) error {
-	balances, err := bc.dao.GetNEP17Balances(acc)
+// GetNEP11Balances returns NEP11 balances for the acc.
+func (bc *Blockchain) GetNEP11Balances(acc util.Uint160) *state.NEP11Balances {
+	bs, err := bc.dao.GetNEP11Balances(acc)
 	if err != nil {
 		return nil
 	}
-	for i := int(balances.NextTransferBatch); i >= 0; i-- {
-		lg, err := bc.dao.GetNEP17TransferLog(acc, uint32(i))
-		if err != nil {
-			return nil
-		}
-		cont, err := lg.ForEach(f)
-		if err != nil {
-			return err
-		}
-		if !cont {
-			break
-		}
-	}
-	return nil
+	return bs
 }
 
 // GetNEP17Balances returns NEP17 balances for the acc.
@@ -1111,6 +1256,14 @@ func (bc *Blockchain) GetTransaction(hash util.Uint256) (*transaction.Transactio
 	return bc.dao.GetTransaction(hash)
 }
 
+// GetTransactionsByAttribute returns at most limit hashes of persisted
+// transactions having the given attribute ordered by the height they were
+// included at skipping the first start ones. Only OracleResponse, Conflicts
+// and NotaryAssisted attributes are supported.
+func (bc *Blockchain) GetTransactionsByAttribute(attr *transaction.Attribute, start, limit int) ([]util.Uint256, error) {
+	return bc.dao.GetTransactionsByAttribute(attr, start, limit)
+}
+
 // GetAppExecResults returns application execution results with the specified trigger by the given
 // tx hash or block hash.
 func (bc *Blockchain) GetAppExecResults(hash util.Uint256, trig trigger.Type) ([]state.AppExecResult, error) {
@@ -1127,6 +1280,12 @@ func (bc *Blockchain) GetStorageItems(id int32) (map[string]state.StorageItem, e
 	return bc.dao.GetStorageItems(id)
 }
 
+// GetStorageItemsWithPrefix returns all storage items with the given prefix
+// for a given contract id. Keys of the returned map don't include the prefix.
+func (bc *Blockchain) GetStorageItemsWithPrefix(id int32, prefix []byte) (map[string]state.StorageItem, error) {
+	return bc.dao.GetStorageItemsWithPrefix(id, prefix)
+}
+
 // GetBlock returns a Block by the given hash.
 func (bc *Blockchain) GetBlock(hash util.Uint256) (*block.Block, error) {
 	topBlock := bc.topBlock.Load()
@@ -1264,6 +1423,28 @@ func (bc *Blockchain) GetNatives() []state.NativeContract {
 	return res
 }
 
+// GetNativeMethods returns prices and required call flags of all methods of the
+// native contract with the given hash, nil is returned for non-native
+// contracts.
+func (bc *Blockchain) GetNativeMethods(h util.Uint160) []state.NativeMethod {
+	c := bc.contracts.ByHash(h)
+	if c == nil {
+		return nil
+	}
+	md := c.Metadata()
+	res := make([]state.NativeMethod, 0, len(md.Methods))
+	for _, m := range md.Methods {
+		res = append(res, state.NativeMethod{
+			Name:          m.MD.Name,
+			ParamCount:    len(m.MD.Parameters),
+			CPUFee:        m.CPUFee,
+			StorageFee:    m.StorageFee,
+			RequiredFlags: m.RequiredFlags,
+		})
+	}
+	return res
+}
+
 // GetConfig returns the config stored in the blockchain.
 func (bc *Blockchain) GetConfig() config.ProtocolConfiguration {
 	return bc.config
@@ -1337,6 +1518,20 @@ func (bc *Blockchain) CalculateClaimable(acc util.Uint160, endHeight uint32) (*b
 	return bc.contracts.NEO.CalculateBonus(bc.dao, acc, endHeight)
 }
 
+// CalculateVoterReward calculates the amount of GAS generated for the account
+// by voting for committee member from the last account's balance change till
+// the specified block. It's a part of the amount returned by
+// CalculateClaimable.
+func (bc *Blockchain) CalculateVoterReward(acc util.Uint160, endHeight uint32) (*big.Int, error) {
+	return bc.contracts.NEO.CalculateVoterReward(bc.dao, acc, endHeight)
+}
+
+// GetGASPerVote returns the amount of GAS per vote (multiplied by 10^8)
+// accumulated by the specified block for the given candidate.
+func (bc *Blockchain) GetGASPerVote(pub *keys.PublicKey, endHeight uint32) *big.Int {
+	return bc.contracts.NEO.GetGASPerVote(bc.dao, pub, endHeight)
+}
+
 // FeePerByte returns transaction network fee per byte.
 func (bc *Blockchain) FeePerByte() int64 {
 	return bc.contracts.Policy.GetFeePerByteInternal(bc.dao)
@@ -1347,15 +1542,40 @@ func (bc *Blockchain) GetMemPool() *mempool.Pool {
 	return bc.memPool
 }
 
+// isZeroFeeTx checks whether transaction sender is exempted from fee per byte
+// policy.
+func (bc *Blockchain) isZeroFeeTx(t *transaction.Transaction) bool {
+	return bc.zeroFeeSenders[t.Sender()]
+}
+
+// isFallbackTx checks whether transaction is a notary fallback transaction,
+// that is it's paid by the Notary contract and has NotaryAssisted attribute
+// with zero keys.
+func (bc *Blockchain) isFallbackTx(t *transaction.Transaction) bool {
+	if !t.Sender().Equals(bc.contracts.Notary.Hash) {
+		return false
+	}
+	attrs := t.GetAttributes(transaction.NotaryAssistedT)
+	return len(attrs) != 0 && attrs[0].Value.(*transaction.NotaryAssisted).NKeys == 0
+}
+
+// txFeePerByte returns fee per byte required for the given transaction.
+func (bc *Blockchain) txFeePerByte(t *transaction.Transaction) int64 {
+	if bc.isZeroFeeTx(t) {
+		return 0
+	}
+	return bc.FeePerByte()
+}
+
 // ApplyPolicyToTxSet applies configured policies to given transaction set. It
 // expects slice to be ordered by fee and returns a subslice of it.
 func (bc *Blockchain) ApplyPolicyToTxSet(txes []*transaction.Transaction) []*transaction.Transaction {
-	maxTx := bc.config.MaxTransactionsPerBlock
+	maxTx := bc.GetMaxTransactionsPerBlock()
 	if maxTx != 0 && len(txes) > int(maxTx) {
 		txes = txes[:maxTx]
 	}
-	maxBlockSize := bc.GetConfig().MaxBlockSize
-	maxBlockSysFee := bc.GetConfig().MaxBlockSystemFee
+	maxBlockSize := bc.GetMaxBlockSize()
+	maxBlockSysFee := bc.GetMaxBlockSystemFee()
 	defaultWitness := bc.defaultBlockWitness.Load()
 	if defaultWitness == nil {
 		m := smartcontract.GetDefaultHonestNodeCount(bc.config.ValidatorsCount)
@@ -1382,6 +1602,25 @@ func (bc *Blockchain) ApplyPolicyToTxSet(txes []*transaction.Transaction) []*tra
 	return txes
 }
 
+// verifyBlockLimits checks whether the given block conforms to the block limits
+// set by Policy contract.
+func (bc *Blockchain) verifyBlockLimits(b *block.Block) error {
+	if maxTx := bc.GetMaxTransactionsPerBlock(); len(b.Transactions) > int(maxTx) {
+		return fmt.Errorf("%w: %d transactions, max = %d", ErrBlockLimitExceeded, len(b.Transactions), maxTx)
+	}
+	if size, maxSize := b.GetExpectedBlockSize(), bc.GetMaxBlockSize(); size > int(maxSize) {
+		return fmt.Errorf("%w: size is %d, max = %d", ErrBlockLimitExceeded, size, maxSize)
+	}
+	var sysFee int64
+	for _, tx := range b.Transactions {
+		sysFee += tx.SystemFee
+	}
+	if maxSysFee := bc.GetMaxBlockSystemFee(); sysFee > maxSysFee {
+		return fmt.Errorf("%w: system fee is %d, max = %d", ErrBlockLimitExceeded, sysFee, maxSysFee)
+	}
+	return nil
+}
+
 // Various errors that could be returns upon header verification.
 var (
 	ErrHdrHashMismatch     = errors.New("previous header hash doesn't match")
@@ -1439,7 +1678,7 @@ func (bc *Blockchain) verifyAndPoolTx(t *transaction.Transaction, pool *mempool.
 	if size > transaction.MaxTransactionSize {
 		return fmt.Errorf("%w: (%d > MaxTransactionSize %d)", ErrTxTooBig, size, transaction.MaxTransactionSize)
 	}
-	needNetworkFee := int64(size) * bc.FeePerByte()
+	needNetworkFee := int64(size) * bc.txFeePerByte(t)
 	if bc.P2PSigExtensionsEnabled() {
 		attrs := t.GetAttributes(transaction.NotaryAssistedT)
 		if len(attrs) != 0 {
@@ -1466,10 +1705,26 @@ func (bc *Blockchain) verifyAndPoolTx(t *transaction.Transaction, pool *mempool.
 	if err != nil {
 		return err
 	}
-	if err := bc.verifyTxAttributes(t, isPartialTx); err != nil {
+	// Completed transactions that are not yet valid can be postponed until
+	// their NotValidBefore height if the pool allows it.
+	nvb := getNotValidBefore(t)
+	postpone := !isPartialTx && nvb > height && pool.PostponedCapacity() > 0
+	if postpone {
+		if t.ValidUntilBlock <= nvb {
+			return fmt.Errorf("%w: ValidUntilBlock = %d, NotValidBefore = %d", ErrTxExpired, t.ValidUntilBlock, nvb)
+		}
+		err = bc.verifyTxAttributes(t, isPartialTx, nvb)
+	} else {
+		err = bc.verifyTxAttributes(t, isPartialTx, height)
+	}
+	if err != nil {
 		return err
 	}
-	err = pool.Add(t, feer, data...)
+	if postpone {
+		err = pool.Postpone(t, nvb, feer)
+	} else {
+		err = pool.Add(t, feer, data...)
+	}
 	if err != nil {
 		switch {
 		case errors.Is(err, mempool.ErrConflict):
@@ -1480,6 +1735,8 @@ func (bc *Blockchain) verifyAndPoolTx(t *transaction.Transaction, pool *mempool.
 			return ErrInsufficientFunds
 		case errors.Is(err, mempool.ErrOOM):
 			return ErrOOM
+		case errors.Is(err, mempool.ErrSenderLimit):
+			return fmt.Errorf("%w: %s", ErrOOM, err)
 		case errors.Is(err, mempool.ErrConflictsAttribute):
 			return fmt.Errorf("mempool: %w: %s", ErrHasConflicts, err)
 		default:
@@ -1490,7 +1747,19 @@ func (bc *Blockchain) verifyAndPoolTx(t *transaction.Transaction, pool *mempool.
 	return nil
 }
 
-func (bc *Blockchain) verifyTxAttributes(tx *transaction.Transaction, isPartialTx bool) error {
+// getNotValidBefore returns the height specified in NotValidBefore attribute
+// of the transaction or 0 if there is no such attribute.
+func getNotValidBefore(tx *transaction.Transaction) uint32 {
+	attrs := tx.GetAttributes(transaction.NotValidBeforeT)
+	if len(attrs) == 0 {
+		return 0
+	}
+	return attrs[0].Value.(*transaction.NotValidBefore).Height
+}
+
+// verifyTxAttributes checks transaction attributes, NotValidBefore of
+// completed transactions is checked against the given height.
+func (bc *Blockchain) verifyTxAttributes(tx *transaction.Transaction, isPartialTx bool, height uint32) error {
 	for i := range tx.Attributes {
 		switch attrType := tx.Attributes[i].Type; attrType {
 		case transaction.HighPriority:
@@ -1540,7 +1809,7 @@ func (bc *Blockchain) verifyTxAttributes(tx *transaction.Transaction, isPartialT
 					return fmt.Errorf("%w: partially-filled transaction should be valid during less than %d blocks", ErrInvalidAttribute, maxNVBDelta)
 				}
 			} else {
-				if height := bc.BlockHeight(); height < nvb {
+				if height < nvb {
 					return fmt.Errorf("%w: transaction is not yet valid: NotValidBefore = %d, current height = %d", ErrInvalidAttribute, nvb, height)
 				}
 			}
@@ -1588,7 +1857,7 @@ func (bc *Blockchain) IsTxStillRelevant(t *transaction.Transaction, txpool *memp
 	} else if txpool.HasConflicts(t, bc) {
 		return false
 	}
-	if err := bc.verifyTxAttributes(t, isPartialTx); err != nil {
+	if err := bc.verifyTxAttributes(t, isPartialTx, curheight); err != nil {
 		return false
 	}
 	for i := range t.Scripts {
@@ -1604,6 +1873,27 @@ func (bc *Blockchain) IsTxStillRelevant(t *transaction.Transaction, txpool *memp
 
 }
 
+// isPooledTxStillRelevant is the same as IsTxStillRelevant, but it also returns
+// the reason for mempooled transaction removal if it's no longer relevant.
+func (bc *Blockchain) isPooledTxStillRelevant(t *transaction.Transaction, txpool *mempool.Pool) (bool, mempool.RemovalReason) {
+	if txpool != nil {
+		if txpool.ContainsKey(t.Hash()) {
+			return false, mempool.RemovedInBlock
+		}
+		if txpool.HasConflicts(t, bc) {
+			return false, mempool.RemovedConflict
+		}
+	} else {
+		switch bc.dao.HasTransaction(t.Hash()) {
+		case dao.ErrAlreadyExists:
+			return false, mempool.RemovedInBlock
+		case dao.ErrHasConflicts:
+			return false, mempool.RemovedConflict
+		}
+	}
+	return bc.IsTxStillRelevant(t, txpool, false), mempool.RemovedStale
+}
+
 // VerifyTx verifies whether transaction is bonafide or not relative to the
 // current blockchain state. Note that this verification is completely isolated
 // from the main node's mempool.
@@ -1672,31 +1962,99 @@ func (bc *Blockchain) GetNextBlockValidators() ([]*keys.PublicKey, error) {
 	return bc.contracts.NEO.GetNextBlockValidatorsInternal(), nil
 }
 
+// GetDesignatedByRole returns nodes designated for the given role at the
+// given height along with the height this designation was made at.
+func (bc *Blockchain) GetDesignatedByRole(r noderoles.Role, index uint32) (keys.PublicKeys, uint32, error) {
+	return bc.contracts.Designate.GetDesignatedByRole(bc.dao, r, index)
+}
+
 // GetEnrollments returns all registered validators.
 func (bc *Blockchain) GetEnrollments() ([]state.Validator, error) {
 	return bc.contracts.NEO.GetCandidates(bc.dao)
 }
 
-// GetTestVM returns a VM and a Store setup for a test run of some sort of code.
-func (bc *Blockchain) GetTestVM(t trigger.Type, tx *transaction.Transaction, b *block.Block) *vm.VM {
+// GetTestVM returns a VM and a DAO setup for a test run of some sort of code.
+// Storage changes made by the script can be retrieved from the DAO batch, they
+// are never persisted.
+func (bc *Blockchain) GetTestVM(t trigger.Type, tx *transaction.Transaction, b *block.Block) (*vm.VM, dao.DAO) {
+	return bc.getTestVM(bc.dao.GetWrapped().(*dao.Simple), t, tx, b)
+}
+
+// GetTestVMWithOverride is the same as GetTestVM, but it applies the given
+// state changes to the VM's storage, so that scripts can be tested against
+// the state that is hard to get on the real chain. Changes are never persisted.
+func (bc *Blockchain) GetTestVMWithOverride(t trigger.Type, tx *transaction.Transaction, b *block.Block, ov *state.Override) (*vm.VM, dao.DAO, error) {
 	d := bc.dao.GetWrapped().(*dao.Simple)
+	for i, bo := range ov.Balances {
+		var err error
+		amount := big.NewInt(bo.Amount)
+		switch bo.Asset {
+		case bc.contracts.GAS.Hash:
+			err = bc.contracts.GAS.SetBalance(d, bo.Account, amount)
+		case bc.contracts.NEO.Hash:
+			err = bc.contracts.NEO.SetBalance(d, bo.Account, amount, bc.BlockHeight())
+		default:
+			err = errors.New("only NEO and GAS balances can be changed")
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("balance override #%d: %w", i, err)
+		}
+	}
+	for i, so := range ov.Storage {
+		cs, err := bc.contracts.Management.GetContract(d, so.Contract)
+		if err != nil {
+			return nil, nil, fmt.Errorf("storage override #%d: %w", i, err)
+		}
+		if so.Value == nil {
+			err = d.DeleteStorageItem(cs.ID, so.Key)
+		} else {
+			err = d.PutStorageItem(cs.ID, so.Key, so.Value)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("storage override #%d: %w", i, err)
+		}
+	}
+	// One more layer is added, so that the batch only contains changes
+	// made by the script.
+	v, vd := bc.getTestVM(d.GetWrapped().(*dao.Simple), t, tx, b)
+	return v, vd, nil
+}
+
+// GetTestHistoricVM is the same as GetTestVM, but contract storage (including
+// native contracts one) is taken from the state with the given root, so it
+// requires all historical states to be kept. Native contract caches (like
+// policy values or deployed contracts list) still reflect the latest state.
+func (bc *Blockchain) GetTestHistoricVM(t trigger.Type, tx *transaction.Transaction, b *block.Block, root util.Uint256) (*vm.VM, dao.DAO, error) {
+	if bc.config.KeepOnlyLatestState {
+		return nil, nil, errors.New("only latest state is kept")
+	}
+	s, err := mpt.NewTrieStore(root, bc.dao.Store)
+	if err != nil {
+		return nil, nil, err
+	}
+	d := dao.NewSimple(s, bc.config.Magic, bc.config.StateRootInHeader)
+	v, vd := bc.getTestVM(d, t, tx, b)
+	return v, vd, nil
+}
+
+func (bc *Blockchain) getTestVM(d *dao.Simple, t trigger.Type, tx *transaction.Transaction, b *block.Block) (*vm.VM, dao.DAO) {
 	systemInterop := bc.newInteropContext(t, d, b, tx)
 	vm := systemInterop.SpawnVM()
 	vm.SetPriceGetter(systemInterop.GetPrice)
 	vm.LoadToken = contract.LoadToken(systemInterop)
-	return vm
+	return vm, d
 }
 
-// Various witness verification errors.
+// Various witness verification errors, all of them wrap ErrVerificationFailed.
 var (
-	ErrWitnessHashMismatch         = errors.New("witness hash mismatch")
-	ErrNativeContractWitness       = errors.New("native contract witness must have empty verification script")
-	ErrVerificationFailed          = errors.New("signature check failed")
-	ErrInvalidInvocation           = errors.New("invalid invocation script")
+	ErrVerificationFailed          = errors.New("witness verification failed")
+	ErrWitnessHashMismatch         = fmt.Errorf("%w: witness hash mismatch", ErrVerificationFailed)
+	ErrNativeContractWitness       = fmt.Errorf("%w: native contract witness must have empty verification script", ErrVerificationFailed)
+	ErrInvalidInvocation           = fmt.Errorf("%w: invalid invocation script", ErrVerificationFailed)
 	ErrInvalidSignature            = fmt.Errorf("%w: invalid signature", ErrVerificationFailed)
-	ErrInvalidVerification         = errors.New("invalid verification script")
-	ErrUnknownVerificationContract = errors.New("unknown verification contract")
-	ErrInvalidVerificationContract = errors.New("verification contract is missing `verify` method")
+	ErrInvalidVerification         = fmt.Errorf("%w: invalid verification script", ErrVerificationFailed)
+	ErrUnknownVerificationContract = fmt.Errorf("%w: unknown verification contract", ErrVerificationFailed)
+	ErrInvalidVerificationContract = fmt.Errorf("%w: verification contract is missing `verify` method", ErrVerificationFailed)
 )
 
 // InitVerificationVM initializes VM for witness check.
@@ -1793,7 +2151,7 @@ func (bc *Blockchain) verifyHashAgainstScript(hash util.Uint160, witness *transa
 // Golang implementation of VerifyWitnesses method in C# (https://github.com/neo-project/neo/blob/master/neo/SmartContract/Helper.cs#L87).
 func (bc *Blockchain) verifyTxWitnesses(t *transaction.Transaction, block *block.Block, isPartialTx bool) error {
 	interopCtx := bc.newInteropContext(trigger.Verification, bc.dao, block, t)
-	gasLimit := t.NetworkFee - int64(t.Size())*bc.FeePerByte()
+	gasLimit := t.NetworkFee - int64(t.Size())*bc.txFeePerByte(t)
 	if bc.P2PSigExtensionsEnabled() {
 		attrs := t.GetAttributes(transaction.NotaryAssistedT)
 		if len(attrs) != 0 {
@@ -1849,6 +2207,9 @@ func hashAndIndexToBytes(h util.Uint256, index uint32) []byte {
 func (bc *Blockchain) newInteropContext(trigger trigger.Type, d dao.DAO, block *block.Block, tx *transaction.Transaction) *interop.Context {
 	ic := interop.NewContext(trigger, bc, d, bc.contracts.Management.GetContract, bc.contracts.Contracts, block, tx, bc.log)
 	ic.Functions = [][]interop.Function{systemInterops, neoInterops}
+	if len(bc.customInterops) != 0 {
+		ic.Functions = append(ic.Functions, bc.customInterops)
+	}
 	switch {
 	case tx != nil:
 		ic.Container = tx
@@ -1886,6 +2247,35 @@ func (bc *Blockchain) GetMaxVerificationGAS() int64 {
 	return bc.contracts.Policy.GetMaxVerificationGas(bc.dao)
 }
 
+// GetMaxBlockSize returns the maximum allowed block size, it's managed by
+// Policy contract if PolicyBlockLimits setting is enabled.
+func (bc *Blockchain) GetMaxBlockSize() uint32 {
+	if !bc.config.PolicyBlockLimits {
+		return bc.config.MaxBlockSize
+	}
+	return bc.contracts.Policy.GetMaxBlockSizeInternal(bc.dao)
+}
+
+// GetMaxBlockSystemFee returns the maximum allowed system fee of all
+// transactions in a block, it's managed by Policy contract if
+// PolicyBlockLimits setting is enabled.
+func (bc *Blockchain) GetMaxBlockSystemFee() int64 {
+	if !bc.config.PolicyBlockLimits {
+		return bc.config.MaxBlockSystemFee
+	}
+	return bc.contracts.Policy.GetMaxBlockSystemFeeInternal(bc.dao)
+}
+
+// GetMaxTransactionsPerBlock returns the maximum allowed number of
+// transactions in a block, it's managed by Policy contract if
+// PolicyBlockLimits setting is enabled.
+func (bc *Blockchain) GetMaxTransactionsPerBlock() uint16 {
+	if !bc.config.PolicyBlockLimits {
+		return bc.config.MaxTransactionsPerBlock
+	}
+	return bc.contracts.Policy.GetMaxTransactionsPerBlockInternal(bc.dao)
+}
+
 // GetStoragePrice returns current storage price.
 func (bc *Blockchain) GetStoragePrice() int64 {
 	if bc.BlockHeight() == 0 {
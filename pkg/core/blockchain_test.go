@@ -41,6 +41,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
 )
 
 func TestVerifyHeader(t *testing.T) {
@@ -1281,6 +1282,88 @@ func TestMemPoolRemoval(t *testing.T) {
 	}
 }
 
+func TestZeroFeeSenders(t *testing.T) {
+	newTx := func(bc *Blockchain) *transaction.Transaction {
+		tx := transaction.New(testchain.Network(), []byte{byte(opcode.PUSH1)}, 0)
+		tx.Nonce = rand.Uint32()
+		tx.ValidUntilBlock = bc.BlockHeight() + 1
+		tx.Signers = []transaction.Signer{{Account: neoOwner}}
+		// Only verification is paid for.
+		tx.NetworkFee, _ = fee.Calculate(bc.GetBaseExecFee(), testchain.MultisigVerificationScript())
+		tx.Scripts = []transaction.Witness{{
+			InvocationScript:   testchain.Sign(tx.GetSignedPart()),
+			VerificationScript: testchain.MultisigVerificationScript(),
+		}}
+		return tx
+	}
+
+	bc := newTestChain(t)
+	require.True(t, errors.Is(bc.PoolTx(newTx(bc)), ErrVerificationFailed))
+
+	bc = newTestChainWithCustomCfg(t, func(c *config.Config) {
+		c.ProtocolConfiguration.ZeroFeeSenders = []string{testchain.MultisigAddress()}
+		c.ProtocolConfiguration.ZeroFeeMemPoolSize = 1
+	})
+	tx := newTx(bc)
+	require.NoError(t, bc.PoolTx(tx))
+	// Regular transactions don't compete with zero fee ones.
+	regular := bc.newTestTx(neoOwner, []byte{byte(opcode.PUSH1)})
+	require.NoError(t, testchain.SignTx(bc, regular))
+	require.NoError(t, bc.PoolTx(regular))
+	require.True(t, errors.Is(bc.PoolTx(newTx(bc)), ErrOOM))
+
+	require.NoError(t, bc.AddBlock(bc.newBlock(tx, regular)))
+	require.Equal(t, 0, bc.GetMemPool().Count())
+
+	t.Run("invalid sender", func(t *testing.T) {
+		cfg, err := config.Load("../../config", testchain.Network())
+		require.NoError(t, err)
+		cfg.ProtocolConfiguration.ZeroFeeSenders = []string{"garbage"}
+		_, err = NewBlockchain(storage.NewMemoryStore(), cfg.ProtocolConfiguration, zaptest.NewLogger(t))
+		require.Error(t, err)
+	})
+}
+
+func TestPostponedTransactions(t *testing.T) {
+	newNVBTx := func(bc *Blockchain, nvb, vub uint32) *transaction.Transaction {
+		tx := bc.newTestTx(neoOwner, []byte{byte(opcode.PUSH1)})
+		tx.ValidUntilBlock = vub
+		tx.Attributes = []transaction.Attribute{{
+			Type:  transaction.NotValidBeforeT,
+			Value: &transaction.NotValidBefore{Height: nvb},
+		}}
+		require.NoError(t, testchain.SignTx(bc, tx))
+		return tx
+	}
+
+	bc := newTestChainWithCustomCfg(t, func(c *config.Config) {
+		c.ProtocolConfiguration.P2PSigExtensions = true
+	})
+	h := bc.BlockHeight()
+	require.True(t, errors.Is(bc.PoolTx(newNVBTx(bc, h+1, h+10)), ErrInvalidAttribute))
+
+	bc = newTestChainWithCustomCfg(t, func(c *config.Config) {
+		c.ProtocolConfiguration.P2PSigExtensions = true
+		c.ProtocolConfiguration.MemPoolPostponedSize = 1
+	})
+	mp := bc.GetMemPool()
+	h = bc.BlockHeight()
+	tx := newNVBTx(bc, h+2, h+10)
+	require.NoError(t, bc.PoolTx(tx))
+	require.True(t, errors.Is(bc.PoolTx(tx), ErrAlreadyExists))
+	require.True(t, mp.ContainsPostponed(tx.Hash()))
+	require.False(t, mp.ContainsKey(tx.Hash()))
+	require.True(t, errors.Is(bc.PoolTx(newNVBTx(bc, h+2, h+2)), ErrTxExpired))
+
+	require.NoError(t, bc.AddBlock(bc.newBlock()))
+	require.True(t, mp.ContainsPostponed(tx.Hash()))
+	require.False(t, mp.ContainsKey(tx.Hash()))
+
+	require.NoError(t, bc.AddBlock(bc.newBlock()))
+	require.False(t, mp.ContainsPostponed(tx.Hash()))
+	require.True(t, mp.ContainsKey(tx.Hash()))
+}
+
 func TestHasBlock(t *testing.T) {
 	bc := newTestChain(t)
 	blocks, err := bc.genBlocks(50)
@@ -1628,7 +1711,7 @@ func TestConfigNativeUpdateHistory(t *testing.T) {
 		cfgPath := path.Join(prefixPath, fmt.Sprintf("protocol.%s.yml", cfgFileSuffix))
 		cfg, err := config.LoadFile(cfgPath)
 		require.NoError(t, err, fmt.Errorf("failed to load %s", cfgPath))
-		natives := native.NewContracts(cfg.ProtocolConfiguration.P2PSigExtensions, map[string][]uint32{})
+		natives := native.NewContracts(cfg.ProtocolConfiguration)
 		assert.Equal(t, len(natives.Contracts),
 			len(cfg.ProtocolConfiguration.NativeUpdateHistories),
 			fmt.Errorf("protocol configuration file %s: extra or missing NativeUpdateHistory in NativeActivations section", cfgPath))
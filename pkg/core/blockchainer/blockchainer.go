@@ -6,7 +6,9 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/blockchainer/services"
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto"
@@ -23,6 +25,7 @@ type Blockchainer interface {
 	GetConfig() config.ProtocolConfiguration
 	AddHeaders(...*block.Header) error
 	AddBlock(*block.Block) error
+	AddWatched(...util.Uint160) error
 	CalculateClaimable(h util.Uint160, endHeight uint32) (*big.Int, error)
 	Close()
 	InitVerificationVM(v *vm.VM, getContract func(util.Uint160) (*state.Contract, error), hash util.Uint160, witness *transaction.Witness) error
@@ -32,8 +35,10 @@ type Blockchainer interface {
 	GetCommittee() (keys.PublicKeys, error)
 	GetContractState(hash util.Uint160) *state.Contract
 	GetContractScriptHash(id int32) (util.Uint160, error)
+	GetDesignatedByRole(noderoles.Role, uint32) (keys.PublicKeys, uint32, error)
 	GetEnrollments() ([]state.Validator, error)
 	GetGoverningTokenBalance(acc util.Uint160) (*big.Int, uint32)
+	ForEachNEP11Transfer(util.Uint160, func(*state.NEP11Transfer) (bool, error)) error
 	ForEachNEP17Transfer(util.Uint160, func(*state.NEP17Transfer) (bool, error)) error
 	GetHeaderHash(int) util.Uint256
 	GetHeader(hash util.Uint256) (*block.Header, error)
@@ -42,29 +47,38 @@ type Blockchainer interface {
 	HasBlock(util.Uint256) bool
 	HasTransaction(util.Uint256) bool
 	IsExtensibleAllowed(util.Uint160) bool
+	IsTracked(util.Uint160) bool
 	GetAppExecResults(util.Uint256, trigger.Type) ([]state.AppExecResult, error)
 	GetNotaryDepositExpiration(acc util.Uint160) uint32
 	GetNativeContractScriptHash(string) (util.Uint160, error)
 	GetNatives() []state.NativeContract
+	GetNativeMethods(util.Uint160) []state.NativeMethod
 	GetNextBlockValidators() ([]*keys.PublicKey, error)
+	GetNEP11Balances(util.Uint160) *state.NEP11Balances
 	GetNEP17Balances(util.Uint160) *state.NEP17Balances
 	GetNotaryContractScriptHash() util.Uint160
 	GetNotaryBalance(acc util.Uint160) *big.Int
 	GetPolicer() Policer
 	GetValidators() ([]*keys.PublicKey, error)
+	GetWatched() ([]util.Uint160, error)
 	GetStandByCommittee() keys.PublicKeys
 	GetStandByValidators() keys.PublicKeys
 	GetStateModule() StateRoot
 	GetStorageItem(id int32, key []byte) state.StorageItem
 	GetStorageItems(id int32) (map[string]state.StorageItem, error)
-	GetTestVM(t trigger.Type, tx *transaction.Transaction, b *block.Block) *vm.VM
+	GetStorageItemsWithPrefix(id int32, prefix []byte) (map[string]state.StorageItem, error)
+	GetTestHistoricVM(t trigger.Type, tx *transaction.Transaction, b *block.Block, root util.Uint256) (*vm.VM, dao.DAO, error)
+	GetTestVM(t trigger.Type, tx *transaction.Transaction, b *block.Block) (*vm.VM, dao.DAO)
+	GetTestVMWithOverride(t trigger.Type, tx *transaction.Transaction, b *block.Block, ov *state.Override) (*vm.VM, dao.DAO, error)
 	GetTransaction(util.Uint256) (*transaction.Transaction, uint32, error)
+	GetTransactionsByAttribute(attr *transaction.Attribute, start, limit int) ([]util.Uint256, error)
 	SetOracle(service services.Oracle)
 	mempool.Feer // fee interface
 	ManagementContractHash() util.Uint160
 	PoolTx(t *transaction.Transaction, pools ...*mempool.Pool) error
 	PoolTxWithData(t *transaction.Transaction, data interface{}, mp *mempool.Pool, feer mempool.Feer, verificationFunction func(bc Blockchainer, t *transaction.Transaction, data interface{}) error) error
 	RegisterPostBlock(f func(Blockchainer, *mempool.Pool, *block.Block))
+	RemoveWatched(...util.Uint160) error
 	SetNotary(mod services.Notary)
 	SubscribeForBlocks(ch chan<- *block.Block)
 	SubscribeForExecutions(ch chan<- *state.AppExecResult)
@@ -73,6 +87,9 @@ type Blockchainer interface {
 	VerifyTx(*transaction.Transaction) error
 	VerifyWitness(util.Uint160, crypto.Verifiable, *transaction.Witness, int64) error
 	GetMemPool() *mempool.Pool
+	GetMaxBlockSize() uint32
+	GetMaxBlockSystemFee() int64
+	GetMaxTransactionsPerBlock() uint16
 	UnsubscribeFromBlocks(ch chan<- *block.Block)
 	UnsubscribeFromExecutions(ch chan<- *state.AppExecResult)
 	UnsubscribeFromNotifications(ch chan<- *state.NotificationEvent)
@@ -1,6 +1,7 @@
 package blockchainer
 
 import (
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -11,9 +12,13 @@ type StateRoot interface {
 	AddStateRoot(root *state.MPTRoot) error
 	CurrentLocalStateRoot() util.Uint256
 	CurrentValidatedHeight() uint32
+	FindStates(root util.Uint256, prefix, start []byte, max int) ([]mpt.KeyValue, error)
+	GetState(root util.Uint256, key []byte) ([]byte, error)
 	GetStateProof(root util.Uint256, key []byte) ([][]byte, error)
 	GetStateRoot(height uint32) (*state.MPTRoot, error)
 	GetStateValidators(height uint32) keys.PublicKeys
 	SetUpdateValidatorsCallback(func(uint32, keys.PublicKeys))
+	SubscribeForStateRoots(ch chan<- *state.MPTRoot)
+	UnsubscribeFromStateRoots(ch chan<- *state.MPTRoot)
 	UpdateStateValidators(height uint32, pubs keys.PublicKeys)
 }
@@ -25,11 +25,15 @@ var (
 	// ErrHasConflicts is returned when transaction is in the list of conflicting
 	// transactions which are already in dao.
 	ErrHasConflicts = errors.New("transaction has conflicts")
+	// ErrAttributeNotIndexed is returned when transactions are requested by
+	// the attribute which is not indexed.
+	ErrAttributeNotIndexed = errors.New("attribute is not indexed")
 )
 
 // DAO is a data access object.
 type DAO interface {
 	AppendAppExecResult(aer *state.AppExecResult, buf *io.BufBinWriter) error
+	AppendNEP11Transfer(acc util.Uint160, index uint32, isNew bool, tr *state.NEP11Transfer) (bool, error)
 	AppendNEP17Transfer(acc util.Uint160, index uint32, isNew bool, tr *state.NEP17Transfer) (bool, error)
 	DeleteBlock(h util.Uint256, buf *io.BufBinWriter) error
 	DeleteContractID(id int32) error
@@ -42,27 +46,42 @@ type DAO interface {
 	GetCurrentBlockHeight() (uint32, error)
 	GetCurrentHeaderHeight() (i uint32, h util.Uint256, err error)
 	GetHeaderHashes() ([]util.Uint256, error)
+	GetNEP11Balances(acc util.Uint160) (*state.NEP11Balances, error)
+	GetNEP11TransferInfo(acc util.Uint160) (*state.NEP11TransferInfo, error)
+	GetNEP11TransferLog(acc util.Uint160, index uint32) (*state.NEP11TransferLog, error)
 	GetNEP17Balances(acc util.Uint160) (*state.NEP17Balances, error)
+	GetNEP17TransferInfo(acc util.Uint160) (*state.NEP17TransferInfo, error)
 	GetNEP17TransferLog(acc util.Uint160, index uint32) (*state.NEP17TransferLog, error)
 	GetStorageItem(id int32, key []byte) state.StorageItem
 	GetStorageItems(id int32) (map[string]state.StorageItem, error)
 	GetStorageItemsWithPrefix(id int32, prefix []byte) (map[string]state.StorageItem, error)
 	GetTransaction(hash util.Uint256) (*transaction.Transaction, uint32, error)
+	GetTransactionsByAttribute(attr *transaction.Attribute, start, limit int) ([]util.Uint256, error)
+	GetTransferLogHeight() (uint32, error)
 	GetVersion() (string, error)
+	GetWatchList() ([]util.Uint160, error)
 	GetWrapped() DAO
 	HasTransaction(hash util.Uint256) error
+	HasTransactionAttributes(tx *transaction.Transaction, index uint32) bool
 	Persist() (int, error)
 	PutAppExecResult(aer *state.AppExecResult, buf *io.BufBinWriter) error
 	PutContractID(id int32, hash util.Uint160) error
 	PutCurrentHeader(hashAndIndex []byte) error
+	PutNEP11Balances(acc util.Uint160, bs *state.NEP11Balances) error
+	PutNEP11TransferInfo(acc util.Uint160, info *state.NEP11TransferInfo) error
+	PutNEP11TransferLog(acc util.Uint160, index uint32, lg *state.NEP11TransferLog) error
 	PutNEP17Balances(acc util.Uint160, bs *state.NEP17Balances) error
+	PutNEP17TransferInfo(acc util.Uint160, info *state.NEP17TransferInfo) error
 	PutNEP17TransferLog(acc util.Uint160, index uint32, lg *state.NEP17TransferLog) error
 	PutStorageItem(id int32, key []byte, si state.StorageItem) error
+	PutTransferLogHeight(index uint32) error
 	PutVersion(v string) error
+	PutWatchList(hashes []util.Uint160) error
 	Seek(id int32, prefix []byte, f func(k, v []byte))
 	StoreAsBlock(block *block.Block, buf *io.BufBinWriter) error
 	StoreAsCurrentBlock(block *block.Block, buf *io.BufBinWriter) error
 	StoreAsTransaction(tx *transaction.Transaction, index uint32, buf *io.BufBinWriter) error
+	StoreTransactionAttributes(tx *transaction.Transaction, index uint32) error
 	putNEP17Balances(acc util.Uint160, bs *state.NEP17Balances, buf *io.BufBinWriter) error
 }
 
@@ -170,6 +189,71 @@ func (dao *Simple) putNEP17Balances(acc util.Uint160, bs *state.NEP17Balances, b
 
 // -- start transfer log.
 
+// GetNEP17TransferInfo retrieves the state of account's transfer log.
+func (dao *Simple) GetNEP17TransferInfo(acc util.Uint160) (*state.NEP17TransferInfo, error) {
+	key := storage.AppendPrefix(storage.STNEP17TransferInfo, acc.BytesBE())
+	info := new(state.NEP17TransferInfo)
+	err := dao.GetAndDecode(info, key)
+	if err != nil && err != storage.ErrKeyNotFound {
+		return nil, err
+	}
+	return info, nil
+}
+
+// PutNEP17TransferInfo saves the state of account's transfer log.
+func (dao *Simple) PutNEP17TransferInfo(acc util.Uint160, info *state.NEP17TransferInfo) error {
+	key := storage.AppendPrefix(storage.STNEP17TransferInfo, acc.BytesBE())
+	return dao.Put(info, key)
+}
+
+// GetTransferLogHeight returns the index of the last block which transfers
+// were written into transfer logs, storage.ErrKeyNotFound is returned if there
+// were none.
+func (dao *Simple) GetTransferLogHeight() (uint32, error) {
+	b, err := dao.Store.Get(storage.SYSTransferLogHeight.Bytes())
+	if err != nil {
+		return 0, err
+	}
+	if len(b) != 4 {
+		return 0, errors.New("invalid transfer log height")
+	}
+	return binary.LittleEndian.Uint32(b), nil
+}
+
+// PutTransferLogHeight saves the index of the last block which transfers were
+// written into transfer logs.
+func (dao *Simple) PutTransferLogHeight(index uint32) error {
+	b := make([]byte, 4)
+	binary.LittleEndian.PutUint32(b, index)
+	return dao.Store.Put(storage.SYSTransferLogHeight.Bytes(), b)
+}
+
+// GetWatchList returns the list of script hashes watched in light wallet mode,
+// storage.ErrKeyNotFound is returned if it was never saved.
+func (dao *Simple) GetWatchList() ([]util.Uint160, error) {
+	b, err := dao.Store.Get(storage.SYSWatchList.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	var hashes []util.Uint160
+	r := io.NewBinReaderFromBuf(b)
+	r.ReadArray(&hashes)
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	return hashes, nil
+}
+
+// PutWatchList saves the list of script hashes watched in light wallet mode.
+func (dao *Simple) PutWatchList(hashes []util.Uint160) error {
+	w := io.NewBufBinWriter()
+	w.WriteArray(hashes)
+	if w.Err != nil {
+		return w.Err
+	}
+	return dao.Store.Put(storage.SYSWatchList.Bytes(), w.Bytes())
+}
+
 func getNEP17TransferLogKey(acc util.Uint160, index uint32) []byte {
 	key := make([]byte, 1+util.Uint160Size+4)
 	key[0] = byte(storage.STNEP17Transfers)
@@ -218,6 +302,90 @@ func (dao *Simple) AppendNEP17Transfer(acc util.Uint160, index uint32, isNew boo
 
 // -- end transfer log.
 
+// -- start nep11 balances and transfer log.
+
+// GetNEP11Balances retrieves nep11 balances from the cache.
+func (dao *Simple) GetNEP11Balances(acc util.Uint160) (*state.NEP11Balances, error) {
+	key := storage.AppendPrefix(storage.STNEP11Balances, acc.BytesBE())
+	bs := state.NewNEP11Balances()
+	err := dao.GetAndDecode(bs, key)
+	if err != nil && err != storage.ErrKeyNotFound {
+		return nil, err
+	}
+	return bs, nil
+}
+
+// PutNEP11Balances saves nep11 balances from the cache.
+func (dao *Simple) PutNEP11Balances(acc util.Uint160, bs *state.NEP11Balances) error {
+	key := storage.AppendPrefix(storage.STNEP11Balances, acc.BytesBE())
+	return dao.Put(bs, key)
+}
+
+// GetNEP11TransferInfo retrieves the state of account's nep11 transfer log.
+func (dao *Simple) GetNEP11TransferInfo(acc util.Uint160) (*state.NEP11TransferInfo, error) {
+	key := storage.AppendPrefix(storage.STNEP11TransferInfo, acc.BytesBE())
+	info := new(state.NEP11TransferInfo)
+	err := dao.GetAndDecode(info, key)
+	if err != nil && err != storage.ErrKeyNotFound {
+		return nil, err
+	}
+	return info, nil
+}
+
+// PutNEP11TransferInfo saves the state of account's nep11 transfer log.
+func (dao *Simple) PutNEP11TransferInfo(acc util.Uint160, info *state.NEP11TransferInfo) error {
+	key := storage.AppendPrefix(storage.STNEP11TransferInfo, acc.BytesBE())
+	return dao.Put(info, key)
+}
+
+func getNEP11TransferLogKey(acc util.Uint160, index uint32) []byte {
+	key := make([]byte, 1+util.Uint160Size+4)
+	key[0] = byte(storage.STNEP11Transfers)
+	copy(key[1:], acc.BytesBE())
+	binary.LittleEndian.PutUint32(key[1+util.Uint160Size:], index)
+	return key
+}
+
+// GetNEP11TransferLog retrieves nep11 transfer log from the cache.
+func (dao *Simple) GetNEP11TransferLog(acc util.Uint160, index uint32) (*state.NEP11TransferLog, error) {
+	key := getNEP11TransferLogKey(acc, index)
+	value, err := dao.Store.Get(key)
+	if err != nil {
+		if err == storage.ErrKeyNotFound {
+			return new(state.NEP11TransferLog), nil
+		}
+		return nil, err
+	}
+	return &state.NEP11TransferLog{Raw: value}, nil
+}
+
+// PutNEP11TransferLog saves given nep11 transfer log in the cache.
+func (dao *Simple) PutNEP11TransferLog(acc util.Uint160, index uint32, lg *state.NEP11TransferLog) error {
+	key := getNEP11TransferLogKey(acc, index)
+	return dao.Store.Put(key, lg.Raw)
+}
+
+// AppendNEP11Transfer appends a single NEP11 transfer to a log.
+// First return value signalizes that log size has exceeded batch size.
+func (dao *Simple) AppendNEP11Transfer(acc util.Uint160, index uint32, isNew bool, tr *state.NEP11Transfer) (bool, error) {
+	var lg *state.NEP11TransferLog
+	if isNew {
+		lg = new(state.NEP11TransferLog)
+	} else {
+		var err error
+		lg, err = dao.GetNEP11TransferLog(acc, index)
+		if err != nil {
+			return false, err
+		}
+	}
+	if err := lg.Append(tr); err != nil {
+		return false, err
+	}
+	return lg.Size() >= state.NEP11TransferBatchSize, dao.PutNEP11TransferLog(acc, index, lg)
+}
+
+// -- end nep11 balances and transfer log.
+
 // -- start notification event.
 
 // GetAppExecResults gets application execution results with the specified trigger from the
@@ -545,8 +713,15 @@ func (dao *Simple) DeleteBlock(h util.Uint256, w *io.BufBinWriter) error {
 	}
 	batch.Put(key, w.Bytes())
 
-	key[0] = byte(storage.DataTransaction)
 	for _, tx := range b.Transactions {
+		// Block contains only transaction hashes, attributes are taken
+		// from the transaction itself.
+		if fullTx, _, err := dao.GetTransaction(tx.Hash()); err == nil {
+			for _, k := range getAttributeIndexKeys(fullTx, b.Index) {
+				batch.Delete(k)
+			}
+		}
+		key[0] = byte(storage.DataTransaction)
 		copy(key[1:], tx.Hash().BytesBE())
 		batch.Delete(key)
 		key[0] = byte(storage.STNotification)
@@ -592,6 +767,123 @@ func (dao *Simple) StoreAsTransaction(tx *transaction.Transaction, index uint32,
 	return dao.Store.Put(key, buf.Bytes())
 }
 
+// StoreTransactionAttributes indexes OracleResponse, Conflicts and
+// NotaryAssisted attributes of the transaction included into the block with
+// the given index, so that it can be found by them later.
+func (dao *Simple) StoreTransactionAttributes(tx *transaction.Transaction, index uint32) error {
+	for _, key := range getAttributeIndexKeys(tx, index) {
+		if err := dao.Store.Put(key, tx.Hash().BytesBE()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HasTransactionAttributes checks whether all attributes of the transaction
+// included into the block with the given index are indexed by
+// StoreTransactionAttributes.
+func (dao *Simple) HasTransactionAttributes(tx *transaction.Transaction, index uint32) bool {
+	for _, key := range getAttributeIndexKeys(tx, index) {
+		if _, err := dao.Store.Get(key); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// GetTransactionsByAttribute returns at most limit hashes of transactions
+// having the given attribute starting from the given position in the list of
+// such transactions ordered by the index of block they're included in. Only
+// the attributes indexed by StoreTransactionAttributes can be used. Not every
+// Store iterates over keys in order, so all matching keys are visited, but
+// only start+limit of them are kept in memory.
+func (dao *Simple) GetTransactionsByAttribute(attr *transaction.Attribute, start, limit int) ([]util.Uint256, error) {
+	prefix, ok := getAttributeIndexPrefix(attr)
+	if !ok {
+		return nil, ErrAttributeNotIndexed
+	}
+	if start < 0 || limit <= 0 {
+		return []util.Uint256{}, nil
+	}
+	type entry struct {
+		key  string
+		hash util.Uint256
+	}
+	var (
+		// Block index is stored in big-endian, so keys are ordered by it.
+		entries []entry
+		max     = start + limit
+		err     error
+	)
+	dao.Store.Seek(prefix, func(k, v []byte) {
+		if err != nil {
+			return
+		}
+		key := string(k)
+		i := sort.Search(len(entries), func(i int) bool { return entries[i].key > key })
+		if i >= max {
+			return
+		}
+		var h util.Uint256
+		h, err = util.Uint256DecodeBytesBE(v)
+		if err != nil {
+			return
+		}
+		if len(entries) < max {
+			entries = append(entries, entry{})
+		}
+		copy(entries[i+1:], entries[i:])
+		entries[i] = entry{key: key, hash: h}
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := []util.Uint256{}
+	for i := start; i < len(entries); i++ {
+		res = append(res, entries[i].hash)
+	}
+	return res, nil
+}
+
+// getAttributeIndexPrefix returns the prefix of keys used to index transactions
+// with the given attribute, false is returned for attributes that are not
+// indexed. NotaryAssisted attribute only has the number of keys, so it's a
+// coarse index returning all notary-assisted transactions with the same
+// number of keys.
+func getAttributeIndexPrefix(attr *transaction.Attribute) ([]byte, bool) {
+	key := []byte{byte(storage.IXTransactionAttr), byte(attr.Type)}
+	switch attr.Type {
+	case transaction.OracleResponseT:
+		id := make([]byte, 8)
+		binary.BigEndian.PutUint64(id, attr.Value.(*transaction.OracleResponse).ID)
+		key = append(key, id...)
+	case transaction.ConflictsT:
+		key = append(key, attr.Value.(*transaction.Conflicts).Hash.BytesBE()...)
+	case transaction.NotaryAssistedT:
+		key = append(key, attr.Value.(*transaction.NotaryAssisted).NKeys)
+	default:
+		return nil, false
+	}
+	return key, true
+}
+
+// getAttributeIndexKeys returns index keys for all indexed attributes of the
+// transaction included into the block with the given index.
+func getAttributeIndexKeys(tx *transaction.Transaction, index uint32) [][]byte {
+	var keys [][]byte
+	for i := range tx.Attributes {
+		key, ok := getAttributeIndexPrefix(&tx.Attributes[i])
+		if !ok {
+			continue
+		}
+		suffix := make([]byte, 4+util.Uint256Size)
+		binary.BigEndian.PutUint32(suffix, index)
+		copy(suffix[4:], tx.Hash().BytesBE())
+		keys = append(keys, append(key, suffix...))
+	}
+	return keys
+}
+
 // Persist flushes all the changes made into the (supposedly) persistent
 // underlying store.
 func (dao *Simple) Persist() (int, error) {
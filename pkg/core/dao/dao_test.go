@@ -2,6 +2,7 @@ package dao
 
 import (
 	"encoding/binary"
+	"errors"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/random"
@@ -12,6 +13,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/stretchr/testify/require"
@@ -150,6 +152,48 @@ func TestGetCurrentHeaderHeight_Store(t *testing.T) {
 	require.Equal(t, uint32(0), height)
 }
 
+func TestPutGetTransferLogHeight(t *testing.T) {
+	dao := NewSimple(storage.NewMemoryStore(), netmode.UnitTestNet, false)
+	_, err := dao.GetTransferLogHeight()
+	require.Equal(t, storage.ErrKeyNotFound, err)
+
+	require.NoError(t, dao.PutTransferLogHeight(42))
+	height, err := dao.GetTransferLogHeight()
+	require.NoError(t, err)
+	require.Equal(t, uint32(42), height)
+}
+
+func TestPutGetWatchList(t *testing.T) {
+	dao := NewSimple(storage.NewMemoryStore(), netmode.UnitTestNet, false)
+	_, err := dao.GetWatchList()
+	require.Equal(t, storage.ErrKeyNotFound, err)
+
+	hashes := []util.Uint160{random.Uint160(), random.Uint160()}
+	require.NoError(t, dao.PutWatchList(hashes))
+	actual, err := dao.GetWatchList()
+	require.NoError(t, err)
+	require.Equal(t, hashes, actual)
+
+	require.NoError(t, dao.PutWatchList(nil))
+	actual, err = dao.GetWatchList()
+	require.NoError(t, err)
+	require.Equal(t, 0, len(actual))
+}
+
+func TestPutGetNEP17TransferInfo(t *testing.T) {
+	dao := NewSimple(storage.NewMemoryStore(), netmode.UnitTestNet, false)
+	acc := random.Uint160()
+	info, err := dao.GetNEP17TransferInfo(acc)
+	require.NoError(t, err)
+	require.Equal(t, &state.NEP17TransferInfo{}, info)
+
+	expected := &state.NEP17TransferInfo{NextTransferBatch: 3, NewBatch: true}
+	require.NoError(t, dao.PutNEP17TransferInfo(acc, expected))
+	info, err = dao.GetNEP17TransferInfo(acc)
+	require.NoError(t, err)
+	require.Equal(t, expected, info)
+}
+
 func TestStoreAsTransaction(t *testing.T) {
 	dao := NewSimple(storage.NewMemoryStore(), netmode.UnitTestNet, false)
 	tx := transaction.New(netmode.UnitTestNet, []byte{byte(opcode.PUSH1)}, 1)
@@ -160,6 +204,51 @@ func TestStoreAsTransaction(t *testing.T) {
 	require.NotNil(t, err)
 }
 
+func TestStoreTransactionAttributes(t *testing.T) {
+	dao := NewSimple(storage.NewMemoryStore(), netmode.UnitTestNet, false)
+	conflicts := transaction.Attribute{Type: transaction.ConflictsT, Value: &transaction.Conflicts{Hash: util.Uint256{1, 2, 3}}}
+	notary := transaction.Attribute{Type: transaction.NotaryAssistedT, Value: &transaction.NotaryAssisted{NKeys: 2}}
+	tx1 := transaction.New(netmode.UnitTestNet, []byte{byte(opcode.PUSH1)}, 1)
+	tx1.Attributes = []transaction.Attribute{conflicts, notary}
+	tx2 := transaction.New(netmode.UnitTestNet, []byte{byte(opcode.PUSH2)}, 1)
+	tx2.Attributes = []transaction.Attribute{notary}
+	require.False(t, dao.HasTransactionAttributes(tx1, 3))
+	require.NoError(t, dao.StoreTransactionAttributes(tx2, 5))
+	require.NoError(t, dao.StoreTransactionAttributes(tx1, 3))
+	require.True(t, dao.HasTransactionAttributes(tx1, 3))
+	require.False(t, dao.HasTransactionAttributes(tx1, 4))
+
+	hashes, err := dao.GetTransactionsByAttribute(&conflicts, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, []util.Uint256{tx1.Hash()}, hashes)
+
+	hashes, err = dao.GetTransactionsByAttribute(&notary, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, []util.Uint256{tx1.Hash(), tx2.Hash()}, hashes)
+
+	hashes, err = dao.GetTransactionsByAttribute(&notary, 0, 1)
+	require.NoError(t, err)
+	require.Equal(t, []util.Uint256{tx1.Hash()}, hashes)
+
+	hashes, err = dao.GetTransactionsByAttribute(&notary, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, []util.Uint256{tx2.Hash()}, hashes)
+
+	hashes, err = dao.GetTransactionsByAttribute(&notary, 2, 1)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(hashes))
+
+	hashes, err = dao.GetTransactionsByAttribute(&transaction.Attribute{
+		Type:  transaction.OracleResponseT,
+		Value: &transaction.OracleResponse{ID: 1},
+	}, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(hashes))
+
+	_, err = dao.GetTransactionsByAttribute(&transaction.Attribute{Type: transaction.HighPriority}, 0, 10)
+	require.True(t, errors.Is(err, ErrAttributeNotIndexed))
+}
+
 func TestMakeStorageItemKey(t *testing.T) {
 	var id int32 = 5
 
@@ -0,0 +1,244 @@
+package core
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// transferLogPrefixes are the storage prefixes of transfer log data, it's
+// dropped and restored from application logs when broken.
+var transferLogPrefixes = []storage.KeyPrefix{
+	storage.STNEP11TransferInfo,
+	storage.STNEP11Transfers,
+	storage.STNEP17TransferInfo,
+	storage.STNEP17Transfers,
+}
+
+// IntegrityProblem is a single database inconsistency found by CheckIntegrity.
+type IntegrityProblem struct {
+	// Index is the index of the block the problem is related to.
+	Index uint32
+	// Description is a human-readable problem description.
+	Description string
+	// Repaired is set if the problem was fixed.
+	Repaired bool
+}
+
+// String implements fmt.Stringer interface.
+func (p IntegrityProblem) String() string {
+	s := fmt.Sprintf("block %d: %s", p.Index, p.Description)
+	if p.Repaired {
+		s += " (repaired)"
+	}
+	return s
+}
+
+// CheckIntegrity verifies internal consistency of the chain database: header
+// chain continuity, block and transaction cross-references, transaction
+// attribute index, state roots and transfer logs. If repair is set, broken
+// secondary indexes (transaction attributes index and transfer logs) are
+// re-derived from the primary data, other problems can only be fixed by
+// resynchronizing the node. It's supposed to be used on a node that doesn't
+// process new blocks, it returns all problems found and an error if the check
+// couldn't be completed.
+func (bc *Blockchain) CheckIntegrity(repair bool) ([]IntegrityProblem, error) {
+	var (
+		problems   []IntegrityProblem
+		height     = bc.BlockHeight()
+		prevHash   util.Uint256
+		prevRoot   *state.MPTRoot
+		addProblem = func(index uint32, repaired bool, format string, args ...interface{}) {
+			problems = append(problems, IntegrityProblem{
+				Index:       index,
+				Description: fmt.Sprintf(format, args...),
+				Repaired:    repaired,
+			})
+		}
+	)
+
+	genesis, err := createGenesisBlock(bc.config)
+	if err != nil {
+		return nil, err
+	}
+	if h := bc.GetHeaderHash(0); !h.Equals(genesis.Hash()) {
+		addProblem(0, false, "genesis block mismatch: expected %s, got %s", genesis.Hash().StringLE(), h.StringLE())
+	}
+	for i := uint32(0); i <= bc.HeaderHeight(); i++ {
+		h := bc.GetHeaderHash(int(i))
+		b, err := bc.dao.GetBlock(h)
+		if err != nil {
+			addProblem(i, false, "can't get block %s: %v", h.StringLE(), err)
+			prevHash = h
+			continue
+		}
+		if b.Index != i {
+			addProblem(i, false, "block %s has index %d", h.StringLE(), b.Index)
+		}
+		if !b.Hash().Equals(h) {
+			addProblem(i, false, "block hash mismatch: expected %s, got %s", h.StringLE(), b.Hash().StringLE())
+		}
+		if i > 0 && !b.PrevHash.Equals(prevHash) {
+			addProblem(i, false, "previous block hash mismatch: expected %s, got %s", prevHash.StringLE(), b.PrevHash.StringLE())
+		}
+		prevHash = h
+		if i > height {
+			continue
+		}
+
+		for _, tx := range b.Transactions {
+			stx, index, err := bc.dao.GetTransaction(tx.Hash())
+			if err != nil {
+				addProblem(i, false, "can't get transaction %s: %v", tx.Hash().StringLE(), err)
+				continue
+			}
+			if index != i {
+				addProblem(i, false, "transaction %s is stored for block %d", tx.Hash().StringLE(), index)
+				continue
+			}
+			if !bc.dao.HasTransactionAttributes(stx, i) {
+				var repaired bool
+				if repair {
+					if err := bc.dao.StoreTransactionAttributes(stx, i); err != nil {
+						return problems, fmt.Errorf("can't store attributes of transaction %s: %w", tx.Hash().StringLE(), err)
+					}
+					repaired = true
+				}
+				addProblem(i, repaired, "attributes of transaction %s are not indexed", tx.Hash().StringLE())
+			}
+		}
+
+		sr, err := bc.stateRoot.GetStateRoot(i)
+		if err != nil {
+			addProblem(i, false, "can't get state root: %v", err)
+		} else if sr.Index != i {
+			addProblem(i, false, "state root has index %d", sr.Index)
+		}
+		if bc.config.StateRootInHeader && i > 0 && prevRoot != nil && !b.PrevStateRoot.Equals(prevRoot.Root) {
+			addProblem(i, false, "previous state root mismatch: expected %s, got %s", prevRoot.Root.StringLE(), b.PrevStateRoot.StringLE())
+		}
+		prevRoot = sr
+	}
+	if prevRoot != nil && prevRoot.Index == height {
+		local := bc.stateRoot.CurrentLocalStateRoot()
+		if !local.Equals(prevRoot.Root) {
+			addProblem(height, false, "current state root mismatch: expected %s, got %s", prevRoot.Root.StringLE(), local.StringLE())
+		}
+		if !local.Equals(util.Uint256{}) {
+			if _, err := bc.dao.Store.Get(append([]byte{byte(storage.DataMPT)}, local.BytesBE()...)); err != nil {
+				addProblem(height, false, "can't get MPT root node %s: %v", local.StringLE(), err)
+			}
+		}
+	}
+
+	logProblems, err := bc.checkTransferLog(height)
+	if err != nil {
+		return problems, err
+	}
+	if len(logProblems) != 0 && repair {
+		if err := bc.rebuildTransferLog(height); err != nil {
+			return problems, fmt.Errorf("can't rebuild transfer log: %w", err)
+		}
+		for i := range logProblems {
+			logProblems[i].Repaired = true
+		}
+	}
+	problems = append(problems, logProblems...)
+
+	if repair {
+		if _, err := bc.dao.Persist(); err != nil {
+			return problems, err
+		}
+	}
+	return problems, nil
+}
+
+// checkTransferLog checks NEP17 transfer log of every account to be readable,
+// ordered and not to contain transfers above the transfer log height.
+func (bc *Blockchain) checkTransferLog(height uint32) ([]IntegrityProblem, error) {
+	var problems []IntegrityProblem
+	logHeight, err := bc.dao.GetTransferLogHeight()
+	if err != nil {
+		if err == storage.ErrKeyNotFound {
+			return nil, nil
+		}
+		return []IntegrityProblem{{Index: height, Description: fmt.Sprintf("can't get transfer log height: %v", err)}}, nil
+	}
+	if logHeight > height {
+		problems = append(problems, IntegrityProblem{
+			Index:       logHeight,
+			Description: fmt.Sprintf("transfer log height is above block height %d", height),
+		})
+	}
+	var accs []util.Uint160
+	bc.dao.Store.Seek(storage.STNEP17TransferInfo.Bytes(), func(k, _ []byte) {
+		acc, err := util.Uint160DecodeBytesBE(k[1:])
+		if err == nil {
+			accs = append(accs, acc)
+		}
+	})
+	for _, acc := range accs {
+		info, err := bc.dao.GetNEP17TransferInfo(acc)
+		if err != nil {
+			problems = append(problems, IntegrityProblem{
+				Index:       logHeight,
+				Description: fmt.Sprintf("can't get transfer log info of %s: %v", acc.StringLE(), err),
+			})
+			continue
+		}
+		var (
+			// Transfers are iterated over from the newest to the oldest.
+			last    = logHeight
+			problem string
+		)
+		for i := int(info.NextTransferBatch); i >= 0 && problem == ""; i-- {
+			lg, err := bc.dao.GetNEP17TransferLog(acc, uint32(i))
+			if err != nil {
+				problem = fmt.Sprintf("can't get transfer log batch %d: %v", i, err)
+				break
+			}
+			_, err = lg.ForEach(func(tr *state.NEP17Transfer) (bool, error) {
+				if tr.Block > last {
+					problem = fmt.Sprintf("transfer log batch %d is out of order at block %d", i, tr.Block)
+					return false, nil
+				}
+				last = tr.Block
+				return true, nil
+			})
+			if err != nil {
+				problem = fmt.Sprintf("can't decode transfer log batch %d: %v", i, err)
+			}
+		}
+		if problem != "" {
+			problems = append(problems, IntegrityProblem{
+				Index:       logHeight,
+				Description: fmt.Sprintf("account %s: %s", acc.StringLE(), problem),
+			})
+		}
+	}
+	return problems, nil
+}
+
+// rebuildTransferLog drops all transfer logs and restores them from
+// application execution results of blocks up to the given height.
+func (bc *Blockchain) rebuildTransferLog(height uint32) error {
+	var keys [][]byte
+	for _, p := range transferLogPrefixes {
+		bc.dao.Store.Seek(p.Bytes(), func(k, _ []byte) {
+			key := make([]byte, len(k))
+			copy(key, k)
+			keys = append(keys, key)
+		})
+	}
+	keys = append(keys, storage.SYSTransferLogHeight.Bytes())
+	for _, k := range keys {
+		if err := bc.dao.Store.Delete(k); err != nil {
+			return err
+		}
+	}
+	atomic.StoreUint32(&bc.transferLogHeight, 0)
+	return bc.catchUpTransferLog(height)
+}
@@ -0,0 +1,71 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckIntegrity(t *testing.T) {
+	bc := newTestChain(t)
+	tx := transferTokenFromMultisigAccount(t, bc, util.Uint160{1, 2, 3}, bc.contracts.GAS.Hash, 1_0000_0000)
+	_, err := bc.genBlocks(2)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return bc.TransferLogHeight() == bc.BlockHeight() },
+		time.Second, 10*time.Millisecond)
+
+	problems, err := bc.CheckIntegrity(false)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(problems))
+
+	t.Run("transfer log", func(t *testing.T) {
+		expected := getGenesisTransfers(t, bc)
+
+		var key []byte
+		bc.dao.Store.Seek(storage.STNEP17Transfers.Bytes(), func(k, _ []byte) {
+			if key == nil {
+				key = make([]byte, len(k))
+				copy(key, k)
+			}
+		})
+		require.NotNil(t, key)
+		require.NoError(t, bc.dao.Store.Put(key, []byte{5, 1}))
+
+		problems, err := bc.CheckIntegrity(false)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(problems))
+		require.False(t, problems[0].Repaired)
+
+		problems, err = bc.CheckIntegrity(true)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(problems))
+		require.True(t, problems[0].Repaired)
+
+		problems, err = bc.CheckIntegrity(false)
+		require.NoError(t, err)
+		require.Equal(t, 0, len(problems))
+		require.Equal(t, expected, getGenesisTransfers(t, bc))
+		require.Equal(t, bc.BlockHeight(), bc.TransferLogHeight())
+	})
+	t.Run("missing transaction", func(t *testing.T) {
+		_, index, err := bc.dao.GetTransaction(tx.Hash())
+		require.NoError(t, err)
+		require.NoError(t, bc.dao.Store.Delete(storage.AppendPrefix(storage.DataTransaction, tx.Hash().BytesBE())))
+
+		problems, err := bc.CheckIntegrity(true)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(problems))
+		require.Equal(t, index, problems[0].Index)
+		require.False(t, problems[0].Repaired)
+	})
+}
+
+func TestIntegrityProblem_String(t *testing.T) {
+	p := IntegrityProblem{Index: 1, Description: "broken"}
+	require.Equal(t, "block 1: broken", p.String())
+	p.Repaired = true
+	require.Equal(t, "block 1: broken (repaired)", p.String())
+}
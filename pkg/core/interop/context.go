@@ -10,6 +10,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/blockchainer"
 	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto"
@@ -45,6 +46,13 @@ type Context struct {
 	VM            *vm.VM
 	Functions     [][]Function
 	getContract   func(dao.DAO, util.Uint160) (*state.Contract, error)
+	// policy is Policy native contract used to check for blocked contracts.
+	policy blockChecker
+}
+
+// blockChecker is implemented by Policy native contract.
+type blockChecker interface {
+	IsBlockedInternal(dao.DAO, util.Uint160) bool
 }
 
 // NewContext returns new interop context.
@@ -53,6 +61,13 @@ func NewContext(trigger trigger.Type, bc blockchainer.Blockchainer, d dao.DAO,
 	block *block.Block, tx *transaction.Transaction, log *zap.Logger) *Context {
 	dao := dao.NewCached(d)
 	nes := make([]state.NotificationEvent, 0)
+	var policy blockChecker
+	for _, n := range natives {
+		if n.Metadata().Name == nativenames.Policy {
+			policy, _ = n.(blockChecker)
+			break
+		}
+	}
 	return &Context{
 		Chain:         bc,
 		Natives:       natives,
@@ -65,6 +80,7 @@ func NewContext(trigger trigger.Type, bc blockchainer.Blockchainer, d dao.DAO,
 		// Functions is a slice of slices of interops sorted by ID.
 		Functions:   [][]Function{},
 		getContract: getContract,
+		policy:      policy,
 	}
 }
 
@@ -220,11 +236,49 @@ func Sort(fs []Function) {
 	sort.Slice(fs, func(i, j int) bool { return fs[i].ID < fs[j].ID })
 }
 
+// NewFunctions returns a sorted copy of the given interop functions ready to
+// be used in Context.Functions. IDs are initialized from names for functions
+// that don't have them set explicitly. It fails if some function has no
+// handler or if IDs clash with each other or with functions from the sets
+// given.
+func NewFunctions(fs []Function, existing ...[]Function) ([]Function, error) {
+	res := make([]Function, len(fs))
+	copy(res, fs)
+	known := &Context{Functions: existing}
+	for i := range res {
+		if res[i].Func == nil {
+			return nil, fmt.Errorf("interop %q has no handler", res[i].Name)
+		}
+		if res[i].ID == 0 {
+			if res[i].Name == "" {
+				return nil, errors.New("interop has neither name nor ID")
+			}
+			res[i].ID = interopnames.ToID([]byte(res[i].Name))
+		}
+		if f := known.GetFunction(res[i].ID); f != nil {
+			return nil, fmt.Errorf("interop %q ID clashes with %q", res[i].Name, f.Name)
+		}
+	}
+	Sort(res)
+	for i := 1; i < len(res); i++ {
+		if res[i].ID == res[i-1].ID {
+			return nil, fmt.Errorf("interops %q and %q have the same ID", res[i-1].Name, res[i].Name)
+		}
+	}
+	return res, nil
+}
+
 // GetContract returns contract by its hash in current interop context.
 func (ic *Context) GetContract(hash util.Uint160) (*state.Contract, error) {
 	return ic.getContract(ic.DAO, hash)
 }
 
+// IsBlocked checks whether the given contract is blocked by Policy native
+// contract.
+func (ic *Context) IsBlocked(hash util.Uint160) bool {
+	return ic.policy != nil && ic.policy.IsBlockedInternal(ic.DAO, hash)
+}
+
 // GetFunction returns metadata for interop with the specified id.
 func (ic *Context) GetFunction(id uint32) *Function {
 	for _, slice := range ic.Functions {
@@ -3,5 +3,10 @@ Package interop contains implementations of Neo interop functions.
 
 This is not the package you use from smart contracts, refer to pkg/interop
 for that.
+
+Applications embedding the node can extend the set of interop functions
+available to scripts: custom functions can be registered for all contexts
+created by the chain with core.Blockchain.RegisterInterops or prepared with
+NewFunctions and appended to Context.Functions of a standalone context.
 */
 package interop
@@ -78,6 +78,28 @@ var neoInterops = []interop.Function{
 	{Name: interopnames.NeoCryptoCheckSig, Func: crypto.ECDSASecp256r1CheckSig, Price: fee.ECDSAVerifyPrice, ParamCount: 2},
 }
 
+// RegisterInterops adds custom interop functions (syscalls) available to all
+// scripts executed by the chain, they're identified by Name (unless ID is set
+// explicitly) and are charged for according to Price and RequiredFlags like
+// the standard ones. Custom interops change script execution results, so all
+// nodes of the network must register the same set of them. It must be called
+// before the chain is run and it fails if some of functions clash with
+// the standard or previously registered ones.
+func (bc *Blockchain) RegisterInterops(fs ...interop.Function) error {
+	sets := [][]interop.Function{systemInterops, neoInterops}
+	if len(bc.customInterops) != 0 {
+		sets = append(sets, bc.customInterops)
+	}
+	custom, err := interop.NewFunctions(fs, sets...)
+	if err != nil {
+		return err
+	}
+	custom = append(custom, bc.customInterops...)
+	interop.Sort(custom)
+	bc.customInterops = custom
+	return nil
+}
+
 // initIDinInteropsSlice initializes IDs from names in one given
 // Function slice and then sorts it.
 func initIDinInteropsSlice(iops []interop.Function) {
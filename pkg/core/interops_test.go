@@ -8,9 +8,13 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/stretchr/testify/require"
 )
 
@@ -49,3 +53,38 @@ func TestUnexpectedNonInterops(t *testing.T) {
 		}
 	}
 }
+
+func TestRegisterInterops(t *testing.T) {
+	bc := newTestChain(t)
+	const name = "Sidechain.Answer"
+	answer := func(ic *interop.Context) error {
+		ic.VM.Estack().PushVal(42)
+		return nil
+	}
+
+	require.Error(t, bc.RegisterInterops(interop.Function{Name: name}))
+	require.Error(t, bc.RegisterInterops(interop.Function{Func: answer}))
+	require.Error(t, bc.RegisterInterops(interop.Function{Name: interopnames.SystemRuntimeGetTime, Func: answer}))
+	require.Error(t, bc.RegisterInterops(interop.Function{Name: name, Func: answer}, interop.Function{Name: name, Func: answer}))
+	require.NoError(t, bc.RegisterInterops(interop.Function{Name: name, Func: answer, Price: 1 << 10,
+		RequiredFlags: callflag.ReadStates}))
+	require.Error(t, bc.RegisterInterops(interop.Function{Name: name, Func: answer}))
+
+	w := io.NewBufBinWriter()
+	emit.Syscall(w.BinWriter, name)
+	require.NoError(t, w.Err)
+	script := w.Bytes()
+
+	t.Run("good", func(t *testing.T) {
+		v, _ := bc.GetTestVM(trigger.Application, nil, nil)
+		v.LoadScriptWithFlags(script, callflag.All)
+		require.NoError(t, v.Run())
+		require.Equal(t, int64(42), v.Estack().Pop().BigInt().Int64())
+		require.True(t, v.GasConsumed() >= (1<<10)*bc.GetPolicer().GetBaseExecFee())
+	})
+	t.Run("missing flags", func(t *testing.T) {
+		v, _ := bc.GetTestVM(trigger.Application, nil, nil)
+		v.LoadScriptWithFlags(script, callflag.NoneFlag)
+		require.Error(t, v.Run())
+	})
+}
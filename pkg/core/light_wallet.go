@@ -0,0 +1,308 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"go.uber.org/zap"
+)
+
+// ErrLightWalletDisabled is returned on attempt to manage watched script
+// hashes when light wallet mode is not enabled.
+var ErrLightWalletDisabled = errors.New("light wallet mode is disabled")
+
+// watchList is a set of script hashes watched in light wallet mode.
+type watchList struct {
+	lock   sync.RWMutex
+	hashes map[util.Uint160]bool
+}
+
+// newWatchList creates watch list containing hashes saved in the DB and the
+// ones specified in the configuration.
+func newWatchList(d *dao.Simple, cfg config.LightWallet) (*watchList, error) {
+	w := &watchList{hashes: make(map[util.Uint160]bool)}
+	stored, err := d.GetWatchList()
+	if err != nil && !errors.Is(err, storage.ErrKeyNotFound) {
+		return nil, fmt.Errorf("can't get watch list: %w", err)
+	}
+	for _, h := range stored {
+		w.hashes[h] = true
+	}
+	for _, s := range cfg.Watched {
+		h, err := address.StringToUint160(s)
+		if err != nil {
+			h, err = util.Uint160DecodeStringLE(s)
+			if err != nil {
+				return nil, fmt.Errorf("invalid watched address or script hash %s: %w", s, err)
+			}
+		}
+		w.hashes[h] = true
+	}
+	return w, nil
+}
+
+// has checks whether the script hash is watched.
+func (w *watchList) has(h util.Uint160) bool {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+	return w.hashes[h]
+}
+
+// list returns sorted watched script hashes.
+func (w *watchList) list() []util.Uint160 {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+	return w.sorted()
+}
+
+// sorted returns sorted watched script hashes, it must be called with the
+// lock held.
+func (w *watchList) sorted() []util.Uint160 {
+	res := make([]util.Uint160, 0, len(w.hashes))
+	for h := range w.hashes {
+		res = append(res, h)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Less(res[j]) })
+	return res
+}
+
+// update adds or removes given script hashes and saves the resulting list.
+func (w *watchList) update(d *dao.Simple, hashes []util.Uint160, add bool) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	for _, h := range hashes {
+		if add {
+			w.hashes[h] = true
+		} else {
+			delete(w.hashes, h)
+		}
+	}
+	return d.PutWatchList(w.sorted())
+}
+
+// IsTracked checks whether NEP17/NEP11 balances and transfers of the account
+// are tracked by the node, that's always the case unless light wallet mode is
+// enabled and the account is not watched.
+func (bc *Blockchain) IsTracked(acc util.Uint160) bool {
+	return bc.watched == nil || bc.watched.has(acc)
+}
+
+// GetWatched returns script hashes watched in light wallet mode.
+func (bc *Blockchain) GetWatched() ([]util.Uint160, error) {
+	if bc.watched == nil {
+		return nil, ErrLightWalletDisabled
+	}
+	return bc.watched.list(), nil
+}
+
+// AddWatched adds script hashes to the set watched in light wallet mode.
+// Current NEP17 and NEP11 balances of the accounts are requested from token
+// contracts and transfers are tracked starting from the next block, transfers
+// from the past are not restored.
+func (bc *Blockchain) AddWatched(hashes ...util.Uint160) error {
+	if bc.watched == nil {
+		return ErrLightWalletDisabled
+	}
+	// Blocks can't be added while balances are being seeded, otherwise
+	// transfers from the next block could be applied to the stale values.
+	bc.addLock.Lock()
+	defer bc.addLock.Unlock()
+
+	cache := dao.NewCached(bc.dao)
+	for _, h := range hashes {
+		if bc.watched.has(h) {
+			continue
+		}
+		if err := bc.seedBalances(cache, h); err != nil {
+			return fmt.Errorf("can't get balances of %s: %w", address.Uint160ToString(h), err)
+		}
+	}
+	bc.lock.Lock()
+	defer bc.lock.Unlock()
+	if _, err := cache.Persist(); err != nil {
+		return err
+	}
+	return bc.watched.update(bc.dao, hashes, true)
+}
+
+// vmIterator is implemented by all VM iterators.
+type vmIterator interface {
+	Next() bool
+	Value() stackitem.Item
+}
+
+// seedBalances replaces stored NEP17 and NEP11 balances of the account with
+// the ones returned by token contracts at the current height. NEO and GAS
+// balances are always tracked, so they're kept as is. Tokens failing to
+// return the balance are skipped.
+func (bc *Blockchain) seedBalances(cache *dao.Cached, acc util.Uint160) error {
+	nep17, err := cache.GetNEP17Balances(acc)
+	if err != nil {
+		return err
+	}
+	for id := range nep17.Trackers {
+		if id != bc.contracts.NEO.ID && id != bc.contracts.GAS.ID {
+			delete(nep17.Trackers, id)
+		}
+	}
+	nep11 := state.NewNEP11Balances()
+
+	var tokens []*state.Contract
+	err = bc.contracts.Management.ForEachContract(bc.dao, func(cs *state.Contract) {
+		tokens = append(tokens, cs)
+	})
+	if err != nil {
+		return err
+	}
+	height := bc.BlockHeight()
+	for _, cs := range tokens {
+		abi := &cs.Manifest.ABI
+		switch {
+		case abi.GetMethod("tokensOf", 1) != nil:
+			err = bc.seedNEP11Balance(cs, acc, nep11, height)
+		case abi.GetMethod("balanceOf", 1) != nil && cs.ID != bc.contracts.NEO.ID && cs.ID != bc.contracts.GAS.ID:
+			var res stackitem.Item
+			res, err = bc.invokeBalanceMethod(cs.Hash, "balanceOf", acc)
+			if err != nil {
+				break
+			}
+			var bal *big.Int
+			bal, err = res.TryInteger()
+			if err == nil && bal.Sign() != 0 {
+				nep17.Trackers[cs.ID] = state.NEP17Tracker{Balance: *bal, LastUpdatedBlock: height}
+			}
+		default:
+			continue
+		}
+		if err != nil {
+			bc.log.Warn("can't get token balance",
+				zap.String("contract", cs.Hash.StringLE()),
+				zap.String("account", address.Uint160ToString(acc)),
+				zap.Error(err))
+		}
+	}
+	if err := cache.PutNEP17Balances(acc, nep17); err != nil {
+		return err
+	}
+	return cache.PutNEP11Balances(acc, nep11)
+}
+
+// seedNEP11Balance adds balances of all NEP11 tokens of the given contract
+// owned by the account to bs.
+func (bc *Blockchain) seedNEP11Balance(cs *state.Contract, acc util.Uint160, bs *state.NEP11Balances, height uint32) error {
+	divisible := cs.Manifest.ABI.GetMethod("balanceOf", 2) != nil
+	res, err := bc.invokeBalanceMethod(cs.Hash, "tokensOf", acc)
+	if err != nil {
+		return err
+	}
+	it, ok := res.Value().(vmIterator)
+	if !ok || res.Type() != stackitem.InteropT {
+		return errors.New("tokensOf didn't return an iterator")
+	}
+	tokens := state.NewNEP11Balances()
+	for it.Next() {
+		id, err := it.Value().TryBytes()
+		if err != nil {
+			return fmt.Errorf("invalid token ID: %w", err)
+		}
+		amount := big.NewInt(1)
+		if divisible {
+			res, err := bc.invokeBalanceMethod(cs.Hash, "balanceOf", acc, id)
+			if err != nil {
+				return err
+			}
+			if amount, err = res.TryInteger(); err != nil {
+				return err
+			}
+		}
+		tokens.Add(cs.ID, id, amount, height)
+	}
+	if t, ok := tokens.Trackers[cs.ID]; ok {
+		bs.Trackers[cs.ID] = t
+	}
+	return nil
+}
+
+// invokeBalanceMethod runs read-only token contract method against the current
+// state and returns its result.
+func (bc *Blockchain) invokeBalanceMethod(hash util.Uint160, method string, args ...interface{}) (stackitem.Item, error) {
+	w := io.NewBufBinWriter()
+	emit.AppCall(w.BinWriter, hash, method, callflag.ReadStates, args...)
+	if w.Err != nil {
+		return nil, w.Err
+	}
+	b := block.New(bc.config.Magic, bc.config.StateRootInHeader)
+	b.Index = bc.BlockHeight() + 1
+	if top, ok := bc.topBlock.Load().(*block.Block); ok {
+		b.Timestamp = top.Timestamp + uint64(bc.config.SecondsPerBlock*int(time.Second/time.Millisecond))
+	}
+	v, _ := bc.getTestVM(bc.dao.GetWrapped().(*dao.Simple), trigger.Application, nil, b)
+	v.GasLimit = bc.contracts.Policy.GetMaxVerificationGas(bc.dao)
+	v.LoadScriptWithFlags(w.Bytes(), callflag.ReadOnly)
+	if err := v.Run(); err != nil {
+		return nil, fmt.Errorf("%s failed: %w", method, err)
+	}
+	if v.Estack().Len() != 1 {
+		return nil, fmt.Errorf("%s returned %d items", method, v.Estack().Len())
+	}
+	return v.Estack().Pop().Item(), nil
+}
+
+// RemoveWatched removes script hashes from the set watched in light wallet
+// mode. Data that was already stored for them is kept, addresses from the
+// configuration are watched again after node restart.
+func (bc *Blockchain) RemoveWatched(hashes ...util.Uint160) error {
+	if bc.watched == nil {
+		return ErrLightWalletDisabled
+	}
+	return bc.watched.update(bc.dao, hashes, false)
+}
+
+// isExecutionTracked checks whether the execution result should be stored.
+// In light wallet mode only executions of transactions signed by the watched
+// accounts, executions with notifications from the watched contracts and
+// transfers involving watched accounts are stored.
+func (bc *Blockchain) isExecutionTracked(d dao.DAO, aer *state.AppExecResult, b *block.Block, tx *transaction.Transaction) bool {
+	if bc.watched == nil {
+		return true
+	}
+	if tx != nil {
+		for _, s := range tx.Signers {
+			if bc.watched.has(s.Account) {
+				return true
+			}
+		}
+	}
+	for i := range aer.Events {
+		note := &aer.Events[i]
+		if bc.watched.has(note.ScriptHash) {
+			return true
+		}
+		if tr := bc.getNEP17Transfer(d, note, b, aer.Container); tr != nil &&
+			(bc.watched.has(tr.From) || bc.watched.has(tr.To)) {
+			return true
+		}
+		if tr := bc.getNEP11Transfer(d, note, b, aer.Container); tr != nil &&
+			(bc.watched.has(tr.From) || bc.watched.has(tr.To)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/internal/testchain"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+func lightWalletConfig(watched ...util.Uint160) func(*config.Config) {
+	return func(c *config.Config) {
+		c.ProtocolConfiguration.LightWallet.Enabled = true
+		for _, h := range watched {
+			c.ProtocolConfiguration.LightWallet.Watched = append(c.ProtocolConfiguration.LightWallet.Watched,
+				address.Uint160ToString(h))
+		}
+	}
+}
+
+func countNEP17Transfers(t *testing.T, bc *Blockchain, acc util.Uint160) int {
+	var n int
+	require.NoError(t, bc.ForEachNEP17Transfer(acc, func(*state.NEP17Transfer) (bool, error) {
+		n++
+		return true, nil
+	}))
+	return n
+}
+
+func TestLightWallet(t *testing.T) {
+	watched := util.Uint160{1, 2, 3}
+	other := util.Uint160{4, 5, 6}
+
+	t.Run("disabled", func(t *testing.T) {
+		bc := newTestChain(t)
+		require.True(t, bc.IsTracked(other))
+		_, err := bc.GetWatched()
+		require.True(t, errors.Is(err, ErrLightWalletDisabled))
+		require.True(t, errors.Is(bc.AddWatched(other), ErrLightWalletDisabled))
+		require.True(t, errors.Is(bc.RemoveWatched(other), ErrLightWalletDisabled))
+	})
+	t.Run("invalid config", func(t *testing.T) {
+		d := dao.NewSimple(storage.NewMemoryStore(), netmode.UnitTestNet, false)
+		_, err := newWatchList(d, config.LightWallet{Enabled: true, Watched: []string{"bad"}})
+		require.Error(t, err)
+	})
+
+	bc := newTestChainWithCustomCfg(t, lightWalletConfig(watched))
+	require.True(t, bc.IsTracked(watched))
+	require.False(t, bc.IsTracked(other))
+	hashes, err := bc.GetWatched()
+	require.NoError(t, err)
+	require.Equal(t, []util.Uint160{watched}, hashes)
+
+	// NEO and GAS balances are used by native contracts.
+	require.Equal(t, 1, bc.GetUtilityTokenBalance(neoOwner).Sign())
+
+	txW := transferTokenFromMultisigAccount(t, bc, watched, bc.contracts.GAS.Hash, 1_0000_0000)
+	txO := transferTokenFromMultisigAccount(t, bc, other, bc.contracts.GAS.Hash, 1_0000_0000)
+	_, err = bc.GetAppExecResults(txW.Hash(), trigger.Application)
+	require.NoError(t, err)
+	_, err = bc.GetAppExecResults(txO.Hash(), trigger.Application)
+	require.Error(t, err)
+
+	require.NoError(t, bc.AddWatched(other))
+	hashes, err = bc.GetWatched()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []util.Uint160{watched, other}, hashes)
+	txO = transferTokenFromMultisigAccount(t, bc, other, bc.contracts.GAS.Hash, 1_0000_0000)
+	_, err = bc.GetAppExecResults(txO.Hash(), trigger.Application)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return bc.TransferLogHeight() == bc.BlockHeight() },
+		time.Second, 10*time.Millisecond)
+	require.Equal(t, 1, countNEP17Transfers(t, bc, watched))
+	require.Equal(t, 1, countNEP17Transfers(t, bc, other))
+	require.Equal(t, 0, countNEP17Transfers(t, bc, neoOwner))
+
+	require.NoError(t, bc.RemoveWatched(watched))
+	require.False(t, bc.IsTracked(watched))
+}
+
+func TestLightWallet_Persist(t *testing.T) {
+	watched := util.Uint160{1, 2, 3}
+	other := util.Uint160{4, 5, 6}
+	st := storage.NewMemoryStore()
+
+	bc := initTestChain(t, st, lightWalletConfig(watched))
+	require.NoError(t, bc.AddWatched(other))
+	require.NoError(t, bc.RemoveWatched(watched))
+	require.NoError(t, bc.persist())
+
+	// Configured addresses are watched again after restart.
+	bc = initTestChain(t, st, lightWalletConfig(watched))
+	hashes, err := bc.GetWatched()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []util.Uint160{watched, other}, hashes)
+
+	bc = initTestChain(t, st, lightWalletConfig())
+	hashes, err = bc.GetWatched()
+	require.NoError(t, err)
+	require.Equal(t, []util.Uint160{other}, hashes)
+}
+
+func TestLightWallet_SeedBalances(t *testing.T) {
+	bc := newTestChainWithCustomCfg(t, lightWalletConfig())
+	committee := testchain.CommitteeScriptHash()
+	nnsID := bc.contracts.NameService.ID
+
+	transferFundsToCommittee(t, bc)
+	testNameServiceInvoke(t, bc, "addRoot", stackitem.Null{}, "com")
+	testNameServiceInvokeAux(t, bc, defaultRegisterSysfee, true, "register", true, "neo.com", committee)
+	require.Equal(t, 0, len(bc.GetNEP11Balances(committee).Trackers))
+	gas := bc.GetNEP17Balances(committee).Trackers[bc.contracts.GAS.ID]
+	require.Equal(t, 1, gas.Balance.Sign())
+
+	require.NoError(t, bc.AddWatched(committee))
+	tokens := bc.GetNEP11Balances(committee).Trackers[nnsID]
+	require.Equal(t, 1, len(tokens))
+	tr := tokens["neo.com"]
+	require.Equal(t, int64(1), tr.Balance.Int64())
+	require.Equal(t, bc.BlockHeight(), tr.LastUpdatedBlock)
+	require.Equal(t, gas, bc.GetNEP17Balances(committee).Trackers[bc.contracts.GAS.ID])
+
+	// Further transfers are applied to the seeded balances.
+	testNameServiceInvokeAux(t, bc, defaultRegisterSysfee, true, "register", true, "neo2.com", committee)
+	tokens = bc.GetNEP11Balances(committee).Trackers[nnsID]
+	require.Equal(t, 2, len(tokens))
+	tr = tokens["neo2.com"]
+	require.Equal(t, int64(1), tr.Balance.Int64())
+}
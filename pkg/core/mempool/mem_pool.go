@@ -34,6 +34,13 @@ var (
 	// ErrOracleResponse is returned when mempool already contains transaction
 	// with the same oracle response ID and higher network fee.
 	ErrOracleResponse = errors.New("conflicts with memory pool due to OracleResponse attribute")
+	// ErrSenderLimit is returned when sender of the transaction being added
+	// already has the maximum allowed number of transactions in the pool and
+	// all of them are more prioritized than the new one.
+	ErrSenderLimit = errors.New("too many transactions from the same sender")
+	// ErrInvalidPercentile is returned by EstimateFee for percentile values
+	// outside of [0, 100] range.
+	ErrInvalidPercentile = errors.New("invalid percentile")
 )
 
 // item represents a transaction in the the Memory pool.
@@ -41,16 +48,28 @@ type item struct {
 	txn        *transaction.Transaction
 	blockStamp uint32
 	data       interface{}
+	// exempt is true for transactions exempted from fee policy.
+	exempt bool
+	// fallback is true for notary fallback transactions.
+	fallback bool
+}
+
+// TxWithStamp is a pooled transaction along with the chain height it was
+// added to the pool at.
+type TxWithStamp struct {
+	Tx         *transaction.Transaction
+	BlockStamp uint32
 }
 
 // items is a slice of item.
 type items []item
 
-// utilityBalanceAndFees stores sender's balance and overall fees of
-// sender's transactions which are currently in mempool
+// utilityBalanceAndFees stores sender's balance, overall fees and the number
+// of sender's transactions which are currently in mempool
 type utilityBalanceAndFees struct {
 	balance *big.Int
 	feeSum  *big.Int
+	txCount int
 }
 
 // Pool stores the unconfirms transactions.
@@ -64,9 +83,39 @@ type Pool struct {
 	// oracleResp contains ids of oracle responses for tx in pool.
 	oracleResp map[uint64]util.Uint256
 
-	capacity   int
-	feePerByte int64
-	payerIndex int
+	capacity    int
+	feePerByte  int64
+	payerIndex  int
+	senderLimit int
+	policy      Policy
+
+	// maxBytes limits the total size of pooled transactions (zero means no
+	// limit), bytes is their current total size.
+	maxBytes int
+	bytes    int
+
+	// isExempt checks whether transaction is exempted from fee policy,
+	// exempted transactions are stored in a separate quota of exemptCapacity.
+	isExempt       func(*transaction.Transaction) bool
+	exemptCapacity int
+	exemptCount    int
+
+	// isFallback checks whether transaction is a notary fallback, such
+	// transactions are stored in a separate quota of fallbackCapacity and
+	// evicted according to fallbackPolicy.
+	isFallback       func(*transaction.Transaction) bool
+	fallbackCapacity int
+	fallbackCount    int
+	fallbackPolicy   Policy
+
+	// postponed contains transactions that are not yet valid because of
+	// their NotValidBefore attribute, see Postpone.
+	postponed         map[util.Uint256]postponedItem
+	postponedCapacity int
+
+	// maxAge is the number of blocks transaction can stay in the pool for,
+	// zero means no limit.
+	maxAge uint32
 
 	resendThreshold uint32
 	resendFunc      func(*transaction.Transaction, interface{})
@@ -84,25 +133,18 @@ func (p items) Len() int           { return len(p) }
 func (p items) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
 func (p items) Less(i, j int) bool { return p[i].CompareTo(p[j]) < 0 }
 
-// CompareTo returns the difference between two items.
+// CompareTo returns the difference between two items according to
+// DefaultPolicy.
 // difference < 0 implies p < otherP.
 // difference = 0 implies p = otherP.
 // difference > 0 implies p > otherP.
 func (p item) CompareTo(otherP item) int {
-	pHigh := p.txn.HasAttribute(transaction.HighPriority)
-	otherHigh := otherP.txn.HasAttribute(transaction.HighPriority)
-	if pHigh && !otherHigh {
-		return 1
-	} else if !pHigh && otherHigh {
-		return -1
-	}
-
-	// Fees sorted ascending.
-	if ret := int(p.txn.FeePerByte() - otherP.txn.FeePerByte()); ret != 0 {
-		return ret
-	}
+	return DefaultPolicy.Compare(p.txn, otherP.txn)
+}
 
-	return int(p.txn.NetworkFee - otherP.txn.NetworkFee)
+// compare compares two items according to the pool's policy.
+func (mp *Pool) compare(p, otherP item) int {
+	return mp.policy.Compare(p.txn, otherP.txn)
 }
 
 // Count returns the total number of uncofirm transactions.
@@ -176,9 +218,100 @@ func (mp *Pool) tryAddSendersFee(tx *transaction.Transaction, feer Feer, needChe
 	} else {
 		senderFee.feeSum.Add(senderFee.feeSum, big.NewInt(tx.SystemFee+tx.NetworkFee))
 	}
+	senderFee.txCount++
+	mp.fees[payer] = senderFee
 	return true
 }
 
+// removeSendersFee subtracts fees of the given transaction from the total
+// sender's fee in mempool and decrements sender's transaction counter.
+func (mp *Pool) removeSendersFee(tx *transaction.Transaction) {
+	payer := tx.Signers[mp.payerIndex].Account
+	senderFee := mp.fees[payer]
+	senderFee.feeSum.Sub(senderFee.feeSum, big.NewInt(tx.SystemFee+tx.NetworkFee))
+	senderFee.txCount--
+	mp.fees[payer] = senderFee
+}
+
+// evictions is a set of pooled transactions that are to be removed in order
+// to add a new one. They're collected before the pool is changed, so that
+// rejected transaction doesn't affect pooled ones.
+type evictions struct {
+	items    []item
+	reasons  []RemovalReason
+	hashes   map[util.Uint256]bool
+	size     int
+	exempt   int
+	fallback int
+}
+
+func newEvictions() *evictions {
+	return &evictions{hashes: make(map[util.Uint256]bool)}
+}
+
+// add adds the given pooled item to the set (if it's not there yet).
+func (ev *evictions) add(itm item, reason RemovalReason) {
+	h := itm.txn.Hash()
+	if ev.hashes[h] {
+		return
+	}
+	ev.hashes[h] = true
+	ev.items = append(ev.items, itm)
+	ev.reasons = append(ev.reasons, reason)
+	ev.size += itm.txn.Size()
+	if itm.exempt {
+		ev.exempt++
+	} else if itm.fallback {
+		ev.fallback++
+	}
+}
+
+// has checks whether the given pooled item is to be removed.
+func (ev *evictions) has(itm item) bool {
+	return ev.hashes[itm.txn.Hash()]
+}
+
+// evict adds the given pooled transaction to the set of transactions that
+// are to be evicted.
+func (mp *Pool) evict(ev *evictions, tx *transaction.Transaction, reason RemovalReason) {
+	if n := mp.indexOf(tx); n >= 0 {
+		ev.add(mp.verifiedTxes[n], reason)
+	}
+}
+
+// checkSenderLimit checks whether the given item fits into the per-sender
+// limit. If the sender already has the maximum number of transactions in the
+// pool the least prioritized of them is to be evicted if it's also less
+// prioritized than the new one, otherwise ErrSenderLimit is returned. Oracle
+// responses are all sent by the Oracle contract, so they're not limited.
+func (mp *Pool) checkSenderLimit(pItem item, ev *evictions) error {
+	if mp.senderLimit <= 0 || pItem.fallback || pItem.txn.HasAttribute(transaction.OracleResponseT) {
+		return nil
+	}
+	payer := pItem.txn.Signers[mp.payerIndex].Account
+	count := mp.fees[payer].txCount
+	for _, itm := range ev.items {
+		if itm.txn.Signers[mp.payerIndex].Account.Equals(payer) {
+			count--
+		}
+	}
+	if count < mp.senderLimit {
+		return nil
+	}
+	for i := len(mp.verifiedTxes) - 1; i >= 0; i-- {
+		itm := mp.verifiedTxes[i]
+		if ev.has(itm) || !itm.txn.Signers[mp.payerIndex].Account.Equals(payer) {
+			continue
+		}
+		if mp.compare(pItem, itm) <= 0 {
+			return ErrSenderLimit
+		}
+		ev.add(itm, RemovedCapacity)
+		return nil
+	}
+	return nil
+}
+
 // checkBalance returns new cumulative fee balance for account or an error in
 // case sender doesn't have enough GAS to pay for the transaction.
 func checkBalance(tx *transaction.Transaction, balance utilityBalanceAndFees) (*big.Int, error) {
@@ -193,7 +326,14 @@ func checkBalance(tx *transaction.Transaction, balance utilityBalanceAndFees) (*
 	return txFee, nil
 }
 
-// Add tries to add given transaction to the Pool.
+// Add tries to add given transaction to the Pool. If P2PSigExtensions are
+// enabled, transaction can replace pooled ones it has Conflicts attributes for
+// (or pooled ones that have Conflicts attributes for it) if it's signed by
+// their sender and pays more network fee, this allows to speed up pooled
+// transaction. Replacement is never done with P2PSigExtensions disabled,
+// because then Conflicts attribute is not accepted by the chain and nothing
+// prevents both the original and the replacing transactions from being
+// included into blocks.
 func (mp *Pool) Add(t *transaction.Transaction, fee Feer, data ...interface{}) error {
 	var pItem = item{
 		txn:        t,
@@ -207,11 +347,20 @@ func (mp *Pool) Add(t *transaction.Transaction, fee Feer, data ...interface{}) e
 		mp.lock.Unlock()
 		return ErrDup
 	}
+	pItem.fallback = mp.isFallback != nil && mp.isFallback(t)
+	pItem.exempt = !pItem.fallback && mp.isExempt != nil && mp.isExempt(t)
 	conflictsToBeRemoved, err := mp.checkTxConflicts(t, fee)
 	if err != nil {
 		mp.lock.Unlock()
 		return err
 	}
+	// Pooled transactions are only removed after all checks are passed.
+	ev := newEvictions()
+	if fee.P2PSigExtensionsEnabled() {
+		for _, conflictingTx := range conflictsToBeRemoved {
+			mp.evict(ev, conflictingTx, RemovedConflict)
+		}
+	}
 	if attrs := t.GetAttributes(transaction.OracleResponseT); len(attrs) != 0 {
 		id := attrs[0].Value.(*transaction.OracleResponse).ID
 		h, ok := mp.oracleResp[id]
@@ -220,58 +369,58 @@ func (mp *Pool) Add(t *transaction.Transaction, fee Feer, data ...interface{}) e
 				mp.lock.Unlock()
 				return ErrOracleResponse
 			}
-			mp.removeInternal(h, fee)
+			mp.evict(ev, mp.verifiedMap[h], RemovedConflict)
 		}
-		mp.oracleResp[id] = t.Hash()
 	}
-
-	if fee.P2PSigExtensionsEnabled() {
-		// Remove conflicting transactions.
-		for _, conflictingTx := range conflictsToBeRemoved {
-			mp.removeInternal(conflictingTx.Hash(), fee)
-		}
+	if err := mp.checkSenderLimit(pItem, ev); err != nil {
+		mp.lock.Unlock()
+		return err
 	}
-	// Insert into sorted array (from max to min, that could also be done
-	// using sort.Sort(sort.Reverse()), but it incurs more overhead. Notice
-	// also that we're searching for position that is strictly more
-	// prioritized than our new item because we do expect a lot of
-	// transactions with the same priority and appending to the end of the
-	// slice is always more efficient.
-	n := sort.Search(len(mp.verifiedTxes), func(n int) bool {
-		return pItem.CompareTo(mp.verifiedTxes[n]) > 0
-	})
-
-	// We've reached our capacity already.
-	if len(mp.verifiedTxes) == mp.capacity {
+	// Position of the new item in the sorted array (from max to min). Notice
+	// that we're searching for position that is strictly more prioritized
+	// than our new item because we do expect a lot of transactions with the
+	// same priority and appending to the end of the slice is always more
+	// efficient.
+	search := func() int {
+		return sort.Search(len(mp.verifiedTxes), func(n int) bool {
+			return mp.compare(pItem, mp.verifiedTxes[n]) > 0
+		})
+	}
+	n := search()
+	if err := mp.checkBytesLimit(pItem, n, ev); err != nil {
+		mp.lock.Unlock()
+		return err
+	}
+	// We've reached our capacity (or exempted/fallback transactions quota)
+	// already.
+	if mp.isFull(pItem, ev) {
+		u := mp.leastPrioritized(pItem, ev)
 		// Less prioritized than the least prioritized we already have, won't fit.
-		if n == len(mp.verifiedTxes) {
+		if !mp.canReplace(pItem, u, n) {
 			mp.lock.Unlock()
 			return ErrOOM
 		}
-		// Ditch the last one.
-		unlucky := mp.verifiedTxes[len(mp.verifiedTxes)-1]
-		delete(mp.verifiedMap, unlucky.txn.Hash())
-		if fee.P2PSigExtensionsEnabled() {
-			mp.removeConflictsOf(unlucky.txn)
-		}
-		if attrs := unlucky.txn.GetAttributes(transaction.OracleResponseT); len(attrs) != 0 {
-			delete(mp.oracleResp, attrs[0].Value.(*transaction.OracleResponse).ID)
-		}
-		mp.verifiedTxes[len(mp.verifiedTxes)-1] = pItem
-		if mp.subscriptionsOn.Load() {
-			mp.events <- Event{
-				Type: TransactionRemoved,
-				Tx:   unlucky.txn,
-				Data: unlucky.data,
-			}
-		}
-	} else {
-		mp.verifiedTxes = append(mp.verifiedTxes, pItem)
+		// Ditch the least prioritized one (it's the last one unless there
+		// are exempted or fallback transactions).
+		ev.add(mp.verifiedTxes[u], RemovedCapacity)
+	}
+
+	for i, itm := range ev.items {
+		mp.removeInternal(itm.txn.Hash(), fee, ev.reasons[i])
 	}
+	if len(ev.items) != 0 {
+		n = search()
+	}
+	mp.verifiedTxes = append(mp.verifiedTxes, pItem)
 	if n != len(mp.verifiedTxes)-1 {
 		copy(mp.verifiedTxes[n+1:], mp.verifiedTxes[n:])
 		mp.verifiedTxes[n] = pItem
 	}
+	if attrs := t.GetAttributes(transaction.OracleResponseT); len(attrs) != 0 {
+		mp.oracleResp[attrs[0].Value.(*transaction.OracleResponse).ID] = t.Hash()
+	}
+	mp.updateClassCount(pItem, 1)
+	mp.bytes += t.Size()
 	mp.verifiedMap[t.Hash()] = t
 	if fee.P2PSigExtensionsEnabled() {
 		// Add conflicting hashes to the mp.conflicts list.
@@ -284,6 +433,7 @@ func (mp *Pool) Add(t *transaction.Transaction, fee Feer, data ...interface{}) e
 	mp.tryAddSendersFee(pItem.txn, fee, false)
 
 	updateMempoolMetrics(len(mp.verifiedTxes))
+	addFeePerByteMetric(t.FeePerByte())
 	mp.lock.Unlock()
 
 	if mp.subscriptionsOn.Load() {
@@ -296,34 +446,124 @@ func (mp *Pool) Add(t *transaction.Transaction, fee Feer, data ...interface{}) e
 	return nil
 }
 
+// checkBytesLimit checks whether the given item that is to be inserted at
+// position n fits into the pool size limit. If it doesn't, the least
+// prioritized items of the same class (exempted from fee policy, fallback or
+// regular) are to be evicted to free enough space if they're all less
+// prioritized than the new one, otherwise ErrOOM is returned.
+func (mp *Pool) checkBytesLimit(pItem item, n int, ev *evictions) error {
+	if mp.maxBytes <= 0 {
+		return nil
+	}
+	need := mp.bytes - ev.size + pItem.txn.Size() - mp.maxBytes
+	if need <= 0 {
+		return nil
+	}
+	var victims []item
+	for i := len(mp.verifiedTxes) - 1; i >= n && need > 0; i-- {
+		if ev.has(mp.verifiedTxes[i]) || !sameClass(mp.verifiedTxes[i], pItem) {
+			continue
+		}
+		need -= mp.verifiedTxes[i].txn.Size()
+		victims = append(victims, mp.verifiedTxes[i])
+	}
+	if need > 0 {
+		return ErrOOM
+	}
+	for _, itm := range victims {
+		ev.add(itm, RemovedCapacity)
+	}
+	return nil
+}
+
+// sameClass checks whether both items belong to the same pool quota.
+func sameClass(a, b item) bool {
+	return a.exempt == b.exempt && a.fallback == b.fallback
+}
+
+// updateClassCount adds delta to the counter of items of the given item class
+// if it has a separate quota.
+func (mp *Pool) updateClassCount(itm item, delta int) {
+	if itm.exempt {
+		mp.exemptCount += delta
+	} else if itm.fallback {
+		mp.fallbackCount += delta
+	}
+}
+
+// isFull checks whether the quota for items of the given item class is
+// exhausted taking into account items that are to be evicted.
+func (mp *Pool) isFull(pItem item, ev *evictions) bool {
+	switch {
+	case pItem.exempt:
+		return mp.exemptCount-ev.exempt >= mp.exemptCapacity
+	case pItem.fallback:
+		return mp.fallbackCount-ev.fallback >= mp.fallbackCapacity
+	default:
+		regular := len(mp.verifiedTxes) - mp.exemptCount - mp.fallbackCount
+		evicted := len(ev.items) - ev.exempt - ev.fallback
+		return regular-evicted >= mp.capacity
+	}
+}
+
+// leastPrioritized returns the index of the least prioritized item of the
+// same class as the given one that is not to be evicted yet, it's -1 if there
+// is no such item. Fallback transactions are compared using fallback policy.
+func (mp *Pool) leastPrioritized(pItem item, ev *evictions) int {
+	if pItem.fallback {
+		u := -1
+		for i := range mp.verifiedTxes {
+			if mp.verifiedTxes[i].fallback && !ev.has(mp.verifiedTxes[i]) && (u < 0 ||
+				mp.fallbackPolicy.Compare(mp.verifiedTxes[i].txn, mp.verifiedTxes[u].txn) <= 0) {
+				u = i
+			}
+		}
+		return u
+	}
+	for i := len(mp.verifiedTxes) - 1; i >= 0; i-- {
+		if sameClass(mp.verifiedTxes[i], pItem) && !ev.has(mp.verifiedTxes[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// canReplace checks whether the given item that is to be inserted at position
+// n is more prioritized than the item at position u.
+func (mp *Pool) canReplace(pItem item, u, n int) bool {
+	if u < 0 {
+		return false
+	}
+	if pItem.fallback {
+		return mp.fallbackPolicy.Compare(pItem.txn, mp.verifiedTxes[u].txn) > 0
+	}
+	return n <= u
+}
+
 // Remove removes an item from the mempool, if it exists there (and does
 // nothing if it doesn't).
 func (mp *Pool) Remove(hash util.Uint256, feer Feer) {
 	mp.lock.Lock()
-	mp.removeInternal(hash, feer)
+	mp.removeInternal(hash, feer, RemovedExplicitly)
 	mp.lock.Unlock()
 }
 
-// removeInternal is an internal unlocked representation of Remove
-func (mp *Pool) removeInternal(hash util.Uint256, feer Feer) {
+// removeInternal is an internal unlocked representation of Remove, reason is
+// passed to the removal event.
+func (mp *Pool) removeInternal(hash util.Uint256, feer Feer, reason RemovalReason) {
 	if tx, ok := mp.verifiedMap[hash]; ok {
-		var num int
 		delete(mp.verifiedMap, hash)
-		for num = range mp.verifiedTxes {
-			if hash.Equals(mp.verifiedTxes[num].txn.Hash()) {
-				break
-			}
+		num := mp.indexOf(tx)
+		if num < 0 {
+			// Can't happen unless the pool is inconsistent.
+			updateMempoolMetrics(len(mp.verifiedTxes))
+			return
 		}
 		itm := mp.verifiedTxes[num]
-		if num < len(mp.verifiedTxes)-1 {
-			mp.verifiedTxes = append(mp.verifiedTxes[:num], mp.verifiedTxes[num+1:]...)
-		} else if num == len(mp.verifiedTxes)-1 {
-			mp.verifiedTxes = mp.verifiedTxes[:num]
-		}
-		payer := itm.txn.Signers[mp.payerIndex].Account
-		senderFee := mp.fees[payer]
-		senderFee.feeSum.Sub(senderFee.feeSum, big.NewInt(tx.SystemFee+tx.NetworkFee))
-		mp.fees[payer] = senderFee
+		mp.verifiedTxes = append(mp.verifiedTxes[:num], mp.verifiedTxes[num+1:]...)
+		mp.removeSendersFee(itm.txn)
+		mp.bytes -= itm.txn.Size()
+		mp.updateClassCount(itm, -1)
 		if feer.P2PSigExtensionsEnabled() {
 			// remove all conflicting hashes from mp.conflicts list
 			mp.removeConflictsOf(tx)
@@ -331,21 +571,38 @@ func (mp *Pool) removeInternal(hash util.Uint256, feer Feer) {
 		if attrs := tx.GetAttributes(transaction.OracleResponseT); len(attrs) != 0 {
 			delete(mp.oracleResp, attrs[0].Value.(*transaction.OracleResponse).ID)
 		}
-		if mp.subscriptionsOn.Load() {
-			mp.events <- Event{
-				Type: TransactionRemoved,
-				Tx:   itm.txn,
-				Data: itm.data,
-			}
-		}
+		mp.notifyRemoved(itm, reason)
 	}
 	updateMempoolMetrics(len(mp.verifiedTxes))
 }
 
+// notifyRemoved updates metrics and sends removal event for the given item to
+// subscribers.
+func (mp *Pool) notifyRemoved(itm item, reason RemovalReason) {
+	addRemovedTxMetric(reason)
+	if mp.subscriptionsOn.Load() {
+		mp.events <- Event{
+			Type:   TransactionRemoved,
+			Tx:     itm.txn,
+			Data:   itm.data,
+			Reason: reason,
+		}
+	}
+}
+
 // RemoveStale filters verified transactions through the given function keeping
 // only the transactions for which it returns a true result. It's used to quickly
 // drop part of the mempool that is now invalid after the block acceptance.
 func (mp *Pool) RemoveStale(isOK func(*transaction.Transaction) bool, feer Feer) {
+	mp.RemoveStaleWithReason(func(tx *transaction.Transaction) (bool, RemovalReason) {
+		return isOK(tx), RemovedStale
+	}, feer)
+}
+
+// RemoveStaleWithReason is the same as RemoveStale, but the given function also
+// returns the reason transaction is removed for (it's ignored for transactions
+// that are kept).
+func (mp *Pool) RemoveStaleWithReason(isOK func(*transaction.Transaction) (bool, RemovalReason), feer Feer) {
 	mp.lock.Lock()
 	policyChanged := mp.loadPolicy(feer)
 	// We can reuse already allocated slice
@@ -355,13 +612,22 @@ func (mp *Pool) RemoveStale(isOK func(*transaction.Transaction) bool, feer Feer)
 	if feer.P2PSigExtensionsEnabled() {
 		mp.conflicts = make(map[util.Uint256][]util.Uint256)
 	}
+	mp.exemptCount = 0
+	mp.fallbackCount = 0
+	mp.bytes = 0
 	height := feer.BlockHeight()
 	var (
 		staleItems []item
 	)
 	for _, itm := range mp.verifiedTxes {
-		if isOK(itm.txn) && mp.checkPolicy(itm.txn, policyChanged) && mp.tryAddSendersFee(itm.txn, feer, true) {
+		ok, reason := isOK(itm.txn)
+		if ok && mp.maxAge != 0 && height-itm.blockStamp >= mp.maxAge {
+			ok, reason = false, RemovedTooOld
+		}
+		if ok && (itm.exempt || mp.checkPolicy(itm.txn, policyChanged)) && mp.tryAddSendersFee(itm.txn, feer, true) {
 			newVerifiedTxes = append(newVerifiedTxes, itm)
+			mp.bytes += itm.txn.Size()
+			mp.updateClassCount(itm, 1)
 			if feer.P2PSigExtensionsEnabled() {
 				for _, attr := range itm.txn.GetAttributes(transaction.ConflictsT) {
 					hash := attr.Value.(*transaction.Conflicts).Hash
@@ -377,17 +643,15 @@ func (mp *Pool) RemoveStale(isOK func(*transaction.Transaction) bool, feer Feer)
 				}
 			}
 		} else {
+			if ok {
+				// Policy or balance check failed.
+				reason = RemovedStale
+			}
 			delete(mp.verifiedMap, itm.txn.Hash())
 			if attrs := itm.txn.GetAttributes(transaction.OracleResponseT); len(attrs) != 0 {
 				delete(mp.oracleResp, attrs[0].Value.(*transaction.OracleResponse).ID)
 			}
-			if mp.subscriptionsOn.Load() {
-				mp.events <- Event{
-					Type: TransactionRemoved,
-					Tx:   itm.txn,
-					Data: itm.data,
-				}
-			}
+			mp.notifyRemoved(itm, reason)
 		}
 	}
 	if len(staleItems) != 0 {
@@ -416,16 +680,23 @@ func (mp *Pool) checkPolicy(tx *transaction.Transaction, policyChanged bool) boo
 	return false
 }
 
-// New returns a new Pool struct.
+// New returns a new Pool struct using DefaultPolicy.
 func New(capacity int, payerIndex int, enableSubscriptions bool) *Pool {
+	return NewWithPolicy(capacity, payerIndex, enableSubscriptions, DefaultPolicy)
+}
+
+// NewWithPolicy returns a new Pool struct using the given prioritization policy.
+func NewWithPolicy(capacity int, payerIndex int, enableSubscriptions bool, policy Policy) *Pool {
 	mp := &Pool{
 		verifiedMap:          make(map[util.Uint256]*transaction.Transaction),
 		verifiedTxes:         make([]item, 0, capacity),
 		capacity:             capacity,
 		payerIndex:           payerIndex,
+		policy:               policy,
 		fees:                 make(map[util.Uint160]utilityBalanceAndFees),
 		conflicts:            make(map[util.Uint256][]util.Uint256),
 		oracleResp:           make(map[uint64]util.Uint256),
+		postponed:            make(map[util.Uint256]postponedItem),
 		subscriptionsEnabled: enableSubscriptions,
 		stopCh:               make(chan struct{}),
 		events:               make(chan Event),
@@ -445,6 +716,90 @@ func (mp *Pool) SetResendThreshold(h uint32, f func(*transaction.Transaction, in
 	mp.resendFunc = f
 }
 
+// SetMaxAge sets the maximum number of blocks transaction can stay in the
+// pool for, older transactions are removed by RemoveStale irrespective of
+// their validity. Zero (the default) means no limit.
+func (mp *Pool) SetMaxAge(blocks uint32) {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+	mp.maxAge = blocks
+}
+
+// SetMaxBytes sets the maximum total size of pooled transactions in bytes, the
+// least prioritized transactions are evicted to fit into it along with the
+// capacity. Zero (the default) means no limit.
+func (mp *Pool) SetMaxBytes(n int) {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+	mp.maxBytes = n
+}
+
+// Bytes returns the total size of pooled transactions in bytes.
+func (mp *Pool) Bytes() int {
+	mp.lock.RLock()
+	defer mp.lock.RUnlock()
+	return mp.bytes
+}
+
+// SetSenderLimit sets the maximum number of transactions a single sender
+// (payer) can have in the pool at once, zero means no limit.
+func (mp *Pool) SetSenderLimit(n int) {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+	mp.senderLimit = n
+}
+
+// SetFeeExemption makes the pool to keep transactions for which isExempt
+// returns true irrespective of fee per byte policy changes. Such transactions
+// are stored in a separate quota of the given capacity, so they never evict
+// regular transactions (and are never evicted by them). It must be called
+// before any transaction is added to the pool.
+func (mp *Pool) SetFeeExemption(isExempt func(*transaction.Transaction) bool, capacity int) {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+	mp.isExempt = isExempt
+	mp.exemptCapacity = capacity
+}
+
+// SetFallbackPool makes the pool to store notary fallback transactions (the
+// ones for which isFallback returns true) in a separate quota of the given
+// capacity, so that they don't compete with regular transactions. Fallbacks
+// are evicted from this quota according to the given policy (FallbackPolicy
+// is used if it's nil) and they're not subject to per-sender limit, because
+// all of them are paid by the Notary contract. It must be called before any
+// transaction is added to the pool.
+func (mp *Pool) SetFallbackPool(isFallback func(*transaction.Transaction) bool, capacity int, p Policy) {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+	if p == nil {
+		p = FallbackPolicy
+	}
+	mp.isFallback = isFallback
+	mp.fallbackCapacity = capacity
+	mp.fallbackPolicy = p
+}
+
+// SetPolicy changes prioritization policy of the pool, pooled transactions
+// are reordered according to the new policy.
+func (mp *Pool) SetPolicy(p Policy) {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+	mp.policy = p
+	sort.SliceStable(mp.verifiedTxes, func(i, j int) bool {
+		return mp.compare(mp.verifiedTxes[i], mp.verifiedTxes[j]) > 0
+	})
+}
+
+// SortTransactions sorts the given transactions according to the pool's
+// policy from the most prioritized to the least prioritized ones.
+func (mp *Pool) SortTransactions(txes []*transaction.Transaction) {
+	mp.lock.RLock()
+	defer mp.lock.RUnlock()
+	sort.SliceStable(txes, func(i, j int) bool {
+		return mp.policy.Compare(txes[i], txes[j]) > 0
+	})
+}
+
 func (mp *Pool) resendStaleItems(items []item) {
 	for i := range items {
 		mp.resendFunc(items[i].txn, items[i].data)
@@ -467,25 +822,36 @@ func (mp *Pool) TryGetData(hash util.Uint256) (interface{}, bool) {
 	mp.lock.RLock()
 	defer mp.lock.RUnlock()
 	if tx, ok := mp.verifiedMap[hash]; ok {
-		itm := item{txn: tx}
-		n := sort.Search(len(mp.verifiedTxes), func(n int) bool {
-			return itm.CompareTo(mp.verifiedTxes[n]) >= 0
-		})
-		if n < len(mp.verifiedTxes) {
-			for i := n; i < len(mp.verifiedTxes); i++ { // items may have equal priority, so `n` is the left bound of the items which are as prioritized as the desired `itm`.
-				if mp.verifiedTxes[i].txn.Hash() == hash {
-					return mp.verifiedTxes[i].data, ok
-				}
-				if itm.CompareTo(mp.verifiedTxes[i]) != 0 {
-					break
-				}
-			}
+		if n := mp.indexOf(tx); n >= 0 {
+			return mp.verifiedTxes[n].data, ok
 		}
 	}
 
 	return nil, false
 }
 
+// indexOf returns the index of the given pooled transaction in verifiedTxes
+// or -1 if there is no such transaction. Items are sorted, so binary search is
+// used to find the position of the transaction instead of iterating over the
+// whole pool. It relies on the Policy being consistent for the whole lifetime
+// of pooled transactions.
+func (mp *Pool) indexOf(tx *transaction.Transaction) int {
+	itm := item{txn: tx}
+	hash := tx.Hash()
+	n := sort.Search(len(mp.verifiedTxes), func(n int) bool {
+		return mp.compare(itm, mp.verifiedTxes[n]) >= 0
+	})
+	for i := n; i < len(mp.verifiedTxes); i++ { // items may have equal priority, so `n` is the left bound of the items which are as prioritized as the desired `itm`.
+		if mp.verifiedTxes[i].txn.Hash() == hash {
+			return i
+		}
+		if mp.compare(itm, mp.verifiedTxes[i]) != 0 {
+			break
+		}
+	}
+	return -1
+}
+
 // GetVerifiedTransactions returns a slice of transactions with their fees.
 func (mp *Pool) GetVerifiedTransactions() []*transaction.Transaction {
 	mp.lock.RLock()
@@ -500,6 +866,91 @@ func (mp *Pool) GetVerifiedTransactions() []*transaction.Transaction {
 	return t
 }
 
+// GetVerifiedTransactionsPage returns at most limit (or all if it's not
+// positive) pooled transactions starting from the given offset in the same
+// order GetVerifiedTransactions returns them. It allows to get the most
+// prioritized transactions or to walk large pools without copying them
+// completely.
+func (mp *Pool) GetVerifiedTransactionsPage(offset, limit int) []*transaction.Transaction {
+	mp.lock.RLock()
+	defer mp.lock.RUnlock()
+
+	if offset < 0 || offset >= len(mp.verifiedTxes) {
+		return []*transaction.Transaction{}
+	}
+	end := len(mp.verifiedTxes)
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+	var t = make([]*transaction.Transaction, end-offset)
+
+	for i := range t {
+		t[i] = mp.verifiedTxes[offset+i].txn
+	}
+
+	return t
+}
+
+// IterateVerifiedTransactions calls the given function for pooled transactions
+// from the most prioritized to the least prioritized ones until it returns
+// false. The pool is locked for reading during iteration, so f must not
+// modify it.
+func (mp *Pool) IterateVerifiedTransactions(f func(tx *transaction.Transaction) bool) {
+	mp.lock.RLock()
+	defer mp.lock.RUnlock()
+
+	for i := range mp.verifiedTxes {
+		if !f(mp.verifiedTxes[i].txn) {
+			return
+		}
+	}
+}
+
+// GetVerifiedTransactionsWithStamps is the same as GetVerifiedTransactions,
+// but it also returns chain heights transactions were added to the pool at.
+func (mp *Pool) GetVerifiedTransactionsWithStamps() []TxWithStamp {
+	mp.lock.RLock()
+	defer mp.lock.RUnlock()
+
+	var t = make([]TxWithStamp, len(mp.verifiedTxes))
+
+	for i := range mp.verifiedTxes {
+		t[i] = TxWithStamp{
+			Tx:         mp.verifiedTxes[i].txn,
+			BlockStamp: mp.verifiedTxes[i].blockStamp,
+		}
+	}
+
+	return t
+}
+
+// EstimateFee returns the given percentile (calculated using nearest-rank
+// method) of fee per byte values of pooled transactions, so that transaction
+// paying more than the returned value per byte is more prioritized than the
+// given percentage of pooled transactions (with the default policy). Zero is
+// returned for empty pool.
+func (mp *Pool) EstimateFee(percentile int) (int64, error) {
+	if percentile < 0 || percentile > 100 {
+		return 0, fmt.Errorf("%w: %d", ErrInvalidPercentile, percentile)
+	}
+	mp.lock.RLock()
+	fees := make([]int64, len(mp.verifiedTxes))
+	for i := range mp.verifiedTxes {
+		fees[i] = mp.verifiedTxes[i].txn.FeePerByte()
+	}
+	mp.lock.RUnlock()
+
+	if len(fees) == 0 {
+		return 0, nil
+	}
+	sort.Slice(fees, func(i, j int) bool { return fees[i] < fees[j] })
+	rank := (percentile*len(fees) + 99) / 100
+	if rank == 0 {
+		rank = 1
+	}
+	return fees[rank-1], nil
+}
+
 // checkTxConflicts is an internal unprotected version of Verify. It takes into
 // consideration conflicting transactions which are about to be removed from mempool.
 func (mp *Pool) checkTxConflicts(tx *transaction.Transaction, fee Feer) ([]*transaction.Transaction, error) {
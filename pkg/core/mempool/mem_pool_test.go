@@ -114,6 +114,32 @@ func TestMemPoolAddRemove(t *testing.T) {
 	testMemPoolAddRemoveWithFeer(t, fs)
 }
 
+func TestMemPoolRemoveOrder(t *testing.T) {
+	fs := &FeerStub{balance: 10000000}
+	mp := New(10, 0, false)
+	txes := make([]*transaction.Transaction, 10)
+	for i := range txes {
+		txes[i] = transaction.New(netmode.UnitTestNet, []byte{byte(opcode.PUSH1)}, 0)
+		txes[i].Nonce = uint32(i)
+		txes[i].NetworkFee = int64(i / 3) // Some have equal priority.
+		txes[i].Signers = []transaction.Signer{{Account: util.Uint160{1, 2, 3}}}
+		require.NoError(t, mp.Add(txes[i], fs, i))
+	}
+	for _, i := range []int{4, 9, 0, 3} {
+		mp.Remove(txes[i].Hash(), fs)
+		require.False(t, mp.ContainsKey(txes[i].Hash()))
+		_, ok := mp.TryGetData(txes[i].Hash())
+		require.False(t, ok)
+	}
+	require.Equal(t, []*transaction.Transaction{txes[6], txes[7], txes[8], txes[5], txes[1], txes[2]},
+		mp.GetVerifiedTransactions())
+	for _, i := range []int{1, 2, 5, 6, 7, 8} {
+		data, ok := mp.TryGetData(txes[i].Hash())
+		require.True(t, ok)
+		require.Equal(t, i, data)
+	}
+}
+
 func TestOverCapacity(t *testing.T) {
 	var fs = &FeerStub{balance: 10000000}
 	const mempoolSize = 10
@@ -190,6 +216,299 @@ func TestOverCapacity(t *testing.T) {
 	require.Equal(t, true, sort.IsSorted(sort.Reverse(mp.verifiedTxes)))
 }
 
+func TestMaxBytes(t *testing.T) {
+	var fs = &FeerStub{balance: 10000000}
+	mp := New(10, 0, false)
+
+	newTx := func(nonce uint32, netFee int64, script []byte) *transaction.Transaction {
+		tx := transaction.New(netmode.UnitTestNet, script, 0)
+		tx.Nonce = nonce
+		tx.NetworkFee = netFee
+		tx.Signers = []transaction.Signer{{Account: util.Uint160{1, 2, 3}}}
+		return tx
+	}
+	txes := make([]*transaction.Transaction, 3)
+	for i := range txes {
+		txes[i] = newTx(uint32(i), int64(100*(i+1)), []byte{byte(opcode.PUSH1)})
+	}
+	size := txes[0].Size()
+	mp.SetMaxBytes(3 * size)
+	for _, tx := range txes {
+		require.NoError(t, mp.Add(tx, fs))
+	}
+	require.Equal(t, 3*size, mp.Bytes())
+	require.True(t, errors.Is(mp.Add(newTx(3, 50, []byte{byte(opcode.PUSH1)}), fs), ErrOOM))
+
+	// Two transactions are to be evicted to fit the big one.
+	script := make([]byte, 11)
+	script[0] = byte(opcode.PUSH1)
+	for i := 1; i < len(script); i++ {
+		script[i] = byte(opcode.NOP)
+	}
+	bigTx := newTx(4, 1000, script)
+	require.True(t, bigTx.Size() > size)
+	require.NoError(t, mp.Add(bigTx, fs))
+	require.Equal(t, 2, mp.Count())
+	require.True(t, mp.ContainsKey(txes[2].Hash()))
+	require.Equal(t, size+bigTx.Size(), mp.Bytes())
+
+	// Not prioritized enough to evict anything.
+	require.True(t, errors.Is(mp.Add(newTx(5, 100, script), fs), ErrOOM))
+
+	mp.Remove(txes[2].Hash(), fs)
+	require.Equal(t, bigTx.Size(), mp.Bytes())
+	mp.RemoveStale(func(*transaction.Transaction) bool { return true }, fs)
+	require.Equal(t, bigTx.Size(), mp.Bytes())
+	mp.RemoveStale(func(*transaction.Transaction) bool { return false }, fs)
+	require.Equal(t, 0, mp.Bytes())
+}
+
+func TestSenderLimit(t *testing.T) {
+	var fs = &FeerStub{balance: 10000000}
+	const senderLimit = 3
+	mp := New(10, 0, false)
+	mp.SetSenderLimit(senderLimit)
+
+	sender := util.Uint160{1, 2, 3}
+	newTx := func(nonce uint32, netFee int64, acc util.Uint160) *transaction.Transaction {
+		tx := transaction.New(netmode.UnitTestNet, []byte{byte(opcode.PUSH1)}, 0)
+		tx.Nonce = nonce
+		tx.NetworkFee = netFee
+		tx.Signers = []transaction.Signer{{Account: acc}}
+		return tx
+	}
+	txes := make([]*transaction.Transaction, senderLimit)
+	for i := range txes {
+		txes[i] = newTx(uint32(i), int64(1000*(i+1)), sender)
+		require.NoError(t, mp.Add(txes[i], fs))
+	}
+	require.Equal(t, senderLimit, mp.fees[sender].txCount)
+
+	// Other senders are not affected.
+	require.NoError(t, mp.Add(newTx(100, 0, util.Uint160{3, 2, 1}), fs))
+
+	// Not more prioritized than any of sender's transactions.
+	tx := newTx(10, 1000, sender)
+	require.True(t, errors.Is(mp.Add(tx, fs), ErrSenderLimit))
+	require.False(t, mp.ContainsKey(tx.Hash()))
+
+	// The least prioritized one is evicted.
+	tx = newTx(11, 1500, sender)
+	require.NoError(t, mp.Add(tx, fs))
+	require.True(t, mp.ContainsKey(tx.Hash()))
+	require.False(t, mp.ContainsKey(txes[0].Hash()))
+	require.Equal(t, senderLimit+1, mp.Count())
+	require.Equal(t, senderLimit, mp.fees[sender].txCount)
+	require.Equal(t, int64(2000+3000+1500), mp.fees[sender].feeSum.Int64())
+
+	mp.Remove(txes[1].Hash(), fs)
+	require.Equal(t, senderLimit-1, mp.fees[sender].txCount)
+	require.NoError(t, mp.Add(txes[0], fs))
+	require.Equal(t, senderLimit, mp.fees[sender].txCount)
+}
+
+func TestEstimateFee(t *testing.T) {
+	var fs = &FeerStub{balance: 10000000}
+	mp := New(10, 0, false)
+
+	_, err := mp.EstimateFee(-1)
+	require.True(t, errors.Is(err, ErrInvalidPercentile))
+	_, err = mp.EstimateFee(101)
+	require.True(t, errors.Is(err, ErrInvalidPercentile))
+
+	fee, err := mp.EstimateFee(50)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), fee)
+
+	var fees []int64
+	for i := 0; i < 10; i++ {
+		tx := transaction.New(netmode.UnitTestNet, []byte{byte(opcode.PUSH1)}, 0)
+		tx.Nonce = uint32(i)
+		tx.NetworkFee = int64(10000 * (10 - i))
+		tx.Signers = []transaction.Signer{{Account: util.Uint160{1, 2, 3}}}
+		require.NoError(t, mp.Add(tx, fs))
+		fees = append(fees, tx.FeePerByte())
+	}
+	sort.Slice(fees, func(i, j int) bool { return fees[i] < fees[j] })
+	for p, idx := range map[int]int{0: 0, 1: 0, 10: 0, 11: 1, 50: 4, 90: 8, 100: 9} {
+		fee, err := mp.EstimateFee(p)
+		require.NoError(t, err)
+		require.Equal(t, fees[idx], fee, p)
+	}
+}
+
+func TestPostpone(t *testing.T) {
+	var fs = &FeerStub{balance: 10000000}
+	mp := New(10, 0, false)
+
+	newTx := func(nonce uint32, netFee int64, vub uint32) *transaction.Transaction {
+		tx := transaction.New(netmode.UnitTestNet, []byte{byte(opcode.PUSH1)}, 0)
+		tx.Nonce = nonce
+		tx.NetworkFee = netFee
+		tx.ValidUntilBlock = vub
+		tx.Signers = []transaction.Signer{{Account: util.Uint160{1, 2, 3}}}
+		return tx
+	}
+	tx1 := newTx(1, 1000, 10)
+	require.True(t, errors.Is(mp.Postpone(tx1, 5, fs), ErrOOM))
+
+	mp.SetPostponedCapacity(2)
+	require.Equal(t, 2, mp.PostponedCapacity())
+	require.True(t, errors.Is(mp.Postpone(newTx(0, fs.balance+1, 10), 5, fs), ErrInsufficientFunds))
+	require.NoError(t, mp.Postpone(tx1, 5, fs))
+	require.True(t, errors.Is(mp.Postpone(tx1, 5, fs), ErrDup))
+	tx2 := newTx(2, 2000, 7)
+	require.NoError(t, mp.Postpone(tx2, 6, fs))
+	require.Equal(t, 2, mp.PostponedCount())
+	require.Equal(t, 0, mp.Count())
+
+	// Full, the least prioritized one is evicted if possible.
+	require.True(t, errors.Is(mp.Postpone(newTx(3, 500, 10), 5, fs), ErrOOM))
+	tx4 := newTx(4, 3000, 10)
+	require.NoError(t, mp.Postpone(tx4, 5, fs))
+	require.False(t, mp.ContainsPostponed(tx1.Hash()))
+	require.True(t, mp.ContainsPostponed(tx2.Hash()))
+	require.True(t, mp.ContainsPostponed(tx4.Hash()))
+
+	require.Equal(t, 0, len(mp.ReleasePostponed(4)))
+	require.Equal(t, []*transaction.Transaction{tx4}, mp.ReleasePostponed(5))
+	require.Equal(t, 1, mp.PostponedCount())
+
+	// Pooled transactions can't be postponed.
+	require.NoError(t, mp.Add(tx4, fs))
+	require.True(t, errors.Is(mp.Postpone(tx4, 8, fs), ErrDup))
+
+	// Expired ones are dropped.
+	require.Equal(t, 0, len(mp.ReleasePostponed(7)))
+	require.Equal(t, 0, mp.PostponedCount())
+
+	t.Run("order", func(t *testing.T) {
+		txes := []*transaction.Transaction{newTx(5, 100, 10), newTx(6, 300, 10)}
+		for _, tx := range txes {
+			require.NoError(t, mp.Postpone(tx, 8, fs))
+		}
+		require.Equal(t, []*transaction.Transaction{txes[1], txes[0]}, mp.ReleasePostponed(8))
+	})
+}
+
+func TestMaxAge(t *testing.T) {
+	var fs = &FeerStub{balance: 10000000}
+	mp := New(10, 0, false)
+	mp.SetMaxAge(3)
+
+	txes := make([]*transaction.Transaction, 3)
+	for i := range txes {
+		fs.blockHeight = uint32(i)
+		txes[i] = transaction.New(netmode.UnitTestNet, []byte{byte(opcode.PUSH1)}, 0)
+		txes[i].Nonce = uint32(i)
+		txes[i].Signers = []transaction.Signer{{Account: util.Uint160{1, 2, 3}}}
+		require.NoError(t, mp.Add(txes[i], fs))
+	}
+	isOK := func(*transaction.Transaction) bool { return true }
+
+	mp.RemoveStale(isOK, fs)
+	require.Equal(t, 3, mp.Count())
+
+	fs.blockHeight = 4
+	mp.RemoveStale(isOK, fs)
+	require.Equal(t, 1, mp.Count())
+	require.True(t, mp.ContainsKey(txes[2].Hash()))
+	require.Equal(t, 1, mp.fees[util.Uint160{1, 2, 3}].txCount)
+
+	mp.SetMaxAge(0)
+	fs.blockHeight = 100
+	mp.RemoveStale(isOK, fs)
+	require.Equal(t, 1, mp.Count())
+}
+
+func TestFeeExemption(t *testing.T) {
+	var fs = &FeerStub{balance: 10000000}
+	exempted := util.Uint160{3, 2, 1}
+	mp := New(2, 0, false)
+	mp.SetFeeExemption(func(tx *transaction.Transaction) bool {
+		return tx.Sender().Equals(exempted)
+	}, 1)
+
+	newTx := func(nonce uint32, netFee int64, acc util.Uint160) *transaction.Transaction {
+		tx := transaction.New(netmode.UnitTestNet, []byte{byte(opcode.PUSH1)}, 0)
+		tx.Nonce = nonce
+		tx.NetworkFee = netFee
+		tx.Signers = []transaction.Signer{{Account: acc}}
+		return tx
+	}
+	free := newTx(0, 0, exempted)
+	require.NoError(t, mp.Add(free, fs))
+	require.True(t, errors.Is(mp.Add(newTx(1, 0, exempted), fs), ErrOOM))
+
+	// Regular transactions don't evict exempted ones and vice versa.
+	regular := []*transaction.Transaction{newTx(2, 100, util.Uint160{1, 2, 3}), newTx(3, 200, util.Uint160{1, 2, 3})}
+	for _, tx := range regular {
+		require.NoError(t, mp.Add(tx, fs))
+	}
+	require.True(t, errors.Is(mp.Add(newTx(4, 50, util.Uint160{1, 2, 3}), fs), ErrOOM))
+	tx := newTx(5, 300, util.Uint160{1, 2, 3})
+	require.NoError(t, mp.Add(tx, fs))
+	require.False(t, mp.ContainsKey(regular[0].Hash()))
+	require.True(t, mp.ContainsKey(free.Hash()))
+	require.Equal(t, []*transaction.Transaction{tx, regular[1], free}, mp.GetVerifiedTransactions())
+
+	paid := newTx(6, 10, exempted)
+	require.NoError(t, mp.Add(paid, fs))
+	require.False(t, mp.ContainsKey(free.Hash()))
+	require.Equal(t, 3, mp.Count())
+
+	// Exempted transactions are not affected by fee per byte policy.
+	mp.RemoveStale(func(*transaction.Transaction) bool { return true }, &FeerStub{balance: 10000000, feePerByte: 1000})
+	require.Equal(t, []*transaction.Transaction{paid}, mp.GetVerifiedTransactions())
+	require.Equal(t, 1, mp.exemptCount)
+}
+
+func TestFallbackPool(t *testing.T) {
+	var fs = &FeerStub{balance: 10000000}
+	notary := util.Uint160{9, 9, 9}
+	mp := New(2, 0, false)
+	mp.SetSenderLimit(1)
+	mp.SetFallbackPool(func(tx *transaction.Transaction) bool {
+		return tx.Sender().Equals(notary)
+	}, 2, nil)
+
+	newTx := func(nonce uint32, netFee int64, vub uint32, acc util.Uint160) *transaction.Transaction {
+		tx := transaction.New(netmode.UnitTestNet, []byte{byte(opcode.PUSH1)}, 0)
+		tx.Nonce = nonce
+		tx.NetworkFee = netFee
+		tx.ValidUntilBlock = vub
+		tx.Signers = []transaction.Signer{{Account: acc}}
+		return tx
+	}
+	require.NoError(t, mp.Add(newTx(0, 1000, 10, util.Uint160{1}), fs))
+	require.NoError(t, mp.Add(newTx(1, 1000, 10, util.Uint160{2}), fs))
+	require.True(t, errors.Is(mp.Add(newTx(2, 100, 10, util.Uint160{3}), fs), ErrOOM))
+
+	// Fallbacks don't compete with regular transactions and are not
+	// restricted by sender limit.
+	fb1 := newTx(3, 10, 7, notary)
+	fb2 := newTx(4, 20, 8, notary)
+	require.NoError(t, mp.Add(fb1, fs))
+	require.NoError(t, mp.Add(fb2, fs))
+	require.Equal(t, 4, mp.Count())
+
+	// Pays more, but expires later.
+	require.True(t, errors.Is(mp.Add(newTx(5, 5000, 9, notary), fs), ErrOOM))
+	// Expires earlier, fb2 is evicted even though it pays more.
+	fb3 := newTx(6, 5, 6, notary)
+	require.NoError(t, mp.Add(fb3, fs))
+	require.Equal(t, 4, mp.Count())
+	require.False(t, mp.ContainsKey(fb2.Hash()))
+	require.True(t, mp.ContainsKey(fb1.Hash()))
+	require.True(t, mp.ContainsKey(fb3.Hash()))
+	require.Equal(t, true, sort.IsSorted(sort.Reverse(mp.verifiedTxes)))
+
+	mp.RemoveStale(func(tx *transaction.Transaction) bool { return tx != fb1 }, fs)
+	require.Equal(t, 1, mp.fallbackCount)
+	require.NoError(t, mp.Add(fb2, fs))
+	require.Equal(t, 2, mp.fallbackCount)
+}
+
 func TestGetVerified(t *testing.T) {
 	var fs = &FeerStub{}
 	const mempoolSize = 10
@@ -201,12 +520,31 @@ func TestGetVerified(t *testing.T) {
 		tx.Nonce = uint32(i)
 		tx.Signers = []transaction.Signer{{Account: util.Uint160{1, 2, 3}}}
 		txes = append(txes, tx)
+		fs.blockHeight = uint32(i)
 		require.NoError(t, mp.Add(tx, fs))
 	}
 	require.Equal(t, mempoolSize, mp.Count())
 	verTxes := mp.GetVerifiedTransactions()
 	require.Equal(t, mempoolSize, len(verTxes))
 	require.ElementsMatch(t, txes, verTxes)
+	stamped := mp.GetVerifiedTransactionsWithStamps()
+	require.Equal(t, mempoolSize, len(stamped))
+	for i := range stamped {
+		require.Equal(t, verTxes[i], stamped[i].Tx)
+		require.Equal(t, stamped[i].Tx.Nonce, stamped[i].BlockStamp)
+	}
+	require.Equal(t, verTxes, mp.GetVerifiedTransactionsPage(0, 0))
+	require.Equal(t, verTxes[:3], mp.GetVerifiedTransactionsPage(0, 3))
+	require.Equal(t, verTxes[8:], mp.GetVerifiedTransactionsPage(8, 5))
+	require.Equal(t, 0, len(mp.GetVerifiedTransactionsPage(mempoolSize, 1)))
+	require.Equal(t, 0, len(mp.GetVerifiedTransactionsPage(-1, 1)))
+
+	var iterated []*transaction.Transaction
+	mp.IterateVerifiedTransactions(func(tx *transaction.Transaction) bool {
+		iterated = append(iterated, tx)
+		return len(iterated) < 4
+	})
+	require.Equal(t, verTxes[:4], iterated)
 	for _, tx := range txes {
 		mp.Remove(tx.Hash(), fs)
 	}
@@ -271,6 +609,7 @@ func TestMemPoolFees(t *testing.T) {
 	require.Equal(t, utilityBalanceAndFees{
 		balance: big.NewInt(fs.balance),
 		feeSum:  big.NewInt(tx1.NetworkFee),
+		txCount: 1,
 	}, mp.fees[sender0])
 
 	// balance shouldn't change after adding one more transaction
@@ -283,6 +622,7 @@ func TestMemPoolFees(t *testing.T) {
 	require.Equal(t, utilityBalanceAndFees{
 		balance: big.NewInt(fs.balance),
 		feeSum:  big.NewInt(fs.balance),
+		txCount: 2,
 	}, mp.fees[sender0])
 
 	// can't add more transactions as we don't have enough GAS
@@ -295,6 +635,7 @@ func TestMemPoolFees(t *testing.T) {
 	require.Equal(t, utilityBalanceAndFees{
 		balance: big.NewInt(fs.balance),
 		feeSum:  big.NewInt(fs.balance),
+		txCount: 2,
 	}, mp.fees[sender0])
 
 	// check whether sender's fee updates correctly
@@ -308,6 +649,7 @@ func TestMemPoolFees(t *testing.T) {
 	require.Equal(t, utilityBalanceAndFees{
 		balance: big.NewInt(fs.balance),
 		feeSum:  big.NewInt(tx2.NetworkFee),
+		txCount: 1,
 	}, mp.fees[sender0])
 
 	// there should be nothing left
@@ -360,6 +702,45 @@ func TestMempoolItemsOrder(t *testing.T) {
 	require.True(t, item4.CompareTo(item3) < 0)
 }
 
+// noncePolicy prioritizes transactions with lower nonces.
+type noncePolicy struct{}
+
+func (noncePolicy) Compare(a, b *transaction.Transaction) int {
+	return int(b.Nonce) - int(a.Nonce)
+}
+
+func TestMempoolPolicy(t *testing.T) {
+	fs := &FeerStub{balance: 10000000}
+	newTx := func(nonce uint32, netFee int64) *transaction.Transaction {
+		tx := transaction.New(netmode.UnitTestNet, []byte{byte(opcode.PUSH1)}, 0)
+		tx.Nonce = nonce
+		tx.NetworkFee = netFee
+		tx.Signers = []transaction.Signer{{Account: util.Uint160{1, 2, 3}}}
+		return tx
+	}
+	txes := []*transaction.Transaction{newTx(1, 100), newTx(2, 300), newTx(3, 200)}
+
+	mp := NewWithPolicy(2, 0, false, noncePolicy{})
+	require.NoError(t, mp.Add(txes[2], fs))
+	require.NoError(t, mp.Add(txes[1], fs))
+	// Evicts txes[2] irrespective of fees.
+	require.NoError(t, mp.Add(txes[0], fs))
+	require.False(t, mp.ContainsKey(txes[2].Hash()))
+	require.True(t, errors.Is(mp.Add(newTx(4, 1000), fs), ErrOOM))
+	require.Equal(t, []*transaction.Transaction{txes[0], txes[1]}, mp.GetVerifiedTransactions())
+
+	data, ok := mp.TryGetData(txes[1].Hash())
+	require.True(t, ok)
+	require.Nil(t, data)
+
+	mp.SetPolicy(DefaultPolicy)
+	require.Equal(t, []*transaction.Transaction{txes[1], txes[0]}, mp.GetVerifiedTransactions())
+
+	toSort := []*transaction.Transaction{txes[0], txes[2], txes[1]}
+	mp.SortTransactions(toSort)
+	require.Equal(t, []*transaction.Transaction{txes[1], txes[2], txes[0]}, toSort)
+}
+
 func TestMempoolAddRemoveOracleResponse(t *testing.T) {
 	mp := New(3, 0, false)
 	nonce := uint32(0)
@@ -429,6 +810,57 @@ func TestMempoolAddRemoveOracleResponse(t *testing.T) {
 	}
 }
 
+func TestMempoolRejectedOracleResponse(t *testing.T) {
+	mp := New(10, 0, false)
+	fs := &FeerStub{balance: 10000}
+	newTx := func(nonce uint32, netFee int64, script []byte, id uint64) *transaction.Transaction {
+		tx := transaction.New(netmode.UnitTestNet, script, 0)
+		tx.NetworkFee = netFee
+		tx.Nonce = nonce
+		tx.Signers = []transaction.Signer{{Account: util.Uint160{1, 2, 3}}}
+		tx.Attributes = []transaction.Attribute{{
+			Type:  transaction.OracleResponseT,
+			Value: &transaction.OracleResponse{ID: id},
+		}}
+		return tx
+	}
+	script := []byte{byte(opcode.PUSH1)}
+	bigScript := make([]byte, 11)
+	bigScript[0] = byte(opcode.PUSH1)
+	for i := 1; i < len(bigScript); i++ {
+		bigScript[i] = byte(opcode.NOP)
+	}
+
+	tx1 := newTx(0, 100, script, 1)
+	tx2 := newTx(1, 10, script, 2)
+	mp.SetMaxBytes(tx1.Size() + tx2.Size())
+	require.NoError(t, mp.Add(tx1, fs))
+	require.NoError(t, mp.Add(tx2, fs))
+
+	// tx2 can be replaced, but tx1 is too prioritized to be evicted.
+	tx3 := newTx(2, 20, bigScript, 2)
+	require.True(t, errors.Is(mp.Add(tx3, fs), ErrOOM))
+	require.True(t, mp.ContainsKey(tx1.Hash()))
+	require.True(t, mp.ContainsKey(tx2.Hash()))
+	require.Equal(t, tx2.Hash(), mp.oracleResp[2])
+
+	tx4 := newTx(3, 30, script, 2)
+	require.NoError(t, mp.Add(tx4, fs))
+	require.False(t, mp.ContainsKey(tx2.Hash()))
+	require.True(t, mp.ContainsKey(tx4.Hash()))
+	require.Equal(t, tx4.Hash(), mp.oracleResp[2])
+
+	t.Run("sender limit", func(t *testing.T) {
+		// All responses are sent by the Oracle contract.
+		mp := New(10, 0, false)
+		mp.SetSenderLimit(1)
+		for i := 0; i < 3; i++ {
+			require.NoError(t, mp.Add(newTx(uint32(i), 10, script, uint64(i)), fs))
+		}
+		require.Equal(t, 3, mp.Count())
+	})
+}
+
 func TestMempoolAddRemoveConflicts(t *testing.T) {
 	capacity := 6
 	mp := New(capacity, 0, false)
@@ -550,6 +982,30 @@ func TestMempoolAddRemoveConflicts(t *testing.T) {
 	require.True(t, errors.Is(mp.Add(tx13, fs), ErrConflictsAttribute))
 }
 
+func TestMempoolConflictsWithoutP2PSig(t *testing.T) {
+	mp := New(10, 0, false)
+	fs := &FeerStub{balance: 100000}
+
+	tx1 := transaction.New(netmode.UnitTestNet, []byte{byte(opcode.PUSH1)}, 0)
+	tx1.NetworkFee = 1
+	tx1.Signers = []transaction.Signer{{Account: util.Uint160{1, 2, 3}}}
+	require.NoError(t, mp.Add(tx1, fs))
+
+	// Conflicts attribute means nothing without P2PSigExtensions, so tx1
+	// can't be replaced.
+	tx2 := transaction.New(netmode.UnitTestNet, []byte{byte(opcode.PUSH1)}, 0)
+	tx2.NetworkFee = 2
+	tx2.Signers = []transaction.Signer{{Account: util.Uint160{1, 2, 3}}}
+	tx2.Attributes = []transaction.Attribute{{
+		Type:  transaction.ConflictsT,
+		Value: &transaction.Conflicts{Hash: tx1.Hash()},
+	}}
+	require.NoError(t, mp.Add(tx2, fs))
+	require.True(t, mp.ContainsKey(tx1.Hash()))
+	require.True(t, mp.ContainsKey(tx2.Hash()))
+	require.Equal(t, 0, len(mp.conflicts))
+}
+
 func TestMempoolAddWithDataGetData(t *testing.T) {
 	var (
 		smallNetFee int64 = 3
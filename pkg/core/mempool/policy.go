@@ -0,0 +1,63 @@
+package mempool
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+)
+
+// Policy defines transaction prioritization in the Pool. Pooled transactions
+// are kept ordered from the most prioritized to the least prioritized ones,
+// the least prioritized are evicted first when the pool is full and the most
+// prioritized are taken first for block proposals.
+type Policy interface {
+	// Compare returns a positive number if a is more prioritized than b,
+	// a negative number if it's less prioritized and zero if they're equally
+	// prioritized. It must be consistent for the whole lifetime of
+	// transactions in the pool.
+	Compare(a, b *transaction.Transaction) int
+}
+
+// DefaultPolicy is the default Pool prioritization policy. Transactions with
+// HighPriority attribute always go first, then transactions are ordered by
+// their fee per byte and then by their network fee.
+var DefaultPolicy Policy = feePolicy{}
+
+// FallbackPolicy is the default prioritization policy of notary fallback
+// transactions sub-pool (see SetFallbackPool). Transactions that expire
+// earlier go first (they have less time left to be accepted), then they're
+// ordered according to DefaultPolicy.
+var FallbackPolicy Policy = fallbackPolicy{}
+
+// feePolicy is the DefaultPolicy implementation.
+type feePolicy struct{}
+
+// Compare implements Policy interface.
+func (feePolicy) Compare(a, b *transaction.Transaction) int {
+	aHigh := a.HasAttribute(transaction.HighPriority)
+	bHigh := b.HasAttribute(transaction.HighPriority)
+	if aHigh && !bHigh {
+		return 1
+	} else if !aHigh && bHigh {
+		return -1
+	}
+
+	// Fees sorted ascending.
+	if ret := int(a.FeePerByte() - b.FeePerByte()); ret != 0 {
+		return ret
+	}
+
+	return int(a.NetworkFee - b.NetworkFee)
+}
+
+// fallbackPolicy is the FallbackPolicy implementation.
+type fallbackPolicy struct{}
+
+// Compare implements Policy interface.
+func (fallbackPolicy) Compare(a, b *transaction.Transaction) int {
+	if a.ValidUntilBlock != b.ValidUntilBlock {
+		if a.ValidUntilBlock < b.ValidUntilBlock {
+			return 1
+		}
+		return -1
+	}
+	return DefaultPolicy.Compare(a, b)
+}
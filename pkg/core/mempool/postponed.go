@@ -0,0 +1,114 @@
+package mempool
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// postponedItem is a transaction that can't be added to the pool yet along
+// with the height it becomes valid at.
+type postponedItem struct {
+	txn    *transaction.Transaction
+	height uint32
+}
+
+// SetPostponedCapacity sets the maximum number of postponed transactions the
+// pool can hold, zero (the default) disables postponing.
+func (mp *Pool) SetPostponedCapacity(n int) {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+	mp.postponedCapacity = n
+}
+
+// PostponedCapacity returns the maximum number of postponed transactions the
+// pool can hold.
+func (mp *Pool) PostponedCapacity() int {
+	mp.lock.RLock()
+	defer mp.lock.RUnlock()
+	return mp.postponedCapacity
+}
+
+// PostponedCount returns the number of postponed transactions.
+func (mp *Pool) PostponedCount() int {
+	mp.lock.RLock()
+	defer mp.lock.RUnlock()
+	return len(mp.postponed)
+}
+
+// ContainsPostponed checks whether transaction with the given hash is
+// postponed.
+func (mp *Pool) ContainsPostponed(hash util.Uint256) bool {
+	mp.lock.RLock()
+	defer mp.lock.RUnlock()
+	_, ok := mp.postponed[hash]
+	return ok
+}
+
+// Postpone stores already verified transaction that becomes valid at the given
+// height in a separate area of the pool until ReleasePostponed is called for
+// this height. Sender's balance is checked against this transaction only, the
+// complete check is performed when it's added to the pool. If this area is
+// full, the least prioritized postponed transaction is evicted if it's also
+// less prioritized than the new one, otherwise ErrOOM is returned.
+func (mp *Pool) Postpone(t *transaction.Transaction, height uint32, feer Feer) error {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+
+	if mp.postponedCapacity <= 0 {
+		return ErrOOM
+	}
+	h := t.Hash()
+	if _, ok := mp.postponed[h]; ok || mp.containsKey(h) {
+		return ErrDup
+	}
+	payer := t.Signers[mp.payerIndex].Account
+	if feer.GetUtilityTokenBalance(payer).Cmp(big.NewInt(t.SystemFee+t.NetworkFee)) < 0 {
+		return ErrInsufficientFunds
+	}
+	if len(mp.postponed) >= mp.postponedCapacity {
+		var victim *postponedItem
+		for _, p := range mp.postponed {
+			if victim == nil || mp.policy.Compare(p.txn, victim.txn) < 0 {
+				p := p
+				victim = &p
+			}
+		}
+		if mp.policy.Compare(t, victim.txn) <= 0 {
+			return ErrOOM
+		}
+		delete(mp.postponed, victim.txn.Hash())
+	}
+	mp.postponed[h] = postponedItem{txn: t, height: height}
+	updatePostponedMetrics(len(mp.postponed))
+	return nil
+}
+
+// ReleasePostponed removes postponed transactions that are valid at the given
+// height from the postponed area and returns them sorted from the most
+// prioritized to the least prioritized ones, so that they can be reverified
+// and added to the pool. Transactions that have expired by this height are
+// just dropped.
+func (mp *Pool) ReleasePostponed(height uint32) []*transaction.Transaction {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+
+	var txes []*transaction.Transaction
+	for h, p := range mp.postponed {
+		if p.txn.ValidUntilBlock <= height {
+			delete(mp.postponed, h)
+			continue
+		}
+		if p.height <= height {
+			txes = append(txes, p.txn)
+			delete(mp.postponed, h)
+		}
+	}
+	updatePostponedMetrics(len(mp.postponed))
+	sort.SliceStable(txes, func(i, j int) bool {
+		return mp.policy.Compare(txes[i], txes[j]) > 0
+	})
+	return txes
+}
@@ -11,14 +11,55 @@ var (
 			Namespace: "neogo",
 		},
 	)
+	//mempoolRemovedTx prometheus metric.
+	mempoolRemovedTx = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of TXs removed from mempool",
+			Name:      "mempool_removed_tx",
+			Namespace: "neogo",
+		},
+		[]string{"reason"},
+	)
+	//mempoolFeePerByte prometheus metric.
+	mempoolFeePerByte = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Help:      "Fee per byte of TXs added to mempool",
+			Name:      "mempool_fee_per_byte",
+			Namespace: "neogo",
+			Buckets:   prometheus.ExponentialBuckets(1000, 2, 12),
+		},
+	)
+	//mempoolPostponedTx prometheus metric.
+	mempoolPostponedTx = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "Mempool postponed TXs",
+			Name:      "mempool_postponed_tx",
+			Namespace: "neogo",
+		},
+	)
 )
 
 func init() {
 	prometheus.MustRegister(
 		mempoolUnsortedTx,
+		mempoolRemovedTx,
+		mempoolFeePerByte,
+		mempoolPostponedTx,
 	)
 }
 
 func updateMempoolMetrics(unsortedTxnLen int) {
 	mempoolUnsortedTx.Set(float64(unsortedTxnLen))
 }
+
+func addRemovedTxMetric(reason RemovalReason) {
+	mempoolRemovedTx.WithLabelValues(reason.String()).Inc()
+}
+
+func addFeePerByteMetric(feePerByte int64) {
+	mempoolFeePerByte.Observe(float64(feePerByte))
+}
+
+func updatePostponedMetrics(postponedTxnLen int) {
+	mempoolPostponedTx.Set(float64(postponedTxnLen))
+}
@@ -1,6 +1,9 @@
 package mempool
 
 import (
+	"encoding/json"
+	"errors"
+
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 )
 
@@ -14,11 +17,124 @@ const (
 	TransactionRemoved EventType = 0x02
 )
 
+// String is a Stringer implementation.
+func (e EventType) String() string {
+	switch e {
+	case TransactionAdded:
+		return "added"
+	case TransactionRemoved:
+		return "removed"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (e EventType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (e *EventType) UnmarshalJSON(b []byte) error {
+	var s string
+
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+	switch s {
+	case "added":
+		*e = TransactionAdded
+	case "removed":
+		*e = TransactionRemoved
+	default:
+		return errors.New("invalid event type")
+	}
+	return nil
+}
+
+// RemovalReason represents the reason transaction was removed from mempool.
+type RemovalReason byte
+
+const (
+	// RemovedInBlock means that transaction was included into a block.
+	RemovedInBlock RemovalReason = 0x01
+	// RemovedConflict means that transaction was replaced by some other
+	// conflicting transaction or conflicts with a transaction included into
+	// a block.
+	RemovedConflict RemovalReason = 0x02
+	// RemovedCapacity means that transaction was evicted by a more
+	// prioritized one because of pool (or sender) capacity limit.
+	RemovedCapacity RemovalReason = 0x03
+	// RemovedStale means that transaction is no longer valid (it's expired,
+	// doesn't fit the policy or sender can't pay for it).
+	RemovedStale RemovalReason = 0x04
+	// RemovedExplicitly means that transaction was removed by Remove call.
+	RemovedExplicitly RemovalReason = 0x05
+	// RemovedTooOld means that transaction stayed in the pool for longer
+	// than the configured maximum number of blocks.
+	RemovedTooOld RemovalReason = 0x06
+)
+
+// String is a Stringer implementation.
+func (r RemovalReason) String() string {
+	switch r {
+	case RemovedInBlock:
+		return "block"
+	case RemovedConflict:
+		return "conflict"
+	case RemovedCapacity:
+		return "capacity"
+	case RemovedStale:
+		return "stale"
+	case RemovedExplicitly:
+		return "explicit"
+	case RemovedTooOld:
+		return "ttl"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (r RemovalReason) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (r *RemovalReason) UnmarshalJSON(b []byte) error {
+	var s string
+
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+	switch s {
+	case "block":
+		*r = RemovedInBlock
+	case "conflict":
+		*r = RemovedConflict
+	case "capacity":
+		*r = RemovedCapacity
+	case "stale":
+		*r = RemovedStale
+	case "explicit":
+		*r = RemovedExplicitly
+	case "ttl":
+		*r = RemovedTooOld
+	default:
+		return errors.New("invalid removal reason")
+	}
+	return nil
+}
+
 // Event represents one of mempool events: transaction was added or removed from mempool.
+// Reason is only set for TransactionRemoved events.
 type Event struct {
-	Type EventType
-	Tx   *transaction.Transaction
-	Data interface{}
+	Type   EventType
+	Tx     *transaction.Transaction
+	Data   interface{}
+	Reason RemovalReason
 }
 
 // RunSubscriptions runs subscriptions goroutine if mempool subscriptions are enabled.
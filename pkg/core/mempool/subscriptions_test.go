@@ -1,6 +1,7 @@
 package mempool
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -59,8 +60,8 @@ func TestSubscriptions(t *testing.T) {
 		require.Eventually(t, func() bool { return len(subChan1) == 2 && len(subChan2) == 2 }, time.Second, time.Millisecond*100)
 		event1 = <-subChan1
 		event2 = <-subChan2
-		require.Equal(t, Event{Type: TransactionRemoved, Tx: txs[0]}, event1)
-		require.Equal(t, Event{Type: TransactionRemoved, Tx: txs[0]}, event2)
+		require.Equal(t, Event{Type: TransactionRemoved, Tx: txs[0], Reason: RemovedCapacity}, event1)
+		require.Equal(t, Event{Type: TransactionRemoved, Tx: txs[0], Reason: RemovedCapacity}, event2)
 		event1 = <-subChan1
 		event2 = <-subChan2
 		require.Equal(t, Event{Type: TransactionAdded, Tx: txs[2]}, event1)
@@ -71,8 +72,8 @@ func TestSubscriptions(t *testing.T) {
 		require.Eventually(t, func() bool { return len(subChan1) == 1 && len(subChan2) == 1 }, time.Second, time.Millisecond*100)
 		event1 = <-subChan1
 		event2 = <-subChan2
-		require.Equal(t, Event{Type: TransactionRemoved, Tx: txs[1]}, event1)
-		require.Equal(t, Event{Type: TransactionRemoved, Tx: txs[1]}, event2)
+		require.Equal(t, Event{Type: TransactionRemoved, Tx: txs[1], Reason: RemovedExplicitly}, event1)
+		require.Equal(t, Event{Type: TransactionRemoved, Tx: txs[1], Reason: RemovedExplicitly}, event2)
 
 		// remove stale
 		mp.RemoveStale(func(tx *transaction.Transaction) bool {
@@ -84,8 +85,8 @@ func TestSubscriptions(t *testing.T) {
 		require.Eventually(t, func() bool { return len(subChan1) == 1 && len(subChan2) == 1 }, time.Second, time.Millisecond*100)
 		event1 = <-subChan1
 		event2 = <-subChan2
-		require.Equal(t, Event{Type: TransactionRemoved, Tx: txs[2]}, event1)
-		require.Equal(t, Event{Type: TransactionRemoved, Tx: txs[2]}, event2)
+		require.Equal(t, Event{Type: TransactionRemoved, Tx: txs[2], Reason: RemovedStale}, event1)
+		require.Equal(t, Event{Type: TransactionRemoved, Tx: txs[2], Reason: RemovedStale}, event2)
 
 		// unsubscribe
 		mp.UnsubscribeFromTransactions(subChan1)
@@ -96,3 +97,37 @@ func TestSubscriptions(t *testing.T) {
 		require.Equal(t, Event{Type: TransactionAdded, Tx: txs[3]}, event2)
 	})
 }
+
+func TestEventTypeJSON(t *testing.T) {
+	for _, typ := range []EventType{TransactionAdded, TransactionRemoved} {
+		data, err := json.Marshal(typ)
+		require.NoError(t, err)
+		var actual EventType
+		require.NoError(t, json.Unmarshal(data, &actual))
+		require.Equal(t, typ, actual)
+	}
+	data, err := json.Marshal(TransactionAdded)
+	require.NoError(t, err)
+	require.Equal(t, `"added"`, string(data))
+
+	var actual EventType
+	require.Error(t, json.Unmarshal([]byte(`"changed"`), &actual))
+	require.Error(t, json.Unmarshal([]byte(`1`), &actual))
+}
+
+func TestRemovalReasonJSON(t *testing.T) {
+	for _, r := range []RemovalReason{RemovedInBlock, RemovedConflict, RemovedCapacity, RemovedStale, RemovedExplicitly, RemovedTooOld} {
+		data, err := json.Marshal(r)
+		require.NoError(t, err)
+		var actual RemovalReason
+		require.NoError(t, json.Unmarshal(data, &actual))
+		require.Equal(t, r, actual)
+	}
+	data, err := json.Marshal(RemovedInBlock)
+	require.NoError(t, err)
+	require.Equal(t, `"block"`, string(data))
+
+	var actual RemovalReason
+	require.Error(t, json.Unmarshal([]byte(`"unknown"`), &actual))
+	require.Error(t, json.Unmarshal([]byte(`1`), &actual))
+}
@@ -0,0 +1,102 @@
+package mpt
+
+import (
+	"bytes"
+	"errors"
+)
+
+// KeyValue is a key-value pair stored in the trie.
+type KeyValue struct {
+	Key   []byte
+	Value []byte
+}
+
+// errStop is used to stop trie traversal.
+var errStop = errors.New("stop")
+
+// Find returns key-value pairs with keys having the given prefix ordered by
+// key. If from is not nil only pairs with keys greater than it are returned.
+// At most max pairs are returned if max is positive.
+func (t *Trie) Find(prefix, from []byte, max int) ([]KeyValue, error) {
+	curr, path, err := t.getSubtrie(t.root, toNibbles(prefix), nil)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var res []KeyValue
+	err = t.traverse(curr, path, func(key, value []byte) error {
+		if from != nil && bytes.Compare(key, from) <= 0 {
+			return nil
+		}
+		res = append(res, KeyValue{Key: key, Value: copySlice(value)})
+		if max > 0 && len(res) >= max {
+			return errStop
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStop) {
+		return nil, err
+	}
+	return res, nil
+}
+
+// getSubtrie returns the node containing all keys with the given path prefix
+// in the subtrie rooting in curr along with the full path to this node
+// (which is added to the base).
+func (t *Trie) getSubtrie(curr Node, path, base []byte) (Node, []byte, error) {
+	if len(path) == 0 {
+		return curr, base, nil
+	}
+	switch n := curr.(type) {
+	case *BranchNode:
+		return t.getSubtrie(n.Children[path[0]], path[1:], append(base, path[0]))
+	case *ExtensionNode:
+		if bytes.HasPrefix(path, n.key) {
+			return t.getSubtrie(n.next, path[len(n.key):], append(base, n.key...))
+		}
+		if bytes.HasPrefix(n.key, path) {
+			return n, base, nil
+		}
+	case *HashNode:
+		if !n.IsEmpty() {
+			r, err := t.getFromStore(n.Hash())
+			if err != nil {
+				return nil, nil, err
+			}
+			return t.getSubtrie(r, path, base)
+		}
+	}
+	return nil, nil, ErrNotFound
+}
+
+// traverse calls f for every key-value pair in the subtrie rooting in curr
+// (which has the given path) in key order, it stops on the first error.
+func (t *Trie) traverse(curr Node, path []byte, f func(key, value []byte) error) error {
+	switch n := curr.(type) {
+	case *LeafNode:
+		return f(fromNibbles(path), n.value)
+	case *BranchNode:
+		// Value stored in the branch itself has the shortest key.
+		if err := t.traverse(n.Children[lastChild], path, f); err != nil {
+			return err
+		}
+		for i := 0; i < lastChild; i++ {
+			if err := t.traverse(n.Children[i], append(path[:len(path):len(path)], byte(i)), f); err != nil {
+				return err
+			}
+		}
+	case *ExtensionNode:
+		return t.traverse(n.next, append(path[:len(path):len(path)], n.key...), f)
+	case *HashNode:
+		if !n.IsEmpty() {
+			r, err := t.getFromStore(n.Hash())
+			if err != nil {
+				return err
+			}
+			return t.traverse(r, path, f)
+		}
+	}
+	return nil
+}
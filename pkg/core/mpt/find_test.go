@@ -0,0 +1,67 @@
+package mpt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrie_Find(t *testing.T) {
+	pairs := []KeyValue{
+		{[]byte{0x01}, []byte("a")},
+		{[]byte{0x01, 0x02}, []byte("b")},
+		{[]byte{0x01, 0x02, 0x03}, []byte("c")},
+		{[]byte{0x01, 0x03}, []byte("d")},
+		{[]byte{0x01, 0xAB, 0xCD}, []byte("e")},
+		{[]byte{0x02}, []byte("f")},
+	}
+	tr := NewTrie(nil, false, newTestStore())
+	for i := len(pairs) - 1; i >= 0; i-- {
+		require.NoError(t, tr.Put(pairs[i].Key, pairs[i].Value))
+	}
+	tr.Flush()
+
+	check := func(t *testing.T, tr *Trie) {
+		t.Run("all", func(t *testing.T) {
+			res, err := tr.Find(nil, nil, 0)
+			require.NoError(t, err)
+			require.Equal(t, pairs, res)
+		})
+		t.Run("prefix", func(t *testing.T) {
+			res, err := tr.Find([]byte{0x01}, nil, 0)
+			require.NoError(t, err)
+			require.Equal(t, pairs[:5], res)
+
+			res, err = tr.Find([]byte{0x01, 0x02}, nil, 0)
+			require.NoError(t, err)
+			require.Equal(t, pairs[1:3], res)
+
+			// Prefix ending in the middle of extension node.
+			res, err = tr.Find([]byte{0x01, 0xAB}, nil, 0)
+			require.NoError(t, err)
+			require.Equal(t, pairs[4:5], res)
+		})
+		t.Run("from and max", func(t *testing.T) {
+			res, err := tr.Find([]byte{0x01}, []byte{0x01, 0x02}, 2)
+			require.NoError(t, err)
+			require.Equal(t, pairs[2:4], res)
+
+			res, err = tr.Find(nil, []byte{0x01, 0xAB, 0xCD}, 0)
+			require.NoError(t, err)
+			require.Equal(t, pairs[5:], res)
+		})
+		t.Run("missing", func(t *testing.T) {
+			res, err := tr.Find([]byte{0x03}, nil, 0)
+			require.NoError(t, err)
+			require.Equal(t, 0, len(res))
+
+			res, err = tr.Find([]byte{0x01, 0xAC}, nil, 0)
+			require.NoError(t, err)
+			require.Equal(t, 0, len(res))
+		})
+	}
+	t.Run("in memory", func(t *testing.T) { check(t, tr) })
+	t.Run("from store", func(t *testing.T) {
+		check(t, NewTrie(NewHashNode(tr.StateRoot()), false, tr.Store))
+	})
+}
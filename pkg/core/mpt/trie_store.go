@@ -0,0 +1,103 @@
+package mpt
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// TrieStore is a read-only storage.Store implementation providing contract
+// storage items (keys with STStorage prefix) from the trie with the given
+// root, all other keys are taken from the underlying store. It allows to use
+// historical contract storage state as a regular storage, so it's intended to
+// be wrapped into MemCachedStore that will keep all changes.
+type TrieStore struct {
+	trie  *Trie
+	lower storage.Store
+}
+
+// ErrReadOnly is returned by TrieStore methods changing the store.
+var ErrReadOnly = errors.New("read-only store")
+
+// NewTrieStore returns new TrieStore for the trie with the given root which
+// nodes are stored in the lower store.
+func NewTrieStore(root util.Uint256, lower storage.Store) (*TrieStore, error) {
+	tr := NewTrie(NewHashNode(root), false, storage.NewMemCachedStore(lower))
+	if !root.Equals(util.Uint256{}) {
+		if _, err := tr.getFromStore(root); err != nil {
+			return nil, fmt.Errorf("unknown state root %s: %w", root.StringLE(), err)
+		}
+	}
+	return &TrieStore{trie: tr, lower: lower}, nil
+}
+
+// Batch implements storage.Store interface, it returns nil as TrieStore can't
+// be changed.
+func (s *TrieStore) Batch() storage.Batch {
+	return nil
+}
+
+// Delete implements storage.Store interface.
+func (s *TrieStore) Delete(k []byte) error {
+	return ErrReadOnly
+}
+
+// Get implements storage.Store interface.
+func (s *TrieStore) Get(k []byte) ([]byte, error) {
+	if !isStorageKey(k) {
+		return s.lower.Get(k)
+	}
+	v, err := s.trie.Get(k[1:])
+	if errors.Is(err, ErrNotFound) {
+		return nil, storage.ErrKeyNotFound
+	}
+	return v, err
+}
+
+// Put implements storage.Store interface.
+func (s *TrieStore) Put(k, v []byte) error {
+	return ErrReadOnly
+}
+
+// PutBatch implements storage.Store interface.
+func (s *TrieStore) PutBatch(storage.Batch) error {
+	return ErrReadOnly
+}
+
+// Seek implements storage.Store interface.
+func (s *TrieStore) Seek(k []byte, f func(k, v []byte)) {
+	if !isStorageKey(k) {
+		s.lower.Seek(k, func(k, v []byte) {
+			if !isStorageKey(k) {
+				f(k, v)
+			}
+		})
+		if len(k) != 0 {
+			return
+		}
+	}
+	var prefix []byte
+	if len(k) != 0 {
+		prefix = k[1:]
+	}
+	curr, path, err := s.trie.getSubtrie(s.trie.root, toNibbles(prefix), nil)
+	if err != nil {
+		return
+	}
+	_ = s.trie.traverse(curr, path, func(key, value []byte) error {
+		f(append(storage.STStorage.Bytes(), key...), value)
+		return nil
+	})
+}
+
+// Close implements storage.Store interface, it does nothing as underlying
+// store is not owned by TrieStore.
+func (s *TrieStore) Close() error {
+	return nil
+}
+
+func isStorageKey(k []byte) bool {
+	return len(k) > 0 && k[0] == byte(storage.STStorage)
+}
@@ -0,0 +1,59 @@
+package mpt
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrieStore(t *testing.T) {
+	lower := storage.NewMemoryStore()
+	tr := NewTrie(nil, false, storage.NewMemCachedStore(lower))
+	require.NoError(t, tr.Put([]byte{1, 2}, []byte("a")))
+	require.NoError(t, tr.Put([]byte{1, 3}, []byte("b")))
+	tr.Flush()
+	_, err := tr.Store.Persist()
+	require.NoError(t, err)
+
+	st := byte(storage.STStorage)
+	require.NoError(t, lower.Put([]byte{st, 1, 2}, []byte("latest")))
+	require.NoError(t, lower.Put([]byte{st, 1, 4}, []byte("latest")))
+	require.NoError(t, lower.Put([]byte{0x42}, []byte("other")))
+
+	s, err := NewTrieStore(tr.StateRoot(), lower)
+	require.NoError(t, err)
+
+	v, err := s.Get([]byte{st, 1, 2})
+	require.NoError(t, err)
+	require.Equal(t, []byte("a"), v)
+	_, err = s.Get([]byte{st, 1, 4})
+	require.True(t, errors.Is(err, storage.ErrKeyNotFound))
+	v, err = s.Get([]byte{0x42})
+	require.NoError(t, err)
+	require.Equal(t, []byte("other"), v)
+
+	seek := func(prefix []byte) map[string]string {
+		res := make(map[string]string)
+		s.Seek(prefix, func(k, v []byte) { res[string(k)] = string(v) })
+		return res
+	}
+	require.Equal(t, map[string]string{
+		string([]byte{st, 1, 2}): "a",
+		string([]byte{st, 1, 3}): "b",
+	}, seek([]byte{st, 1}))
+	require.Equal(t, map[string]string{string([]byte{0x42}): "other"}, seek([]byte{0x42}))
+	all := seek(nil) // Includes trie nodes.
+	require.Equal(t, "a", all[string([]byte{st, 1, 2})])
+	require.Equal(t, "b", all[string([]byte{st, 1, 3})])
+	require.Equal(t, "other", all[string([]byte{0x42})])
+	require.NotContains(t, all, string([]byte{st, 1, 4}))
+
+	require.True(t, errors.Is(s.Put([]byte{st, 1, 2}, []byte("c")), ErrReadOnly))
+	require.True(t, errors.Is(s.Delete([]byte{st, 1, 2}), ErrReadOnly))
+
+	_, err = NewTrieStore(util.Uint256{1, 2, 3}, lower)
+	require.Error(t, err)
+}
@@ -4,12 +4,20 @@ import (
 	"testing"
 	"unicode"
 
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/stretchr/testify/require"
 )
 
 // "C" and "O" can easily be typed by accident.
 func TestNamesASCII(t *testing.T) {
-	cs := NewContracts(true, map[string][]uint32{})
+	cs := NewContracts(config.ProtocolConfiguration{
+		P2PSigExtensions:     true,
+		OracleResponseRefund: true,
+		PolicyBlockLimits:    true,
+		DesignationEvent:     true,
+		VoterRewardMethods:   true,
+		NotaryEvents:         true,
+	})
 	for _, c := range cs.Contracts {
 		require.True(t, isASCII(c.Metadata().Name))
 		for _, m := range c.Metadata().Methods {
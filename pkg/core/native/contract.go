@@ -3,6 +3,7 @@ package native
 import (
 	"strings"
 
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
 	"github.com/nspcc-dev/neo-go/pkg/io"
@@ -55,8 +56,9 @@ func (cs *Contracts) ByName(name string) interop.Contract {
 }
 
 // NewContracts returns new set of native contracts with new GAS, NEO, Policy, Oracle,
-// Designate and (optional) Notary contracts.
-func NewContracts(p2pSigExtensionsEnabled bool, nativeUpdateHistories map[string][]uint32) *Contracts {
+// Designate and (optional) Notary contracts configured according to the given
+// protocol configuration.
+func NewContracts(cfg config.ProtocolConfiguration) *Contracts {
 	cs := new(Contracts)
 
 	mgmt := newManagement()
@@ -76,7 +78,7 @@ func NewContracts(p2pSigExtensionsEnabled bool, nativeUpdateHistories map[string
 	cs.Contracts = append(cs.Contracts, ledger)
 
 	gas := newGAS()
-	neo := newNEO()
+	neo := newNEO(cfg.VoterRewardMethods)
 	neo.GAS = gas
 	gas.NEO = neo
 	mgmt.NEO = neo
@@ -86,17 +88,25 @@ func NewContracts(p2pSigExtensionsEnabled bool, nativeUpdateHistories map[string
 	cs.Contracts = append(cs.Contracts, neo)
 	cs.Contracts = append(cs.Contracts, gas)
 
-	policy := newPolicy()
+	var blockLimits *BlockLimits
+	if cfg.PolicyBlockLimits {
+		blockLimits = &BlockLimits{
+			MaxBlockSize:            cfg.MaxBlockSize,
+			MaxBlockSystemFee:       cfg.MaxBlockSystemFee,
+			MaxTransactionsPerBlock: cfg.MaxTransactionsPerBlock,
+		}
+	}
+	policy := newPolicy(blockLimits)
 	policy.NEO = neo
 	cs.Policy = policy
 	cs.Contracts = append(cs.Contracts, policy)
 
-	desig := newDesignate(p2pSigExtensionsEnabled)
+	desig := newDesignate(cfg.P2PSigExtensions, cfg.DesignationEvent)
 	desig.NEO = neo
 	cs.Designate = desig
 	cs.Contracts = append(cs.Contracts, desig)
 
-	oracle := newOracle()
+	oracle := newOracle(cfg.OracleResponseRefund)
 	oracle.GAS = gas
 	oracle.NEO = neo
 	oracle.Desig = desig
@@ -108,8 +118,8 @@ func NewContracts(p2pSigExtensionsEnabled bool, nativeUpdateHistories map[string
 	cs.NameService = ns
 	cs.Contracts = append(cs.Contracts, ns)
 
-	if p2pSigExtensionsEnabled {
-		notary := newNotary()
+	if cfg.P2PSigExtensions {
+		notary := newNotary(cfg.NotaryEvents)
 		notary.GAS = gas
 		notary.NEO = neo
 		notary.Desig = desig
@@ -117,7 +127,11 @@ func NewContracts(p2pSigExtensionsEnabled bool, nativeUpdateHistories map[string
 		cs.Contracts = append(cs.Contracts, notary)
 	}
 
+	nativeUpdateHistories := cfg.NativeUpdateHistories
 	setDefaultHistory := len(nativeUpdateHistories) == 0
+	if nativeUpdateHistories == nil {
+		nativeUpdateHistories = make(map[string][]uint32)
+	}
 	for _, c := range cs.Contracts {
 		if setDefaultHistory {
 			nativeUpdateHistories[c.Metadata().Name] = []uint32{0}
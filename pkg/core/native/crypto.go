@@ -2,6 +2,7 @@ package native
 
 import (
 	"crypto/elliptic"
+	"encoding/binary"
 	"errors"
 	"fmt"
 
@@ -46,6 +47,12 @@ func newCrypto() *Crypto {
 	md = newMethodAndPrice(c.ripemd160, 1<<15, callflag.NoneFlag)
 	c.AddMethod(md, desc)
 
+	desc = newDescriptor("murmur32", smartcontract.ByteArrayType,
+		manifest.NewParameter("data", smartcontract.ByteArrayType),
+		manifest.NewParameter("seed", smartcontract.IntegerType))
+	md = newMethodAndPrice(c.murmur32, 1<<13, callflag.NoneFlag)
+	c.AddMethod(md, desc)
+
 	desc = newDescriptor("verifyWithECDsa", smartcontract.BoolType,
 		manifest.NewParameter("message", smartcontract.ByteArrayType),
 		manifest.NewParameter("pubkey", smartcontract.ByteArrayType),
@@ -72,6 +79,17 @@ func (c *Crypto) ripemd160(_ *interop.Context, args []stackitem.Item) stackitem.
 	return stackitem.NewByteArray(hash.RipeMD160(bs).BytesBE())
 }
 
+func (c *Crypto) murmur32(_ *interop.Context, args []stackitem.Item) stackitem.Item {
+	bs, err := args[0].TryBytes()
+	if err != nil {
+		panic(err)
+	}
+	seed := toUint32(args[1])
+	h := make([]byte, 4)
+	binary.LittleEndian.PutUint32(h, hash.Murmur32(bs, seed))
+	return stackitem.NewByteArray(h)
+}
+
 func (c *Crypto) verifyWithECDsa(_ *interop.Context, args []stackitem.Item) stackitem.Item {
 	msg, err := args[0].TryBytes()
 	if err != nil {
@@ -43,6 +43,26 @@ func TestRIPEMD160(t *testing.T) {
 	})
 }
 
+func TestMurmur32(t *testing.T) {
+	c := newCrypto()
+	ic := &interop.Context{VM: vm.New()}
+
+	t.Run("bad arg type", func(t *testing.T) {
+		require.Panics(t, func() {
+			c.murmur32(ic, []stackitem.Item{stackitem.NewInterop(nil), stackitem.Make(1)})
+		})
+	})
+	t.Run("bad seed", func(t *testing.T) {
+		require.Panics(t, func() {
+			c.murmur32(ic, []stackitem.Item{stackitem.NewByteArray([]byte{1}), stackitem.Make(-1)})
+		})
+	})
+	t.Run("good", func(t *testing.T) {
+		// "Hello, world!" with seed 1234 hashes to 0xfaf6cdb3 (little-endian)
+		require.Equal(t, "b3cdf6fa", hex.EncodeToString(c.murmur32(ic, []stackitem.Item{stackitem.NewByteArray([]byte("Hello, world!")), stackitem.Make(1234)}).Value().([]byte)))
+	})
+}
+
 func TestCryptoLibVerifyWithECDsa(t *testing.T) {
 	t.Run("R1", func(t *testing.T) {
 		testECDSAVerify(t, Secp256r1)
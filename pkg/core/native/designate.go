@@ -38,6 +38,8 @@ type Designate struct {
 
 	// p2pSigExtensionsEnabled defines whether the P2P signature extensions logic is relevant.
 	p2pSigExtensionsEnabled bool
+	// designationEventEnabled defines whether Designation notification is emitted.
+	designationEventEnabled bool
 
 	OracleService atomic.Value
 	// NotaryService represents Notary node module.
@@ -57,6 +59,10 @@ const (
 
 	// maxNodeCount is the maximum number of nodes to set the role for.
 	maxNodeCount = 32
+
+	// DesignationEventName is the name of the notification emitted when
+	// nodes are designated for some role.
+	DesignationEventName = "Designation"
 )
 
 // Various errors.
@@ -74,9 +80,10 @@ func (s *Designate) isValidRole(r noderoles.Role) bool {
 		r == noderoles.NeoFSAlphabet || (s.p2pSigExtensionsEnabled && r == noderoles.P2PNotary)
 }
 
-func newDesignate(p2pSigExtensionsEnabled bool) *Designate {
+func newDesignate(p2pSigExtensionsEnabled, designationEventEnabled bool) *Designate {
 	s := &Designate{ContractMD: *interop.NewContractMD(nativenames.Designation, designateContractID)}
 	s.p2pSigExtensionsEnabled = p2pSigExtensionsEnabled
+	s.designationEventEnabled = designationEventEnabled
 	defer s.UpdateHash()
 
 	desc := newDescriptor("getDesignatedByRole", smartcontract.ArrayType,
@@ -88,9 +95,21 @@ func newDesignate(p2pSigExtensionsEnabled bool) *Designate {
 	desc = newDescriptor("designateAsRole", smartcontract.VoidType,
 		manifest.NewParameter("role", smartcontract.IntegerType),
 		manifest.NewParameter("nodes", smartcontract.ArrayType))
-	md = newMethodAndPrice(s.designateAsRole, 1<<15, callflag.States)
+	if designationEventEnabled {
+		md = newMethodAndPrice(s.designateAsRole, 1<<15, callflag.States|callflag.AllowNotify)
+	} else {
+		md = newMethodAndPrice(s.designateAsRole, 1<<15, callflag.States)
+	}
 	s.AddMethod(md, desc)
 
+	if designationEventEnabled {
+		s.AddEvent(DesignationEventName,
+			manifest.NewParameter("Role", smartcontract.IntegerType),
+			manifest.NewParameter("OldNodes", smartcontract.IntegerType),
+			manifest.NewParameter("NewNodes", smartcontract.IntegerType),
+			manifest.NewParameter("BlockIndex", smartcontract.IntegerType))
+	}
+
 	return s
 }
 
@@ -290,7 +309,10 @@ func (s *Designate) designateAsRole(ic *interop.Context, args []stackitem.Item)
 	return stackitem.Null{}
 }
 
-// DesignateAsRole sets nodes for role r.
+// DesignateAsRole sets nodes for role r. If Designation event is enabled it
+// also emits a notification with the role, the number of previously and newly
+// designated nodes and the current block index (nodes are active starting
+// from the next block).
 func (s *Designate) DesignateAsRole(ic *interop.Context, r noderoles.Role, pubs keys.PublicKeys) error {
 	length := len(pubs)
 	if length == 0 {
@@ -317,9 +339,32 @@ func (s *Designate) DesignateAsRole(ic *interop.Context, r noderoles.Role, pubs
 	if si != nil {
 		return ErrAlreadyDesignated
 	}
+	var old keys.PublicKeys
+	if s.designationEventEnabled {
+		var err error
+		old, _, err = s.GetDesignatedByRole(ic.DAO, r, ic.Block.Index+1)
+		if err != nil {
+			return err
+		}
+	}
 	sort.Sort(pubs)
 	s.rolesChangedFlag.Store(true)
-	return ic.DAO.PutStorageItem(s.ID, key, NodeList(pubs).Bytes())
+	if err := ic.DAO.PutStorageItem(s.ID, key, NodeList(pubs).Bytes()); err != nil {
+		return err
+	}
+	if s.designationEventEnabled {
+		ic.Notifications = append(ic.Notifications, state.NotificationEvent{
+			ScriptHash: s.Hash,
+			Name:       DesignationEventName,
+			Item: stackitem.NewArray([]stackitem.Item{
+				stackitem.Make(int64(r)),
+				stackitem.Make(len(old)),
+				stackitem.Make(length),
+				stackitem.Make(ic.Block.Index),
+			}),
+		})
+	}
+	return nil
 }
 
 func (s *Designate) getRole(item stackitem.Item) (noderoles.Role, bool) {
@@ -54,8 +54,9 @@ var (
 	keyMinimumDeploymentFee = []byte{20}
 )
 
-// makeContractKey creates a key from account script hash.
-func makeContractKey(h util.Uint160) []byte {
+// MakeContractKey creates a key from account script hash, contract states are
+// stored under such keys in Management contract storage.
+func MakeContractKey(h util.Uint160) []byte {
 	return makeUint160Key(prefixContract, h)
 }
 
@@ -154,7 +155,7 @@ func (m *Management) GetContract(d dao.DAO, hash util.Uint160) (*state.Contract,
 
 func (m *Management) getContractFromDAO(d dao.DAO, hash util.Uint160) (*state.Contract, error) {
 	contract := new(state.Contract)
-	key := makeContractKey(hash)
+	key := MakeContractKey(hash)
 	err := getSerializableFromDAO(m.ID, d, key, contract)
 	if err != nil {
 		return nil, err
@@ -266,7 +267,7 @@ func (m *Management) markUpdated(h util.Uint160) {
 // It doesn't run _deploy method and doesn't emit notification.
 func (m *Management) Deploy(d dao.DAO, sender util.Uint160, neff *nef.File, manif *manifest.Manifest) (*state.Contract, error) {
 	h := state.CreateContractHash(sender, neff.Checksum, manif.Name)
-	key := makeContractKey(h)
+	key := MakeContractKey(h)
 	si := d.GetStorageItem(m.ID, key)
 	if si != nil {
 		return nil, errors.New("contract already exists")
@@ -376,7 +377,7 @@ func (m *Management) Destroy(d dao.DAO, hash util.Uint160) error {
 	if err != nil {
 		return err
 	}
-	key := makeContractKey(hash)
+	key := MakeContractKey(hash)
 	err = d.DeleteStorageItem(m.ID, key)
 	if err != nil {
 		return err
@@ -495,6 +496,26 @@ func (m *Management) InitializeCache(d dao.DAO) error {
 	return initErr
 }
 
+// ForEachContract calls f for every contract state (including native ones)
+// stored in the given DAO.
+func (m *Management) ForEachContract(d dao.DAO, f func(*state.Contract)) error {
+	var seekErr error
+	d.Seek(m.ID, []byte{prefixContract}, func(_, v []byte) {
+		if seekErr != nil {
+			return
+		}
+		var cs state.Contract
+		r := io.NewBinReaderFromBuf(v)
+		cs.DecodeBinary(r)
+		if r.Err != nil {
+			seekErr = r.Err
+			return
+		}
+		f(&cs)
+	})
+	return seekErr
+}
+
 // PostPersist implements Contract interface.
 func (m *Management) PostPersist(ic *interop.Context) error {
 	m.mtx.Lock()
@@ -524,7 +545,7 @@ func (m *Management) Initialize(ic *interop.Context) error {
 
 // PutContractState saves given contract state into given DAO.
 func (m *Management) PutContractState(d dao.DAO, cs *state.Contract) error {
-	key := makeContractKey(cs.Hash)
+	key := MakeContractKey(cs.Hash)
 	if err := putSerializableToDAO(m.ID, d, key, cs); err != nil {
 		return err
 	}
@@ -561,7 +582,7 @@ func checkScriptAndMethods(script []byte, methods []manifest.Method) error {
 	l := len(script)
 	offsets := bitfield.New(l)
 	for i := range methods {
-		if methods[i].Offset >= l {
+		if methods[i].Offset < 0 || methods[i].Offset >= l {
 			return errors.New("out of bounds method offset")
 		}
 		offsets.Set(methods[i].Offset)
@@ -6,12 +6,15 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/stretchr/testify/require"
 )
@@ -83,3 +86,34 @@ func TestManagement_Initialize(t *testing.T) {
 		require.Error(t, mgmt.InitializeCache(d))
 	})
 }
+
+func TestCheckScriptAndMethods(t *testing.T) {
+	w := io.NewBufBinWriter()
+	emit.String(w.BinWriter, "log")
+	emit.Syscall(w.BinWriter, interopnames.SystemRuntimeLog)
+	emit.Opcodes(w.BinWriter, opcode.RET)
+	good := w.Bytes()
+	methods := []manifest.Method{{Name: "main", Offset: 0}}
+
+	require.NoError(t, checkScriptAndMethods(good, methods))
+
+	t.Run("bad method offset", func(t *testing.T) {
+		require.Error(t, checkScriptAndMethods(good, []manifest.Method{{Name: "main", Offset: 1}}))
+		require.Error(t, checkScriptAndMethods(good, []manifest.Method{{Name: "main", Offset: len(good)}}))
+		require.Error(t, checkScriptAndMethods(good, []manifest.Method{{Name: "main", Offset: -1}}))
+	})
+	t.Run("bad instruction", func(t *testing.T) {
+		require.Error(t, checkScriptAndMethods([]byte{0xff}, nil))
+	})
+	t.Run("bad jump", func(t *testing.T) {
+		require.Error(t, checkScriptAndMethods([]byte{byte(opcode.JMP), 0x7f}, nil))
+	})
+	t.Run("syscalls are not checked", func(t *testing.T) {
+		// Syscalls are resolved at runtime, so that custom ones can be used.
+		w := io.NewBufBinWriter()
+		emit.Syscall(w.BinWriter, "Unknown.Syscall")
+		emit.Int(w.BinWriter, 0)
+		emit.Syscall(w.BinWriter, interopnames.SystemContractCallNative)
+		require.NoError(t, checkScriptAndMethods(w.Bytes(), nil))
+	})
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/runtime"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nnsrecords"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
@@ -43,14 +44,14 @@ type nameState struct {
 }
 
 // RecordType represents name record type.
-type RecordType byte
+type RecordType = nnsrecords.Type
 
 // Pre-defined record types.
 const (
-	RecordTypeA     RecordType = 1
-	RecordTypeCNAME RecordType = 5
-	RecordTypeTXT   RecordType = 16
-	RecordTypeAAAA  RecordType = 28
+	RecordTypeA     = nnsrecords.A
+	RecordTypeCNAME = nnsrecords.CNAME
+	RecordTypeTXT   = nnsrecords.TXT
+	RecordTypeAAAA  = nnsrecords.AAAA
 )
 
 const (
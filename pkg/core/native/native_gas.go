@@ -4,6 +4,7 @@ import (
 	"errors"
 	"math/big"
 
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
@@ -99,6 +100,19 @@ func (g *GAS) PostPersist(ic *interop.Context) error {
 	return nil
 }
 
+// SetBalance sets GAS balance of the account directly in the storage, no
+// notifications are emitted and total supply is not changed, so it's only
+// intended to be used for test invocations.
+func (g *GAS) SetBalance(d dao.DAO, acc util.Uint160, amount *big.Int) error {
+	key := makeAccountKey(acc)
+	if amount.Sign() == 0 {
+		return d.DeleteStorageItem(g.ID, key)
+	}
+	st := new(state.NEP17BalanceState)
+	st.Balance.Set(amount)
+	return d.PutStorageItem(g.ID, key, st.Bytes())
+}
+
 func getStandbyValidatorsHash(ic *interop.Context) (util.Uint160, error) {
 	s, err := smartcontract.CreateDefaultMultiSigRedeemScript(ic.Chain.GetStandByValidators())
 	if err != nil {
@@ -40,9 +40,13 @@ type NEO struct {
 	registerPrice        atomic.Value
 	registerPriceChanged atomic.Value
 
+	// votesChanged is set when candidates or their votes change, committee
+	// is only recomputed (on committee update) if it's set.
 	votesChanged   atomic.Value
 	nextValidators atomic.Value
-	validators     atomic.Value
+	// validators contains cached result of ComputeNextBlockValidators, it's
+	// reset along with setting votesChanged.
+	validators atomic.Value
 	// committee contains cached committee members and their votes.
 	// It is updated once in a while depending on committee size
 	// (every 28 blocks for mainnet). It's value
@@ -97,8 +101,9 @@ func makeValidatorKey(key *keys.PublicKey) []byte {
 	return b
 }
 
-// newNEO returns NEO native contract.
-func newNEO() *NEO {
+// newNEO returns NEO native contract. unclaimedVoterGas and getGasPerVote
+// methods are only available if voterRewardMethods is set.
+func newNEO(voterRewardMethods bool) *NEO {
 	n := &NEO{}
 	defer n.UpdateHash()
 
@@ -122,6 +127,20 @@ func newNEO() *NEO {
 	md := newMethodAndPrice(n.unclaimedGas, 1<<17, callflag.ReadStates)
 	n.AddMethod(md, desc)
 
+	if voterRewardMethods {
+		desc = newDescriptor("unclaimedVoterGas", smartcontract.IntegerType,
+			manifest.NewParameter("account", smartcontract.Hash160Type),
+			manifest.NewParameter("end", smartcontract.IntegerType))
+		md = newMethodAndPrice(n.unclaimedVoterGas, 1<<17, callflag.ReadStates)
+		n.AddMethod(md, desc)
+
+		desc = newDescriptor("getGasPerVote", smartcontract.IntegerType,
+			manifest.NewParameter("pubkey", smartcontract.PublicKeyType),
+			manifest.NewParameter("end", smartcontract.IntegerType))
+		md = newMethodAndPrice(n.getGASPerVoteCall, 1<<17, callflag.ReadStates)
+		n.AddMethod(md, desc)
+	}
+
 	desc = newDescriptor("registerCandidate", smartcontract.BoolType,
 		manifest.NewParameter("pubkey", smartcontract.PublicKeyType))
 	md = newMethodAndPrice(n.registerCandidate, 0, callflag.States)
@@ -427,6 +446,22 @@ func (n *NEO) unclaimedGas(ic *interop.Context, args []stackitem.Item) stackitem
 	return stackitem.NewBigInteger(gen)
 }
 
+func (n *NEO) unclaimedVoterGas(ic *interop.Context, args []stackitem.Item) stackitem.Item {
+	u := toUint160(args[0])
+	end := toUint32(args[1])
+	gen, err := n.CalculateVoterReward(ic.DAO, u, end)
+	if err != nil {
+		panic(err)
+	}
+	return stackitem.NewBigInteger(gen)
+}
+
+func (n *NEO) getGASPerVoteCall(ic *interop.Context, args []stackitem.Item) stackitem.Item {
+	pub := toPublicKey(args[0])
+	end := toUint32(args[1])
+	return stackitem.NewBigInteger(n.GetGASPerVote(ic.DAO, pub, end))
+}
+
 func (n *NEO) getGASPerBlock(ic *interop.Context, _ []stackitem.Item) stackitem.Item {
 	gas := n.GetGASPerBlock(ic.DAO, ic.Block.Index)
 	return stackitem.NewBigInteger(gas)
@@ -586,19 +621,50 @@ func (n *NEO) CalculateBonus(d dao.DAO, acc util.Uint160, end uint32) (*big.Int,
 	return n.calculateBonus(d, st.VoteTo, &st.Balance, st.BalanceHeight, end)
 }
 
+// CalculateVoterReward calculates amount of gas generated for the account from
+// start to end block by voting for committee member, it's a part of the amount
+// returned by CalculateBonus.
+func (n *NEO) CalculateVoterReward(d dao.DAO, acc util.Uint160, end uint32) (*big.Int, error) {
+	key := makeAccountKey(acc)
+	si := d.GetStorageItem(n.ID, key)
+	if si == nil {
+		return nil, storage.ErrKeyNotFound
+	}
+	st, err := state.NEOBalanceStateFromBytes(si)
+	if err != nil {
+		return nil, err
+	}
+	return n.calculateVoterReward(d, st.VoteTo, &st.Balance, st.BalanceHeight, end), nil
+}
+
 func (n *NEO) calculateBonus(d dao.DAO, vote *keys.PublicKey, value *big.Int, start, end uint32) (*big.Int, error) {
 	r, err := n.CalculateNEOHolderReward(d, value, start, end)
 	if err != nil || vote == nil {
 		return r, err
 	}
+	return r.Add(r, n.calculateVoterReward(d, vote, value, start, end)), nil
+}
 
+func (n *NEO) calculateVoterReward(d dao.DAO, vote *keys.PublicKey, value *big.Int, start, end uint32) *big.Int {
+	if vote == nil {
+		return big.NewInt(0)
+	}
 	var key = makeVoterKey(vote.Bytes())
 	var reward = n.getGASPerVote(d, key, start, end)
 	var tmp = new(big.Int).Sub(&reward[1], &reward[0])
 	tmp.Mul(tmp, value)
 	tmp.Div(tmp, big.NewInt(voterRewardFactor))
-	tmp.Add(tmp, r)
-	return tmp, nil
+	return tmp
+}
+
+// GetGASPerVote returns the amount of GAS accumulated per single vote for the
+// given candidate by the end block. It's multiplied by voterRewardFactor
+// (10^8), so the difference between two values taken at different heights
+// multiplied by the number of votes and divided by 10^8 is the reward of voter
+// for this period.
+func (n *NEO) GetGASPerVote(d dao.DAO, pub *keys.PublicKey, end uint32) *big.Int {
+	var reward = n.getGASPerVote(d, makeVoterKey(pub.Bytes()), end)
+	return &reward[0]
 }
 
 // CalculateNEOHolderReward return GAS reward for holding `value` of NEO from start to end block.
@@ -665,11 +731,22 @@ func (n *NEO) RegisterCandidateInternal(ic *interop.Context, pub *keys.PublicKey
 		c = &candidate{Registered: true}
 	} else {
 		c = new(candidate).FromBytes(si)
+		if c.Registered {
+			return nil
+		}
 		c.Registered = true
 	}
+	n.invalidateVotes()
 	return ic.DAO.PutStorageItem(n.ID, key, c.Bytes())
 }
 
+// invalidateVotes marks cached committee and validators as outdated, it
+// must be called on any change affecting candidates list or their votes.
+func (n *NEO) invalidateVotes() {
+	n.votesChanged.Store(true)
+	n.validators.Store(keys.PublicKeys(nil))
+}
+
 func (n *NEO) unregisterCandidate(ic *interop.Context, args []stackitem.Item) stackitem.Item {
 	pub := toPublicKey(args[0])
 	ok, err := runtime.CheckKeyedWitness(ic, pub)
@@ -689,8 +766,11 @@ func (n *NEO) UnregisterCandidateInternal(ic *interop.Context, pub *keys.PublicK
 	if si == nil {
 		return nil
 	}
-	n.validators.Store(keys.PublicKeys(nil))
 	c := new(candidate).FromBytes(si)
+	if !c.Registered {
+		return nil
+	}
+	n.invalidateVotes()
 	c.Registered = false
 	ok, err := n.dropCandidateIfZero(ic.DAO, pub, c)
 	if ok {
@@ -751,7 +831,6 @@ func (n *NEO) VoteInternal(ic *interop.Context, h util.Uint160, pub *keys.Public
 // ModifyAccountVotes modifies votes of the specified account by value (can be negative).
 // typ specifies if this modify is occurring during transfer or vote (with old or new validator).
 func (n *NEO) ModifyAccountVotes(acc *state.NEOBalanceState, d dao.DAO, value *big.Int, isNewVote bool) error {
-	n.votesChanged.Store(true)
 	if acc.VoteTo != nil {
 		key := makeValidatorKey(acc.VoteTo)
 		si := d.GetStorageItem(n.ID, key)
@@ -768,7 +847,9 @@ func (n *NEO) ModifyAccountVotes(acc *state.NEOBalanceState, d dao.DAO, value *b
 		} else if !cd.Registered {
 			return errors.New("validator must be registered")
 		}
-		n.validators.Store(keys.PublicKeys(nil))
+		if value.Sign() != 0 {
+			n.invalidateVotes()
+		}
 		return d.PutStorageItem(n.ID, key, cd.Bytes())
 	}
 	return nil
@@ -802,6 +883,26 @@ func (n *NEO) getCandidates(d dao.DAO, sortByKey bool) ([]keyWithVotes, error) {
 	return arr, nil
 }
 
+// SetBalance is the same as GAS.SetBalance, but for NEO. Account's vote is
+// preserved (new accounts get the given GAS distribution height), but votes
+// of the candidate and voter turnout are not changed.
+func (n *NEO) SetBalance(d dao.DAO, acc util.Uint160, amount *big.Int, height uint32) error {
+	key := makeAccountKey(acc)
+	si := d.GetStorageItem(n.ID, key)
+	st, err := state.NEOBalanceStateFromBytes(si)
+	if err != nil {
+		return err
+	}
+	if si == nil {
+		st.BalanceHeight = height
+	}
+	if amount.Sign() == 0 && st.VoteTo == nil {
+		return d.DeleteStorageItem(n.ID, key)
+	}
+	st.Balance.Set(amount)
+	return d.PutStorageItem(n.ID, key, st.Bytes())
+}
+
 // GetCandidates returns current registered validators list with keys
 // and votes.
 func (n *NEO) GetCandidates(d dao.DAO) ([]state.Validator, error) {
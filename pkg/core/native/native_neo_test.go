@@ -4,6 +4,12 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/stretchr/testify/require"
 )
 
@@ -16,3 +22,42 @@ func TestCandidate_Bytes(t *testing.T) {
 	actual := new(candidate).FromBytes(data)
 	require.Equal(t, expected, actual)
 }
+
+func TestNEO_InvalidateVotes(t *testing.T) {
+	n := newNEO(false)
+	ic := &interop.Context{DAO: dao.NewCached(dao.NewSimple(storage.NewMemoryStore(), netmode.UnitTestNet, false))}
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	pub := priv.PublicKey()
+
+	checkChanged := func(t *testing.T, expected bool) {
+		require.Equal(t, expected, n.votesChanged.Load().(bool))
+		if expected {
+			require.Nil(t, n.validators.Load().(keys.PublicKeys))
+		}
+		n.votesChanged.Store(false)
+		n.validators.Store(keys.PublicKeys{pub})
+	}
+	checkChanged(t, true)
+
+	require.NoError(t, n.RegisterCandidateInternal(ic, pub))
+	checkChanged(t, true)
+	require.NoError(t, n.RegisterCandidateInternal(ic, pub))
+	checkChanged(t, false)
+
+	// Balance changes of accounts that don't vote don't affect committee.
+	acc := &state.NEOBalanceState{}
+	require.NoError(t, n.ModifyAccountVotes(acc, ic.DAO, big.NewInt(10), false))
+	checkChanged(t, false)
+
+	acc.VoteTo = pub
+	require.NoError(t, n.ModifyAccountVotes(acc, ic.DAO, big.NewInt(0), true))
+	checkChanged(t, false)
+	require.NoError(t, n.ModifyAccountVotes(acc, ic.DAO, big.NewInt(10), true))
+	checkChanged(t, true)
+
+	require.NoError(t, n.UnregisterCandidateInternal(ic, pub))
+	checkChanged(t, true)
+	require.NoError(t, n.UnregisterCandidateInternal(ic, pub))
+	checkChanged(t, false)
+}
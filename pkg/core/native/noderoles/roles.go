@@ -1,12 +1,46 @@
 package noderoles
 
+import "fmt"
+
 // Role represents type of participant.
 type Role byte
 
 // Role enumeration.
 const (
+	// StateValidator is a role of nodes signing state roots.
 	StateValidator Role = 4
-	Oracle         Role = 8
-	NeoFSAlphabet  Role = 16
-	P2PNotary      Role = 128
+	// Oracle is a role of nodes processing oracle requests.
+	Oracle Role = 8
+	// NeoFSAlphabet is a role of NeoFS Alphabet (inner ring) nodes. It's a
+	// standard protocol role, so it's always valid for designation.
+	NeoFSAlphabet Role = 16
+	// P2PNotary is a role of Notary nodes, it's only valid if P2P signature
+	// extensions are enabled.
+	P2PNotary Role = 128
 )
+
+// roleNames maps roles to their names.
+var roleNames = map[Role]string{
+	StateValidator: "StateValidator",
+	Oracle:         "Oracle",
+	NeoFSAlphabet:  "NeoFSAlphabet",
+	P2PNotary:      "P2PNotary",
+}
+
+// String implements fmt.Stringer interface.
+func (r Role) String() string {
+	if s, ok := roleNames[r]; ok {
+		return s
+	}
+	return fmt.Sprintf("Role(%d)", byte(r))
+}
+
+// FromString returns role by its name.
+func FromString(s string) (Role, error) {
+	for r, name := range roleNames {
+		if name == s {
+			return r, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown role %q", s)
+}
@@ -1,6 +1,7 @@
 package native
 
 import (
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"math"
@@ -38,40 +39,60 @@ type Notary struct {
 	// blockchain DAO persisting. If true, we can safely use cached values.
 	isValid                bool
 	maxNotValidBeforeDelta uint32
+
+	// eventsEnabled defines whether deposit-related notifications are emitted.
+	eventsEnabled bool
 }
 
 const (
 	notaryContractID = reservedContractID - 1
 	// prefixDeposit is a prefix for storing Notary deposits.
-	prefixDeposit                 = 1
+	prefixDeposit = 1
+	// prefixDepositExpiration is a prefix for deposit expiration index, it's only
+	// maintained if Notary events are enabled.
+	prefixDepositExpiration       = 2
 	defaultDepositDeltaTill       = 5760
 	defaultMaxNotValidBeforeDelta = 140 // 20 rounds for 7 validators, a little more than half an hour
+
+	// Notary events emitted on deposit changes.
+	notaryDepositEventName  = "Deposit"
+	notaryLockEventName     = "LockDepositUntil"
+	notaryWithdrawEventName = "Withdraw"
+	notarySpendEventName    = "DepositSpent"
+	notaryExpireEventName   = "DepositExpired"
 )
 
 var maxNotValidBeforeDeltaKey = []byte{10}
 
-// newNotary returns Notary native contract.
-func newNotary() *Notary {
+// newNotary returns Notary native contract. Deposit-related notifications are
+// only emitted if eventsEnabled is set.
+func newNotary(eventsEnabled bool) *Notary {
 	n := &Notary{ContractMD: *interop.NewContractMD(nativenames.Notary, notaryContractID)}
+	n.eventsEnabled = eventsEnabled
 	defer n.UpdateHash()
 
+	changeFlags := callflag.States
+	if eventsEnabled {
+		changeFlags |= callflag.AllowNotify
+	}
+
 	desc := newDescriptor("onNEP17Payment", smartcontract.VoidType,
 		manifest.NewParameter("from", smartcontract.Hash160Type),
 		manifest.NewParameter("amount", smartcontract.IntegerType),
 		manifest.NewParameter("data", smartcontract.AnyType))
-	md := newMethodAndPrice(n.onPayment, 1<<15, callflag.States)
+	md := newMethodAndPrice(n.onPayment, 1<<15, changeFlags)
 	n.AddMethod(md, desc)
 
 	desc = newDescriptor("lockDepositUntil", smartcontract.BoolType,
 		manifest.NewParameter("address", smartcontract.Hash160Type),
 		manifest.NewParameter("till", smartcontract.IntegerType))
-	md = newMethodAndPrice(n.lockDepositUntil, 1<<15, callflag.States)
+	md = newMethodAndPrice(n.lockDepositUntil, 1<<15, changeFlags)
 	n.AddMethod(md, desc)
 
 	desc = newDescriptor("withdraw", smartcontract.BoolType,
 		manifest.NewParameter("from", smartcontract.Hash160Type),
 		manifest.NewParameter("to", smartcontract.Hash160Type))
-	md = newMethodAndPrice(n.withdraw, 1<<15, callflag.States)
+	md = newMethodAndPrice(n.withdraw, 1<<15, changeFlags)
 	n.AddMethod(md, desc)
 
 	desc = newDescriptor("balanceOf", smartcontract.IntegerType,
@@ -98,6 +119,27 @@ func newNotary() *Notary {
 	md = newMethodAndPrice(n.setMaxNotValidBeforeDelta, 1<<15, callflag.States)
 	n.AddMethod(md, desc)
 
+	if eventsEnabled {
+		n.AddEvent(notaryDepositEventName,
+			manifest.NewParameter("Account", smartcontract.Hash160Type),
+			manifest.NewParameter("Amount", smartcontract.IntegerType),
+			manifest.NewParameter("Till", smartcontract.IntegerType))
+		n.AddEvent(notaryLockEventName,
+			manifest.NewParameter("Account", smartcontract.Hash160Type),
+			manifest.NewParameter("Till", smartcontract.IntegerType))
+		n.AddEvent(notaryWithdrawEventName,
+			manifest.NewParameter("From", smartcontract.Hash160Type),
+			manifest.NewParameter("To", smartcontract.Hash160Type),
+			manifest.NewParameter("Amount", smartcontract.IntegerType))
+		n.AddEvent(notarySpendEventName,
+			manifest.NewParameter("Account", smartcontract.Hash160Type),
+			manifest.NewParameter("Amount", smartcontract.IntegerType),
+			manifest.NewParameter("Balance", smartcontract.IntegerType))
+		n.AddEvent(notaryExpireEventName,
+			manifest.NewParameter("Account", smartcontract.Hash160Type),
+			manifest.NewParameter("Amount", smartcontract.IntegerType))
+	}
+
 	return n
 }
 
@@ -138,12 +180,19 @@ func (n *Notary) OnPersist(ic *interop.Context) error {
 			if tx.Sender() == n.Hash {
 				payer := tx.Signers[1]
 				balance := n.GetDepositFor(ic.DAO, payer.Account)
-				balance.Amount.Sub(balance.Amount, big.NewInt(tx.SystemFee+tx.NetworkFee))
+				spent := big.NewInt(tx.SystemFee + tx.NetworkFee)
+				balance.Amount.Sub(balance.Amount, spent)
+				n.emitEvent(ic, notarySpendEventName, stackitem.NewByteArray(payer.Account.BytesBE()),
+					stackitem.NewBigInteger(spent), stackitem.NewBigInteger(balance.Amount))
 				if balance.Amount.Sign() == 0 {
 					err := n.removeDepositFor(ic.DAO, payer.Account)
 					if err != nil {
 						return fmt.Errorf("failed to remove an empty deposit for %s from storage: %w", payer.Account.StringBE(), err)
 					}
+					err = n.removeExpiration(ic.DAO, payer.Account, balance.Till)
+					if err != nil {
+						return fmt.Errorf("failed to remove expiration of an empty deposit for %s: %w", payer.Account.StringBE(), err)
+					}
 				} else {
 					err := n.putDepositFor(ic.DAO, balance, payer.Account)
 					if err != nil {
@@ -153,6 +202,9 @@ func (n *Notary) OnPersist(ic *interop.Context) error {
 			}
 		}
 	}
+	if err := n.processExpired(ic); err != nil {
+		return err
+	}
 	if nFees == 0 {
 		return nil
 	}
@@ -163,6 +215,31 @@ func (n *Notary) OnPersist(ic *interop.Context) error {
 	return nil
 }
 
+// processExpired emits DepositExpired notification for every deposit that can
+// be withdrawn starting from the current block and drops corresponding
+// expiration index entries.
+func (n *Notary) processExpired(ic *interop.Context) error {
+	if !n.eventsEnabled || ic.Block.Index == 0 {
+		return nil
+	}
+	prefix := makeDepositExpirationKey(ic.Block.Index-1, util.Uint160{})[:5]
+	var accs []util.Uint160
+	ic.DAO.Seek(n.ID, prefix, func(k, _ []byte) {
+		acc, err := util.Uint160DecodeBytesBE(k)
+		if err == nil {
+			accs = append(accs, acc)
+		}
+	})
+	for _, acc := range accs {
+		if err := ic.DAO.DeleteStorageItem(n.ID, makeDepositExpirationKey(ic.Block.Index-1, acc)); err != nil {
+			return fmt.Errorf("failed to remove expiration of a deposit for %s: %w", acc.StringBE(), err)
+		}
+		n.emitEvent(ic, notaryExpireEventName, stackitem.NewByteArray(acc.BytesBE()),
+			stackitem.NewBigInteger(n.BalanceOf(ic.DAO, acc)))
+	}
+	return nil
+}
+
 // PostPersist implements Contract interface.
 func (n *Notary) PostPersist(ic *interop.Context) error {
 	n.lock.Lock()
@@ -204,6 +281,10 @@ func (n *Notary) onPayment(ic *interop.Context, args []stackitem.Item) stackitem
 	if deposit != nil && till < deposit.Till {
 		panic(fmt.Errorf("`till` shouldn't be less then the previous value %d", deposit.Till))
 	}
+	var oldTill uint32
+	if deposit != nil {
+		oldTill = deposit.Till
+	}
 	if deposit == nil {
 		if amount.Cmp(big.NewInt(2*transaction.NotaryServiceFeePerKey)) < 0 {
 			panic(fmt.Errorf("first deposit can not be less then %d, got %d", 2*transaction.NotaryServiceFeePerKey, amount.Int64()))
@@ -223,6 +304,13 @@ func (n *Notary) onPayment(ic *interop.Context, args []stackitem.Item) stackitem
 	if err := n.putDepositFor(ic.DAO, deposit, to); err != nil {
 		panic(fmt.Errorf("failed to put deposit for %s into the storage: %w", from.StringBE(), err))
 	}
+	if oldTill != till {
+		if err := n.updateExpiration(ic, to, oldTill, till); err != nil {
+			panic(fmt.Errorf("failed to update deposit expiration for %s: %w", to.StringBE(), err))
+		}
+	}
+	n.emitEvent(ic, notaryDepositEventName, stackitem.NewByteArray(to.BytesBE()),
+		stackitem.NewBigInteger(amount), stackitem.Make(till))
 	return stackitem.Null{}
 }
 
@@ -247,11 +335,18 @@ func (n *Notary) lockDepositUntil(ic *interop.Context, args []stackitem.Item) st
 	if till < deposit.Till {
 		return stackitem.NewBool(false)
 	}
+	oldTill := deposit.Till
 	deposit.Till = till
 	err = n.putDepositFor(ic.DAO, deposit, addr)
 	if err != nil {
 		panic(fmt.Errorf("failed to put deposit for %s into the storage: %w", addr.StringBE(), err))
 	}
+	if oldTill != till {
+		if err := n.updateExpiration(ic, addr, oldTill, till); err != nil {
+			panic(fmt.Errorf("failed to update deposit expiration for %s: %w", addr.StringBE(), err))
+		}
+	}
+	n.emitEvent(ic, notaryLockEventName, stackitem.NewByteArray(addr.BytesBE()), stackitem.Make(till))
 	return stackitem.NewBool(true)
 }
 
@@ -291,9 +386,24 @@ func (n *Notary) withdraw(ic *interop.Context, args []stackitem.Item) stackitem.
 	if err := n.removeDepositFor(ic.DAO, from); err != nil {
 		panic(fmt.Errorf("failed to remove withdrawn deposit for %s from the storage: %w", from.StringBE(), err))
 	}
+	n.emitEvent(ic, notaryWithdrawEventName, stackitem.NewByteArray(from.BytesBE()),
+		stackitem.NewByteArray(to.BytesBE()), stackitem.NewBigInteger(deposit.Amount))
 	return stackitem.NewBool(true)
 }
 
+// emitEvent adds Notary notification with the given name and parameters if
+// Notary events are enabled.
+func (n *Notary) emitEvent(ic *interop.Context, name string, items ...stackitem.Item) {
+	if !n.eventsEnabled {
+		return
+	}
+	ic.Notifications = append(ic.Notifications, state.NotificationEvent{
+		ScriptHash: n.Hash,
+		Name:       name,
+		Item:       stackitem.NewArray(items),
+	})
+}
+
 // balanceOf returns deposited GAS amount for specified address.
 func (n *Notary) balanceOf(ic *interop.Context, args []stackitem.Item) stackitem.Item {
 	acc := toUint160(args[0])
@@ -434,6 +544,44 @@ func (n *Notary) removeDepositFor(dao dao.DAO, acc util.Uint160) error {
 	return dao.DeleteStorageItem(n.ID, key)
 }
 
+// makeDepositExpirationKey returns deposit expiration index key for the given account
+// and lock height.
+func makeDepositExpirationKey(till uint32, acc util.Uint160) []byte {
+	key := make([]byte, 5, 5+util.Uint160Size)
+	key[0] = prefixDepositExpiration
+	binary.BigEndian.PutUint32(key[1:], till)
+	return append(key, acc.BytesBE()...)
+}
+
+// updateExpiration moves deposit expiration index entry for the given account
+// from the old lock height to the new one. Deposits that are already expired
+// are not indexed, so they never get DepositExpired notification.
+func (n *Notary) updateExpiration(ic *interop.Context, acc util.Uint160, oldTill, till uint32) error {
+	if !n.eventsEnabled {
+		return nil
+	}
+	if err := n.removeExpiration(ic.DAO, acc, oldTill); err != nil {
+		return err
+	}
+	if till <= ic.Chain.BlockHeight() {
+		return nil
+	}
+	// Storage items can't be empty, the value itself is not used.
+	return ic.DAO.PutStorageItem(n.ID, makeDepositExpirationKey(till, acc), []byte{1})
+}
+
+// removeExpiration removes deposit expiration index entry if there is any.
+func (n *Notary) removeExpiration(d dao.DAO, acc util.Uint160, till uint32) error {
+	if !n.eventsEnabled {
+		return nil
+	}
+	key := makeDepositExpirationKey(till, acc)
+	if d.GetStorageItem(n.ID, key) == nil {
+		return nil
+	}
+	return d.DeleteStorageItem(n.ID, key)
+}
+
 // calculateNotaryReward calculates the reward for a single notary node based on FEE's count and Notary nodes count.
 func calculateNotaryReward(nFees int64, notariesCount int) *big.Int {
 	return big.NewInt(nFees * transaction.NotaryServiceFeePerKey / int64(notariesCount))
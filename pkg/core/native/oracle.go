@@ -48,6 +48,12 @@ type Oracle struct {
 	Module atomic.Value
 	// newRequests contains new requests created during current block.
 	newRequests map[uint64]*state.OracleRequest
+
+	// refundEnabled specifies whether unused response GAS is refunded.
+	refundEnabled bool
+	// responseGas contains GAS consumed by response transactions of
+	// the current block.
+	responseGas map[util.Uint256]int64
 }
 
 const (
@@ -79,8 +85,11 @@ var (
 	ErrResponseNotFound = errors.New("oracle response not found")
 )
 
-func newOracle() *Oracle {
-	o := &Oracle{ContractMD: *interop.NewContractMD(nativenames.Oracle, oracleContractID)}
+func newOracle(refundEnabled bool) *Oracle {
+	o := &Oracle{
+		ContractMD:    *interop.NewContractMD(nativenames.Oracle, oracleContractID),
+		refundEnabled: refundEnabled,
+	}
 	defer o.UpdateHash()
 
 	w := io.NewBufBinWriter()
@@ -114,6 +123,11 @@ func newOracle() *Oracle {
 		manifest.NewParameter("Filter", smartcontract.StringType))
 	o.AddEvent("OracleResponse", manifest.NewParameter("Id", smartcontract.IntegerType),
 		manifest.NewParameter("OriginalTx", smartcontract.Hash256Type))
+	if refundEnabled {
+		o.AddEvent("OracleRefund", manifest.NewParameter("Id", smartcontract.IntegerType),
+			manifest.NewParameter("Account", smartcontract.Hash160Type),
+			manifest.NewParameter("Amount", smartcontract.IntegerType))
+	}
 
 	desc = newDescriptor("getPrice", smartcontract.IntegerType)
 	md = newMethodAndPrice(o.getPrice, 1<<15, callflag.ReadStates)
@@ -142,9 +156,22 @@ func (o *Oracle) OnPersist(ic *interop.Context) error {
 	if o.newRequests == nil {
 		o.newRequests, err = o.getRequests(ic.DAO)
 	}
+	if o.refundEnabled {
+		o.responseGas = make(map[util.Uint256]int64)
+	}
 	return err
 }
 
+// AddResponseGasConsumed remembers the amount of GAS consumed by the oracle
+// response transaction, it's used to refund unused GAS to the requester in
+// PostPersist. It does nothing for other transactions or if refunds are not
+// enabled.
+func (o *Oracle) AddResponseGasConsumed(tx *transaction.Transaction, gas int64) {
+	if o.refundEnabled && o.responseGas != nil && getResponse(tx) != nil {
+		o.responseGas[tx.Hash()] = gas
+	}
+}
+
 // PostPersist represents `postPersist` method.
 func (o *Oracle) PostPersist(ic *interop.Context) error {
 	p := o.getPriceInternal(ic.DAO)
@@ -207,7 +234,11 @@ func (o *Oracle) PostPersist(ic *interop.Context) error {
 			index := resp.ID % uint64(len(nodes))
 			reward[index].Add(&reward[index], single)
 		}
+		if o.refundEnabled {
+			o.refund(ic, tx, resp.ID, req)
+		}
 	}
+	o.responseGas = nil
 	for i := range reward {
 		o.GAS.mint(ic, nodes[i].GetScriptHash(), &reward[i], false)
 	}
@@ -218,6 +249,34 @@ func (o *Oracle) PostPersist(ic *interop.Context) error {
 	return o.updateCache(ic.DAO)
 }
 
+// refund mints GAS left unused by the response transaction to the sender of
+// the original request transaction.
+func (o *Oracle) refund(ic *interop.Context, tx *transaction.Transaction, id uint64, req *state.OracleRequest) {
+	consumed, ok := o.responseGas[tx.Hash()]
+	if !ok {
+		return
+	}
+	amount := tx.SystemFee - consumed
+	if amount <= 0 {
+		return
+	}
+	origTx, _, err := ic.DAO.GetTransaction(req.OriginalTxID)
+	if err != nil {
+		return
+	}
+	sender := origTx.Sender()
+	o.GAS.mint(ic, sender, big.NewInt(amount), false)
+	ic.Notifications = append(ic.Notifications, state.NotificationEvent{
+		ScriptHash: o.Hash,
+		Name:       "OracleRefund",
+		Item: stackitem.NewArray([]stackitem.Item{
+			stackitem.Make(id),
+			stackitem.Make(sender.BytesBE()),
+			stackitem.Make(amount),
+		}),
+	})
+}
+
 // Metadata returns contract metadata.
 func (o *Oracle) Metadata() *interop.ContractMD {
 	return &o.ContractMD
@@ -452,7 +511,7 @@ func (o *Oracle) getPriceInternal(d dao.DAO) int64 {
 func (o *Oracle) setPrice(ic *interop.Context, args []stackitem.Item) stackitem.Item {
 	price := toBigInt(args[0])
 	if price.Sign() <= 0 || !price.IsInt64() {
-		panic("invalid register price")
+		panic("invalid oracle request price")
 	}
 	if !o.NEO.checkCommittee(ic) {
 		panic("invalid committee signature")
@@ -2,10 +2,12 @@ package native
 
 import (
 	"fmt"
+	"math"
 	"math/big"
 	"sort"
 	"sync"
 
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
@@ -33,6 +35,9 @@ const (
 	maxFeePerByte = 100_000_000
 	// maxStoragePrice is the maximum allowed price for a byte of storage.
 	maxStoragePrice = 10000000
+	// maxMaxBlockSize is the maximum allowed block size limit, it's the same
+	// as the maximum size of network message payload.
+	maxMaxBlockSize = 0x02000000
 
 	// blockedAccountPrefix is a prefix used to store blocked account.
 	blockedAccountPrefix = 15
@@ -46,8 +51,23 @@ var (
 	feePerByteKey = []byte{10}
 	// storagePriceKey is a key used to store storage price.
 	storagePriceKey = []byte{19}
+	// maxBlockSizeKey is a key used to store the maximum block size.
+	maxBlockSizeKey = []byte{12}
+	// maxBlockSystemFeeKey is a key used to store the maximum block system fee.
+	maxBlockSystemFeeKey = []byte{17}
+	// maxTransactionsPerBlockKey is a key used to store the maximum number of
+	// transactions per block.
+	maxTransactionsPerBlockKey = []byte{23}
 )
 
+// BlockLimits contains initial values of block limits managed by Policy
+// contract.
+type BlockLimits struct {
+	MaxBlockSize            uint32
+	MaxBlockSystemFee       int64
+	MaxTransactionsPerBlock uint16
+}
+
 // Policy represents Policy native contract.
 type Policy struct {
 	interop.ContractMD
@@ -62,13 +82,24 @@ type Policy struct {
 	maxVerificationGas int64
 	storagePrice       uint32
 	blockedAccounts    []util.Uint160
+
+	// blockLimits contains initial block limits, they're not managed by the
+	// contract if it's nil.
+	blockLimits             *BlockLimits
+	maxBlockSize            uint32
+	maxBlockSystemFee       int64
+	maxTransactionsPerBlock uint16
 }
 
 var _ interop.Contract = (*Policy)(nil)
 
-// newPolicy returns Policy native contract.
-func newPolicy() *Policy {
-	p := &Policy{ContractMD: *interop.NewContractMD(nativenames.Policy, policyContractID)}
+// newPolicy returns Policy native contract. Block limits can be changed by
+// committee if limits are not nil.
+func newPolicy(limits *BlockLimits) *Policy {
+	p := &Policy{
+		ContractMD:  *interop.NewContractMD(nativenames.Policy, policyContractID),
+		blockLimits: limits,
+	}
 	defer p.UpdateHash()
 
 	desc := newDescriptor("getFeePerByte", smartcontract.IntegerType)
@@ -113,6 +144,35 @@ func newPolicy() *Policy {
 	md = newMethodAndPrice(p.unblockAccount, 1<<15, callflag.States)
 	p.AddMethod(md, desc)
 
+	if limits != nil {
+		desc = newDescriptor("getMaxBlockSize", smartcontract.IntegerType)
+		md = newMethodAndPrice(p.getMaxBlockSize, 1<<15, callflag.ReadStates)
+		p.AddMethod(md, desc)
+
+		desc = newDescriptor("setMaxBlockSize", smartcontract.VoidType,
+			manifest.NewParameter("value", smartcontract.IntegerType))
+		md = newMethodAndPrice(p.setMaxBlockSize, 1<<15, callflag.States)
+		p.AddMethod(md, desc)
+
+		desc = newDescriptor("getMaxTransactionsPerBlock", smartcontract.IntegerType)
+		md = newMethodAndPrice(p.getMaxTransactionsPerBlock, 1<<15, callflag.ReadStates)
+		p.AddMethod(md, desc)
+
+		desc = newDescriptor("setMaxTransactionsPerBlock", smartcontract.VoidType,
+			manifest.NewParameter("value", smartcontract.IntegerType))
+		md = newMethodAndPrice(p.setMaxTransactionsPerBlock, 1<<15, callflag.States)
+		p.AddMethod(md, desc)
+
+		desc = newDescriptor("getMaxBlockSystemFee", smartcontract.IntegerType)
+		md = newMethodAndPrice(p.getMaxBlockSystemFee, 1<<15, callflag.ReadStates)
+		p.AddMethod(md, desc)
+
+		desc = newDescriptor("setMaxBlockSystemFee", smartcontract.VoidType,
+			manifest.NewParameter("value", smartcontract.IntegerType))
+		md = newMethodAndPrice(p.setMaxBlockSystemFee, 1<<15, callflag.States)
+		p.AddMethod(md, desc)
+	}
+
 	return p
 }
 
@@ -132,6 +192,20 @@ func (p *Policy) Initialize(ic *interop.Context) error {
 	if err := setIntWithKey(p.ID, ic.DAO, storagePriceKey, DefaultStoragePrice); err != nil {
 		return err
 	}
+	if p.blockLimits != nil {
+		if err := setIntWithKey(p.ID, ic.DAO, maxBlockSizeKey, int64(p.blockLimits.MaxBlockSize)); err != nil {
+			return err
+		}
+		if err := setIntWithKey(p.ID, ic.DAO, maxBlockSystemFeeKey, p.blockLimits.MaxBlockSystemFee); err != nil {
+			return err
+		}
+		if err := setIntWithKey(p.ID, ic.DAO, maxTransactionsPerBlockKey, int64(p.blockLimits.MaxTransactionsPerBlock)); err != nil {
+			return err
+		}
+		p.maxBlockSize = p.blockLimits.MaxBlockSize
+		p.maxBlockSystemFee = p.blockLimits.MaxBlockSystemFee
+		p.maxTransactionsPerBlock = p.blockLimits.MaxTransactionsPerBlock
+	}
 
 	p.isValid = true
 	p.execFeeFactor = defaultExecFeeFactor
@@ -160,6 +234,11 @@ func (p *Policy) PostPersist(ic *interop.Context) error {
 	p.feePerByte = getIntWithKey(p.ID, ic.DAO, feePerByteKey)
 	p.maxVerificationGas = defaultMaxVerificationGas
 	p.storagePrice = uint32(getIntWithKey(p.ID, ic.DAO, storagePriceKey))
+	if p.blockLimits != nil {
+		p.maxBlockSize = uint32(getIntWithKey(p.ID, ic.DAO, maxBlockSizeKey))
+		p.maxBlockSystemFee = getIntWithKey(p.ID, ic.DAO, maxBlockSystemFeeKey)
+		p.maxTransactionsPerBlock = uint16(getIntWithKey(p.ID, ic.DAO, maxTransactionsPerBlockKey))
+	}
 
 	p.blockedAccounts = make([]util.Uint160, 0)
 	siMap, err := ic.DAO.GetStorageItemsWithPrefix(p.ID, []byte{blockedAccountPrefix})
@@ -312,6 +391,107 @@ func (p *Policy) setFeePerByte(ic *interop.Context, args []stackitem.Item) stack
 	return stackitem.Null{}
 }
 
+func (p *Policy) getMaxBlockSize(ic *interop.Context, _ []stackitem.Item) stackitem.Item {
+	return stackitem.NewBigInteger(big.NewInt(int64(p.GetMaxBlockSizeInternal(ic.DAO))))
+}
+
+// GetMaxBlockSizeInternal returns the maximum block size. It must only be used
+// if block limits are managed by the contract.
+func (p *Policy) GetMaxBlockSizeInternal(d dao.DAO) uint32 {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	if p.isValid {
+		return p.maxBlockSize
+	}
+	return uint32(getIntWithKey(p.ID, d, maxBlockSizeKey))
+}
+
+func (p *Policy) setMaxBlockSize(ic *interop.Context, args []stackitem.Item) stackitem.Item {
+	value := toUint32(args[0])
+	if value <= 0 || maxMaxBlockSize < value {
+		panic(fmt.Errorf("MaxBlockSize must be between 1 and %d", maxMaxBlockSize))
+	}
+	if !p.NEO.checkCommittee(ic) {
+		panic("invalid committee signature")
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	err := setIntWithKey(p.ID, ic.DAO, maxBlockSizeKey, int64(value))
+	if err != nil {
+		panic(err)
+	}
+	p.isValid = false
+	return stackitem.Null{}
+}
+
+func (p *Policy) getMaxTransactionsPerBlock(ic *interop.Context, _ []stackitem.Item) stackitem.Item {
+	return stackitem.NewBigInteger(big.NewInt(int64(p.GetMaxTransactionsPerBlockInternal(ic.DAO))))
+}
+
+// GetMaxTransactionsPerBlockInternal returns the maximum number of transactions
+// per block. It must only be used if block limits are managed by the contract.
+func (p *Policy) GetMaxTransactionsPerBlockInternal(d dao.DAO) uint16 {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	if p.isValid {
+		return p.maxTransactionsPerBlock
+	}
+	return uint16(getIntWithKey(p.ID, d, maxTransactionsPerBlockKey))
+}
+
+func (p *Policy) setMaxTransactionsPerBlock(ic *interop.Context, args []stackitem.Item) stackitem.Item {
+	value := toUint32(args[0])
+	if value <= 0 || block.MaxTransactionsPerBlock < value {
+		panic(fmt.Errorf("MaxTransactionsPerBlock must be between 1 and %d", block.MaxTransactionsPerBlock))
+	}
+	if !p.NEO.checkCommittee(ic) {
+		panic("invalid committee signature")
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	err := setIntWithKey(p.ID, ic.DAO, maxTransactionsPerBlockKey, int64(value))
+	if err != nil {
+		panic(err)
+	}
+	p.isValid = false
+	return stackitem.Null{}
+}
+
+func (p *Policy) getMaxBlockSystemFee(ic *interop.Context, _ []stackitem.Item) stackitem.Item {
+	return stackitem.NewBigInteger(big.NewInt(p.GetMaxBlockSystemFeeInternal(ic.DAO)))
+}
+
+// GetMaxBlockSystemFeeInternal returns the maximum system fee of all
+// transactions in a block. It must only be used if block limits are managed by
+// the contract.
+func (p *Policy) GetMaxBlockSystemFeeInternal(d dao.DAO) int64 {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	if p.isValid {
+		return p.maxBlockSystemFee
+	}
+	return getIntWithKey(p.ID, d, maxBlockSystemFeeKey)
+}
+
+func (p *Policy) setMaxBlockSystemFee(ic *interop.Context, args []stackitem.Item) stackitem.Item {
+	bi := toBigInt(args[0])
+	if !bi.IsInt64() || bi.Sign() <= 0 {
+		panic(fmt.Errorf("MaxBlockSystemFee must be between 1 and %d", int64(math.MaxInt64)))
+	}
+	value := bi.Int64()
+	if !p.NEO.checkCommittee(ic) {
+		panic("invalid committee signature")
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	err := setIntWithKey(p.ID, ic.DAO, maxBlockSystemFeeKey, value)
+	if err != nil {
+		panic(err)
+	}
+	p.isValid = false
+	return stackitem.Null{}
+}
+
 // blockAccount is Policy contract method and adds given account hash to the list
 // of blocked accounts.
 func (p *Policy) blockAccount(ic *interop.Context, args []stackitem.Item) stackitem.Item {
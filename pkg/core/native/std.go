@@ -1,6 +1,7 @@
 package native
 
 import (
+	"bytes"
 	"encoding/base64"
 	"encoding/hex"
 	"errors"
@@ -22,13 +23,20 @@ type Std struct {
 	interop.ContractMD
 }
 
-const stdContractID = -2
+const (
+	stdContractID = -2
+	// stdMaxInputLength is the maximum input length for memory-related
+	// StdLib methods.
+	stdMaxInputLength = 1024
+)
 
 var (
 	// ErrInvalidBase is returned when base is invalid.
 	ErrInvalidBase = errors.New("invalid base")
 	// ErrInvalidFormat is returned when string is not a number.
 	ErrInvalidFormat = errors.New("invalid format")
+	// ErrTooBigInput is returned when input exceeds size limit.
+	ErrTooBigInput = errors.New("input is too big")
 )
 
 func newStd() *Std {
@@ -87,6 +95,33 @@ func newStd() *Std {
 	md = newMethodAndPrice(s.base58Decode, 1<<12, callflag.NoneFlag)
 	s.AddMethod(md, desc)
 
+	desc = newDescriptor("memoryCompare", smartcontract.IntegerType,
+		manifest.NewParameter("str1", smartcontract.ByteArrayType),
+		manifest.NewParameter("str2", smartcontract.ByteArrayType))
+	md = newMethodAndPrice(s.memoryCompare, 1<<5, callflag.NoneFlag)
+	s.AddMethod(md, desc)
+
+	desc = newDescriptor("memorySearch", smartcontract.IntegerType,
+		manifest.NewParameter("mem", smartcontract.ByteArrayType),
+		manifest.NewParameter("value", smartcontract.ByteArrayType))
+	md = newMethodAndPrice(s.memorySearch2, 1<<6, callflag.NoneFlag)
+	s.AddMethod(md, desc)
+
+	desc = newDescriptor("memorySearch", smartcontract.IntegerType,
+		manifest.NewParameter("mem", smartcontract.ByteArrayType),
+		manifest.NewParameter("value", smartcontract.ByteArrayType),
+		manifest.NewParameter("start", smartcontract.IntegerType))
+	md = newMethodAndPrice(s.memorySearch3, 1<<6, callflag.NoneFlag)
+	s.AddMethod(md, desc)
+
+	desc = newDescriptor("memorySearch", smartcontract.IntegerType,
+		manifest.NewParameter("mem", smartcontract.ByteArrayType),
+		manifest.NewParameter("value", smartcontract.ByteArrayType),
+		manifest.NewParameter("start", smartcontract.IntegerType),
+		manifest.NewParameter("backward", smartcontract.BoolType))
+	md = newMethodAndPrice(s.memorySearch4, 1<<6, callflag.NoneFlag)
+	s.AddMethod(md, desc)
+
 	return s
 }
 
@@ -252,6 +287,74 @@ func (s *Std) base58Decode(_ *interop.Context, args []stackitem.Item) stackitem.
 	return stackitem.NewByteArray(result)
 }
 
+func (s *Std) memoryCompare(_ *interop.Context, args []stackitem.Item) stackitem.Item {
+	s1 := toLimitedBytes(args[0])
+	s2 := toLimitedBytes(args[1])
+	return stackitem.NewBigInteger(big.NewInt(int64(bytes.Compare(s1, s2))))
+}
+
+func (s *Std) memorySearch2(_ *interop.Context, args []stackitem.Item) stackitem.Item {
+	mem := toLimitedBytes(args[0])
+	val := toLimitedBytes(args[1])
+	index := memorySearchAux(mem, val, 0, false)
+	return stackitem.NewBigInteger(big.NewInt(int64(index)))
+}
+
+func (s *Std) memorySearch3(_ *interop.Context, args []stackitem.Item) stackitem.Item {
+	mem := toLimitedBytes(args[0])
+	val := toLimitedBytes(args[1])
+	start := toMemoryIndex(args[2], len(mem))
+	index := memorySearchAux(mem, val, start, false)
+	return stackitem.NewBigInteger(big.NewInt(int64(index)))
+}
+
+func (s *Std) memorySearch4(_ *interop.Context, args []stackitem.Item) stackitem.Item {
+	mem := toLimitedBytes(args[0])
+	val := toLimitedBytes(args[1])
+	start := toMemoryIndex(args[2], len(mem))
+	backward, err := args[3].TryBool()
+	if err != nil {
+		panic(err)
+	}
+	index := memorySearchAux(mem, val, start, backward)
+	return stackitem.NewBigInteger(big.NewInt(int64(index)))
+}
+
+// memorySearchAux returns the index of the first occurrence of val in mem
+// starting from start or the index of the last occurrence of val in mem
+// ending before start if backward is true. It returns -1 if there is no match.
+func memorySearchAux(mem, val []byte, start int, backward bool) int {
+	if backward {
+		return bytes.LastIndex(mem[:start], val)
+	}
+	index := bytes.Index(mem[start:], val)
+	if index < 0 {
+		return -1
+	}
+	return index + start
+}
+
+// toMemoryIndex converts stack item to an index in the memory of the given
+// length, it panics if the index is out of [0, length] range.
+func toMemoryIndex(item stackitem.Item, length int) int {
+	bi := toBigInt(item)
+	if !bi.IsInt64() || bi.Int64() < 0 || bi.Int64() > int64(length) {
+		panic(errors.New("invalid start index"))
+	}
+	return int(bi.Int64())
+}
+
+func toLimitedBytes(item stackitem.Item) []byte {
+	src, err := item.TryBytes()
+	if err != nil {
+		panic(err)
+	}
+	if len(src) > stdMaxInputLength {
+		panic(ErrTooBigInput)
+	}
+	return src
+}
+
 // Metadata implements Contract interface.
 func (s *Std) Metadata() *interop.ContractMD {
 	return &s.ContractMD
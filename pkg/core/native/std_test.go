@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"math"
 	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/mr-tron/base58"
@@ -326,3 +327,113 @@ func TestStdLibSerializeDeserialize(t *testing.T) {
 		})
 	})
 }
+
+func TestMemoryCompare(t *testing.T) {
+	s := newStd()
+	ic := &interop.Context{VM: vm.New()}
+
+	check := func(t *testing.T, result int64, s1, s2 string) {
+		actual := s.memoryCompare(ic, []stackitem.Item{stackitem.Make(s1), stackitem.Make(s2)})
+		require.Equal(t, big.NewInt(result), actual.Value())
+	}
+
+	check(t, -1, "a", "ab")
+	check(t, 1, "ab", "a")
+	check(t, 0, "ab", "ab")
+	check(t, -1, "", "a")
+	check(t, 0, "", "")
+
+	t.Run("C# compatibility", func(t *testing.T) {
+		// These tests are taken from C# node.
+		check(t, -1, "abc", "c")
+		check(t, -1, "abc", "d")
+		check(t, 0, "abc", "abc")
+		check(t, -1, "abc", "abcd")
+	})
+
+	t.Run("big arguments", func(t *testing.T) {
+		s1 := stackitem.Make(strings.Repeat("x", stdMaxInputLength+1))
+		s2 := stackitem.Make("xxx")
+
+		require.PanicsWithError(t, ErrTooBigInput.Error(),
+			func() { s.memoryCompare(ic, []stackitem.Item{s1, s2}) })
+
+		require.PanicsWithError(t, ErrTooBigInput.Error(),
+			func() { s.memoryCompare(ic, []stackitem.Item{s2, s1}) })
+	})
+}
+
+func TestMemorySearch(t *testing.T) {
+	s := newStd()
+	ic := &interop.Context{VM: vm.New()}
+
+	check := func(t *testing.T, result int64, args ...interface{}) {
+		items := make([]stackitem.Item, len(args))
+		for i := range args {
+			items[i] = stackitem.Make(args[i])
+		}
+
+		var actual stackitem.Item
+		switch len(items) {
+		case 2:
+			actual = s.memorySearch2(ic, items)
+		case 3:
+			actual = s.memorySearch3(ic, items)
+		case 4:
+			actual = s.memorySearch4(ic, items)
+		default:
+			panic("invalid args length")
+		}
+		require.Equal(t, big.NewInt(result), actual.Value())
+	}
+
+	t.Run("C# compatibility", func(t *testing.T) {
+		// These tests are taken from C# node.
+		check(t, 2, "abc", "c", 0)
+		check(t, 2, "abc", "c", 1)
+		check(t, 2, "abc", "c", 2)
+		check(t, -1, "abc", "c", 3)
+		check(t, -1, "abc", "d", 0)
+
+		check(t, 2, "abc", "c", 0, false)
+		check(t, 2, "abc", "c", 1, false)
+		check(t, 2, "abc", "c", 2, false)
+		check(t, -1, "abc", "c", 3, false)
+		check(t, -1, "abc", "d", 0, false)
+
+		check(t, -1, "abc", "c", 0, true)
+		check(t, -1, "abc", "c", 1, true)
+		check(t, -1, "abc", "c", 2, true)
+		check(t, 2, "abc", "c", 3, true)
+		check(t, -1, "abc", "d", 0, true)
+	})
+
+	t.Run("2 arguments", func(t *testing.T) {
+		check(t, 1, "ab", "b")
+		check(t, 0, "ab", "ab")
+		check(t, -1, "", "a")
+	})
+
+	t.Run("big arguments", func(t *testing.T) {
+		s1 := stackitem.Make(strings.Repeat("x", stdMaxInputLength+1))
+		s2 := stackitem.Make("xxx")
+
+		require.PanicsWithError(t, ErrTooBigInput.Error(),
+			func() { s.memorySearch2(ic, []stackitem.Item{s1, s2}) })
+		require.PanicsWithError(t, ErrTooBigInput.Error(),
+			func() { s.memorySearch3(ic, []stackitem.Item{s1, s2, stackitem.Make(3)}) })
+		require.PanicsWithError(t, ErrTooBigInput.Error(),
+			func() { s.memorySearch4(ic, []stackitem.Item{s1, s2, stackitem.Make(3), stackitem.Make(false)}) })
+	})
+
+	t.Run("invalid start index", func(t *testing.T) {
+		for _, start := range []int{-1, 4} {
+			require.Panics(t, func() {
+				s.memorySearch3(ic, []stackitem.Item{stackitem.Make("abc"), stackitem.Make("a"), stackitem.Make(start)})
+			})
+			require.Panics(t, func() {
+				s.memorySearch4(ic, []stackitem.Item{stackitem.Make("abc"), stackitem.Make("a"), stackitem.Make(start), stackitem.Make(true)})
+			})
+		}
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/testchain"
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/native"
@@ -52,16 +53,34 @@ func (bc *Blockchain) setNodesByRole(t *testing.T, ok bool, r noderoles.Role, no
 		InvocationScript:   testchain.SignCommittee(tx.GetSignedPart()),
 		VerificationScript: testchain.CommitteeVerificationScript(),
 	})
+	old, _, err := bc.contracts.Designate.GetDesignatedByRole(bc.dao, r, bc.BlockHeight()+2)
+	if ok {
+		require.NoError(t, err)
+	}
 	require.NoError(t, bc.AddBlock(bc.newBlock(tx)))
 
 	aer, err := bc.GetAppExecResults(tx.Hash(), trigger.Application)
 	require.NoError(t, err)
 	require.Equal(t, 1, len(aer))
-	if ok {
-		require.Equal(t, vm.HaltState, aer[0].VMState)
-	} else {
+	if !ok {
 		require.Equal(t, vm.FaultState, aer[0].VMState)
+		return
 	}
+	require.Equal(t, vm.HaltState, aer[0].VMState)
+	if !bc.config.DesignationEvent {
+		require.Equal(t, 0, len(aer[0].Events))
+		return
+	}
+	require.Equal(t, 1, len(aer[0].Events))
+	ev := aer[0].Events[0]
+	require.Equal(t, bc.contracts.Designate.Hash, ev.ScriptHash)
+	require.Equal(t, native.DesignationEventName, ev.Name)
+	require.Equal(t, []stackitem.Item{
+		stackitem.Make(int64(r)),
+		stackitem.Make(len(old)),
+		stackitem.Make(len(nodes)),
+		stackitem.Make(bc.BlockHeight()),
+	}, ev.Item.Value().([]stackitem.Item))
 }
 
 func (bc *Blockchain) getNodesByRole(t *testing.T, ok bool, r noderoles.Role, index uint32, resLen int) {
@@ -80,8 +99,17 @@ func (bc *Blockchain) getNodesByRole(t *testing.T, ok bool, r noderoles.Role, in
 }
 
 func TestDesignate_DesignateAsRoleTx(t *testing.T) {
-	bc := newTestChain(t)
+	t.Run("without event", func(t *testing.T) {
+		testDesignateAsRoleTx(t, newTestChain(t))
+	})
+	t.Run("with event", func(t *testing.T) {
+		testDesignateAsRoleTx(t, newTestChainWithCustomCfg(t, func(c *config.Config) {
+			c.ProtocolConfiguration.DesignationEvent = true
+		}))
+	})
+}
 
+func testDesignateAsRoleTx(t *testing.T, bc *Blockchain) {
 	priv, err := keys.NewPrivateKey()
 	require.NoError(t, err)
 	pubs := keys.PublicKeys{priv.PublicKey()}
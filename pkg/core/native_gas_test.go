@@ -0,0 +1,61 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+// checkGASTransfer checks that there is exactly one GAS transfer event with
+// the given parameters, nil addresses stand for minting and burning.
+func checkGASTransfer(t *testing.T, bc *Blockchain, events []state.NotificationEvent, from, to *util.Uint160, amount int64) {
+	addr := func(u *util.Uint160) stackitem.Item {
+		if u == nil {
+			return stackitem.Null{}
+		}
+		return stackitem.NewByteArray(u.BytesBE())
+	}
+	params := []stackitem.Item{addr(from), addr(to), stackitem.NewBigInteger(big.NewInt(amount))}
+	var found int
+	for _, e := range events {
+		if e.ScriptHash != bc.contracts.GAS.Hash || e.Name != "Transfer" {
+			continue
+		}
+		arr := e.Item.Value().([]stackitem.Item)
+		require.Equal(t, len(params), len(arr))
+		if params[0].Equals(arr[0]) && params[1].Equals(arr[1]) && params[2].Equals(arr[2]) {
+			found++
+		}
+	}
+	require.Equal(t, 1, found)
+}
+
+func TestGAS_BurnMintEvents(t *testing.T) {
+	bc := newTestChain(t)
+
+	tx := transferTokenFromMultisigAccount(t, bc, neoOwner, bc.contracts.GAS.Hash, 1)
+	checkTxHalt(t, bc, tx.Hash())
+	b, err := bc.GetBlock(bc.GetHeaderHash(int(bc.BlockHeight())))
+	require.NoError(t, err)
+
+	aers, err := bc.GetAppExecResults(b.Hash(), trigger.OnPersist)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(aers))
+	sender := tx.Sender()
+	checkGASTransfer(t, bc, aers[0].Events, &sender, nil, tx.SystemFee+tx.NetworkFee)
+	primary := bc.contracts.NEO.GetNextBlockValidatorsInternal()[b.PrimaryIndex].GetScriptHash()
+	checkGASTransfer(t, bc, aers[0].Events, nil, &primary, tx.NetworkFee)
+
+	aers, err = bc.GetAppExecResults(b.Hash(), trigger.PostPersist)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(aers))
+	pubs := bc.contracts.NEO.GetCommitteeMembers()
+	member := pubs[int(b.Index)%len(pubs)].GetScriptHash()
+	const committeeBounty = 50000000
+	checkGASTransfer(t, bc, aers[0].Events, nil, &member, committeeBounty)
+}
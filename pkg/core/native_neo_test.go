@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/testchain"
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core/native"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
@@ -141,7 +142,24 @@ func TestNEO_Vote(t *testing.T) {
 			w := io.NewBufBinWriter()
 			h := accs[i].PrivateKey().GetScriptHash()
 			gasBalance[i] = bc.GetUtilityTokenBalance(h)
-			neoBalance[i], _ = bc.GetGoverningTokenBalance(h)
+			var balanceHeight uint32
+			neoBalance[i], balanceHeight = bc.GetGoverningTokenBalance(h)
+
+			end := bc.BlockHeight() + 1
+			voterReward, err := bc.CalculateVoterReward(h, end)
+			require.NoError(t, err)
+			require.True(t, voterReward.Sign() > 0)
+			perVote := new(big.Int).Sub(bc.GetGASPerVote(candidates[i], end), bc.GetGASPerVote(candidates[i], balanceHeight))
+			perVote.Mul(perVote, neoBalance[i])
+			perVote.Div(perVote, big.NewInt(100_000_000))
+			require.Equal(t, 0, voterReward.Cmp(perVote))
+
+			claimable, err := bc.CalculateClaimable(h, end)
+			require.NoError(t, err)
+			gasForHold, err := bc.contracts.NEO.CalculateNEOHolderReward(bc.dao, neoBalance[i], balanceHeight, end)
+			require.NoError(t, err)
+			require.Equal(t, 0, claimable.Cmp(gasForHold.Add(gasForHold, voterReward)))
+
 			emit.AppCall(w.BinWriter, bc.contracts.NEO.Hash, "transfer", callflag.All,
 				h.BytesBE(), h.BytesBE(), int64(1), nil)
 			emit.Opcodes(w.BinWriter, opcode.ASSERT)
@@ -229,6 +247,37 @@ func TestNEO_CalculateBonus(t *testing.T) {
 	})
 }
 
+func TestNEO_VoterRewardMethods(t *testing.T) {
+	acc := testchain.MultisigScriptHash()
+	t.Run("disabled", func(t *testing.T) {
+		bc := newTestChain(t)
+		res, err := invokeContractMethod(bc, 1_0000_0000, bc.contracts.NEO.Hash, "unclaimedVoterGas", acc, int64(1))
+		require.NoError(t, err)
+		checkFAULTState(t, res)
+	})
+
+	bc := newTestChainWithCustomCfg(t, func(c *config.Config) {
+		c.ProtocolConfiguration.VoterRewardMethods = true
+	})
+	res, err := invokeContractMethod(bc, 1_0000_0000, bc.contracts.NEO.Hash, "unclaimedVoterGas", acc, int64(1))
+	require.NoError(t, err)
+	checkResult(t, res, stackitem.Make(0))
+
+	pub := bc.contracts.NEO.GetCommitteeMembers()[0]
+	res, err = invokeContractMethod(bc, 1_0000_0000, bc.contracts.NEO.Hash, "getGasPerVote", pub.Bytes(), int64(1))
+	require.NoError(t, err)
+	checkResult(t, res, stackitem.Make(0))
+
+	for _, end := range []int64{-1, math.MaxUint32 + 1} {
+		res, err = invokeContractMethod(bc, 1_0000_0000, bc.contracts.NEO.Hash, "unclaimedVoterGas", acc, end)
+		require.NoError(t, err)
+		checkFAULTState(t, res)
+		res, err = invokeContractMethod(bc, 1_0000_0000, bc.contracts.NEO.Hash, "getGasPerVote", pub.Bytes(), end)
+		require.NoError(t, err)
+		checkFAULTState(t, res)
+	}
+}
+
 func TestNEO_CommitteeBountyOnPersist(t *testing.T) {
 	bc := newTestChain(t)
 
@@ -5,7 +5,9 @@ import (
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/testchain"
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/io"
@@ -20,8 +22,32 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// checkNotaryEvent checks that there is a single Notary event with the given
+// name and parameters among the given notifications.
+func checkNotaryEvent(t *testing.T, chain *Blockchain, events []state.NotificationEvent, name string, params ...stackitem.Item) {
+	var found []state.NotificationEvent
+	for _, e := range events {
+		if e.ScriptHash == chain.contracts.Notary.Hash && e.Name == name {
+			found = append(found, e)
+		}
+	}
+	require.Equal(t, 1, len(found))
+	arr := found[0].Item.Value().([]stackitem.Item)
+	require.Equal(t, len(params), len(arr))
+	for i := range params {
+		require.True(t, params[i].Equals(arr[i]), "parameter #%d", i)
+	}
+}
+
+// newTestChainWithNotaryEvents returns a test chain with Notary events enabled.
+func newTestChainWithNotaryEvents(t *testing.T) *Blockchain {
+	return newTestChainWithCustomCfg(t, func(c *config.Config) {
+		c.ProtocolConfiguration.NotaryEvents = true
+	})
+}
+
 func TestNotaryContractPipeline(t *testing.T) {
-	chain := newTestChain(t)
+	chain := newTestChainWithNotaryEvents(t)
 
 	notaryHash := chain.contracts.Notary.Hash
 	gasHash := chain.contracts.GAS.Hash
@@ -71,6 +97,8 @@ func TestNotaryContractPipeline(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, vm.HaltState, res[0].VMState)
 	require.Equal(t, 0, len(res[0].Stack))
+	checkNotaryEvent(t, chain, res[0].Events, "Deposit", stackitem.NewByteArray(testchain.MultisigScriptHash().BytesBE()),
+		stackitem.Make(2*transaction.NotaryServiceFeePerKey), stackitem.Make(depositLock))
 	checkBalanceOf(t, chain, notaryHash, 2*transaction.NotaryServiceFeePerKey)
 
 	// `expirationOf`: check `till` was set
@@ -160,6 +188,8 @@ func TestNotaryContractPipeline(t *testing.T) {
 	lockDepositUntilRes, err = invokeContractMethod(chain, 100000000, notaryHash, "lockDepositUntil", testchain.MultisigScriptHash(), int64(depositLock+3))
 	require.NoError(t, err)
 	checkResult(t, lockDepositUntilRes, stackitem.NewBool(true))
+	checkNotaryEvent(t, chain, lockDepositUntilRes.Events, "LockDepositUntil",
+		stackitem.NewByteArray(testchain.MultisigScriptHash().BytesBE()), stackitem.Make(depositLock+3))
 	till, err = invokeContractMethod(chain, 100000000, notaryHash, "expirationOf", testchain.MultisigScriptHash())
 	require.NoError(t, err)
 	checkResult(t, till, stackitem.Make(depositLock+3))
@@ -209,9 +239,18 @@ func TestNotaryContractPipeline(t *testing.T) {
 
 	// `withdraw`: unlock deposit and transfer GAS back to owner
 	chain.genBlocks(depositLock)
+	aers, err := chain.GetAppExecResults(chain.GetHeaderHash(depositLock+4), trigger.OnPersist)
+	require.NoError(t, err)
+	checkNotaryEvent(t, chain, aers[0].Events, "DepositExpired",
+		stackitem.NewByteArray(testchain.MultisigScriptHash().BytesBE()),
+		stackitem.Make(3*transaction.NotaryServiceFeePerKey))
 	withdrawRes, err = invokeContractMethod(chain, 100000000, notaryHash, "withdraw", testchain.MultisigScriptHash(), testchain.MultisigScriptHash())
 	require.NoError(t, err)
 	checkResult(t, withdrawRes, stackitem.NewBool(true))
+	checkNotaryEvent(t, chain, withdrawRes.Events, "Withdraw",
+		stackitem.NewByteArray(testchain.MultisigScriptHash().BytesBE()),
+		stackitem.NewByteArray(testchain.MultisigScriptHash().BytesBE()),
+		stackitem.Make(3*transaction.NotaryServiceFeePerKey))
 	balance, err = invokeContractMethod(chain, 100000000, notaryHash, "balanceOf", testchain.MultisigScriptHash())
 	require.NoError(t, err)
 	checkResult(t, balance, stackitem.Make(0))
@@ -245,9 +284,29 @@ func TestNotaryContractPipeline(t *testing.T) {
 	checkResult(t, till, stackitem.Make(5760+chain.BlockHeight()-4))
 }
 
+func TestNotaryEventsDisabled(t *testing.T) {
+	chain := newTestChain(t)
+
+	transferTx := transferTokenFromMultisigAccount(t, chain, chain.contracts.Notary.Hash, chain.contracts.GAS.Hash,
+		2*transaction.NotaryServiceFeePerKey, nil, int64(chain.BlockHeight()+2))
+	res, err := chain.GetAppExecResults(transferTx.Hash(), trigger.Application)
+	require.NoError(t, err)
+	require.Equal(t, vm.HaltState, res[0].VMState)
+	for _, e := range res[0].Events {
+		require.NotEqual(t, chain.contracts.Notary.Hash, e.ScriptHash)
+	}
+	till := chain.BlockHeight() + 1
+	chain.genBlocks(2)
+	aers, err := chain.GetAppExecResults(chain.GetHeaderHash(int(till)+1), trigger.OnPersist)
+	require.NoError(t, err)
+	for _, e := range aers[0].Events {
+		require.NotEqual(t, chain.contracts.Notary.Hash, e.ScriptHash)
+	}
+}
+
 func TestNotaryNodesReward(t *testing.T) {
 	checkReward := func(nKeys int, nNotaryNodes int, spendFullDeposit bool) {
-		chain := newTestChain(t)
+		chain := newTestChainWithNotaryEvents(t)
 		notaryHash := chain.contracts.Notary.Hash
 		gasHash := chain.contracts.GAS.Hash
 		signer := testchain.MultisigScriptHash()
@@ -304,6 +363,10 @@ func TestNotaryNodesReward(t *testing.T) {
 		b := chain.newBlock(tx)
 		require.NoError(t, chain.AddBlock(b))
 		checkBalanceOf(t, chain, notaryHash, int(depositAmount-tx.SystemFee-tx.NetworkFee))
+		aers, err := chain.GetAppExecResults(b.Hash(), trigger.OnPersist)
+		require.NoError(t, err)
+		checkNotaryEvent(t, chain, aers[0].Events, "DepositSpent", stackitem.NewByteArray(signer.BytesBE()),
+			stackitem.Make(tx.SystemFee+tx.NetworkFee), stackitem.Make(depositAmount-tx.SystemFee-tx.NetworkFee))
 		for _, notaryNode := range notaryNodesPublicKeys {
 			checkBalanceOf(t, chain, notaryNode.GetScriptHash(), transaction.NotaryServiceFeePerKey*(nKeys+1)/nNotaryNodes)
 		}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/testchain"
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
@@ -251,6 +252,69 @@ func TestOracle_Request(t *testing.T) {
 	})
 }
 
+func TestOracle_Refund(t *testing.T) {
+	bc := newTestChainWithCustomCfg(t, func(c *config.Config) {
+		c.ProtocolConfiguration.OracleResponseRefund = true
+	})
+
+	orc := bc.contracts.Oracle
+	cs := getOracleContractState(orc.Hash, bc.contracts.Std.Hash)
+	require.NoError(t, bc.contracts.Management.PutContractState(bc.dao, cs))
+
+	gasForResponse := int64(2000_1234)
+	txHash := putOracleRequest(t, cs.Hash, bc, "url", nil, "handle", []byte{1, 2}, gasForResponse)
+	reqTx, _, err := bc.GetTransaction(txHash)
+	require.NoError(t, err)
+	sender := reqTx.Sender()
+
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	tx := transaction.New(netmode.UnitTestNet, []byte{}, 0)
+	setSigner(tx, testchain.CommitteeScriptHash())
+	bl := block.New(netmode.UnitTestNet, bc.config.StateRootInHeader)
+	bl.Index = bc.BlockHeight() + 1
+	ic := bc.newInteropContext(trigger.Application, bc.dao, bl, tx)
+	ic.SpawnVM()
+	ic.VM.LoadScript([]byte{byte(opcode.RET)})
+	require.NoError(t, bc.contracts.Designate.DesignateAsRole(ic, noderoles.Oracle, keys.PublicKeys{priv.PublicKey()}))
+
+	tx = transaction.New(netmode.UnitTestNet, orc.GetOracleResponseScript(), gasForResponse)
+	tx.Attributes = []transaction.Attribute{{
+		Type: transaction.OracleResponseT,
+		Value: &transaction.OracleResponse{
+			ID:     0,
+			Code:   transaction.Success,
+			Result: []byte{4, 8, 15, 16, 23, 42},
+		},
+	}}
+	ic = bc.newInteropContext(trigger.Application, bc.dao, bc.newBlock(tx), tx)
+	require.NoError(t, orc.OnPersist(ic))
+	ic.VM = ic.SpawnVM()
+	ic.VM.LoadScriptWithFlags(tx.Script, callflag.All)
+	require.NoError(t, ic.VM.Run())
+	consumed := ic.VM.GasConsumed()
+	require.True(t, consumed < gasForResponse)
+	orc.AddResponseGasConsumed(tx, consumed)
+
+	balance := bc.GetUtilityTokenBalance(sender)
+	ic.Notifications = ic.Notifications[:0]
+	require.NoError(t, orc.PostPersist(ic))
+	refund := gasForResponse - consumed
+	require.Equal(t, new(big.Int).Add(balance, big.NewInt(refund)), bc.GetUtilityTokenBalance(sender))
+
+	var found bool
+	for _, ev := range ic.Notifications {
+		if ev.ScriptHash == orc.Hash && ev.Name == "OracleRefund" {
+			arr := ev.Item.Value().([]stackitem.Item)
+			require.Equal(t, big.NewInt(0), arr[0].Value())
+			require.Equal(t, sender.BytesBE(), arr[1].Value())
+			require.Equal(t, big.NewInt(refund), arr[2].Value())
+			found = true
+		}
+	}
+	require.True(t, found)
+}
+
 func TestGetSetPrice(t *testing.T) {
 	bc := newTestChain(t)
 	testGetSet(t, bc, bc.contracts.Oracle.Hash, "Price",
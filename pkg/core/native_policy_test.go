@@ -1,13 +1,17 @@
 package core
 
 import (
+	"errors"
+	"math"
 	"math/big"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/random"
 	"github.com/nspcc-dev/neo-go/internal/testchain"
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/native"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
@@ -111,6 +115,75 @@ func TestStoragePrice(t *testing.T) {
 	testGetSet(t, chain, chain.contracts.Policy.Hash, "StoragePrice", native.DefaultStoragePrice, 1, 10000000)
 }
 
+func newTestChainWithBlockLimits(t *testing.T) *Blockchain {
+	return newTestChainWithCustomCfg(t, func(c *config.Config) {
+		c.ProtocolConfiguration.PolicyBlockLimits = true
+	})
+}
+
+func TestMaxBlockSize(t *testing.T) {
+	chain := newTestChainWithBlockLimits(t)
+
+	t.Run("get, internal method", func(t *testing.T) {
+		n := chain.contracts.Policy.GetMaxBlockSizeInternal(chain.dao)
+		require.Equal(t, chain.GetConfig().MaxBlockSize, n)
+	})
+
+	testGetSet(t, chain, chain.contracts.Policy.Hash, "MaxBlockSize", int64(chain.GetConfig().MaxBlockSize), 1, 0x02000000)
+	require.Equal(t, chain.GetConfig().MaxBlockSize+1, chain.GetMaxBlockSize())
+}
+
+func TestMaxBlockSystemFee(t *testing.T) {
+	chain := newTestChainWithBlockLimits(t)
+
+	t.Run("get, internal method", func(t *testing.T) {
+		n := chain.contracts.Policy.GetMaxBlockSystemFeeInternal(chain.dao)
+		require.Equal(t, chain.GetConfig().MaxBlockSystemFee, n)
+	})
+
+	testGetSet(t, chain, chain.contracts.Policy.Hash, "MaxBlockSystemFee", chain.GetConfig().MaxBlockSystemFee, 1, math.MaxInt64)
+	require.Equal(t, chain.GetConfig().MaxBlockSystemFee+1, chain.GetMaxBlockSystemFee())
+}
+
+func TestMaxTransactionsPerBlock(t *testing.T) {
+	chain := newTestChainWithBlockLimits(t)
+
+	t.Run("get, internal method", func(t *testing.T) {
+		n := chain.contracts.Policy.GetMaxTransactionsPerBlockInternal(chain.dao)
+		require.Equal(t, chain.GetConfig().MaxTransactionsPerBlock, n)
+	})
+
+	testGetSet(t, chain, chain.contracts.Policy.Hash, "MaxTransactionsPerBlock", int64(chain.GetConfig().MaxTransactionsPerBlock), 1, 65535)
+	require.Equal(t, chain.GetConfig().MaxTransactionsPerBlock+1, chain.GetMaxTransactionsPerBlock())
+
+	t.Run("block verification", func(t *testing.T) {
+		policyHash := chain.contracts.Policy.Hash
+		res, err := invokeContractMethodGeneric(chain, 100000000, policyHash, "setMaxTransactionsPerBlock", true, 1)
+		require.NoError(t, err)
+		checkResult(t, res, stackitem.Null{})
+		require.Equal(t, uint16(1), chain.GetMaxTransactionsPerBlock())
+
+		tx1, err := prepareContractMethodInvoke(chain, 100000000, policyHash, "getMaxTransactionsPerBlock")
+		require.NoError(t, err)
+		tx2, err := prepareContractMethodInvoke(chain, 100000000, policyHash, "getMaxTransactionsPerBlock")
+		require.NoError(t, err)
+		require.Equal(t, 1, len(chain.ApplyPolicyToTxSet([]*transaction.Transaction{tx1, tx2})))
+
+		err = chain.AddBlock(chain.newBlock(tx1, tx2))
+		require.True(t, errors.Is(err, ErrBlockLimitExceeded))
+	})
+}
+
+func TestBlockLimitsDisabled(t *testing.T) {
+	chain := newTestChain(t)
+
+	_, ok := chain.contracts.Policy.GetMethod("getMaxBlockSize", 0)
+	require.False(t, ok)
+	require.Equal(t, chain.GetConfig().MaxBlockSize, chain.GetMaxBlockSize())
+	require.Equal(t, chain.GetConfig().MaxBlockSystemFee, chain.GetMaxBlockSystemFee())
+	require.Equal(t, chain.GetConfig().MaxTransactionsPerBlock, chain.GetMaxTransactionsPerBlock())
+}
+
 func TestBlockedAccounts(t *testing.T) {
 	chain := newTestChain(t)
 	account := util.Uint160{1, 2, 3}
@@ -175,6 +248,29 @@ func TestBlockedAccounts(t *testing.T) {
 		require.NoError(t, chain.persist())
 	})
 
+	t.Run("block-unblock contract", func(t *testing.T) {
+		cs, _ := getTestContractState(chain)
+		require.NoError(t, chain.contracts.Management.PutContractState(chain.dao, cs))
+
+		res, err := invokeContractMethodGeneric(chain, 100000000, policyHash, "blockAccount", true, cs.Hash.BytesBE())
+		require.NoError(t, err)
+		checkResult(t, res, stackitem.NewBool(true))
+		require.NoError(t, chain.persist())
+
+		res, err = invokeContractMethod(chain, 100000000, cs.Hash, "justReturn")
+		require.NoError(t, err)
+		checkFAULTState(t, res)
+
+		res, err = invokeContractMethodGeneric(chain, 100000000, policyHash, "unblockAccount", true, cs.Hash.BytesBE())
+		require.NoError(t, err)
+		checkResult(t, res, stackitem.NewBool(true))
+		require.NoError(t, chain.persist())
+
+		res, err = invokeContractMethod(chain, 100000000, cs.Hash, "justReturn")
+		require.NoError(t, err)
+		checkResult(t, res, stackitem.Null{})
+	})
+
 	t.Run("not signed by committee", func(t *testing.T) {
 		signer, err := wallet.NewAccount()
 		require.NoError(t, err)
@@ -0,0 +1,310 @@
+package core
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/stateroot"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+)
+
+// snapshotFlushInterval is the number of storage items after which restored
+// MPT nodes are flushed to the underlying store.
+const snapshotFlushInterval = 10000
+
+// snapshotPrefixes are the storage prefixes included into the state snapshot.
+var snapshotPrefixes = []storage.KeyPrefix{storage.STContractID, storage.STStorage}
+
+// CreateStateSnapshot writes state snapshot of the current chain height into
+// w. Snapshot contains all block headers, the current block, state root for
+// the current height and all contract storage items, it can then be applied
+// to an empty database with ApplyStateSnapshot. Notice that state root is only
+// signed by state validators when it was received from the network, so
+// snapshots with unsigned roots can't be verified on restoration.
+func (bc *Blockchain) CreateStateSnapshot(w *io.BinWriter) error {
+	height := bc.BlockHeight()
+	sr, err := bc.stateRoot.GetStateRoot(height)
+	if err != nil {
+		return fmt.Errorf("can't get state root for height %d: %w", height, err)
+	}
+	b, err := bc.GetBlock(bc.GetHeaderHash(int(height)))
+	if err != nil {
+		return fmt.Errorf("can't get block %d: %w", height, err)
+	}
+
+	w.WriteU32LE(uint32(bc.config.Magic))
+	w.WriteString(version)
+	w.WriteU32LE(height)
+	for i := uint32(0); i < height; i++ {
+		h, err := bc.GetHeader(bc.GetHeaderHash(int(i)))
+		if err != nil {
+			return fmt.Errorf("can't get header %d: %w", i, err)
+		}
+		h.EncodeBinary(w)
+	}
+	b.EncodeBinary(w)
+	sr.EncodeBinary(w)
+	for _, p := range snapshotPrefixes {
+		bc.dao.Store.Seek(p.Bytes(), func(k, v []byte) {
+			w.WriteVarBytes(k)
+			w.WriteVarBytes(v)
+		})
+	}
+	w.WriteVarBytes(nil)
+	return w.Err
+}
+
+// ApplyStateSnapshot restores state snapshot created by CreateStateSnapshot
+// into the given empty store, so that Blockchain created over this store can
+// continue synchronization from the snapshot height. Header chain is checked
+// to start from the genesis block of the given configuration, MPT root of the
+// restored storage is checked to match snapshot state root. Header witnesses
+// are verified, so every header is signed by the validators the previous one
+// commits to. If stateValidators are given, state root is also checked to be
+// signed by the majority of them; they must come from a trusted source, since
+// the designation stored in the snapshot can't vouch for the snapshot itself.
+// Blocks, transactions and logs below snapshot height are not restored. It
+// returns snapshot height.
+func ApplyStateSnapshot(s storage.Store, cfg config.ProtocolConfiguration, r *io.BinReader, stateValidators keys.PublicKeys) (uint32, error) {
+	if _, err := s.Get(storage.SYSVersion.Bytes()); err == nil {
+		return 0, errors.New("storage is not empty")
+	}
+	magic := r.ReadU32LE()
+	ver := r.ReadString()
+	height := r.ReadU32LE()
+	if r.Err != nil {
+		return 0, r.Err
+	}
+	if magic != uint32(cfg.Magic) {
+		return 0, fmt.Errorf("network magic mismatch: expected %d, got %d", cfg.Magic, magic)
+	}
+	if ver != version {
+		return 0, fmt.Errorf("storage version mismatch between %s and %s", version, ver)
+	}
+
+	genesis, err := createGenesisBlock(cfg)
+	if err != nil {
+		return 0, err
+	}
+	var (
+		d      = dao.NewSimple(s, cfg.Magic, cfg.StateRootInHeader)
+		mem    = d.Store
+		buf    = io.NewBufBinWriter()
+		hashes = make([]util.Uint256, 0, height+1)
+		prev   *block.Header
+	)
+	checkHeader := func(h *block.Header) error {
+		if prev == nil {
+			if !h.Hash().Equals(genesis.Hash()) {
+				return errors.New("genesis block mismatch")
+			}
+		} else if h.Index != prev.Index+1 || !h.PrevHash.Equals(prev.Hash()) {
+			return fmt.Errorf("header %d doesn't follow the previous one", h.Index)
+		} else if !hash.Hash160(h.Script.VerificationScript).Equals(prev.NextConsensus) {
+			return fmt.Errorf("header %d is not signed by next consensus nodes", h.Index)
+		} else if err := verifyStandardWitness(&h.Script, h.GetSignedHash().BytesBE()); err != nil {
+			return fmt.Errorf("header %d: %w", h.Index, err)
+		}
+		prev = h
+		hashes = append(hashes, h.Hash())
+		return nil
+	}
+	for i := uint32(0); i < height; i++ {
+		h := &block.Header{Network: cfg.Magic, StateRootEnabled: cfg.StateRootInHeader}
+		h.DecodeBinary(r)
+		if r.Err != nil {
+			return 0, r.Err
+		}
+		if err := checkHeader(h); err != nil {
+			return 0, err
+		}
+		h.EncodeBinary(buf.BinWriter)
+		buf.BinWriter.WriteB(0)
+		if buf.Err != nil {
+			return 0, buf.Err
+		}
+		if err := mem.Put(storage.AppendPrefix(storage.DataBlock, h.Hash().BytesBE()), buf.Bytes()); err != nil {
+			return 0, err
+		}
+		buf.Reset()
+	}
+	b := block.New(cfg.Magic, cfg.StateRootInHeader)
+	b.DecodeBinary(r)
+	if r.Err != nil {
+		return 0, r.Err
+	}
+	if err := checkHeader(&b.Header); err != nil {
+		return 0, err
+	}
+	if !b.MerkleRoot.Equals(b.ComputeMerkleRoot()) {
+		return 0, errors.New("invalid block: MerkleRoot mismatch")
+	}
+	sr := new(state.MPTRoot)
+	sr.DecodeBinary(r)
+	if r.Err != nil {
+		return 0, r.Err
+	}
+	if sr.Index != height {
+		return 0, fmt.Errorf("state root index mismatch: expected %d, got %d", height, sr.Index)
+	}
+
+	tr := mpt.NewTrie(nil, cfg.KeepOnlyLatestState, mem)
+	for i := 1; ; i++ {
+		k := r.ReadVarBytes()
+		if r.Err != nil {
+			return 0, r.Err
+		}
+		if len(k) == 0 {
+			break
+		}
+		v := r.ReadVarBytes()
+		if r.Err != nil {
+			return 0, r.Err
+		}
+		switch storage.KeyPrefix(k[0]) {
+		case storage.STStorage:
+			if err := tr.Put(k[1:], v); err != nil {
+				return 0, fmt.Errorf("can't put storage item into MPT: %w", err)
+			}
+		case storage.STContractID:
+		default:
+			return 0, fmt.Errorf("unexpected storage key prefix %d", k[0])
+		}
+		if err := mem.Put(k, v); err != nil {
+			return 0, err
+		}
+		if i%snapshotFlushInterval == 0 {
+			tr.Flush()
+			tr.Collapse(10)
+			if _, err := mem.Persist(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	tr.Flush()
+	if root := tr.StateRoot(); !root.Equals(sr.Root) {
+		return 0, fmt.Errorf("state root mismatch: expected %s, got %s", sr.Root.StringLE(), root.StringLE())
+	}
+	if len(stateValidators) != 0 {
+		if err := verifySnapshotRoot(sr, stateValidators); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := d.PutVersion(version); err != nil {
+		return 0, err
+	}
+	if err := d.StoreAsBlock(b, nil); err != nil {
+		return 0, err
+	}
+	for _, tx := range b.Transactions {
+		if err := d.StoreAsTransaction(tx, b.Index, nil); err != nil {
+			return 0, err
+		}
+	}
+	if err := d.StoreAsCurrentBlock(b, nil); err != nil {
+		return 0, err
+	}
+	for stored := 0; int(height)-headerBatchCount >= stored; stored += headerBatchCount {
+		buf.Reset()
+		buf.WriteArray(hashes[stored : stored+headerBatchCount])
+		if buf.Err != nil {
+			return 0, buf.Err
+		}
+		if err := mem.Put(storage.AppendPrefixInt(storage.IXHeaderHashList, stored), buf.Bytes()); err != nil {
+			return 0, err
+		}
+	}
+	if err := d.PutCurrentHeader(hashAndIndexToBytes(b.Hash(), b.Index)); err != nil {
+		return 0, err
+	}
+	if err := stateroot.PutSnapshotRoot(mem, sr, cfg.KeepOnlyLatestState); err != nil {
+		return 0, err
+	}
+	if _, err := mem.Persist(); err != nil {
+		return 0, err
+	}
+	return height, nil
+}
+
+// verifySnapshotRoot checks that state root is signed by the majority of the
+// given state validators.
+func verifySnapshotRoot(sr *state.MPTRoot, pubs keys.PublicKeys) error {
+	if sr.Witness == nil {
+		return errors.New("state root is not signed")
+	}
+	script, err := smartcontract.CreateDefaultMultiSigRedeemScript(pubs)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(script, sr.Witness.VerificationScript) {
+		return errors.New("state root is not signed by the given state validators")
+	}
+	if err := verifyStandardWitness(sr.Witness, sr.GetSignedHash().BytesBE()); err != nil {
+		return fmt.Errorf("state root: %w", err)
+	}
+	return nil
+}
+
+// verifyStandardWitness checks signatures of the witness with the standard
+// signature or multisignature verification script against the given hash
+// without running the VM.
+func verifyStandardWitness(w *transaction.Witness, h []byte) error {
+	m, pubBytes, ok := vm.ParseMultiSigContract(w.VerificationScript)
+	if !ok {
+		pub, ok := vm.ParseSignatureContract(w.VerificationScript)
+		if !ok {
+			return errors.New("unsupported verification script")
+		}
+		m, pubBytes = 1, [][]byte{pub}
+	}
+
+	var (
+		inv  = w.InvocationScript
+		sigs = make([][]byte, 0, m)
+	)
+	for len(inv) > 0 {
+		if len(inv) < 2+keys.SignatureLen || inv[0] != byte(opcode.PUSHDATA1) || inv[1] != keys.SignatureLen {
+			return errors.New("invalid invocation script")
+		}
+		sigs = append(sigs, inv[2:2+keys.SignatureLen])
+		inv = inv[2+keys.SignatureLen:]
+	}
+	if len(sigs) != m {
+		return fmt.Errorf("expected %d signatures, got %d", m, len(sigs))
+	}
+	// Signatures are to be ordered the same way keys are, just like for
+	// CHECKMULTISIG.
+	var k int
+	for _, sig := range sigs {
+		for ; k < len(pubBytes); k++ {
+			pub, err := keys.NewPublicKeyFromBytes(pubBytes[k], elliptic.P256())
+			if err != nil {
+				return err
+			}
+			if pub.Verify(sig, h) {
+				break
+			}
+		}
+		if k == len(pubBytes) {
+			return errors.New("invalid signature")
+		}
+		k++
+	}
+	return nil
+}
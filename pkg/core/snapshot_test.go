@@ -0,0 +1,112 @@
+package core
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateSnapshot(t *testing.T) {
+	bc := newTestChain(t)
+
+	h, pubs, accs := newMajorityMultisigWithGAS(t, 2)
+	bc.setNodesByRole(t, true, noderoles.StateValidator, pubs)
+	transferTokenFromMultisigAccount(t, bc, h, bc.contracts.GAS.Hash, 1_0000_0000)
+
+	createSnapshot := func(t *testing.T) []byte {
+		w := io.NewBufBinWriter()
+		require.NoError(t, bc.CreateStateSnapshot(w.BinWriter))
+		return w.Bytes()
+	}
+	applySnapshot := func(st storage.Store, data []byte, validators keys.PublicKeys) (uint32, error) {
+		return ApplyStateSnapshot(st, bc.config, io.NewBinReaderFromBuf(data), validators)
+	}
+
+	unsigned := createSnapshot(t)
+	t.Run("unsigned root", func(t *testing.T) {
+		_, err := applySnapshot(storage.NewMemoryStore(), unsigned, pubs)
+		require.Error(t, err)
+	})
+	t.Run("unsigned root, no verification", func(t *testing.T) {
+		height, err := applySnapshot(storage.NewMemoryStore(), unsigned, nil)
+		require.NoError(t, err)
+		require.Equal(t, bc.BlockHeight(), height)
+	})
+	t.Run("magic mismatch", func(t *testing.T) {
+		cfg := bc.config
+		cfg.Magic++
+		_, err := ApplyStateSnapshot(storage.NewMemoryStore(), cfg, io.NewBinReaderFromBuf(unsigned), nil)
+		require.Error(t, err)
+	})
+	t.Run("corrupted", func(t *testing.T) {
+		data := make([]byte, len(unsigned))
+		copy(data, unsigned)
+		data[len(data)-2] ^= 0xFF
+		_, err := applySnapshot(storage.NewMemoryStore(), data, nil)
+		require.Error(t, err)
+	})
+	t.Run("tampered transaction", func(t *testing.T) {
+		b, err := bc.GetBlock(bc.CurrentBlockHash())
+		require.NoError(t, err)
+		require.NotEqual(t, 0, len(b.Transactions))
+		script := b.Transactions[0].Script
+		data := make([]byte, len(unsigned))
+		copy(data, unsigned)
+		i := bytes.Index(data, script)
+		require.True(t, i > 0)
+		data[i+len(script)-1] ^= 0xFF
+		_, err = applySnapshot(storage.NewMemoryStore(), data, nil)
+		require.Error(t, err)
+	})
+	t.Run("invalid header signature", func(t *testing.T) {
+		h, err := bc.GetHeader(bc.GetHeaderHash(1))
+		require.NoError(t, err)
+		data := make([]byte, len(unsigned))
+		copy(data, unsigned)
+		i := bytes.Index(data, h.Script.InvocationScript)
+		require.True(t, i > 0)
+		data[i+len(h.Script.InvocationScript)-1] ^= 0xFF
+		_, err = applySnapshot(storage.NewMemoryStore(), data, nil)
+		require.Error(t, err)
+	})
+
+	r, err := bc.GetStateModule().GetStateRoot(bc.BlockHeight())
+	require.NoError(t, err)
+	testSignStateRoot(t, r, pubs, accs...)
+	require.NoError(t, bc.GetStateModule().AddStateRoot(r))
+
+	t.Run("untrusted state validators", func(t *testing.T) {
+		priv, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+		_, err = applySnapshot(storage.NewMemoryStore(), createSnapshot(t), keys.PublicKeys{priv.PublicKey()})
+		require.Error(t, err)
+	})
+
+	st := memoryStore{storage.NewMemoryStore()}
+	height, err := applySnapshot(st, createSnapshot(t), pubs)
+	require.NoError(t, err)
+	require.Equal(t, bc.BlockHeight(), height)
+
+	t.Run("not empty", func(t *testing.T) {
+		_, err := applySnapshot(st, createSnapshot(t), pubs)
+		require.Error(t, err)
+	})
+
+	bc2 := newTestChainWithCustomCfgAndStore(t, st, nil)
+	require.Equal(t, bc.BlockHeight(), bc2.BlockHeight())
+	require.Equal(t, bc.HeaderHeight(), bc2.HeaderHeight())
+	require.Equal(t, bc.CurrentBlockHash(), bc2.CurrentBlockHash())
+	require.Equal(t, bc.GetStateModule().CurrentLocalStateRoot(), bc2.GetStateModule().CurrentLocalStateRoot())
+	require.Equal(t, height, bc2.GetStateModule().CurrentValidatedHeight())
+	require.Equal(t, bc.GetUtilityTokenBalance(h), bc2.GetUtilityTokenBalance(h))
+
+	b := bc.newBlock()
+	require.NoError(t, bc.AddBlock(b))
+	require.NoError(t, bc2.AddBlock(b))
+	require.Equal(t, bc.GetStateModule().CurrentLocalStateRoot(), bc2.GetStateModule().CurrentLocalStateRoot())
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -35,6 +36,17 @@ type NativeContract struct {
 	UpdateHistory []uint32 `json:"updatehistory"`
 }
 
+// NativeMethod holds information about native contract method price (in fee
+// units that are to be multiplied by the current execution fee factor and
+// storage price) and call flags required to invoke it.
+type NativeMethod struct {
+	Name          string            `json:"name"`
+	ParamCount    int               `json:"paramcount"`
+	CPUFee        int64             `json:"cpufee"`
+	StorageFee    int64             `json:"storagefee"`
+	RequiredFlags callflag.CallFlag `json:"requiredflags"`
+}
+
 // DecodeBinary implements Serializable interface.
 func (c *Contract) DecodeBinary(r *io.BinReader) {
 	si := stackitem.DecodeBinaryStackItem(r)
@@ -0,0 +1,172 @@
+package state
+
+import (
+	"math/big"
+
+	"github.com/nspcc-dev/neo-go/pkg/io"
+)
+
+// NEP11TransferBatchSize is the maximum number of entries for NEP11TransferLog.
+const NEP11TransferBatchSize = 128
+
+// NEP11Transfer represents a single NEP11 Transfer event.
+type NEP11Transfer struct {
+	NEP17Transfer
+	// ID is the ID of the token transferred.
+	ID []byte
+}
+
+// NEP11TransferLog is a log of NEP11 token transfers for the specific account.
+type NEP11TransferLog struct {
+	Raw []byte
+}
+
+// NEP11TransferInfo stores the state of account's NEP11 transfer log.
+type NEP11TransferInfo struct {
+	// NextTransferBatch stores an index of the next transfer batch.
+	NextTransferBatch uint32
+	// NewBatch is true if batch with the `NextTransferBatch` index should be created.
+	NewBatch bool
+}
+
+// NEP11Balances is a map of the NEP11 contract IDs to the balances of tokens
+// (indexed by token ID) owned by the account.
+type NEP11Balances struct {
+	Trackers map[int32]map[string]NEP17Tracker
+}
+
+// NewNEP11Balances returns new NEP11Balances.
+func NewNEP11Balances() *NEP11Balances {
+	return &NEP11Balances{
+		Trackers: make(map[int32]map[string]NEP17Tracker),
+	}
+}
+
+// Add adds amount (which can be negative) to the balance of the token,
+// tokens with zero balance are removed.
+func (bs *NEP11Balances) Add(asset int32, id []byte, amount *big.Int, block uint32) {
+	tokens := bs.Trackers[asset]
+	if tokens == nil {
+		tokens = make(map[string]NEP17Tracker)
+		bs.Trackers[asset] = tokens
+	}
+	tr := tokens[string(id)]
+	tr.Balance = *new(big.Int).Add(&tr.Balance, amount)
+	tr.LastUpdatedBlock = block
+	if tr.Balance.Sign() == 0 {
+		delete(tokens, string(id))
+		if len(tokens) == 0 {
+			delete(bs.Trackers, asset)
+		}
+		return
+	}
+	tokens[string(id)] = tr
+}
+
+// DecodeBinary implements io.Serializable interface.
+func (bs *NEP11Balances) DecodeBinary(r *io.BinReader) {
+	lenBalances := r.ReadVarUint()
+	m := make(map[int32]map[string]NEP17Tracker, lenBalances)
+	for i := 0; i < int(lenBalances); i++ {
+		key := int32(r.ReadU32LE())
+		lenTokens := r.ReadVarUint()
+		tokens := make(map[string]NEP17Tracker, lenTokens)
+		for j := 0; j < int(lenTokens); j++ {
+			id := r.ReadVarBytes()
+			var tr NEP17Tracker
+			tr.DecodeBinary(r)
+			tokens[string(id)] = tr
+		}
+		m[key] = tokens
+	}
+	bs.Trackers = m
+}
+
+// EncodeBinary implements io.Serializable interface.
+func (bs *NEP11Balances) EncodeBinary(w *io.BinWriter) {
+	w.WriteVarUint(uint64(len(bs.Trackers)))
+	for k, tokens := range bs.Trackers {
+		w.WriteU32LE(uint32(k))
+		w.WriteVarUint(uint64(len(tokens)))
+		for id, tr := range tokens {
+			w.WriteVarBytes([]byte(id))
+			tr.EncodeBinary(w)
+		}
+	}
+}
+
+// DecodeBinary implements io.Serializable interface.
+func (i *NEP11TransferInfo) DecodeBinary(r *io.BinReader) {
+	i.NextTransferBatch = r.ReadU32LE()
+	i.NewBatch = r.ReadBool()
+}
+
+// EncodeBinary implements io.Serializable interface.
+func (i *NEP11TransferInfo) EncodeBinary(w *io.BinWriter) {
+	w.WriteU32LE(i.NextTransferBatch)
+	w.WriteBool(i.NewBatch)
+}
+
+// Append appends single transfer to a log.
+func (lg *NEP11TransferLog) Append(tr *NEP11Transfer) error {
+	w := io.NewBufBinWriter()
+	// The first entry, set up counter.
+	if len(lg.Raw) == 0 {
+		w.WriteB(1)
+	}
+	tr.EncodeBinary(w.BinWriter)
+	if w.Err != nil {
+		return w.Err
+	}
+	if len(lg.Raw) != 0 {
+		lg.Raw[0]++
+	}
+	lg.Raw = append(lg.Raw, w.Bytes()...)
+	return nil
+}
+
+// ForEach iterates over transfer log from the newest transfer to the oldest
+// one returning on first error.
+func (lg *NEP11TransferLog) ForEach(f func(*NEP11Transfer) (bool, error)) (bool, error) {
+	if lg == nil || len(lg.Raw) == 0 {
+		return true, nil
+	}
+	transfers := make([]NEP11Transfer, lg.Size())
+	r := io.NewBinReaderFromBuf(lg.Raw[1:])
+	for i := 0; i < lg.Size(); i++ {
+		transfers[i].DecodeBinary(r)
+	}
+	if r.Err != nil {
+		return false, r.Err
+	}
+	for i := len(transfers) - 1; i >= 0; i-- {
+		cont, err := f(&transfers[i])
+		if err != nil {
+			return false, err
+		}
+		if !cont {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Size returns an amount of transfer written in log.
+func (lg *NEP11TransferLog) Size() int {
+	if len(lg.Raw) == 0 {
+		return 0
+	}
+	return int(lg.Raw[0])
+}
+
+// EncodeBinary implements io.Serializable interface.
+func (t *NEP11Transfer) EncodeBinary(w *io.BinWriter) {
+	t.NEP17Transfer.EncodeBinary(w)
+	w.WriteVarBytes(t.ID)
+}
+
+// DecodeBinary implements io.Serializable interface.
+func (t *NEP11Transfer) DecodeBinary(r *io.BinReader) {
+	t.NEP17Transfer.DecodeBinary(r)
+	t.ID = r.ReadVarBytes()
+}
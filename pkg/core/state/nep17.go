@@ -48,6 +48,10 @@ type NEP17Transfer struct {
 // to the corresponding structures.
 type NEP17Balances struct {
 	Trackers map[int32]NEP17Tracker
+}
+
+// NEP17TransferInfo stores the state of account's NEP17 transfer log.
+type NEP17TransferInfo struct {
 	// NextTransferBatch stores an index of the next transfer batch.
 	NextTransferBatch uint32
 	// NewBatch is true if batch with the `NextTransferBatch` index should be created.
@@ -63,8 +67,6 @@ func NewNEP17Balances() *NEP17Balances {
 
 // DecodeBinary implements io.Serializable interface.
 func (bs *NEP17Balances) DecodeBinary(r *io.BinReader) {
-	bs.NextTransferBatch = r.ReadU32LE()
-	bs.NewBatch = r.ReadBool()
 	lenBalances := r.ReadVarUint()
 	m := make(map[int32]NEP17Tracker, lenBalances)
 	for i := 0; i < int(lenBalances); i++ {
@@ -78,8 +80,6 @@ func (bs *NEP17Balances) DecodeBinary(r *io.BinReader) {
 
 // EncodeBinary implements io.Serializable interface.
 func (bs *NEP17Balances) EncodeBinary(w *io.BinWriter) {
-	w.WriteU32LE(bs.NextTransferBatch)
-	w.WriteBool(bs.NewBatch)
 	w.WriteVarUint(uint64(len(bs.Trackers)))
 	for k, v := range bs.Trackers {
 		w.WriteU32LE(uint32(k))
@@ -87,6 +87,18 @@ func (bs *NEP17Balances) EncodeBinary(w *io.BinWriter) {
 	}
 }
 
+// DecodeBinary implements io.Serializable interface.
+func (i *NEP17TransferInfo) DecodeBinary(r *io.BinReader) {
+	i.NextTransferBatch = r.ReadU32LE()
+	i.NewBatch = r.ReadBool()
+}
+
+// EncodeBinary implements io.Serializable interface.
+func (i *NEP17TransferInfo) EncodeBinary(w *io.BinWriter) {
+	w.WriteU32LE(i.NextTransferBatch)
+	w.WriteBool(i.NewBatch)
+}
+
 // Append appends single transfer to a log.
 func (lg *NEP17TransferLog) Append(tr *NEP17Transfer) error {
 	w := io.NewBufBinWriter()
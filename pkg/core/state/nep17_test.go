@@ -47,6 +47,15 @@ func TestNEP17Tracker_EncodeBinary(t *testing.T) {
 	testserdes.EncodeDecodeBinary(t, expected, new(NEP17Tracker))
 }
 
+func TestNEP17TransferInfo_EncodeBinary(t *testing.T) {
+	expected := &NEP17TransferInfo{
+		NextTransferBatch: rand.Uint32(),
+		NewBatch:          true,
+	}
+
+	testserdes.EncodeDecodeBinary(t, expected, new(NEP17TransferInfo))
+}
+
 func TestNEP17Transfer_DecodeBinary(t *testing.T) {
 	expected := &NEP17Transfer{
 		Asset:     123,
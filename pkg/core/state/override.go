@@ -0,0 +1,26 @@
+package state
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// Override contains temporary state changes that can be applied to the chain
+// state for test invocations, they're never persisted.
+type Override struct {
+	Balances []BalanceOverride `json:"balances,omitempty"`
+	Storage  []StorageOverride `json:"storage,omitempty"`
+}
+
+// BalanceOverride sets account balance of the native NEO or GAS token.
+type BalanceOverride struct {
+	Account util.Uint160 `json:"account"`
+	Asset   util.Uint160 `json:"asset"`
+	Amount  int64        `json:"amount,string"`
+}
+
+// StorageOverride sets (or deletes if Value is nil) contract storage item.
+type StorageOverride struct {
+	Contract util.Uint160 `json:"contract"`
+	Key      []byte       `json:"key"`
+	Value    []byte       `json:"value"`
+}
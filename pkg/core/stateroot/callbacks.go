@@ -1,6 +1,7 @@
 package stateroot
 
 import (
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 )
 
@@ -10,3 +11,37 @@ func (s *Module) SetUpdateValidatorsCallback(f func(uint32, keys.PublicKeys)) {
 	defer s.mtx.Unlock()
 	s.updateValidatorsCb = f
 }
+
+// SubscribeForStateRoots adds given channel to validated state roots
+// broadcasting, every state root accepted by AddStateRoot is sent to it. Make
+// sure it's read from regularly as not reading these events might affect
+// state root processing.
+func (s *Module) SubscribeForStateRoots(ch chan<- *state.MPTRoot) {
+	s.subsLock.Lock()
+	defer s.subsLock.Unlock()
+	s.subs[ch] = true
+}
+
+// UnsubscribeFromStateRoots unsubscribes given channel from state root
+// notifications, you can close it afterwards. Passing non-subscribed channel
+// is a no-op. Events being sent concurrently can still be delivered to this
+// channel, so it should be drained before closing.
+func (s *Module) UnsubscribeFromStateRoots(ch chan<- *state.MPTRoot) {
+	s.subsLock.Lock()
+	defer s.subsLock.Unlock()
+	delete(s.subs, ch)
+}
+
+// notifyStateRoot sends validated state root to all subscribers. Channels are
+// written to without the lock held to allow unsubscribing while blocked.
+func (s *Module) notifyStateRoot(sr *state.MPTRoot) {
+	s.subsLock.RLock()
+	subs := make([]chan<- *state.MPTRoot, 0, len(s.subs))
+	for ch := range s.subs {
+		subs = append(subs, ch)
+	}
+	s.subsLock.RUnlock()
+	for _, ch := range subs {
+		ch <- sr
+	}
+}
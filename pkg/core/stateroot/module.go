@@ -32,6 +32,9 @@ type (
 		keys []keyCache
 
 		updateValidatorsCb func(height uint32, publicKeys keys.PublicKeys)
+
+		subsLock sync.RWMutex
+		subs     map[chan<- *state.MPTRoot]bool
 	}
 
 	keyCache struct {
@@ -48,6 +51,7 @@ func NewModule(bc blockchainer.Blockchainer, log *zap.Logger, s *storage.MemCach
 		bc:    bc,
 		log:   log,
 		Store: s,
+		subs:  make(map[chan<- *state.MPTRoot]bool),
 	}
 }
 
@@ -57,6 +61,20 @@ func (s *Module) GetStateProof(root util.Uint256, key []byte) ([][]byte, error)
 	return tr.GetProof(key)
 }
 
+// GetState returns value of the key in the MPT with the specified root.
+func (s *Module) GetState(root util.Uint256, key []byte) ([]byte, error) {
+	tr := mpt.NewTrie(mpt.NewHashNode(root), false, storage.NewMemCachedStore(s.Store))
+	return tr.Get(key)
+}
+
+// FindStates returns at most max key-value pairs (all of them if max is not
+// positive) with keys having the specified prefix and greater than start (if
+// it's not nil) from the MPT with the specified root ordered by key.
+func (s *Module) FindStates(root util.Uint256, prefix, start []byte, max int) ([]mpt.KeyValue, error) {
+	tr := mpt.NewTrie(mpt.NewHashNode(root), false, storage.NewMemCachedStore(s.Store))
+	return tr.Find(prefix, start, max)
+}
+
 // GetStateRoot returns state root for a given height.
 func (s *Module) GetStateRoot(height uint32) (*state.MPTRoot, error) {
 	return s.getStateRoot(makeStateRootKey(height))
@@ -85,5 +85,34 @@ func (s *Module) AddStateRoot(sr *state.MPTRoot) error {
 	if !s.bc.GetConfig().StateRootInHeader {
 		updateStateHeightMetric(sr.Index)
 	}
+	s.notifyStateRoot(sr)
+	return nil
+}
+
+// PutSnapshotRoot stores state root restored from the state snapshot into the
+// given store making it both the latest local and (if it's signed) the latest
+// validated one, so that Module can be initialized at sr.Index.
+func PutSnapshotRoot(st storage.Store, sr *state.MPTRoot, enableRefCount bool) error {
+	var gc byte
+	if enableRefCount {
+		gc = 1
+	}
+	if err := st.Put([]byte{byte(storage.DataMPT), prefixGC}, []byte{gc}); err != nil {
+		return err
+	}
+	w := io.NewBufBinWriter()
+	sr.EncodeBinary(w.BinWriter)
+	if err := st.Put(makeStateRootKey(sr.Index), w.Bytes()); err != nil {
+		return err
+	}
+
+	data := make([]byte, 4)
+	binary.LittleEndian.PutUint32(data, sr.Index)
+	if err := st.Put([]byte{byte(storage.DataMPT), prefixLocal}, data); err != nil {
+		return err
+	}
+	if sr.Witness != nil {
+		return st.Put([]byte{byte(storage.DataMPT), prefixValidated}, data)
+	}
 	return nil
 }
@@ -123,6 +123,8 @@ func TestStateRoot(t *testing.T) {
 		require.EqualValues(t, 0, srv.CurrentValidatedHeight())
 	})
 
+	ch := make(chan *state.MPTRoot, 1)
+	srv.SubscribeForStateRoots(ch)
 	r, err = srv.GetStateRoot(updateIndex + 1)
 	require.NoError(t, err)
 	data := testSignStateRoot(t, r, pubs, accs...)
@@ -133,6 +135,17 @@ func TestStateRoot(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, r.Witness)
 	require.Equal(t, h, r.Witness.ScriptHash())
+
+	require.Equal(t, 1, len(ch))
+	sr := <-ch
+	require.Equal(t, r.Index, sr.Index)
+	require.Equal(t, r.Root, sr.Root)
+	require.NotNil(t, sr.Witness)
+
+	// Validated roots are not announced twice.
+	srv.UnsubscribeFromStateRoots(ch)
+	require.NoError(t, srv.OnPayload(&payload.Extensible{Data: data}))
+	require.Equal(t, 0, len(ch))
 }
 
 func TestStateRootInitNonZeroHeight(t *testing.T) {
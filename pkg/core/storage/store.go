@@ -8,19 +8,26 @@ import (
 
 // KeyPrefix constants.
 const (
-	DataBlock        KeyPrefix = 0x01
-	DataTransaction  KeyPrefix = 0x02
-	DataMPT          KeyPrefix = 0x03
-	STAccount        KeyPrefix = 0x40
-	STNotification   KeyPrefix = 0x4d
-	STContractID     KeyPrefix = 0x51
-	STStorage        KeyPrefix = 0x70
-	STNEP17Transfers KeyPrefix = 0x72
-	STNEP17Balances  KeyPrefix = 0x73
-	IXHeaderHashList KeyPrefix = 0x80
-	SYSCurrentBlock  KeyPrefix = 0xc0
-	SYSCurrentHeader KeyPrefix = 0xc1
-	SYSVersion       KeyPrefix = 0xf0
+	DataBlock            KeyPrefix = 0x01
+	DataTransaction      KeyPrefix = 0x02
+	DataMPT              KeyPrefix = 0x03
+	STAccount            KeyPrefix = 0x40
+	STNotification       KeyPrefix = 0x4d
+	STContractID         KeyPrefix = 0x51
+	STStorage            KeyPrefix = 0x70
+	STNEP17Transfers     KeyPrefix = 0x72
+	STNEP17Balances      KeyPrefix = 0x73
+	STNEP17TransferInfo  KeyPrefix = 0x74
+	STNEP11Balances      KeyPrefix = 0x75
+	STNEP11Transfers     KeyPrefix = 0x76
+	STNEP11TransferInfo  KeyPrefix = 0x77
+	IXHeaderHashList     KeyPrefix = 0x80
+	IXTransactionAttr    KeyPrefix = 0x81
+	SYSCurrentBlock      KeyPrefix = 0xc0
+	SYSCurrentHeader     KeyPrefix = 0xc1
+	SYSTransferLogHeight KeyPrefix = 0xc2
+	SYSWatchList         KeyPrefix = 0xc3
+	SYSVersion           KeyPrefix = 0xf0
 )
 
 // ErrKeyNotFound is an error returned by Store implementations
@@ -70,7 +77,7 @@ func AppendPrefix(k KeyPrefix, b []byte) []byte {
 }
 
 // AppendPrefixInt append int n to the given KeyPrefix.
-//AppendPrefixInt(SYSCurrentHeader, 10001)
+// AppendPrefixInt(SYSCurrentHeader, 10001)
 func AppendPrefixInt(k KeyPrefix, n int) []byte {
 	b := make([]byte, 4)
 	binary.LittleEndian.PutUint32(b, uint32(n))
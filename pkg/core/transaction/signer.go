@@ -8,8 +8,8 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/util"
 )
 
-// The maximum number of AllowedContracts or AllowedGroups
-const maxSubitems = 16
+// MaxSubitems is the maximum number of AllowedContracts or AllowedGroups.
+const MaxSubitems = 16
 
 // Signer implements a Transaction signer.
 type Signer struct {
@@ -44,9 +44,9 @@ func (c *Signer) DecodeBinary(br *io.BinReader) {
 		return
 	}
 	if c.Scopes&CustomContracts != 0 {
-		br.ReadArray(&c.AllowedContracts, maxSubitems)
+		br.ReadArray(&c.AllowedContracts, MaxSubitems)
 	}
 	if c.Scopes&CustomGroups != 0 {
-		br.ReadArray(&c.AllowedGroups, maxSubitems)
+		br.ReadArray(&c.AllowedGroups, MaxSubitems)
 	}
 }
@@ -109,7 +109,9 @@ func New(network netmode.Magic, script []byte, gas int64) *Transaction {
 	}
 }
 
-// Hash returns the hash of the transaction.
+// Hash returns the hash of the transaction. It's calculated once and then
+// cached, so if any of the hashable fields (everything except Scripts) are
+// changed after that, DirtyHash must be called to invalidate the cache.
 func (t *Transaction) Hash() util.Uint256 {
 	if t.hash.Equals(util.Uint256{}) {
 		if t.createHash() != nil {
@@ -129,6 +131,15 @@ func (t *Transaction) GetSignedHash() util.Uint256 {
 	return t.verificationHash
 }
 
+// DirtyHash invalidates cached transaction hashes and size, so that they're
+// recalculated on the next Hash, GetSignedHash or Size call. It must be used
+// after changing transaction fields of already hashed (or signed) transaction.
+func (t *Transaction) DirtyHash() {
+	t.hash = util.Uint256{}
+	t.verificationHash = util.Uint256{}
+	t.size = 0
+}
+
 // HasAttribute returns true iff t has an attribute of type typ.
 func (t *Transaction) HasAttribute(typ AttrType) bool {
 	for i := range t.Attributes {
@@ -308,7 +319,8 @@ func (t *Transaction) FeePerByte() int64 {
 	return t.NetworkFee / int64(t.Size())
 }
 
-// Size returns size of the serialized transaction.
+// Size returns size of the serialized transaction. It's cached the same way
+// hashes are, see DirtyHash.
 func (t *Transaction) Size() int {
 	if t.size == 0 {
 		t.size = io.GetVarSize(t)
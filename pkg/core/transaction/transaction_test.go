@@ -12,6 +12,7 @@ import (
 	"github.com/nspcc-dev/neo-go/internal/testserdes"
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
+	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/stretchr/testify/assert"
@@ -81,6 +82,26 @@ func TestNew(t *testing.T) {
 	testserdes.EncodeDecodeBinary(t, tx, &Transaction{Network: netmode.UnitTestNet})
 }
 
+func TestDirtyHash(t *testing.T) {
+	tx := New(netmode.UnitTestNet, []byte{byte(opcode.PUSH1)}, 1)
+	h, sh, size := tx.Hash(), tx.GetSignedHash(), tx.Size()
+
+	tx.Nonce++
+	tx.Signers = []Signer{{Account: util.Uint160{1, 2, 3}}}
+	require.Equal(t, h, tx.Hash())
+	require.Equal(t, sh, tx.GetSignedHash())
+	require.Equal(t, size, tx.Size())
+
+	tx.DirtyHash()
+	require.NotEqual(t, h, tx.Hash())
+	require.NotEqual(t, sh, tx.GetSignedHash())
+	require.Equal(t, io.GetVarSize(tx), tx.Size())
+
+	actual, err := NewTransactionFromBytes(netmode.UnitTestNet, tx.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, actual.Hash(), tx.Hash())
+}
+
 func TestNewTransactionFromBytes(t *testing.T) {
 	script := []byte{0x51}
 	tx := New(netmode.UnitTestNet, script, 1)
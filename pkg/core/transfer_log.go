@@ -0,0 +1,281 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"go.uber.org/zap"
+)
+
+// transferLogQueueSize is the number of blocks which transfers can be queued
+// for writing into transfer logs before block processing is stalled.
+const transferLogQueueSize = 64
+
+// transferLogBatch is a set of transfers from a single block to be written
+// into transfer logs.
+type transferLogBatch struct {
+	index          uint32
+	transfers      []state.NEP17Transfer
+	nep11Transfers []state.NEP11Transfer
+}
+
+// transferLogWriter writes transfer logs in the order blocks are stored. It
+// runs until transferLogCh is closed. If some batch can't be written, the
+// writer stops at the previous block (so that transfer log height is never
+// moved past a missing batch) and just drains the channel, transfer logs are
+// unavailable then until they're restored on node restart.
+func (bc *Blockchain) transferLogWriter() {
+	defer close(bc.transferLogDone)
+	for b := range bc.transferLogCh {
+		if bc.transferLogError() != nil {
+			continue
+		}
+		if err := bc.writeTransferLog(&b); err != nil {
+			bc.log.Error("failed to write transfer log, transfer logs are disabled until restart",
+				zap.Uint32("block", b.index),
+				zap.Error(err))
+			bc.transferLogErr.Store(fmt.Errorf("failed to write transfer log for block %d: %w", b.index, err))
+		}
+	}
+}
+
+// transferLogError returns the error transfer log writer has stopped with if
+// any.
+func (bc *Blockchain) transferLogError() error {
+	err, _ := bc.transferLogErr.Load().(error)
+	return err
+}
+
+// writeTransferLog appends the transfers given to the logs of tracked accounts
+// participating in them and moves transfer log height to the batch index,
+// all changes are committed at once.
+func (bc *Blockchain) writeTransferLog(b *transferLogBatch) error {
+	cache := dao.NewCached(bc.dao)
+	for i := range b.transfers {
+		tr := &b.transfers[i]
+		if !tr.From.Equals(util.Uint160{}) && bc.IsTracked(tr.From) {
+			sent := *tr
+			sent.Amount = *new(big.Int).Neg(&tr.Amount)
+			if err := appendNEP17Transfer(cache, tr.From, &sent); err != nil {
+				return err
+			}
+		}
+		if !tr.To.Equals(util.Uint160{}) && bc.IsTracked(tr.To) {
+			if err := appendNEP17Transfer(cache, tr.To, tr); err != nil {
+				return err
+			}
+		}
+	}
+	for i := range b.nep11Transfers {
+		tr := &b.nep11Transfers[i]
+		if !tr.From.Equals(util.Uint160{}) && bc.IsTracked(tr.From) {
+			sent := *tr
+			sent.Amount = *new(big.Int).Neg(&tr.Amount)
+			if err := appendNEP11Transfer(cache, tr.From, &sent); err != nil {
+				return err
+			}
+		}
+		if !tr.To.Equals(util.Uint160{}) && bc.IsTracked(tr.To) {
+			if err := appendNEP11Transfer(cache, tr.To, tr); err != nil {
+				return err
+			}
+		}
+	}
+	if err := cache.PutTransferLogHeight(b.index); err != nil {
+		return err
+	}
+	if _, err := cache.Persist(); err != nil {
+		return err
+	}
+	atomic.StoreUint32(&bc.transferLogHeight, b.index)
+	return nil
+}
+
+// appendNEP17Transfer appends a single transfer to the log of the account given.
+func appendNEP17Transfer(cache *dao.Cached, acc util.Uint160, tr *state.NEP17Transfer) error {
+	info, err := cache.GetNEP17TransferInfo(acc)
+	if err != nil {
+		return err
+	}
+	info.NewBatch, err = cache.AppendNEP17Transfer(acc, info.NextTransferBatch, info.NewBatch, tr)
+	if err != nil {
+		return err
+	}
+	if info.NewBatch {
+		info.NextTransferBatch++
+	}
+	return cache.PutNEP17TransferInfo(acc, info)
+}
+
+// appendNEP11Transfer appends a single NEP11 transfer to the log of the account
+// given.
+func appendNEP11Transfer(cache *dao.Cached, acc util.Uint160, tr *state.NEP11Transfer) error {
+	info, err := cache.GetNEP11TransferInfo(acc)
+	if err != nil {
+		return err
+	}
+	info.NewBatch, err = cache.AppendNEP11Transfer(acc, info.NextTransferBatch, info.NewBatch, tr)
+	if err != nil {
+		return err
+	}
+	if info.NewBatch {
+		info.NextTransferBatch++
+	}
+	return cache.PutNEP11TransferInfo(acc, info)
+}
+
+// catchUpTransferLog writes transfer logs for blocks up to the given height
+// that were stored, but not processed by the log writer before node shutdown.
+// Transfers are restored from application execution results.
+func (bc *Blockchain) catchUpTransferLog(height uint32) error {
+	var start uint32
+	logHeight, err := bc.dao.GetTransferLogHeight()
+	if err == nil {
+		start = logHeight + 1
+		bc.transferLogHeight = logHeight
+	} else if !errors.Is(err, storage.ErrKeyNotFound) {
+		return err
+	}
+	if start <= height {
+		bc.log.Info("restoring transfer log",
+			zap.Uint32("from", start),
+			zap.Uint32("to", height))
+	}
+	for i := start; i <= height; i++ {
+		b, err := bc.dao.GetBlock(bc.headerHashes[i])
+		if err != nil {
+			return fmt.Errorf("can't get block %d: %w", i, err)
+		}
+		transfers, err := bc.getBlockTransfers(b)
+		if err != nil {
+			return fmt.Errorf("can't get transfers for block %d: %w", i, err)
+		}
+		if err := bc.writeTransferLog(transfers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getBlockTransfers returns all NEP17 and NEP11 transfers from the given stored
+// block in the order they were made. Execution results that are not stored in
+// light wallet mode are skipped.
+func (bc *Blockchain) getBlockTransfers(b *block.Block) (*transferLogBatch, error) {
+	transfers := &transferLogBatch{index: b.Index}
+	collect := func(h util.Uint256, trig trigger.Type) error {
+		aers, err := bc.dao.GetAppExecResults(h, trig)
+		if err != nil {
+			if bc.watched != nil && errors.Is(err, storage.ErrKeyNotFound) {
+				return nil
+			}
+			return err
+		}
+		for i := range aers {
+			if aers[i].VMState != vm.HaltState {
+				continue
+			}
+			for j := range aers[i].Events {
+				if tr := bc.getNEP17Transfer(bc.dao, &aers[i].Events[j], b, h); tr != nil {
+					transfers.transfers = append(transfers.transfers, *tr)
+				} else if tr := bc.getNEP11Transfer(bc.dao, &aers[i].Events[j], b, h); tr != nil {
+					transfers.nep11Transfers = append(transfers.nep11Transfers, *tr)
+				}
+			}
+		}
+		return nil
+	}
+	if err := collect(b.Hash(), trigger.OnPersist); err != nil {
+		return nil, err
+	}
+	for _, tx := range b.Transactions {
+		if err := collect(tx.Hash(), trigger.Application); err != nil {
+			return nil, err
+		}
+	}
+	if err := collect(b.Hash(), trigger.PostPersist); err != nil {
+		return nil, err
+	}
+	return transfers, nil
+}
+
+// TransferLogHeight returns the index of the last block which transfers are
+// written into transfer logs. Transfer logs are written asynchronously, so it
+// can be lower than the current block height.
+func (bc *Blockchain) TransferLogHeight() uint32 {
+	return atomic.LoadUint32(&bc.transferLogHeight)
+}
+
+// ForEachNEP17Transfer executes f for each nep17 transfer in log. Only
+// transfers from blocks up to TransferLogHeight are iterated over, an error
+// is returned if transfer log writer has failed.
+func (bc *Blockchain) ForEachNEP17Transfer(acc util.Uint160, f func(*state.NEP17Transfer) (bool, error)) error {
+	if err := bc.transferLogError(); err != nil {
+		return err
+	}
+	height := bc.TransferLogHeight()
+	info, err := bc.dao.GetNEP17TransferInfo(acc)
+	if err != nil {
+		return nil
+	}
+	for i := int(info.NextTransferBatch); i >= 0; i-- {
+		lg, err := bc.dao.GetNEP17TransferLog(acc, uint32(i))
+		if err != nil {
+			return nil
+		}
+		cont, err := lg.ForEach(func(tr *state.NEP17Transfer) (bool, error) {
+			if tr.Block > height {
+				return true, nil
+			}
+			return f(tr)
+		})
+		if err != nil {
+			return err
+		}
+		if !cont {
+			break
+		}
+	}
+	return nil
+}
+
+// ForEachNEP11Transfer executes f for each nep11 transfer in log. Only
+// transfers from blocks up to TransferLogHeight are iterated over, an error
+// is returned if transfer log writer has failed.
+func (bc *Blockchain) ForEachNEP11Transfer(acc util.Uint160, f func(*state.NEP11Transfer) (bool, error)) error {
+	if err := bc.transferLogError(); err != nil {
+		return err
+	}
+	height := bc.TransferLogHeight()
+	info, err := bc.dao.GetNEP11TransferInfo(acc)
+	if err != nil {
+		return nil
+	}
+	for i := int(info.NextTransferBatch); i >= 0; i-- {
+		lg, err := bc.dao.GetNEP11TransferLog(acc, uint32(i))
+		if err != nil {
+			return nil
+		}
+		cont, err := lg.ForEach(func(tr *state.NEP11Transfer) (bool, error) {
+			if tr.Block > height {
+				return true, nil
+			}
+			return f(tr)
+		})
+		if err != nil {
+			return err
+		}
+		if !cont {
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/internal/testchain"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func getGenesisTransfers(t *testing.T, bc *Blockchain) []state.NEP17Transfer {
+	var res []state.NEP17Transfer
+	require.NoError(t, bc.ForEachNEP17Transfer(testchain.MultisigScriptHash(), func(tr *state.NEP17Transfer) (bool, error) {
+		if tr.Block == 0 {
+			res = append(res, *tr)
+		}
+		return true, nil
+	}))
+	return res
+}
+
+func TestTransferLog(t *testing.T) {
+	bc := newTestChain(t)
+	_, err := bc.genBlocks(3)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return bc.TransferLogHeight() == bc.BlockHeight() },
+		time.Second, 10*time.Millisecond)
+
+	transfers := getGenesisTransfers(t, bc)
+	require.Equal(t, 2, len(transfers)) // NEO and GAS
+	assets := map[int32]bool{bc.contracts.NEO.ID: true, bc.contracts.GAS.ID: true}
+	for _, tr := range transfers {
+		require.True(t, assets[tr.Asset])
+		require.Equal(t, testchain.MultisigScriptHash(), tr.To)
+		require.Equal(t, 1, tr.Amount.Sign())
+	}
+}
+
+func TestTransferLog_CatchUp(t *testing.T) {
+	st := storage.NewMemoryStore()
+	bc := initTestChain(t, st, nil)
+	// Log writer is not running, so genesis transfers remain queued.
+	require.NoError(t, bc.persist())
+	require.Equal(t, 0, len(getGenesisTransfers(t, bc)))
+
+	bc = initTestChain(t, st, nil)
+	height, err := bc.dao.GetTransferLogHeight()
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), height)
+	require.Equal(t, 2, len(getGenesisTransfers(t, bc)))
+}
+
+func TestTransferLog_WriterError(t *testing.T) {
+	bc := newTestChain(t)
+	require.Eventually(t, func() bool { return bc.TransferLogHeight() == bc.BlockHeight() },
+		time.Second, 10*time.Millisecond)
+
+	bc.transferLogErr.Store(errors.New("write failed"))
+	height := bc.TransferLogHeight()
+	_, err := bc.genBlocks(2)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return len(bc.transferLogCh) == 0 },
+		time.Second, 10*time.Millisecond)
+	require.Equal(t, height, bc.TransferLogHeight())
+	require.Error(t, bc.ForEachNEP17Transfer(testchain.MultisigScriptHash(), func(*state.NEP17Transfer) (bool, error) {
+		return true, nil
+	}))
+	require.Error(t, bc.ForEachNEP11Transfer(testchain.MultisigScriptHash(), func(*state.NEP11Transfer) (bool, error) {
+		return true, nil
+	}))
+}
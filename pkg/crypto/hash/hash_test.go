@@ -64,3 +64,24 @@ func TestChecksum(t *testing.T) {
 		require.Equal(t, tc.sum, binary.LittleEndian.Uint32(Checksum(tc.data)))
 	}
 }
+
+func TestMurmur32(t *testing.T) {
+	testCases := []struct {
+		data []byte
+		seed uint32
+		sum  uint32
+	}{
+		{nil, 0, 0},
+		{nil, 1, 0x514e28b7},
+		{nil, 0xffffffff, 0x81f16f39},
+		{[]byte{0, 0, 0, 0}, 0, 0x2362f9de},
+		{[]byte("abc"), 0, 0xb3dd93fa},
+		{[]byte("aaaa"), 0x9747b28c, 0x5a97808a},
+		{[]byte("Hello, world!"), 1234, 0xfaf6cdb3},
+		{[]byte("The quick brown fox jumps over the lazy dog"), 0, 0x2e4ff723},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.sum, Murmur32(tc.data, tc.seed))
+	}
+}
@@ -31,6 +31,12 @@ func Ripemd160(b []byte) interop.Hash160 {
 	return contract.Call(interop.Hash160(Hash), "ripemd160", contract.NoneFlag, b).(interop.Hash160)
 }
 
+// Murmur32 calls `murmur32` method of native CryptoLib contract and computes Murmur32 hash of b
+// using the given seed.
+func Murmur32(b []byte, seed int) []byte {
+	return contract.Call(interop.Hash160(Hash), "murmur32", contract.NoneFlag, b, seed).([]byte)
+}
+
 // VerifyWithECDsa calls `verifyWithECDsa` method of native CryptoLib contract and checks that sig is
 // correct msg's signature for a given pub (serialized public key on a given curve).
 func VerifyWithECDsa(msg []byte, pub interop.PublicKey, sig interop.Signature, curve NamedCurve) bool {
@@ -94,3 +94,15 @@ func Vote(addr interop.Hash160, pub interop.PublicKey) bool {
 func UnclaimedGAS(addr interop.Hash160, end int) int {
 	return contract.Call(interop.Hash160(Hash), "unclaimedGas", contract.ReadStates, addr, end).(int)
 }
+
+// UnclaimedVoterGAS represents `unclaimedVoterGas` method of NEO native contract.
+// It's only available if VoterRewardMethods protocol setting is enabled.
+func UnclaimedVoterGAS(addr interop.Hash160, end int) int {
+	return contract.Call(interop.Hash160(Hash), "unclaimedVoterGas", contract.ReadStates, addr, end).(int)
+}
+
+// GetGASPerVote represents `getGasPerVote` method of NEO native contract.
+// It's only available if VoterRewardMethods protocol setting is enabled.
+func GetGASPerVote(pub interop.PublicKey, end int) int {
+	return contract.Call(interop.Hash160(Hash), "getGasPerVote", contract.ReadStates, pub, end).(int)
+}
@@ -15,13 +15,13 @@ const Hash = "\x3b\xec\x35\x31\x11\x9b\xba\xd7\x6d\xd0\x44\x92\x0b\x0d\xe6\xc3\x
 
 // LockDepositUntil represents `lockDepositUntil` method of Notary native contract.
 func LockDepositUntil(addr interop.Hash160, till int) bool {
-	return contract.Call(interop.Hash160(Hash), "lockDepositUntil", contract.States,
+	return contract.Call(interop.Hash160(Hash), "lockDepositUntil", contract.States|contract.AllowNotify,
 		addr, till).(bool)
 }
 
 // Withdraw represents `withdraw` method of Notary native contract.
 func Withdraw(from, to interop.Hash160) bool {
-	return contract.Call(interop.Hash160(Hash), "withdraw", contract.States,
+	return contract.Call(interop.Hash160(Hash), "withdraw", contract.States|contract.AllowNotify,
 		from, to).(bool)
 }
 
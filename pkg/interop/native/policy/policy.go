@@ -56,3 +56,43 @@ func BlockAccount(addr interop.Hash160) bool {
 func UnblockAccount(addr interop.Hash160) bool {
 	return contract.Call(interop.Hash160(Hash), "unblockAccount", contract.States, addr).(bool)
 }
+
+// GetMaxBlockSize represents `getMaxBlockSize` method of Policy native contract.
+// It's only available if block limits are managed by Policy contract.
+func GetMaxBlockSize() int {
+	return contract.Call(interop.Hash160(Hash), "getMaxBlockSize", contract.ReadStates).(int)
+}
+
+// SetMaxBlockSize represents `setMaxBlockSize` method of Policy native contract.
+// It's only available if block limits are managed by Policy contract.
+func SetMaxBlockSize(value int) {
+	contract.Call(interop.Hash160(Hash), "setMaxBlockSize", contract.States, value)
+}
+
+// GetMaxTransactionsPerBlock represents `getMaxTransactionsPerBlock` method of
+// Policy native contract. It's only available if block limits are managed by
+// Policy contract.
+func GetMaxTransactionsPerBlock() int {
+	return contract.Call(interop.Hash160(Hash), "getMaxTransactionsPerBlock", contract.ReadStates).(int)
+}
+
+// SetMaxTransactionsPerBlock represents `setMaxTransactionsPerBlock` method of
+// Policy native contract. It's only available if block limits are managed by
+// Policy contract.
+func SetMaxTransactionsPerBlock(value int) {
+	contract.Call(interop.Hash160(Hash), "setMaxTransactionsPerBlock", contract.States, value)
+}
+
+// GetMaxBlockSystemFee represents `getMaxBlockSystemFee` method of Policy
+// native contract. It's only available if block limits are managed by Policy
+// contract.
+func GetMaxBlockSystemFee() int {
+	return contract.Call(interop.Hash160(Hash), "getMaxBlockSystemFee", contract.ReadStates).(int)
+}
+
+// SetMaxBlockSystemFee represents `setMaxBlockSystemFee` method of Policy
+// native contract. It's only available if block limits are managed by Policy
+// contract.
+func SetMaxBlockSystemFee(value int) {
+	contract.Call(interop.Hash160(Hash), "setMaxBlockSystemFee", contract.States, value)
+}
@@ -99,3 +99,32 @@ func Atoi(s string, base int) int {
 	return contract.Call(interop.Hash160(Hash), "atoi", contract.NoneFlag,
 		s, base).(int)
 }
+
+// MemoryCompare is similar to bytes.Compare:
+// The result will be 0 if a==b, -1 if a < b, and +1 if a > b.
+// It uses `memoryCompare` method of StdLib native contract.
+func MemoryCompare(s1, s2 []byte) int {
+	return contract.Call(interop.Hash160(Hash), "memoryCompare", contract.NoneFlag,
+		s1, s2).(int)
+}
+
+// MemorySearch returns index of the first occurrence of pattern in mem.
+// If not found, -1 is returned. It uses `memorySearch` method of StdLib native contract.
+func MemorySearch(mem, pattern []byte) int {
+	return contract.Call(interop.Hash160(Hash), "memorySearch", contract.NoneFlag,
+		mem, pattern).(int)
+}
+
+// MemorySearchIndex returns index of the first occurrence of pattern in mem starting from start.
+// If not found, -1 is returned. It uses `memorySearch` method of StdLib native contract.
+func MemorySearchIndex(mem, pattern []byte, start int) int {
+	return contract.Call(interop.Hash160(Hash), "memorySearch", contract.NoneFlag,
+		mem, pattern, start).(int)
+}
+
+// MemorySearchLastIndex returns index of the last occurrence of pattern in mem ending before start.
+// If not found, -1 is returned. It uses `memorySearch` method of StdLib native contract.
+func MemorySearchLastIndex(mem, pattern []byte, start int) int {
+	return contract.Call(interop.Hash160(Hash), "memorySearch", contract.NoneFlag,
+		mem, pattern, start, true).(int)
+}
@@ -73,6 +73,9 @@ type localPeer struct {
 	lastBlockIndex uint32
 	handshaked     bool
 	isFullNode     bool
+	inbound        bool
+	lastMsgTime    time.Time
+	traffic        Traffic
 	t              *testing.T
 	messageHandler func(t *testing.T, msg *Message)
 	pingSent       int
@@ -180,6 +183,18 @@ func (p *localPeer) IsFullNode() bool {
 	return p.isFullNode
 }
 
+func (p *localPeer) IsInbound() bool {
+	return p.inbound
+}
+
+func (p *localPeer) LastMessageTime() time.Time {
+	return p.lastMsgTime
+}
+
+func (p *localPeer) Traffic() Traffic {
+	return p.traffic
+}
+
 func (p *localPeer) AddGetAddrSent() {
 	p.getAddrSent++
 }
@@ -177,6 +177,12 @@ func (m *Message) decodePayload() error {
 	return r.Err
 }
 
+// size returns the size of the encoded message as it is transmitted over the
+// wire. It's only valid for encoded or decoded messages.
+func (m *Message) size() int {
+	return 2 + io.GetVarSize(len(m.compressedPayload)) + len(m.compressedPayload)
+}
+
 // Encode encodes a Message to any given BinWriter.
 func (m *Message) Encode(br *io.BinWriter) error {
 	if err := m.tryCompressPayload(); err != nil {
@@ -2,6 +2,7 @@ package network
 
 import (
 	"net"
+	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
 )
@@ -52,6 +53,15 @@ type Peer interface {
 	LastBlockIndex() uint32
 	Handshaked() bool
 	IsFullNode() bool
+	// IsInbound returns true if the connection was initiated by the remote
+	// node.
+	IsInbound() bool
+	// LastMessageTime returns the time the last message was received from
+	// the peer (zero if there were none).
+	LastMessageTime() time.Time
+	// Traffic returns the number of bytes sent to and received from the
+	// peer per message command.
+	Traffic() Traffic
 
 	// SendPing enqueues a ping message to be sent to the peer and does
 	// appropriate protocol handling like timeouts and outstanding pings
@@ -1,6 +1,8 @@
 package network
 
 import (
+	"strconv"
+
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -38,6 +40,24 @@ var (
 			Namespace: "neogo",
 		},
 	)
+
+	p2pBytesSent = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of bytes sent to peers",
+			Name:      "p2p_bytes_sent",
+			Namespace: "neogo",
+		},
+		[]string{"command", "compressed"},
+	)
+
+	p2pBytesReceived = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of bytes received from peers",
+			Name:      "p2p_bytes_received",
+			Namespace: "neogo",
+		},
+		[]string{"command", "compressed"},
+	)
 )
 
 func init() {
@@ -46,6 +66,8 @@ func init() {
 		servAndNodeVersion,
 		poolCount,
 		blockQueueLength,
+		p2pBytesSent,
+		p2pBytesReceived,
 	)
 }
 
@@ -57,6 +79,14 @@ func updatePoolCountMetric(pCount int) {
 	poolCount.Set(float64(pCount))
 }
 
+func addSentBytesMetric(cmd CommandType, compressed bool, n int) {
+	p2pBytesSent.WithLabelValues(commandLabel(cmd), strconv.FormatBool(compressed)).Add(float64(n))
+}
+
+func addReceivedBytesMetric(cmd CommandType, compressed bool, n int) {
+	p2pBytesReceived.WithLabelValues(commandLabel(cmd), strconv.FormatBool(compressed)).Add(float64(n))
+}
+
 func updatePeersConnectedMetric(pConnected int) {
 	peersConnected.Set(float64(pConnected))
 }
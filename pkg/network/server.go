@@ -347,6 +347,46 @@ func (s *Server) ConnectedPeers() []string {
 	return peers
 }
 
+// PeerInfo contains diagnostic information about connected peer.
+type PeerInfo struct {
+	// Address is the address that should be used to connect to the peer.
+	Address      string
+	UserAgent    string
+	Height       uint32
+	Capabilities capability.Capabilities
+	// Inbound is true if the connection was initiated by the peer.
+	Inbound bool
+	// LastMessage is the time of the last message received from the peer.
+	LastMessage time.Time
+	// Traffic is the number of bytes exchanged with the peer per command.
+	Traffic Traffic
+}
+
+// ConnectedPeersInfo returns detailed information about currently connected
+// peers. Version-related fields are only filled in for peers that have sent
+// their version already.
+func (s *Server) ConnectedPeersInfo() []PeerInfo {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	peers := make([]PeerInfo, 0, len(s.peers))
+	for p := range s.peers {
+		info := PeerInfo{
+			Address:     p.PeerAddr().String(),
+			Height:      p.LastBlockIndex(),
+			Inbound:     p.IsInbound(),
+			LastMessage: p.LastMessageTime(),
+			Traffic:     p.Traffic(),
+		}
+		if v := p.Version(); v != nil {
+			info.UserAgent = string(v.UserAgent)
+			info.Capabilities = v.Capabilities
+		}
+		peers = append(peers, info)
+	}
+	return peers
+}
+
 // run is a goroutine that starts another goroutine to manage protocol specifics
 // while itself dealing with peers management (handling connects/disconnects).
 func (s *Server) run() {
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"math/big"
 	"net"
+	"sort"
 	"strconv"
 	atomic2 "sync/atomic"
 	"testing"
@@ -182,6 +183,44 @@ func TestServerRegisterPeer(t *testing.T) {
 
 }
 
+func TestConnectedPeersInfo(t *testing.T) {
+	s := newTestServer(t, ServerConfig{})
+	p1 := newLocalPeer(t, s)
+	p1.netaddr.Port = 1
+	p1.version = payload.NewVersion(netmode.UnitTestNet, 42, "/test/", []capability.Capability{{
+		Type: capability.TCPServer,
+		Data: &capability.Server{Port: 1},
+	}})
+	p1.lastBlockIndex = 123
+	p1.inbound = true
+	p1.lastMsgTime = time.Unix(100500, 0)
+	p1.traffic = Traffic{
+		Sent:     map[CommandType]uint64{CMDVersion: 42},
+		Received: map[CommandType]uint64{CMDVersion: 43, CMDBlock: 1024},
+	}
+	p2 := newLocalPeer(t, s)
+	p2.netaddr.Port = 2
+
+	s.lock.Lock()
+	s.peers[p1] = true
+	s.peers[p2] = true
+	s.lock.Unlock()
+
+	infos := s.ConnectedPeersInfo()
+	require.Equal(t, 2, len(infos))
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Address < infos[j].Address })
+	require.Equal(t, PeerInfo{
+		Address:      p1.PeerAddr().String(),
+		UserAgent:    "/test/",
+		Height:       123,
+		Capabilities: p1.version.Capabilities,
+		Inbound:      true,
+		LastMessage:  p1.lastMsgTime,
+		Traffic:      p1.traffic,
+	}, infos[0])
+	require.Equal(t, PeerInfo{Address: p2.PeerAddr().String()}, infos[1])
+}
+
 func TestGetBlocksByIndex(t *testing.T) {
 	s := newTestServer(t, ServerConfig{Port: 0, UserAgent: "/test/"})
 	ps := make([]*localPeer, 10)
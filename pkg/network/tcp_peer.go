@@ -52,6 +52,13 @@ type TCPPeer struct {
 	finale     sync.Once
 	handShake  handShakeStage
 	isFullNode bool
+	// inbound is true if the connection was accepted by us.
+	inbound bool
+
+	// time of the last message received in nanoseconds since epoch.
+	lastMsgTime atomic.Int64
+	// traffic accounts for the bytes sent to and received from the peer.
+	traffic *trafficCounter
 
 	done     chan struct{}
 	sendQ    chan []byte
@@ -77,6 +84,7 @@ func NewTCPPeer(conn net.Conn, s *Server) *TCPPeer {
 		sendQ:    make(chan []byte, requestQueueSize),
 		p2pSendQ: make(chan []byte, p2pMsgQueueSize),
 		hpSendQ:  make(chan []byte, hpRequestQueueSize),
+		traffic:  newTrafficCounter(),
 	}
 }
 
@@ -148,7 +156,9 @@ func (p *TCPPeer) writeMsg(msg *Message) error {
 	}
 
 	_, err = p.conn.Write(b)
-
+	if err == nil {
+		p.traffic.addSent(b)
+	}
 	return err
 }
 
@@ -174,6 +184,8 @@ func (p *TCPPeer) handleConn() {
 			} else if err != nil {
 				break
 			}
+			p.traffic.addReceived(msg, msg.size())
+			p.lastMsgTime.Store(time.Now().UnixNano())
 			if err = p.server.handleMessage(p, msg); err != nil {
 				if p.Handshaked() {
 					err = fmt.Errorf("handling %s message: %w", msg.Command.String(), err)
@@ -237,6 +249,7 @@ func (p *TCPPeer) handleQueues() {
 		if err != nil {
 			break
 		}
+		p.traffic.addSent(msg)
 		p2pSkipCounter++
 	}
 	p.Disconnect(err)
@@ -285,6 +298,25 @@ func (p *TCPPeer) StartProtocol() {
 	}
 }
 
+// IsInbound implements the Peer interface.
+func (p *TCPPeer) IsInbound() bool {
+	return p.inbound
+}
+
+// Traffic implements the Peer interface.
+func (p *TCPPeer) Traffic() Traffic {
+	return p.traffic.get()
+}
+
+// LastMessageTime implements the Peer interface.
+func (p *TCPPeer) LastMessageTime() time.Time {
+	t := p.lastMsgTime.Load()
+	if t == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, t)
+}
+
 // Handshaked returns status of the handshake, whether it's completed or not.
 func (p *TCPPeer) Handshaked() bool {
 	p.lock.RLock()
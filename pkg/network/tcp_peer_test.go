@@ -79,6 +79,10 @@ func TestPeerHandshake(t *testing.T) {
 	require.Error(t, tcpC.HandleVersionAck())
 	require.Error(t, tcpS.SendVersionAck(&Message{}))
 
+	// Handshake messages are accounted for.
+	require.NotZero(t, tcpC.Traffic().Sent[CMDVersion])
+	require.NotZero(t, tcpS.Traffic().Sent[CMDVersion])
+
 	// Now regular messaging can proceed.
 	require.NoError(t, tcpS.EnqueueMessage(&Message{}))
 	require.NoError(t, tcpC.EnqueueMessage(&Message{}))
@@ -63,6 +63,7 @@ func (t *TCPTransport) Accept() {
 			continue
 		}
 		p := NewTCPPeer(conn, t.server)
+		p.inbound = true
 		go p.handleConn()
 	}
 }
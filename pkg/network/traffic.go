@@ -0,0 +1,90 @@
+package network
+
+import (
+	"strconv"
+	"sync"
+)
+
+// Traffic contains the number of bytes sent to and received from a peer per
+// message command. Sizes include message headers and are counted as they are
+// transmitted over the wire (that is, after compression).
+type Traffic struct {
+	Sent     map[CommandType]uint64
+	Received map[CommandType]uint64
+}
+
+// ByName returns traffic statistics keyed by command names (like "Block" or
+// "TX") instead of command codes.
+func (t Traffic) ByName() (sent map[string]uint64, received map[string]uint64) {
+	return namedTraffic(t.Sent), namedTraffic(t.Received)
+}
+
+func namedTraffic(m map[CommandType]uint64) map[string]uint64 {
+	res := make(map[string]uint64, len(m))
+	for cmd, n := range m {
+		res[commandLabel(cmd)] = n
+	}
+	return res
+}
+
+// trafficCounter is a concurrency-safe per-command byte counter for a peer,
+// it also updates global traffic metrics.
+type trafficCounter struct {
+	lock     sync.Mutex
+	sent     map[CommandType]uint64
+	received map[CommandType]uint64
+}
+
+func newTrafficCounter() *trafficCounter {
+	return &trafficCounter{
+		sent:     make(map[CommandType]uint64),
+		received: make(map[CommandType]uint64),
+	}
+}
+
+// addSent accounts for the serialized message sent to the peer.
+func (c *trafficCounter) addSent(msg []byte) {
+	if len(msg) < 2 {
+		return
+	}
+	cmd := CommandType(msg[1])
+	c.lock.Lock()
+	c.sent[cmd] += uint64(len(msg))
+	c.lock.Unlock()
+	addSentBytesMetric(cmd, MessageFlag(msg[0])&Compressed != 0, len(msg))
+}
+
+// addReceived accounts for the message of the given size received from the
+// peer.
+func (c *trafficCounter) addReceived(msg *Message, size int) {
+	c.lock.Lock()
+	c.received[msg.Command] += uint64(size)
+	c.lock.Unlock()
+	addReceivedBytesMetric(msg.Command, msg.Flags&Compressed != 0, size)
+}
+
+// get returns a copy of the current traffic statistics.
+func (c *trafficCounter) get() Traffic {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	res := Traffic{
+		Sent:     make(map[CommandType]uint64, len(c.sent)),
+		Received: make(map[CommandType]uint64, len(c.received)),
+	}
+	for cmd, n := range c.sent {
+		res.Sent[cmd] = n
+	}
+	for cmd, n := range c.received {
+		res.Received[cmd] = n
+	}
+	return res
+}
+
+// commandLabel returns the command name to be used as a metric label.
+func commandLabel(cmd CommandType) string {
+	s := cmd.String()
+	if len(s) > 3 && s[:3] == "CMD" {
+		return s[3:]
+	}
+	return strconv.Itoa(int(cmd))
+}
@@ -0,0 +1,44 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrafficCounter(t *testing.T) {
+	c := newTrafficCounter()
+
+	ping := NewMessage(CMDPing, payload.NewPing(1, 2))
+	b, err := ping.Bytes()
+	require.NoError(t, err)
+	c.addSent(b)
+	c.addSent(b)
+	c.addSent(nil)
+
+	decoded := &Message{}
+	require.NoError(t, decoded.Decode(io.NewBinReaderFromBuf(b)))
+	require.Equal(t, len(b), decoded.size())
+	c.addReceived(decoded, decoded.size())
+
+	tr := c.get()
+	require.Equal(t, map[CommandType]uint64{CMDPing: uint64(2 * len(b))}, tr.Sent)
+	require.Equal(t, map[CommandType]uint64{CMDPing: uint64(len(b))}, tr.Received)
+
+	// Returned statistics is a copy.
+	tr.Sent[CMDPing] = 0
+	require.Equal(t, uint64(2*len(b)), c.get().Sent[CMDPing])
+
+	sent, received := tr.ByName()
+	require.Equal(t, map[string]uint64{"Ping": 0}, sent)
+	require.Equal(t, map[string]uint64{"Ping": uint64(len(b))}, received)
+
+	t.Run("empty payload size", func(t *testing.T) {
+		m := NewMessage(CMDGetAddr, payload.NewNullPayload())
+		b, err := m.Bytes()
+		require.NoError(t, err)
+		require.Equal(t, len(b), m.size())
+	})
+}
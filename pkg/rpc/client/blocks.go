@@ -0,0 +1,81 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+)
+
+// IterateBlocks fetches blocks with indexes from start to end (both inclusive)
+// making no more than parallel concurrent requests at a time and calls f for
+// each of them in order of their indexes. Iteration stops on the first
+// request failure or f error, this error is returned then. You should
+// initialize network magic with Init before calling IterateBlocks.
+func (c *Client) IterateBlocks(start, end uint32, parallel int, f func(*block.Block) error) error {
+	type result struct {
+		b   *block.Block
+		err error
+	}
+	if end < start {
+		return errors.New("invalid block range")
+	}
+	if parallel <= 0 {
+		parallel = 1
+	}
+
+	var (
+		// One request is always being waited for by the consumer, so
+		// the queue holds one element less.
+		queue = make(chan chan result, parallel-1)
+		done  = make(chan struct{})
+	)
+	defer close(done)
+	go func() {
+		defer close(queue)
+		for i := start; ; i++ {
+			res := make(chan result, 1)
+			select {
+			case queue <- res:
+			case <-done:
+				return
+			}
+			go func(i uint32) {
+				b, err := c.GetBlockByIndex(i)
+				res <- result{b: b, err: err}
+			}(i)
+			if i == end {
+				return
+			}
+		}
+	}()
+	for res := range queue {
+		r := <-res
+		if r.err != nil {
+			return fmt.Errorf("failed to get block: %w", r.err)
+		}
+		if err := f(r.b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetBlocksRange returns blocks with indexes from start to end (both
+// inclusive) fetched concurrently with no more than parallel requests at a
+// time. Blocks are returned in order of their indexes. You should initialize
+// network magic with Init before calling GetBlocksRange.
+func (c *Client) GetBlocksRange(start, end uint32, parallel int) ([]*block.Block, error) {
+	var blocks []*block.Block
+	if end >= start {
+		blocks = make([]*block.Block, 0, end-start+1)
+	}
+	err := c.IterateBlocks(start, end, parallel, func(b *block.Block) error {
+		blocks = append(blocks, b)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+func TestClientIterateBlocks(t *testing.T) {
+	var (
+		current atomic.Int32
+		maxPar  atomic.Int32
+		calls   atomic.Int32
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r := request.NewRequest()
+		err := r.DecodeData(req.Body)
+		require.NoErrorf(t, err, "Cannot decode request body: %s", req.Body)
+		var response string
+		if r.In.Method == "getblock" {
+			calls.Inc()
+			n := current.Inc()
+			for m := maxPar.Load(); n > m && !maxPar.CAS(m, n); m = maxPar.Load() {
+			}
+			time.Sleep(10 * time.Millisecond)
+			current.Dec()
+
+			p, _ := r.In.Params()
+			index, _ := p.Value(0).GetInt()
+			if index == 42 {
+				response = `{"id":1,"jsonrpc":"2.0","error":{"code":-100,"message":"Unknown block"}}`
+			} else {
+				response = `{"id":1,"jsonrpc":"2.0","result":"` + base64B1 + `"}`
+			}
+		}
+		requestHandler(t, r.In, w, response)
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := New(context.TODO(), srv.URL, Options{})
+	require.NoError(t, err)
+
+	_, err = c.GetBlocksRange(1, 10, 3)
+	require.True(t, errors.Is(err, errNetworkNotInitialized))
+	require.NoError(t, c.Init())
+
+	_, err = c.GetBlocksRange(10, 1, 3)
+	require.Error(t, err)
+
+	calls.Store(0)
+	blocks, err := c.GetBlocksRange(1, 10, 3)
+	require.NoError(t, err)
+	require.Equal(t, 10, len(blocks))
+	require.EqualValues(t, 10, calls.Load())
+	require.True(t, maxPar.Load() <= 3)
+
+	t.Run("request error", func(t *testing.T) {
+		_, err := c.GetBlocksRange(40, 50, 4)
+		require.Error(t, err)
+	})
+	t.Run("callback error", func(t *testing.T) {
+		var count int
+		errStop := errors.New("stop")
+		err := c.IterateBlocks(1, 100, 5, func(b *block.Block) error {
+			count++
+			if count == 3 {
+				return errStop
+			}
+			return nil
+		})
+		require.True(t, errors.Is(err, errStop))
+		require.Equal(t, 3, count)
+	})
+}
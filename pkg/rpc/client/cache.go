@@ -0,0 +1,167 @@
+package client
+
+import (
+	"strconv"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// Cache is a storage for immutable chain data (blocks, headers and
+// transactions identified by their hashes, contract states identified by
+// their hashes and update counters and native contract hashes) that can be
+// used by Client to avoid repeated requests to the node. Implementations
+// must be safe for concurrent use. Values stored in cache are shared between
+// callers, so they must not be modified by the user.
+type Cache interface {
+	// Get returns cached value for the given key if it's present.
+	Get(key string) (interface{}, bool)
+	// Add puts value into the cache, it's up to implementation to decide
+	// whether (and which) old values should be evicted.
+	Add(key string, value interface{})
+}
+
+// lruCache is a Cache implementation backed by fixed-size LRU cache.
+type lruCache struct {
+	cache *lru.Cache
+}
+
+// NewLRUCache returns a new in-memory Cache holding no more than size
+// elements with the least recently used ones evicted first.
+func NewLRUCache(size int) (Cache, error) {
+	c, err := lru.New(size)
+	if err != nil {
+		return nil, err
+	}
+	return &lruCache{cache: c}, nil
+}
+
+// Get implements Cache interface.
+func (c *lruCache) Get(key string) (interface{}, bool) {
+	return c.cache.Get(key)
+}
+
+// Add implements Cache interface.
+func (c *lruCache) Add(key string, value interface{}) {
+	c.cache.Add(key, value)
+}
+
+// Cache key prefixes for different types of data.
+const (
+	blockCachePrefix  = "b"
+	headerCachePrefix = "h"
+	txCachePrefix     = "t"
+	contractPrefix    = "c"
+	nativeHashPrefix  = "n"
+)
+
+func cacheKey(prefix string, h util.Uint256) string {
+	return prefix + h.StringLE()
+}
+
+// getCachedBlock returns block with the given hash if it's present in cache.
+func (c *Client) getCachedBlock(h util.Uint256) *block.Block {
+	if c.opts.Cache == nil {
+		return nil
+	}
+	v, ok := c.opts.Cache.Get(cacheKey(blockCachePrefix, h))
+	if !ok {
+		return nil
+	}
+	return v.(*block.Block)
+}
+
+// cacheBlock stores given block in cache if it's enabled.
+func (c *Client) cacheBlock(b *block.Block) {
+	if c.opts.Cache != nil {
+		c.opts.Cache.Add(cacheKey(blockCachePrefix, b.Hash()), b)
+	}
+}
+
+// getCachedHeader returns header with the given hash if it's present in cache.
+func (c *Client) getCachedHeader(h util.Uint256) *block.Header {
+	if c.opts.Cache == nil {
+		return nil
+	}
+	v, ok := c.opts.Cache.Get(cacheKey(headerCachePrefix, h))
+	if !ok {
+		return nil
+	}
+	return v.(*block.Header)
+}
+
+// cacheHeader stores given header in cache if it's enabled.
+func (c *Client) cacheHeader(h *block.Header) {
+	if c.opts.Cache != nil {
+		c.opts.Cache.Add(cacheKey(headerCachePrefix, h.Hash()), h)
+	}
+}
+
+// getCachedTransaction returns transaction with the given hash if it's present
+// in cache.
+func (c *Client) getCachedTransaction(h util.Uint256) *transaction.Transaction {
+	if c.opts.Cache == nil {
+		return nil
+	}
+	v, ok := c.opts.Cache.Get(cacheKey(txCachePrefix, h))
+	if !ok {
+		return nil
+	}
+	return v.(*transaction.Transaction)
+}
+
+// cacheTransaction stores given transaction in cache if it's enabled.
+func (c *Client) cacheTransaction(tx *transaction.Transaction) {
+	if c.opts.Cache != nil {
+		c.opts.Cache.Add(cacheKey(txCachePrefix, tx.Hash()), tx)
+	}
+}
+
+func contractCacheKey(h util.Uint160, updateCounter uint16) string {
+	return contractPrefix + h.StringLE() + strconv.Itoa(int(updateCounter))
+}
+
+// getCachedContractState returns contract state with the given hash and update
+// counter if it's present in cache.
+func (c *Client) getCachedContractState(h util.Uint160, updateCounter uint16) *state.Contract {
+	if c.opts.Cache == nil {
+		return nil
+	}
+	v, ok := c.opts.Cache.Get(contractCacheKey(h, updateCounter))
+	if !ok {
+		return nil
+	}
+	return v.(*state.Contract)
+}
+
+// cacheContractState stores given contract state in cache if it's enabled.
+// Contract state can be changed by update, but it's not possible to change
+// it without update counter increment, so each version is immutable.
+func (c *Client) cacheContractState(cs *state.Contract) {
+	if c.opts.Cache != nil {
+		c.opts.Cache.Add(contractCacheKey(cs.Hash, cs.UpdateCounter), cs)
+	}
+}
+
+// getCachedNativeHash returns hash of the native contract with the given name
+// if it's present in cache.
+func (c *Client) getCachedNativeHash(name string) (util.Uint160, bool) {
+	if c.opts.Cache == nil {
+		return util.Uint160{}, false
+	}
+	v, ok := c.opts.Cache.Get(nativeHashPrefix + name)
+	if !ok {
+		return util.Uint160{}, false
+	}
+	return v.(util.Uint160), true
+}
+
+// cacheNativeHash stores given native contract hash in cache if it's enabled.
+func (c *Client) cacheNativeHash(name string, h util.Uint160) {
+	if c.opts.Cache != nil {
+		c.opts.Cache.Add(nativeHashPrefix+name, h)
+	}
+}
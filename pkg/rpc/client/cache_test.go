@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientCache(t *testing.T) {
+	var getBlockCalled int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r := request.NewRequest()
+		err := r.DecodeData(req.Body)
+		require.NoErrorf(t, err, "Cannot decode request body: %s", req.Body)
+		var response string
+		if r.In.Method == "getblock" {
+			getBlockCalled++
+			response = `{"id":1,"jsonrpc":"2.0","result":"` + base64B1 + `"}`
+		}
+		requestHandler(t, r.In, w, response)
+	}))
+	t.Cleanup(srv.Close)
+
+	_, err := NewLRUCache(0)
+	require.Error(t, err)
+
+	cache, err := NewLRUCache(16)
+	require.NoError(t, err)
+	c, err := New(context.TODO(), srv.URL, Options{Cache: cache})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	h, err := util.Uint256DecodeStringLE("81a439175d3bdd8961b6223a9b6f6d234f996824c5cfce6af17e6fc14cd84355")
+	require.NoError(t, err)
+
+	b, err := c.GetBlockByHash(h)
+	require.NoError(t, err)
+	require.Equal(t, h, b.Hash())
+	require.Equal(t, 1, getBlockCalled)
+
+	cached, err := c.GetBlockByHash(h)
+	require.NoError(t, err)
+	require.Equal(t, b, cached)
+	require.Equal(t, 1, getBlockCalled)
+
+	// Blocks requested by index are not taken from the cache.
+	_, err = c.GetBlockByIndex(1)
+	require.NoError(t, err)
+	require.Equal(t, 2, getBlockCalled)
+}
+
+func TestClientCacheContractState(t *testing.T) {
+	var getContractStateCalled int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r := request.NewRequest()
+		err := r.DecodeData(req.Body)
+		require.NoErrorf(t, err, "Cannot decode request body: %s", req.Body)
+		var response string
+		if r.In.Method == "getcontractstate" {
+			getContractStateCalled++
+			response = rpcClientTestCases["getcontractstate"][0].serverResponse
+		}
+		requestHandler(t, r.In, w, response)
+	}))
+	t.Cleanup(srv.Close)
+
+	cache, err := NewLRUCache(16)
+	require.NoError(t, err)
+	c, err := New(context.TODO(), srv.URL, Options{Cache: cache})
+	require.NoError(t, err)
+
+	cs, err := c.GetContractStateByHash(util.Uint160{1, 2, 3})
+	require.NoError(t, err)
+	require.Equal(t, 1, getContractStateCalled)
+
+	cached, err := c.GetContractStateByUpdateCounter(cs.Hash, cs.UpdateCounter)
+	require.NoError(t, err)
+	require.Equal(t, cs, cached)
+	require.Equal(t, 1, getContractStateCalled)
+
+	// Other versions are requested from the node.
+	_, err = c.GetContractStateByUpdateCounter(cs.Hash, cs.UpdateCounter+1)
+	require.Error(t, err)
+	require.Equal(t, 2, getContractStateCalled)
+
+	h, err := c.GetNativeContractHash("SomeNative")
+	require.NoError(t, err)
+	require.Equal(t, cs.Hash, h)
+	require.Equal(t, 3, getContractStateCalled)
+
+	// Native hashes are shared via the cache between clients.
+	c2, err := New(context.TODO(), srv.URL, Options{Cache: cache})
+	require.NoError(t, err)
+	h, err = c2.GetNativeContractHash("SomeNative")
+	require.NoError(t, err)
+	require.Equal(t, cs.Hash, h)
+	require.Equal(t, 3, getContractStateCalled)
+}
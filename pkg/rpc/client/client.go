@@ -3,9 +3,13 @@ package client
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"net/url"
@@ -13,6 +17,7 @@ import (
 
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -36,21 +41,99 @@ type Client struct {
 	initDone          bool
 	ctx               context.Context
 	opts              Options
-	requestF          func(*request.Raw) (*response.Raw, error)
-	cache             cache
+	// header contains headers added to every request.
+	header    http.Header
+	tlsConfig *tls.Config
+	requestF  func(*request.Raw) (*response.Raw, error)
+	cache     cache
 }
 
 // Options defines options for the RPC client.
 // All values are optional. If any duration is not specified
 // a default of 4 seconds will be used.
 type Options struct {
-	// Cert is a client-side certificate, it doesn't work at the moment along
-	// with the other two options below.
-	Cert           string
-	Key            string
-	CACert         string
+	// Cert and Key are paths to PEM-encoded client-side TLS certificate and
+	// its private key, they're used together.
+	Cert string
+	Key  string
+	// CACert is a path to PEM-encoded CA certificates used to verify server
+	// certificate instead of system ones.
+	CACert string
+	// RootCAs is a pool of CA certificates used to verify server
+	// certificate instead of system ones, it can't be used along with
+	// CACert.
+	RootCAs *x509.CertPool
+	// Headers are added to every HTTP request (including websocket
+	// handshake) made by the client.
+	Headers http.Header
+	// User and Password are used for HTTP basic authentication if User is
+	// not empty.
+	User     string
+	Password string
+	// BearerToken is used for HTTP bearer authentication if it's not empty,
+	// it can't be used along with basic authentication.
+	BearerToken    string
 	DialTimeout    time.Duration
 	RequestTimeout time.Duration
+	// Cache is an optional storage for immutable chain data (blocks, headers
+	// and transactions requested by hash, contract states of particular
+	// versions and native contract hashes). See NewLRUCache for the default
+	// in-memory implementation.
+	Cache Cache
+	// ValidUntilBlockOffset is added to the current chain height to get
+	// ValidUntilBlock value of transactions created by the client. If it's
+	// not set, the number of validators plus one is used (see
+	// CalculateValidUntilBlock).
+	ValidUntilBlockOffset uint32
+	// NonceGenerator is used to get nonces for transactions created by the
+	// client, it must be safe for concurrent use. Random nonces are used if
+	// it's not set.
+	NonceGenerator func() uint32
+	// ReconnectAttempts enables automatic WSClient reconnection if it's not
+	// zero. It's the number of attempts made after connection loss before
+	// giving up, negative value means no limit. Active subscriptions and
+	// transaction watches are restored after reconnection and Gap
+	// notification is sent.
+	ReconnectAttempts int
+	// ReconnectDelay is the initial delay between reconnection attempts (1
+	// second by default), it's doubled after each failed attempt up to
+	// MaxReconnectDelay (1 minute by default).
+	ReconnectDelay    time.Duration
+	MaxReconnectDelay time.Duration
+	// ReplayOnReconnect makes WSClient request replay of block,
+	// transaction, notification and execution events missed while it was
+	// disconnected when subscriptions are restored. Subscriptions are
+	// restored without replay if the server can't replay events (e.g. if
+	// too many blocks were missed). Replayed events are delivered before
+	// live ones, but some of them can be delivered twice.
+	ReplayOnReconnect bool
+	// Hooks are optional callbacks invoked for every RPC call made by the
+	// client, they can be used to collect metrics or traces.
+	Hooks Hooks
+	// MaxIteratorItems enables automatic expansion of iterators returned by
+	// InvokeScript and InvokeFunction (and their WithGas variants) if it's
+	// positive. Iterators are replaced with arrays of at most MaxIteratorItems
+	// of their values, this requires an additional invocation.
+	MaxIteratorItems int
+	// FeeOracle is used to get extra network fee for transactions when
+	// DefaultExtraFee is passed instead of the fee value (see
+	// MempoolFeeOracle for an example).
+	FeeOracle FeeOracle
+}
+
+// Hooks allows to instrument RPC calls made by Client. Both methods are called
+// synchronously from the goroutine making the call, so they should be fast
+// and they must be safe for concurrent use.
+type Hooks interface {
+	// OnRequest is called before making a call to the given RPC method. ctx
+	// is the context Client was created with, the context returned is then
+	// passed to OnResponse for the same call, so it can carry tracing spans
+	// or other call-specific data.
+	OnRequest(ctx context.Context, method string) context.Context
+	// OnResponse is called after the call to the given RPC method is done,
+	// d is its duration and err is the error returned to the caller (nil if
+	// the call succeeded).
+	OnResponse(ctx context.Context, method string, d time.Duration, err error)
 }
 
 // cache stores cache values for the RPC client methods
@@ -66,6 +149,58 @@ type calculateValidUntilBlockCache struct {
 	expiresAt       uint32
 }
 
+// getTLSConfig returns TLS configuration for the given options or nil if
+// default one should be used.
+func (o *Options) getTLSConfig() (*tls.Config, error) {
+	if o.Cert == "" && o.Key == "" && o.CACert == "" && o.RootCAs == nil {
+		return nil, nil
+	}
+	cfg := &tls.Config{RootCAs: o.RootCAs}
+	if o.Cert != "" || o.Key != "" {
+		if o.Cert == "" || o.Key == "" {
+			return nil, errors.New("both client certificate and key must be specified")
+		}
+		cert, err := tls.LoadX509KeyPair(o.Cert, o.Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	if o.CACert != "" {
+		if o.RootCAs != nil {
+			return nil, errors.New("CACert and RootCAs can't be used together")
+		}
+		data, err := ioutil.ReadFile(o.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+		}
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(data) {
+			return nil, errors.New("no valid CA certificates found")
+		}
+	}
+	return cfg, nil
+}
+
+// getHeader returns headers to be added to every request made with the
+// given options.
+func (o *Options) getHeader() (http.Header, error) {
+	if o.User != "" && o.BearerToken != "" {
+		return nil, errors.New("basic and bearer authentication can't be used together")
+	}
+	h := make(http.Header, len(o.Headers)+1)
+	for k, v := range o.Headers {
+		h[k] = v
+	}
+	if o.User != "" {
+		h.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(o.User+":"+o.Password)))
+	}
+	if o.BearerToken != "" {
+		h.Set("Authorization", "Bearer "+o.BearerToken)
+	}
+	return h, nil
+}
+
 // New returns a new Client ready to use. You should call Init method to
 // initialize network magic the client is operating on.
 func New(ctx context.Context, endpoint string, opts Options) (*Client, error) {
@@ -82,23 +217,35 @@ func New(ctx context.Context, endpoint string, opts Options) (*Client, error) {
 		opts.RequestTimeout = defaultRequestTimeout
 	}
 
+	if opts.ValidUntilBlockOffset > transaction.MaxValidUntilBlockIncrement {
+		return nil, fmt.Errorf("ValidUntilBlockOffset can't exceed %d", transaction.MaxValidUntilBlockIncrement)
+	}
+
+	tlsConfig, err := opts.getTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	header, err := opts.getHeader()
+	if err != nil {
+		return nil, err
+	}
+
 	httpClient := &http.Client{
 		Transport: &http.Transport{
 			DialContext: (&net.Dialer{
 				Timeout: opts.DialTimeout,
 			}).DialContext,
+			TLSClientConfig: tlsConfig,
 		},
 		Timeout: opts.RequestTimeout,
 	}
 
-	// TODO(@antdm): Enable SSL.
-	if opts.Cert != "" && opts.Key != "" {
-	}
-
 	cl := &Client{
-		ctx:      ctx,
-		cli:      httpClient,
-		endpoint: url,
+		ctx:       ctx,
+		cli:       httpClient,
+		endpoint:  url,
+		header:    header,
+		tlsConfig: tlsConfig,
 		cache: cache{
 			nativeHashes: make(map[string]util.Uint160),
 		},
@@ -138,6 +285,19 @@ func (c *Client) Init() error {
 }
 
 func (c *Client) performRequest(method string, p request.RawParams, v interface{}) error {
+	if c.opts.Hooks == nil {
+		return c.doRequest(method, p, v)
+	}
+	ctx := c.opts.Hooks.OnRequest(c.ctx, method)
+	start := time.Now()
+	err := c.doRequest(method, p, v)
+	c.opts.Hooks.OnResponse(ctx, method, time.Since(start), err)
+	return err
+}
+
+// doRequest makes a call to the given RPC method and unmarshals its result
+// into v.
+func (c *Client) doRequest(method string, p request.RawParams, v interface{}) error {
 	var r = request.Raw{
 		JSONRPC:   request.JSONRPCVersion,
 		Method:    method,
@@ -171,6 +331,9 @@ func (c *Client) makeHTTPRequest(r *request.Raw) (*response.Raw, error) {
 	if err != nil {
 		return nil, err
 	}
+	for k, v := range c.header {
+		req.Header[k] = v
+	}
 	resp, err := c.cli.Do(req)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,202 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptionsHeaders(t *testing.T) {
+	var header http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		header = req.Header
+		if req.URL.Path == "/ws" {
+			ws, err := (&websocket.Upgrader{}).Upgrade(w, req, nil)
+			require.NoError(t, err)
+			ws.Close()
+			return
+		}
+		r := request.NewRequest()
+		require.NoError(t, r.DecodeData(req.Body))
+		requestHandler(t, r.In, w, `{"jsonrpc":"2.0","id":1,"result":50}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	t.Run("basic", func(t *testing.T) {
+		c, err := New(context.TODO(), srv.URL, Options{
+			Headers:  http.Header{"X-Custom": []string{"value"}},
+			User:     "user",
+			Password: "pass",
+		})
+		require.NoError(t, err)
+		_, err = c.GetBlockCount()
+		require.NoError(t, err)
+		require.Equal(t, "value", header.Get("X-Custom"))
+		require.Equal(t, "Basic dXNlcjpwYXNz", header.Get("Authorization"))
+	})
+	t.Run("bearer", func(t *testing.T) {
+		c, err := New(context.TODO(), srv.URL, Options{BearerToken: "token"})
+		require.NoError(t, err)
+		_, err = c.GetBlockCount()
+		require.NoError(t, err)
+		require.Equal(t, "Bearer token", header.Get("Authorization"))
+	})
+	t.Run("websocket", func(t *testing.T) {
+		wsc, err := NewWS(context.TODO(), httpURLtoWS(srv.URL+"/ws"), Options{
+			Headers:     http.Header{"X-Custom": []string{"ws"}},
+			BearerToken: "token",
+		})
+		require.NoError(t, err)
+		wsc.Close()
+		require.Equal(t, "ws", header.Get("X-Custom"))
+		require.Equal(t, "Bearer token", header.Get("Authorization"))
+	})
+	t.Run("both auth methods", func(t *testing.T) {
+		_, err := New(context.TODO(), srv.URL, Options{User: "user", BearerToken: "token"})
+		require.Error(t, err)
+	})
+}
+
+func TestOptionsTLS(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r := request.NewRequest()
+		require.NoError(t, r.DecodeData(req.Body))
+		requestHandler(t, r.In, w, `{"jsonrpc":"2.0","id":1,"result":50}`)
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	dir, err := ioutil.TempDir("", "neogo.tls")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	certFile, keyFile := filepath.Join(dir, "client.crt"), filepath.Join(dir, "client.key")
+	writeTestCertificate(t, certFile, keyFile)
+	caFile := filepath.Join(dir, "ca.crt")
+	require.NoError(t, ioutil.WriteFile(caFile,
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}), 0644))
+
+	roots := x509.NewCertPool()
+	roots.AddCert(srv.Certificate())
+
+	t.Run("good", func(t *testing.T) {
+		for _, opts := range []Options{
+			{Cert: certFile, Key: keyFile, CACert: caFile},
+			{Cert: certFile, Key: keyFile, RootCAs: roots},
+		} {
+			c, err := New(context.TODO(), srv.URL, opts)
+			require.NoError(t, err)
+			count, err := c.GetBlockCount()
+			require.NoError(t, err)
+			require.Equal(t, uint32(50), count)
+		}
+	})
+	t.Run("no client certificate", func(t *testing.T) {
+		c, err := New(context.TODO(), srv.URL, Options{RootCAs: roots})
+		require.NoError(t, err)
+		_, err = c.GetBlockCount()
+		require.Error(t, err)
+	})
+	t.Run("unknown CA", func(t *testing.T) {
+		c, err := New(context.TODO(), srv.URL, Options{Cert: certFile, Key: keyFile})
+		require.NoError(t, err)
+		_, err = c.GetBlockCount()
+		require.Error(t, err)
+	})
+	t.Run("bad options", func(t *testing.T) {
+		for _, opts := range []Options{
+			{Cert: certFile},
+			{Key: keyFile},
+			{Cert: keyFile, Key: certFile},
+			{CACert: filepath.Join(dir, "unknown")},
+			{CACert: keyFile},
+			{CACert: caFile, RootCAs: roots},
+		} {
+			_, err := New(context.TODO(), srv.URL, opts)
+			require.Error(t, err)
+		}
+	})
+}
+
+// writeTestCertificate generates self-signed certificate and writes it along
+// with its private key to the specified files.
+func writeTestCertificate(t *testing.T, certFile, keyFile string) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &priv.PublicKey, priv)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(priv)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644))
+	require.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+}
+
+type testHooks struct {
+	calls []string
+}
+
+type testHooksKey struct{}
+
+func (h *testHooks) OnRequest(ctx context.Context, method string) context.Context {
+	h.calls = append(h.calls, "request "+method)
+	return context.WithValue(ctx, testHooksKey{}, method)
+}
+
+func (h *testHooks) OnResponse(ctx context.Context, method string, d time.Duration, err error) {
+	call := "response " + ctx.Value(testHooksKey{}).(string)
+	if err != nil {
+		call += " error"
+	}
+	h.calls = append(h.calls, call)
+}
+
+func TestOptionsHooks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r := request.NewRequest()
+		require.NoError(t, r.DecodeData(req.Body))
+		response := `{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"Method not found"}}`
+		if r.In.Method == "getblockcount" {
+			response = `{"jsonrpc":"2.0","id":1,"result":50}`
+		}
+		requestHandler(t, r.In, w, response)
+	}))
+	t.Cleanup(srv.Close)
+
+	hooks := new(testHooks)
+	c, err := New(context.TODO(), srv.URL, Options{Hooks: hooks})
+	require.NoError(t, err)
+	_, err = c.GetBlockCount()
+	require.NoError(t, err)
+	_, err = c.GetConnectionCount()
+	require.Error(t, err)
+	require.Equal(t, []string{
+		"request getblockcount",
+		"response getblockcount",
+		"request getconnectioncount",
+		"response getconnectioncount error",
+	}, hooks.calls)
+}
@@ -0,0 +1,226 @@
+package client
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+)
+
+// ContractInvoker is a runtime helper for typed contract wrappers (see
+// pkg/smartcontract/binding), it invokes methods of a single deployed
+// contract. Method parameters can be of any type supported by emit.Array.
+type ContractInvoker struct {
+	Client *Client
+	Hash   util.Uint160
+	// Account is used as a signer (with CalledByEntry scope) for test
+	// invocations and to sign transactions. It can be nil for test
+	// invocations.
+	Account *wallet.Account
+	// NetworkFee is an additional network fee added to transactions.
+	NetworkFee int64
+}
+
+// NewContractInvoker returns a ContractInvoker for the contract with the given
+// hash using the given account (which can be nil for read-only use).
+func (c *Client) NewContractInvoker(hash util.Uint160, acc *wallet.Account) *ContractInvoker {
+	return &ContractInvoker{
+		Client:  c,
+		Hash:    hash,
+		Account: acc,
+	}
+}
+
+// TestInvoke performs test invocation of the given contract method returning
+// its result. Invocation state is not checked.
+func (ci *ContractInvoker) TestInvoke(method string, params ...interface{}) (*result.Invoke, error) {
+	script, err := ci.script(method, params)
+	if err != nil {
+		return nil, err
+	}
+	var signers []transaction.Signer
+	if ci.Account != nil {
+		s, err := ci.signer()
+		if err != nil {
+			return nil, err
+		}
+		signers = []transaction.Signer{s}
+	}
+	return ci.Client.InvokeScript(script, signers)
+}
+
+// Call performs test invocation of the given contract method and returns the
+// item on top of the resulting stack (nil if stack is empty). An error is
+// returned if invocation fails.
+func (ci *ContractInvoker) Call(method string, params ...interface{}) (stackitem.Item, error) {
+	res, err := ci.TestInvoke(method, params...)
+	if err != nil {
+		return nil, err
+	}
+	err = getInvocationError(res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to invoke %s: %w", method, err)
+	}
+	if len(res.Stack) == 0 {
+		return nil, nil
+	}
+	return res.Stack[len(res.Stack)-1], nil
+}
+
+// Invoke creates a transaction invoking the given contract method (system fee
+// is determined via test invocation), signs it with Account and sends it to
+// the network returning its hash.
+func (ci *ContractInvoker) Invoke(method string, params ...interface{}) (util.Uint256, error) {
+	if ci.Account == nil {
+		return util.Uint256{}, errors.New("no account to sign transaction with")
+	}
+	script, err := ci.script(method, params)
+	if err != nil {
+		return util.Uint256{}, err
+	}
+	s, err := ci.signer()
+	if err != nil {
+		return util.Uint256{}, err
+	}
+	tx, err := ci.Client.CreateTxFromScript(script, ci.Account, -1, ci.NetworkFee, []SignerAccount{{
+		Signer:  s,
+		Account: ci.Account,
+	}})
+	if err != nil {
+		return util.Uint256{}, fmt.Errorf("failed to create tx: %w", err)
+	}
+	if err := ci.Account.SignTx(tx); err != nil {
+		return util.Uint256{}, fmt.Errorf("failed to sign tx: %w", err)
+	}
+	return ci.Client.SendRawTransaction(tx)
+}
+
+func (ci *ContractInvoker) script(method string, params []interface{}) ([]byte, error) {
+	w := io.NewBufBinWriter()
+	emit.AppCall(w.BinWriter, ci.Hash, method, callflag.All, params...)
+	if w.Err != nil {
+		return nil, fmt.Errorf("failed to create %s invocation script: %w", method, w.Err)
+	}
+	return w.Bytes(), nil
+}
+
+func (ci *ContractInvoker) signer() (transaction.Signer, error) {
+	acc, err := address.StringToUint160(ci.Account.Address)
+	if err != nil {
+		return transaction.Signer{}, fmt.Errorf("bad account address: %w", err)
+	}
+	return transaction.Signer{
+		Account: acc,
+		Scopes:  transaction.CalledByEntry,
+	}, nil
+}
+
+// CheckNotification checks that the given notification is an event with the
+// given name and number of parameters, it returns event parameters.
+func CheckNotification(ev *state.NotificationEvent, name string, paramCount int) ([]stackitem.Item, error) {
+	if ev.Name != name {
+		return nil, fmt.Errorf("not a %s event: %s", name, ev.Name)
+	}
+	if ev.Item == nil {
+		return nil, errors.New("no event parameters")
+	}
+	params := ev.Item.Value().([]stackitem.Item)
+	if len(params) != paramCount {
+		return nil, fmt.Errorf("wrong number of %s event parameters: expected %d, got %d", name, paramCount, len(params))
+	}
+	return params, nil
+}
+
+// ItemToBool converts stack item to bool.
+func ItemToBool(item stackitem.Item) (bool, error) {
+	if item == nil {
+		return false, errors.New("no result")
+	}
+	return item.TryBool()
+}
+
+// ItemToBigInt converts stack item to integer.
+func ItemToBigInt(item stackitem.Item) (*big.Int, error) {
+	if item == nil {
+		return nil, errors.New("no result")
+	}
+	return item.TryInteger()
+}
+
+// ItemToBytes converts stack item to a byte slice.
+func ItemToBytes(item stackitem.Item) ([]byte, error) {
+	if item == nil {
+		return nil, errors.New("no result")
+	}
+	return item.TryBytes()
+}
+
+// ItemToString converts stack item to a valid UTF-8 string.
+func ItemToString(item stackitem.Item) (string, error) {
+	if item == nil {
+		return "", errors.New("no result")
+	}
+	return stackitem.ToString(item)
+}
+
+// ItemToUint160 converts stack item to Uint160.
+func ItemToUint160(item stackitem.Item) (util.Uint160, error) {
+	bs, err := ItemToBytes(item)
+	if err != nil {
+		return util.Uint160{}, err
+	}
+	return util.Uint160DecodeBytesBE(bs)
+}
+
+// ItemToUint256 converts stack item to Uint256.
+func ItemToUint256(item stackitem.Item) (util.Uint256, error) {
+	bs, err := ItemToBytes(item)
+	if err != nil {
+		return util.Uint256{}, err
+	}
+	return util.Uint256DecodeBytesBE(bs)
+}
+
+// ItemToPublicKey converts stack item to public key.
+func ItemToPublicKey(item stackitem.Item) (*keys.PublicKey, error) {
+	bs, err := ItemToBytes(item)
+	if err != nil {
+		return nil, err
+	}
+	return keys.NewPublicKeyFromBytes(bs, elliptic.P256())
+}
+
+// ItemToArray converts stack item to a slice of items (it works for both
+// arrays and structs).
+func ItemToArray(item stackitem.Item) ([]stackitem.Item, error) {
+	if item == nil {
+		return nil, errors.New("no result")
+	}
+	switch item.(type) {
+	case *stackitem.Array, *stackitem.Struct:
+		return item.Value().([]stackitem.Item), nil
+	default:
+		return nil, fmt.Errorf("not an array: %s", item.Type())
+	}
+}
+
+// ItemToMap converts stack item to map.
+func ItemToMap(item stackitem.Item) (*stackitem.Map, error) {
+	m, ok := item.(*stackitem.Map)
+	if !ok {
+		return nil, errors.New("not a map")
+	}
+	return m, nil
+}
@@ -0,0 +1,85 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+)
+
+// NewContractAccount returns wallet account for the deployed contract with the
+// given hash. Its parameters are taken from the contract's `verify` method
+// descriptor, so the contract must have this method returning boolean value.
+// The account can be passed to AddNetworkFee and other methods accepting
+// signers' accounts.
+func (c *Client) NewContractAccount(h util.Uint160) (*wallet.Account, error) {
+	cs, err := c.GetContractStateByHash(h)
+	if err != nil {
+		return nil, fmt.Errorf("can't get contract state: %w", err)
+	}
+	md := cs.Manifest.ABI.GetMethod(manifest.MethodVerify, -1)
+	if md == nil {
+		return nil, fmt.Errorf("contract %s has no `verify` method", h.StringLE())
+	}
+	if md.ReturnType != smartcontract.BoolType {
+		return nil, fmt.Errorf("`verify` method returns %s instead of boolean", md.ReturnType)
+	}
+	params := make([]wallet.ContractParam, len(md.Parameters))
+	for i := range md.Parameters {
+		params[i] = wallet.ContractParam{
+			Name: md.Parameters[i].Name,
+			Type: md.Parameters[i].Type,
+		}
+	}
+	return wallet.NewContractAccount(h, params...), nil
+}
+
+// getDummyVerifyArgs returns `verify` method arguments of the given types and
+// the size of invocation script pushing them. Arguments have zero values, but
+// their sizes match the real ones for fixed-size types (like signatures), so
+// they can be used to estimate verification fee.
+func getDummyVerifyArgs(params []wallet.ContractParam) ([]smartcontract.Parameter, int, error) {
+	var (
+		args = make([]smartcontract.Parameter, len(params))
+		w    = io.NewBufBinWriter()
+	)
+	for i := len(params) - 1; i >= 0; i-- {
+		var size int
+		switch params[i].Type {
+		case smartcontract.SignatureType:
+			size = 64
+		case smartcontract.PublicKeyType:
+			size = 33
+		case smartcontract.Hash160Type:
+			size = util.Uint160Size
+		case smartcontract.Hash256Type:
+			size = util.Uint256Size
+		case smartcontract.ByteArrayType, smartcontract.StringType:
+		case smartcontract.IntegerType, smartcontract.BoolType:
+			args[i] = smartcontract.Parameter{Type: smartcontract.IntegerType, Value: int64(0)}
+			emit.Int(w.BinWriter, 0)
+			continue
+		case smartcontract.ArrayType:
+			args[i] = smartcontract.Parameter{Type: smartcontract.ArrayType, Value: []smartcontract.Parameter{}}
+			emit.Int(w.BinWriter, 0)
+			emit.Opcodes(w.BinWriter, opcode.PACK)
+			continue
+		default:
+			return nil, 0, fmt.Errorf("parameter #%d: unsupported type %s", i, params[i].Type)
+		}
+		// Byte arrays are used for all fixed-size types as they're pushed
+		// the same way.
+		args[i] = smartcontract.Parameter{Type: smartcontract.ByteArrayType, Value: make([]byte, size)}
+		emit.Bytes(w.BinWriter, make([]byte, size))
+	}
+	if w.Err != nil {
+		return nil, 0, errors.New("can't create invocation script")
+	}
+	return args, io.GetVarSize(w.Bytes()), nil
+}
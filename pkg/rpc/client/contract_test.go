@@ -0,0 +1,88 @@
+package client
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckNotification(t *testing.T) {
+	ev := &state.NotificationEvent{
+		Name: "Transfer",
+		Item: stackitem.NewArray([]stackitem.Item{stackitem.Null{}, stackitem.NewBigInteger(big.NewInt(1))}),
+	}
+	params, err := CheckNotification(ev, "Transfer", 2)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(params))
+
+	_, err = CheckNotification(ev, "Burn", 2)
+	require.Error(t, err)
+	_, err = CheckNotification(ev, "Transfer", 3)
+	require.Error(t, err)
+	_, err = CheckNotification(&state.NotificationEvent{Name: "Transfer"}, "Transfer", 0)
+	require.Error(t, err)
+}
+
+func TestItemConverters(t *testing.T) {
+	h160 := util.Uint160{1, 2, 3}
+	h256 := util.Uint256{4, 5, 6}
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	pub := priv.PublicKey()
+
+	b, err := ItemToBool(stackitem.NewBool(true))
+	require.NoError(t, err)
+	require.True(t, b)
+
+	i, err := ItemToBigInt(stackitem.Make(42))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(42), i)
+
+	s, err := ItemToString(stackitem.Make("str"))
+	require.NoError(t, err)
+	require.Equal(t, "str", s)
+
+	u160, err := ItemToUint160(stackitem.Make(h160.BytesBE()))
+	require.NoError(t, err)
+	require.Equal(t, h160, u160)
+
+	u256, err := ItemToUint256(stackitem.Make(h256.BytesBE()))
+	require.NoError(t, err)
+	require.Equal(t, h256, u256)
+
+	pk, err := ItemToPublicKey(stackitem.Make(pub.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, pub, pk)
+
+	arr, err := ItemToArray(stackitem.NewStruct([]stackitem.Item{stackitem.Make(1)}))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(arr))
+
+	m, err := ItemToMap(stackitem.NewMap())
+	require.NoError(t, err)
+	require.Equal(t, 0, m.Len())
+
+	t.Run("bad", func(t *testing.T) {
+		_, err := ItemToBool(nil)
+		require.Error(t, err)
+		_, err = ItemToBigInt(nil)
+		require.Error(t, err)
+		_, err = ItemToString(nil)
+		require.Error(t, err)
+		_, err = ItemToUint160(stackitem.Make([]byte{1, 2, 3}))
+		require.Error(t, err)
+		_, err = ItemToUint256(nil)
+		require.Error(t, err)
+		_, err = ItemToPublicKey(stackitem.Make([]byte{1, 2, 3}))
+		require.Error(t, err)
+		_, err = ItemToArray(stackitem.Make(1))
+		require.Error(t, err)
+		_, err = ItemToMap(nil)
+		require.Error(t, err)
+	})
+}
@@ -11,6 +11,12 @@ Some of the methods also allow to pass a verbose bool. This will
 return a more pretty printed response from the server instead of
 a raw hex string.
 
+Errors returned by the server are *response.Error values that can be checked
+with errors.Is against error classes from the response package (like
+response.ErrNotFound, response.ErrAlreadyExists, response.ErrInsufficientFunds,
+response.ErrVerificationFailed or response.ErrInvalidParams), the match is done
+by error code.
+
 TODO:
 	Add missing methods to client.
 	Allow client to connect using client cert.
@@ -18,31 +24,54 @@ TODO:
 
 Supported methods
 
+	addwatched
+	calculatenetworkfee
+	estimatefee
+	findstates
+	findstorage
 	getapplicationlog
 	getbestblockhash
 	getblock
 	getblockcount
 	getblockhash
 	getblockheader
+	getblockheadercount
 	getblocksysfee
+	getcandidates
 	getconnectioncount
 	getcontractstate
+	getdesignatedbyrole
+	getnep11balances
+	getnep11transfers
 	getnep17balances
 	getnep17transfers
 	getpeers
+	getproof
 	getrawmempool
 	getrawtransaction
+	getstate
+	getstateheight
+	getstateroot
 	getstorage
 	gettransactionheight
 	getunclaimedgas
 	getvalidators
 	getversion
+	getwatched
 	invoke
 	invokefunction
+	invokefunctionhistoric
 	invokescript
+	invokescripthistoric
+	removewatched
 	sendrawtransaction
+	simulatescript
 	submitblock
+	terminatesession
+	traverseiterator
 	validateaddress
+	verifyproof
+	watchtransaction
 
 Unsupported methods
 
@@ -0,0 +1,202 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+)
+
+// ErrNonStandardWitness is returned by FeeCalculator when network fee can't be
+// calculated locally because verification script is neither a signature nor
+// a multisignature one.
+var ErrNonStandardWitness = errors.New("non-standard verification script")
+
+// FeeCalculator calculates network fee for transactions signed by standard
+// (signature and multisignature) accounts without any RPC calls using Policy
+// contract values provided. It can be used by offline signers that have these
+// values obtained in advance (see GetFeeCalculator).
+type FeeCalculator struct {
+	// ExecFeeFactor is Policy contract execution fee factor.
+	ExecFeeFactor int64
+	// FeePerByte is Policy contract fee per transaction byte.
+	FeePerByte int64
+}
+
+// GetFeeCalculator returns FeeCalculator with the current Policy contract
+// values.
+func (c *Client) GetFeeCalculator() (*FeeCalculator, error) {
+	ef, err := c.GetExecFeeFactor()
+	if err != nil {
+		return nil, fmt.Errorf("can't get `ExecFeeFactor`: %w", err)
+	}
+	fpb, err := c.GetFeePerByte()
+	if err != nil {
+		return nil, fmt.Errorf("can't get `FeePerByte`: %w", err)
+	}
+	return &FeeCalculator{
+		ExecFeeFactor: ef,
+		FeePerByte:    fpb,
+	}, nil
+}
+
+// CalculateNetworkFee returns network fee for the given unsigned transaction
+// with the given verification scripts (one per signer). It returns
+// ErrNonStandardWitness for scripts that are not signature or multisignature
+// ones.
+func (f *FeeCalculator) CalculateNetworkFee(tx *transaction.Transaction, verificationScripts ...[]byte) (int64, error) {
+	if len(tx.Signers) != len(verificationScripts) {
+		return 0, errors.New("number of signers must match number of scripts")
+	}
+	var (
+		netFee int64
+		size   = io.GetVarSize(tx)
+	)
+	for i, script := range verificationScripts {
+		scriptFee, sizeDelta, err := f.witnessFee(script)
+		if err != nil {
+			return 0, fmt.Errorf("signer #%d: %w", i, err)
+		}
+		netFee += scriptFee
+		size += sizeDelta
+	}
+	return netFee + int64(size)*f.FeePerByte, nil
+}
+
+// AddNetworkFee adds network fee for each witness script and optional extra
+// network fee to transaction. `accs` is an array signer's accounts, they all
+// must be standard (non-deployed) ones. DefaultExtraFee is treated as zero
+// extra fee here, there is no FeeOracle to use.
+func (f *FeeCalculator) AddNetworkFee(tx *transaction.Transaction, extraFee int64, accs ...*wallet.Account) error {
+	if extraFee == DefaultExtraFee {
+		extraFee = 0
+	}
+	scripts := make([][]byte, len(accs))
+	for i := range accs {
+		scripts[i] = accs[i].Contract.Script
+	}
+	netFee, err := f.CalculateNetworkFee(tx, scripts...)
+	if err != nil {
+		return err
+	}
+	tx.NetworkFee += netFee + extraFee
+	return nil
+}
+
+// witnessFee returns verification fee and witness size for the given standard
+// verification script.
+func (f *FeeCalculator) witnessFee(script []byte) (int64, int, error) {
+	netFee, size := fee.Calculate(f.ExecFeeFactor, script)
+	if size == 0 {
+		return 0, 0, ErrNonStandardWitness
+	}
+	return netFee, size, nil
+}
+
+// DefaultExtraFee can be passed as an extra network fee to AddNetworkFee,
+// CreateTxFromScript and other methods accepting it to get the extra fee from
+// FeeOracle specified in client Options (no extra fee is added if there is no
+// oracle).
+const DefaultExtraFee int64 = -1
+
+// Default parameters of MempoolFeeOracle.
+const (
+	defaultFeeOraclePercentile = 50
+	defaultFeeOracleMaxSamples = 64
+)
+
+// FeeOracle provides extra network fee (priority tip) for transactions created
+// by the client.
+type FeeOracle interface {
+	// ExtraFee returns extra network fee for the transaction of the given
+	// (final, with witnesses) size that already has netFee network fee.
+	ExtraFee(size int, netFee int64) (int64, error)
+}
+
+// FeeOracleFunc is an adapter allowing to use ordinary functions as
+// FeeOracle.
+type FeeOracleFunc func(size int, netFee int64) (int64, error)
+
+// ExtraFee implements FeeOracle interface.
+func (f FeeOracleFunc) ExtraFee(size int, netFee int64) (int64, error) {
+	return f(size, netFee)
+}
+
+// MempoolFeeOracle is FeeOracle that makes transactions compete with the ones
+// currently in the node's memory pool: extra fee is chosen so that the fee per
+// byte of the transaction is not less than the given percentile of fees per
+// byte of pooled transactions.
+type MempoolFeeOracle struct {
+	// Client is used to get memory pool contents, it must be initialized.
+	Client *Client
+	// Percentile is the percentile (1-100) of pooled transactions fees per
+	// byte to match, 50 (median) is used if it's not set.
+	Percentile int
+	// MaxSamples is the maximum number of pooled transactions fetched to
+	// calculate fee distribution, 64 is used if it's not set.
+	MaxSamples int
+}
+
+// ExtraFee implements FeeOracle interface.
+func (o *MempoolFeeOracle) ExtraFee(size int, netFee int64) (int64, error) {
+	hashes, err := o.Client.GetRawMemPool()
+	if err != nil {
+		return 0, fmt.Errorf("can't get mempool contents: %w", err)
+	}
+	max := o.MaxSamples
+	if max <= 0 {
+		max = defaultFeeOracleMaxSamples
+	}
+	if len(hashes) > max {
+		hashes = hashes[:max]
+	}
+	fees := make([]int64, 0, len(hashes))
+	for _, h := range hashes {
+		tx, err := o.Client.GetRawTransaction(h)
+		if err != nil {
+			// Transaction could've been removed from the pool already.
+			continue
+		}
+		fees = append(fees, tx.FeePerByte())
+	}
+	percentile := o.Percentile
+	if percentile <= 0 || percentile > 100 {
+		percentile = defaultFeeOraclePercentile
+	}
+	target := feePercentile(fees, percentile)
+	if extra := target*int64(size) - netFee; extra > 0 {
+		return extra, nil
+	}
+	return 0, nil
+}
+
+// feePercentile returns the given percentile of fees (which are sorted in
+// place), 0 is returned for an empty slice.
+func feePercentile(fees []int64, percentile int) int64 {
+	if len(fees) == 0 {
+		return 0
+	}
+	sort.Slice(fees, func(i, j int) bool { return fees[i] < fees[j] })
+	i := (len(fees)*percentile+99)/100 - 1
+	if i < 0 {
+		i = 0
+	}
+	return fees[i]
+}
+
+// getExtraFee returns extra network fee from the FeeOracle configured for the
+// transaction of the given size with the given network fee.
+func (c *Client) getExtraFee(size int, netFee int64) (int64, error) {
+	if c.opts.FeeOracle == nil {
+		return 0, nil
+	}
+	extra, err := c.opts.FeeOracle.ExtraFee(size, netFee)
+	if err != nil {
+		return 0, fmt.Errorf("can't get extra fee from oracle: %w", err)
+	}
+	return extra, nil
+}
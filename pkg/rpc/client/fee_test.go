@@ -0,0 +1,55 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeeCalculator(t *testing.T) {
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	acc := wallet.NewAccountFromPrivateKey(priv)
+
+	calc := &FeeCalculator{ExecFeeFactor: 30, FeePerByte: 1000}
+	newTx := func() *transaction.Transaction {
+		tx := transaction.New(netmode.UnitTestNet, []byte{byte(opcode.PUSH1)}, 0)
+		tx.Signers = []transaction.Signer{{Account: acc.Contract.ScriptHash()}}
+		return tx
+	}
+
+	t.Run("signature", func(t *testing.T) {
+		tx := newTx()
+		require.NoError(t, calc.AddNetworkFee(tx, 10, acc))
+		require.NoError(t, acc.SignTx(tx))
+
+		execFee, _ := fee.Calculate(calc.ExecFeeFactor, acc.Contract.Script)
+		require.Equal(t, execFee+int64(io.GetVarSize(tx))*calc.FeePerByte+10, tx.NetworkFee)
+	})
+	t.Run("signers mismatch", func(t *testing.T) {
+		_, err := calc.CalculateNetworkFee(newTx())
+		require.Error(t, err)
+	})
+	t.Run("non-standard", func(t *testing.T) {
+		_, err := calc.CalculateNetworkFee(newTx(), []byte{byte(opcode.PUSHT)})
+		require.True(t, errors.Is(err, ErrNonStandardWitness))
+	})
+}
+
+func TestFeePercentile(t *testing.T) {
+	require.Equal(t, int64(0), feePercentile(nil, 50))
+	require.Equal(t, int64(7), feePercentile([]int64{7}, 1))
+	fees := []int64{5, 1, 4, 2, 3}
+	require.Equal(t, int64(1), feePercentile(fees, 1))
+	require.Equal(t, int64(3), feePercentile(fees, 50))
+	require.Equal(t, int64(4), feePercentile(fees, 80))
+	require.Equal(t, int64(5), feePercentile(fees, 100))
+}
@@ -0,0 +1,157 @@
+package client
+
+import (
+	"encoding/json"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// TraverseIterator returns at most maxItems next values of the iterator from
+// the server-side session (see result.Invoke.Session and result.Iterator).
+// An empty slice is returned when the iterator has no more values. Sessions
+// must be enabled on the server for it to work.
+func (c *Client) TraverseIterator(sessionID, iteratorID string, maxItems int) ([]stackitem.Item, error) {
+	var (
+		params = request.NewRawParams(sessionID, iteratorID, maxItems)
+		resp   []json.RawMessage
+	)
+	if err := c.performRequest("traverseiterator", params, &resp); err != nil {
+		return nil, err
+	}
+	items := make([]stackitem.Item, len(resp))
+	for i := range resp {
+		var err error
+		items[i], err = stackitem.FromJSONWithTypes(resp[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+// TerminateSession drops the server-side iterator session with the given ID,
+// false is returned if there is no such session.
+func (c *Client) TerminateSession(sessionID string) (bool, error) {
+	var (
+		params = request.NewRawParams(sessionID)
+		resp   bool
+	)
+	if err := c.performRequest("terminatesession", params, &resp); err != nil {
+		return false, err
+	}
+	return resp, nil
+}
+
+// expandIterators replaces iterators on the resulting stack of successful
+// invocation with arrays of their values (limited by MaxIteratorItems option).
+// It's done by invoking the same script again with iterator traversal code
+// appended to it, the original result is returned if this invocation fails.
+// method is the script invocation method to use, prefix contains parameters
+// passed before the script and gas is only used if it's not nil.
+func (c *Client) expandIterators(res *result.Invoke, method string, prefix []interface{}, signers []transaction.Signer, gas *int64) *result.Invoke {
+	if c.opts.MaxIteratorItems <= 0 || res.State != vm.HaltState.String() || len(res.Script) == 0 {
+		return res
+	}
+	var hasIterators bool
+	for i := range res.Stack {
+		if res.Stack[i].Type() == stackitem.InteropT {
+			hasIterators = true
+			break
+		}
+	}
+	if !hasIterators {
+		return res
+	}
+	script, err := createIteratorExpansionScript(res.Script, res.Stack, c.opts.MaxIteratorItems)
+	if err != nil {
+		return res
+	}
+	var p = request.NewRawParams(append(prefix, script)...)
+	if signers != nil || gas != nil {
+		if signers == nil {
+			signers = []transaction.Signer{}
+		}
+		p.Values = append(p.Values, signers)
+	}
+	if gas != nil {
+		p.Values = append(p.Values, *gas)
+	}
+	var expanded = new(result.Invoke)
+	if err := c.performRequest(method, p, expanded); err != nil ||
+		expanded.State != vm.HaltState.String() || len(expanded.Stack) != len(res.Stack) {
+		return res
+	}
+	expanded.Script = res.Script
+	expanded.Transaction = res.Transaction
+	return expanded
+}
+
+// createIteratorExpansionScript appends code converting iterators on the
+// resulting stack (according to its expected contents) into arrays of at
+// most maxItems elements to the given script. Stack items are processed from
+// the top one by one with the whole stack being rotated after each item, so
+// that their order is preserved in the end.
+func createIteratorExpansionScript(script []byte, stack []stackitem.Item, maxItems int) ([]byte, error) {
+	w := io.NewBufBinWriter()
+	w.WriteBytes(script)
+	n := len(stack)
+	for k := 0; k < n; k++ {
+		// Top item first, then all the others starting from the bottom.
+		i := n - 1
+		if k > 0 {
+			emit.Int(w.BinWriter, int64(n-1))
+			emit.Opcodes(w.BinWriter, opcode.ROLL)
+			i = k - 1
+		}
+		if stack[i].Type() == stackitem.InteropT {
+			emitIteratorTraversal(w, maxItems)
+		}
+	}
+	if n > 1 {
+		emit.Int(w.BinWriter, int64(n-1))
+		emit.Opcodes(w.BinWriter, opcode.ROLL)
+	}
+	if w.Err != nil {
+		return nil, w.Err
+	}
+	return w.Bytes(), nil
+}
+
+// emitIteratorTraversal emits code replacing the iterator on top of the stack
+// with an array of at most maxItems of its values.
+func emitIteratorTraversal(w *io.BufBinWriter, maxItems int) {
+	code := io.NewBufBinWriter()
+	emit.Opcodes(code.BinWriter, opcode.NEWARRAY0) // iterator, array
+	loopStart := code.Len()
+	emit.Opcodes(code.BinWriter, opcode.OVER)
+	emit.Syscall(code.BinWriter, interopnames.SystemIteratorNext)
+	exitJump := code.Len()
+	emit.Instruction(code.BinWriter, opcode.JMPIFNOT, []byte{0}) // Fixed below.
+	emit.Opcodes(code.BinWriter, opcode.DUP, opcode.PUSH2, opcode.PICK)
+	emit.Syscall(code.BinWriter, interopnames.SystemIteratorValue)
+	emit.Opcodes(code.BinWriter, opcode.APPEND, opcode.DUP, opcode.SIZE)
+	emit.Int(code.BinWriter, int64(maxItems))
+	limitJump := code.Len()
+	emit.Instruction(code.BinWriter, opcode.JMPGE, []byte{0}) // Fixed below.
+	emit.Instruction(code.BinWriter, opcode.JMP, []byte{byte(int8(loopStart - code.Len()))})
+	loopEnd := code.Len()
+	emit.Opcodes(code.BinWriter, opcode.NIP) // array
+	if code.Err != nil {
+		w.Err = code.Err
+		return
+	}
+	b := code.Bytes()
+	// Jump offsets are relative to the jump instruction, the code is small
+	// enough for all of them to fit into a single byte.
+	b[exitJump+1] = byte(loopEnd - exitJump)
+	b[limitJump+1] = byte(loopEnd - limitJump)
+	w.WriteBytes(b)
+}
@@ -0,0 +1,154 @@
+package client
+
+// Contract deployment and update helpers.
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest/standard"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/util/bitfield"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+)
+
+// DeployContract creates, signs and sends a transaction deploying the contract
+// with the given NEF and manifest from the given (decrypted) account. Both are
+// checked locally the same way management contract does it before sending
+// anything. data is passed to contract's _deploy method if it's not nil. System
+// fee is determined via test invocation, gas is an additional network fee. It
+// returns the hash of the contract deployed and the hash of the transaction sent.
+func (c *Client) DeployContract(acc *wallet.Account, nefFile *nef.File, m *manifest.Manifest, data interface{}, gas int64) (util.Uint160, util.Uint256, error) {
+	sender, err := address.StringToUint160(acc.Address)
+	if err != nil {
+		return util.Uint160{}, util.Uint256{}, fmt.Errorf("bad account address: %w", err)
+	}
+	h := state.CreateContractHash(sender, nefFile.Checksum, m.Name)
+	nefBytes, manifBytes, err := prepareContract(nefFile, m, h)
+	if err != nil {
+		return util.Uint160{}, util.Uint256{}, err
+	}
+	mgmtHash, err := c.GetNativeContractHash(nativenames.Management)
+	if err != nil {
+		return util.Uint160{}, util.Uint256{}, fmt.Errorf("failed to get management contract's hash: %w", err)
+	}
+	params := []interface{}{nefBytes, manifBytes}
+	if data != nil {
+		params = append(params, data)
+	}
+	w := io.NewBufBinWriter()
+	emit.AppCall(w.BinWriter, mgmtHash, "deploy", callflag.States|callflag.AllowNotify, params...)
+	if w.Err != nil {
+		return util.Uint160{}, util.Uint256{}, fmt.Errorf("failed to create deployment script: %w", w.Err)
+	}
+	txHash, err := c.sendCalledByEntryScript(acc, sender, w.Bytes(), gas)
+	if err != nil {
+		return util.Uint160{}, util.Uint256{}, err
+	}
+	return h, txHash, nil
+}
+
+// UpdateContract creates, signs and sends a transaction invoking `update`
+// method of the given deployed contract with the new NEF and manifest. Both are
+// checked locally before sending anything as well as the presence of `update`
+// method in the current contract's manifest. data is passed to contract's
+// _deploy method if it's not nil (which requires 3-parameter `update` method).
+// System fee is determined via test invocation, gas is an additional network
+// fee. It returns the hash of the transaction sent.
+func (c *Client) UpdateContract(acc *wallet.Account, contract util.Uint160, nefFile *nef.File, m *manifest.Manifest, data interface{}, gas int64) (util.Uint256, error) {
+	sender, err := address.StringToUint160(acc.Address)
+	if err != nil {
+		return util.Uint256{}, fmt.Errorf("bad account address: %w", err)
+	}
+	cs, err := c.GetContractStateByHash(contract)
+	if err != nil {
+		return util.Uint256{}, fmt.Errorf("failed to get contract state: %w", err)
+	}
+	params := []interface{}{nil, nil}
+	if data != nil {
+		params = append(params, data)
+	}
+	if cs.Manifest.ABI.GetMethod("update", len(params)) == nil {
+		if data != nil || cs.Manifest.ABI.GetMethod("update", 3) == nil {
+			return util.Uint256{}, fmt.Errorf("contract has no `update` method with %d parameters", len(params))
+		}
+		params = append(params, nil)
+	}
+	params[0], params[1], err = prepareContract(nefFile, m, contract)
+	if err != nil {
+		return util.Uint256{}, err
+	}
+	w := io.NewBufBinWriter()
+	emit.AppCall(w.BinWriter, contract, "update", callflag.All, params...)
+	if w.Err != nil {
+		return util.Uint256{}, fmt.Errorf("failed to create update script: %w", w.Err)
+	}
+	return c.sendCalledByEntryScript(acc, sender, w.Bytes(), gas)
+}
+
+// prepareContract checks NEF and manifest of the contract with the given hash
+// and returns their serialized forms.
+func prepareContract(nefFile *nef.File, m *manifest.Manifest, h util.Uint160) ([]byte, []byte, error) {
+	if nefFile.Checksum != nefFile.CalculateChecksum() {
+		return nil, nil, errors.New("invalid NEF checksum")
+	}
+	nefBytes, err := nefFile.Bytes()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to serialize NEF: %w", err)
+	}
+	manifBytes, err := json.Marshal(m)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to serialize manifest: %w", err)
+	}
+	if len(manifBytes) > manifest.MaxManifestSize {
+		return nil, nil, fmt.Errorf("manifest is too big: %d bytes", len(manifBytes))
+	}
+	if err := m.IsValid(h); err != nil {
+		return nil, nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	if err := standard.Check(m, m.SupportedStandards...); err != nil {
+		return nil, nil, err
+	}
+	offsets := bitfield.New(len(nefFile.Script))
+	for i := range m.ABI.Methods {
+		if m.ABI.Methods[i].Offset < 0 || m.ABI.Methods[i].Offset >= len(nefFile.Script) {
+			return nil, nil, fmt.Errorf("method %s: out of bounds offset", m.ABI.Methods[i].Name)
+		}
+		offsets.Set(m.ABI.Methods[i].Offset)
+	}
+	if err := vm.IsScriptCorrect(nefFile.Script, offsets); err != nil {
+		return nil, nil, fmt.Errorf("invalid script: %w", err)
+	}
+	return nefBytes, manifBytes, nil
+}
+
+// sendCalledByEntryScript creates, signs and sends a transaction with the
+// given script using the given account as a sender with CalledByEntry scope.
+// System fee is determined via test invocation.
+func (c *Client) sendCalledByEntryScript(acc *wallet.Account, sender util.Uint160, script []byte, netFee int64) (util.Uint256, error) {
+	tx, err := c.CreateTxFromScript(script, acc, -1, netFee, []SignerAccount{{
+		Signer: transaction.Signer{
+			Account: sender,
+			Scopes:  transaction.CalledByEntry,
+		},
+		Account: acc,
+	}})
+	if err != nil {
+		return util.Uint256{}, err
+	}
+	if err := acc.SignTx(tx); err != nil {
+		return util.Uint256{}, fmt.Errorf("can't sign tx: %w", err)
+	}
+	return c.SendRawTransaction(tx)
+}
@@ -9,8 +9,11 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
 )
 
 // GetOraclePrice invokes `getPrice` method on a native Oracle contract.
@@ -40,6 +43,23 @@ func (c *Client) GetGasPerBlock() (int64, error) {
 	return c.invokeNativeGetMethod(neoHash, "getGasPerBlock")
 }
 
+// ClaimGas creates, signs and sends a transaction transferring zero NEO from
+// the given account to itself, this makes NEO contract distribute all GAS
+// accumulated for this account. gas is an additional network fee to add to
+// the transaction. It returns the hash of the transaction sent, the amount
+// that can be claimed can be checked beforehand with GetUnclaimedGas.
+func (c *Client) ClaimGas(acc *wallet.Account, gas int64) (util.Uint256, error) {
+	neoHash, err := c.GetNativeContractHash(nativenames.Neo)
+	if err != nil {
+		return util.Uint256{}, fmt.Errorf("failed to get native NEO hash: %w", err)
+	}
+	accHash, err := address.StringToUint160(acc.Address)
+	if err != nil {
+		return util.Uint256{}, fmt.Errorf("bad account address: %w", err)
+	}
+	return c.TransferNEP17(acc, accHash, neoHash, 0, gas, nil)
+}
+
 // GetDesignatedByRole invokes `getDesignatedByRole` method on a native RoleManagement contract.
 func (c *Client) GetDesignatedByRole(role noderoles.Role, index uint32) (keys.PublicKeys, error) {
 	rmHash, err := c.GetNativeContractHash(nativenames.Designation)
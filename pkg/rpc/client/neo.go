@@ -0,0 +1,156 @@
+package client
+
+// NEO governance helpers: candidate registration, voting and queries.
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+)
+
+// registerFeeReserve is the amount of GAS added to the candidate registration
+// price to pay for the execution of registration script. Test invocation can't
+// be used to compute it because registration price is usually above server's
+// GAS limit for invocations.
+const registerFeeReserve = 1_0000_0000
+
+// Candidate represents registered NEO candidate with the number of votes it
+// has.
+type Candidate struct {
+	PublicKey *keys.PublicKey
+	Votes     *big.Int
+}
+
+// GetCandidates invokes `getCandidates` method on a native NEO contract and
+// returns the list of registered candidates.
+func (c *Client) GetCandidates() ([]Candidate, error) {
+	neoHash, err := c.GetNativeContractHash(nativenames.Neo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get native NEO hash: %w", err)
+	}
+	item, err := c.NewContractInvoker(neoHash, nil).Call("getCandidates")
+	if err != nil {
+		return nil, err
+	}
+	arr, err := ItemToArray(item)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]Candidate, len(arr))
+	for i := range arr {
+		fields, err := ItemToArray(arr[i])
+		if err != nil {
+			return nil, fmt.Errorf("candidate #%d: %w", i, err)
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("candidate #%d: wrong number of fields: %d", i, len(fields))
+		}
+		res[i].PublicKey, err = ItemToPublicKey(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("candidate #%d: %w", i, err)
+		}
+		res[i].Votes, err = ItemToBigInt(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("candidate #%d: %w", i, err)
+		}
+	}
+	return res, nil
+}
+
+// GetCandidateRegisterPrice invokes `getRegisterPrice` method on a native NEO
+// contract.
+func (c *Client) GetCandidateRegisterPrice() (int64, error) {
+	neoHash, err := c.GetNativeContractHash(nativenames.Neo)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get native NEO hash: %w", err)
+	}
+	return c.invokeNativeGetMethod(neoHash, "getRegisterPrice")
+}
+
+// RegisterCandidate creates, signs and sends a transaction registering the
+// public key of the given (decrypted) account as a candidate. System fee is
+// calculated using current registration price, gas is an additional network
+// fee. It returns the hash of the transaction sent.
+func (c *Client) RegisterCandidate(acc *wallet.Account, gas int64) (util.Uint256, error) {
+	priv := acc.PrivateKey()
+	if priv == nil {
+		return util.Uint256{}, errors.New("account is not decrypted")
+	}
+	price, err := c.GetCandidateRegisterPrice()
+	if err != nil {
+		return util.Uint256{}, err
+	}
+	return c.sendNEOInvocation(acc, price+registerFeeReserve, gas, "registerCandidate", priv.PublicKey().Bytes())
+}
+
+// UnregisterCandidate creates, signs and sends a transaction unregistering the
+// public key of the given (decrypted) account. gas is an additional network
+// fee. It returns the hash of the transaction sent.
+func (c *Client) UnregisterCandidate(acc *wallet.Account, gas int64) (util.Uint256, error) {
+	priv := acc.PrivateKey()
+	if priv == nil {
+		return util.Uint256{}, errors.New("account is not decrypted")
+	}
+	return c.sendNEOInvocation(acc, -1, gas, "unregisterCandidate", priv.PublicKey().Bytes())
+}
+
+// Vote creates, signs and sends a transaction voting for the given candidate
+// with all NEO of the given account, nil candidate removes the vote. gas is an
+// additional network fee. It returns the hash of the transaction sent.
+func (c *Client) Vote(acc *wallet.Account, candidate *keys.PublicKey, gas int64) (util.Uint256, error) {
+	accHash, err := address.StringToUint160(acc.Address)
+	if err != nil {
+		return util.Uint256{}, fmt.Errorf("bad account address: %w", err)
+	}
+	var pubArg interface{}
+	if candidate != nil {
+		pubArg = candidate.Bytes()
+	}
+	return c.sendNEOInvocation(acc, -1, gas, "vote", accHash.BytesBE(), pubArg)
+}
+
+// sendNEOInvocation creates, signs and sends a transaction invoking the given
+// NEO contract method that returns a boolean success flag (failing the
+// transaction if it's false). If sysFee is negative it's determined via test
+// invocation.
+func (c *Client) sendNEOInvocation(acc *wallet.Account, sysFee, netFee int64, method string, params ...interface{}) (util.Uint256, error) {
+	neoHash, err := c.GetNativeContractHash(nativenames.Neo)
+	if err != nil {
+		return util.Uint256{}, fmt.Errorf("failed to get native NEO hash: %w", err)
+	}
+	accHash, err := address.StringToUint160(acc.Address)
+	if err != nil {
+		return util.Uint256{}, fmt.Errorf("bad account address: %w", err)
+	}
+	w := io.NewBufBinWriter()
+	emit.AppCall(w.BinWriter, neoHash, method, callflag.States, params...)
+	emit.Opcodes(w.BinWriter, opcode.ASSERT)
+	if w.Err != nil {
+		return util.Uint256{}, fmt.Errorf("failed to create %s script: %w", method, w.Err)
+	}
+	tx, err := c.CreateTxFromScript(w.Bytes(), acc, sysFee, netFee, []SignerAccount{{
+		Signer: transaction.Signer{
+			Account: accHash,
+			Scopes:  transaction.CalledByEntry,
+		},
+		Account: acc,
+	}})
+	if err != nil {
+		return util.Uint256{}, err
+	}
+	if err := acc.SignTx(tx); err != nil {
+		return util.Uint256{}, fmt.Errorf("can't sign tx: %w", err)
+	}
+	return c.SendRawTransaction(tx)
+}
@@ -0,0 +1,117 @@
+package client
+
+import (
+	"encoding/hex"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// TokenProperties represents NEP11 token properties returned by the optional
+// `properties` method. Standard properties are stored in the corresponding
+// fields, all others are put into Extra.
+type TokenProperties struct {
+	Name        string
+	Description string
+	Image       string
+	TokenURI    string
+	Extra       map[string]string
+}
+
+// NEP11Properties invokes optional `properties` NEP11 method on a specified
+// contract and returns raw properties map.
+func (c *Client) NEP11Properties(tokenHash util.Uint160, tokenID []byte) (*stackitem.Map, error) {
+	result, err := c.InvokeFunction(tokenHash, "properties", []smartcontract.Parameter{{
+		Type:  smartcontract.ByteArrayType,
+		Value: tokenID,
+	}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = getInvocationError(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get NEP11 properties: %w", err)
+	}
+
+	return topMapFromStack(result.Stack)
+}
+
+// NEP11TokenProperties invokes optional `properties` NEP11 method on a
+// specified contract and returns decoded properties.
+func (c *Client) NEP11TokenProperties(tokenHash util.Uint160, tokenID []byte) (*TokenProperties, error) {
+	m, err := c.NEP11Properties(tokenHash, tokenID)
+	if err != nil {
+		return nil, err
+	}
+	return NewTokenPropertiesFromMap(m)
+}
+
+// NewTokenPropertiesFromMap decodes NEP11 token properties from the given map.
+// Keys and values are expected to be primitive stack items, byte strings that
+// are not valid UTF-8 are hex-encoded.
+func NewTokenPropertiesFromMap(m *stackitem.Map) (*TokenProperties, error) {
+	props := &TokenProperties{
+		Extra: make(map[string]string),
+	}
+	for _, e := range m.Value().([]stackitem.MapElement) {
+		k, err := propertyToString(e.Key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid property key: %w", err)
+		}
+		v, err := propertyToString(e.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid '%s' property value: %w", k, err)
+		}
+		switch k {
+		case "name":
+			props.Name = v
+		case "description":
+			props.Description = v
+		case "image":
+			props.Image = v
+		case "tokenURI":
+			props.TokenURI = v
+		default:
+			props.Extra[k] = v
+		}
+	}
+	return props, nil
+}
+
+// propertyToString converts primitive stack item to string.
+func propertyToString(item stackitem.Item) (string, error) {
+	switch item.Type() {
+	case stackitem.ByteArrayT, stackitem.BufferT:
+		bs, err := item.TryBytes()
+		if err != nil {
+			return "", err
+		}
+		if !utf8.Valid(bs) {
+			return hex.EncodeToString(bs), nil
+		}
+		return string(bs), nil
+	case stackitem.IntegerT:
+		bi, err := item.TryInteger()
+		if err != nil {
+			return "", err
+		}
+		return bi.String(), nil
+	case stackitem.BooleanT:
+		return fmt.Sprint(item.Value()), nil
+	default:
+		return "", fmt.Errorf("unsupported item type: %s", item.Type())
+	}
+}
+
+// topMapFromStack returns the top map from stack.
+func topMapFromStack(st []stackitem.Item) (*stackitem.Map, error) {
+	index := len(st) - 1 // top stack element is last in the array
+	m, ok := st[index].(*stackitem.Map)
+	if !ok {
+		return nil, fmt.Errorf("invalid stack item type: %s", st[index].Type())
+	}
+	return m, nil
+}
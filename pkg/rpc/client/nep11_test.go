@@ -0,0 +1,40 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTokenPropertiesFromMap(t *testing.T) {
+	m := stackitem.NewMap()
+	m.Add(stackitem.NewByteArray([]byte("name")), stackitem.NewByteArray([]byte("Token")))
+	m.Add(stackitem.NewByteArray([]byte("description")), stackitem.NewBuffer([]byte("Some token")))
+	m.Add(stackitem.NewByteArray([]byte("image")), stackitem.NewByteArray([]byte("https://example.com/token.png")))
+	m.Add(stackitem.NewByteArray([]byte("tokenURI")), stackitem.NewByteArray([]byte("https://example.com/token")))
+	m.Add(stackitem.NewByteArray([]byte("expiration")), stackitem.Make(1234))
+	m.Add(stackitem.NewByteArray([]byte("binary")), stackitem.NewByteArray([]byte{0xff, 0xfe}))
+	m.Add(stackitem.NewByteArray([]byte{0xff}), stackitem.NewBool(true))
+
+	props, err := NewTokenPropertiesFromMap(m)
+	require.NoError(t, err)
+	require.Equal(t, &TokenProperties{
+		Name:        "Token",
+		Description: "Some token",
+		Image:       "https://example.com/token.png",
+		TokenURI:    "https://example.com/token",
+		Extra: map[string]string{
+			"expiration": "1234",
+			"binary":     "fffe",
+			"ff":         "true",
+		},
+	}, props)
+
+	t.Run("compound value", func(t *testing.T) {
+		m := stackitem.NewMap()
+		m.Add(stackitem.NewByteArray([]byte("name")), stackitem.NewArray(nil))
+		_, err := NewTokenPropertiesFromMap(m)
+		require.Error(t, err)
+	})
+}
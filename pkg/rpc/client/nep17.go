@@ -5,12 +5,14 @@ import (
 	"fmt"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
@@ -31,6 +33,37 @@ type SignerAccount struct {
 	Account *wallet.Account
 }
 
+// NewSignerAccount returns SignerAccount for the given wallet account with the
+// given scopes. Allowed contracts and groups can be added to it with
+// AllowContracts and AllowGroups.
+func NewSignerAccount(acc *wallet.Account, scopes transaction.WitnessScope) (SignerAccount, error) {
+	u, err := address.StringToUint160(acc.Address)
+	if err != nil {
+		return SignerAccount{}, fmt.Errorf("bad account address: %w", err)
+	}
+	return SignerAccount{
+		Signer: transaction.Signer{
+			Account: u,
+			Scopes:  scopes,
+		},
+		Account: acc,
+	}, nil
+}
+
+// AllowContracts adds the given contracts to the list of contracts allowed to
+// use signer's witness and sets CustomContracts scope.
+func (s *SignerAccount) AllowContracts(hashes ...util.Uint160) {
+	s.Signer.Scopes |= transaction.CustomContracts
+	s.Signer.AllowedContracts = append(s.Signer.AllowedContracts, hashes...)
+}
+
+// AllowGroups adds the given groups to the list of groups allowed to use
+// signer's witness and sets CustomGroups scope.
+func (s *SignerAccount) AllowGroups(groups ...*keys.PublicKey) {
+	s.Signer.Scopes |= transaction.CustomGroups
+	s.Signer.AllowedGroups = append(s.Signer.AllowedGroups, groups...)
+}
+
 // NEP17Decimals invokes `decimals` NEP17 method on a specified contract.
 func (c *Client) NEP17Decimals(tokenHash util.Uint160) (int64, error) {
 	result, err := c.InvokeFunction(tokenHash, "decimals", []smartcontract.Parameter{}, nil)
@@ -159,6 +192,9 @@ func (c *Client) CreateNEP17MultiTransferTx(acc *wallet.Account, gas int64, reci
 // CreateTxFromScript creates transaction and properly sets cosigners and NetworkFee.
 // If sysFee <= 0, it is determined via result of `invokescript` RPC. You should
 // initialize network magic with Init before calling CreateTxFromScript.
+// Transactions sent by the committee account get HighPriority attribute.
+// netFee is an extra network fee, pass DefaultExtraFee to get it from the
+// FeeOracle set in client Options.
 func (c *Client) CreateTxFromScript(script []byte, acc *wallet.Account, sysFee, netFee int64,
 	cosigners []SignerAccount) (*transaction.Transaction, error) {
 	signers, accounts, err := getSigners(acc, cosigners)
@@ -180,9 +216,21 @@ func (c *Client) CreateTxFromScript(script []byte, acc *wallet.Account, sysFee,
 		return nil, errNetworkNotInitialized
 	}
 	tx := transaction.New(c.GetNetwork(), script, sysFee)
+	tx.Nonce = c.getNonce()
 	tx.Signers = signers
+	// Committee can only be a multisignature account, so there is no need
+	// to check other senders.
+	if acc.Contract != nil && vm.IsMultiSigContract(acc.Contract.Script) {
+		committee, err := c.GetCommitteeAddress()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get committee address: %w", err)
+		}
+		if signers[0].Account == committee {
+			tx.Attributes = append(tx.Attributes, transaction.Attribute{Type: transaction.HighPriority})
+		}
+	}
 
-	tx.ValidUntilBlock, err = c.CalculateValidUntilBlock()
+	tx.ValidUntilBlock, err = c.getValidUntilBlock()
 	if err != nil {
 		return nil, fmt.Errorf("failed to add validUntilBlock to transaction: %w", err)
 	}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nnsrecords"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// ErrNNSRecordNotFound is returned when requested NameService record doesn't
+// exist.
+var ErrNNSRecordNotFound = errors.New("record not found")
+
+// NNSResolve invokes `resolve` method on a native NameService contract. It
+// returns the record of the specified type following CNAME redirects.
+func (c *Client) NNSResolve(name string, typ nnsrecords.Type) (string, error) {
+	return c.nnsGetRecord("resolve", name, typ)
+}
+
+// NNSGetRecord invokes `getRecord` method on a native NameService contract.
+// Unlike NNSResolve it doesn't follow CNAME redirects.
+func (c *Client) NNSGetRecord(name string, typ nnsrecords.Type) (string, error) {
+	return c.nnsGetRecord("getRecord", name, typ)
+}
+
+func (c *Client) nnsGetRecord(method string, name string, typ nnsrecords.Type) (string, error) {
+	nnsHash, err := c.GetNativeContractHash(nativenames.NameService)
+	if err != nil {
+		return "", fmt.Errorf("failed to get native NameService hash: %w", err)
+	}
+	result, err := c.InvokeFunction(nnsHash, method, []smartcontract.Parameter{
+		{
+			Type:  smartcontract.StringType,
+			Value: name,
+		},
+		{
+			Type:  smartcontract.IntegerType,
+			Value: int64(typ),
+		},
+	}, nil)
+	if err != nil {
+		return "", err
+	}
+	err = getInvocationError(result)
+	if err != nil {
+		return "", fmt.Errorf("`%s`: %w", method, err)
+	}
+	if _, ok := result.Stack[len(result.Stack)-1].(stackitem.Null); ok {
+		return "", ErrNNSRecordNotFound
+	}
+	return topStringFromStack(result.Stack)
+}
+
+// NNSOwnerOf invokes `ownerOf` method on a native NameService contract and
+// returns the owner of the given name.
+func (c *Client) NNSOwnerOf(name string) (util.Uint160, error) {
+	nnsHash, err := c.GetNativeContractHash(nativenames.NameService)
+	if err != nil {
+		return util.Uint160{}, fmt.Errorf("failed to get native NameService hash: %w", err)
+	}
+	result, err := c.InvokeFunction(nnsHash, "ownerOf", []smartcontract.Parameter{{
+		Type:  smartcontract.ByteArrayType,
+		Value: []byte(name),
+	}}, nil)
+	if err != nil {
+		return util.Uint160{}, err
+	}
+	err = getInvocationError(result)
+	if err != nil {
+		return util.Uint160{}, fmt.Errorf("`ownerOf`: %w", err)
+	}
+	return topUint160FromStack(result.Stack)
+}
+
+// NNSIsAvailable invokes `isAvailable` method on a native NameService contract
+// and checks whether the given name can be registered.
+func (c *Client) NNSIsAvailable(name string) (bool, error) {
+	nnsHash, err := c.GetNativeContractHash(nativenames.NameService)
+	if err != nil {
+		return false, fmt.Errorf("failed to get native NameService hash: %w", err)
+	}
+	result, err := c.InvokeFunction(nnsHash, "isAvailable", []smartcontract.Parameter{{
+		Type:  smartcontract.StringType,
+		Value: name,
+	}}, nil)
+	if err != nil {
+		return false, err
+	}
+	err = getInvocationError(result)
+	if err != nil {
+		return false, fmt.Errorf("`isAvailable`: %w", err)
+	}
+	return topBoolFromStack(result.Stack)
+}
+
+// ResolveAddress returns script hash for the given string which can be either
+// an address or a NameService name. Names are resolved to their TXT records
+// which are expected to contain an address.
+func (c *Client) ResolveAddress(s string) (util.Uint160, error) {
+	if u, err := address.StringToUint160(s); err == nil {
+		return u, nil
+	}
+	rec, err := c.NNSResolve(s, nnsrecords.TXT)
+	if err != nil {
+		return util.Uint160{}, fmt.Errorf("failed to resolve '%s': %w", s, err)
+	}
+	u, err := address.StringToUint160(rec)
+	if err != nil {
+		return util.Uint160{}, fmt.Errorf("'%s' TXT record is not an address: %w", s, err)
+	}
+	return u, nil
+}
+
+// topUint160FromStack returns the top Uint160 value from stack.
+func topUint160FromStack(st []stackitem.Item) (util.Uint160, error) {
+	index := len(st) - 1 // top stack element is last in the array
+	bs, err := st[index].TryBytes()
+	if err != nil {
+		return util.Uint160{}, err
+	}
+	return util.Uint160DecodeBytesBE(bs)
+}
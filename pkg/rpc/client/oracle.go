@@ -0,0 +1,78 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+)
+
+// OracleRequest contains parameters of native Oracle contract `request` method.
+type OracleRequest struct {
+	URL string
+	// Filter is an optional JSONPath filter, nil means no filter.
+	Filter   *string
+	Callback string
+	UserData interface{}
+	// GasForResponse is the amount of GAS (in GAS fractions) paid for the
+	// response transaction, it's included into the request system fee.
+	GasForResponse int64
+}
+
+// CreateOracleRequestTx creates an invocation transaction calling the given
+// contract method with oracle request parameters (url, filter, callback,
+// userData, gasForResponse). Oracle contract only accepts requests made by
+// deployed contracts (callback method is called on the requesting contract),
+// so this method is expected to pass its parameters to Oracle's `request`.
+// System fee is determined via test invocation, so it includes Oracle request
+// price and GAS for response. The returned transaction is not signed.
+func (c *Client) CreateOracleRequestTx(acc *wallet.Account, contract util.Uint160, method string, req OracleRequest, netFee int64) (*transaction.Transaction, error) {
+	script, err := createOracleRequestScript(contract, method, req)
+	if err != nil {
+		return nil, err
+	}
+	accAddr, err := address.StringToUint160(acc.Address)
+	if err != nil {
+		return nil, fmt.Errorf("bad account address: %w", err)
+	}
+	return c.CreateTxFromScript(script, acc, -1, netFee, []SignerAccount{{
+		Signer: transaction.Signer{
+			Account: accAddr,
+			Scopes:  transaction.CalledByEntry,
+		},
+		Account: acc,
+	}})
+}
+
+// SubmitOracleRequest creates an oracle request transaction (see
+// CreateOracleRequestTx), signs it with the given account and sends it to the
+// network returning its hash.
+func (c *Client) SubmitOracleRequest(acc *wallet.Account, contract util.Uint160, method string, req OracleRequest, netFee int64) (util.Uint256, error) {
+	tx, err := c.CreateOracleRequestTx(acc, contract, method, req, netFee)
+	if err != nil {
+		return util.Uint256{}, err
+	}
+	if err := acc.SignTx(tx); err != nil {
+		return util.Uint256{}, fmt.Errorf("can't sign tx: %w", err)
+	}
+	return c.SendRawTransaction(tx)
+}
+
+func createOracleRequestScript(contract util.Uint160, method string, req OracleRequest) ([]byte, error) {
+	var filter interface{}
+	if req.Filter != nil {
+		filter = *req.Filter
+	}
+	w := io.NewBufBinWriter()
+	emit.AppCall(w.BinWriter, contract, method, callflag.All,
+		req.URL, filter, req.Callback, req.UserData, req.GasForResponse)
+	if w.Err != nil {
+		return nil, fmt.Errorf("failed to create oracle request script: %w", w.Err)
+	}
+	return w.Bytes(), nil
+}
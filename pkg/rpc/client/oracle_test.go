@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateOracleRequestTx(t *testing.T) {
+	var invoked []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r := request.NewRequest()
+		err := r.DecodeData(req.Body)
+		require.NoErrorf(t, err, "Cannot decode request body: %s", req.Body)
+		var response string
+		switch r.In.Method {
+		case "getblockcount":
+			response = `{"jsonrpc":"2.0","id":1,"result":10}`
+		case "invokescript":
+			p, err := r.In.Params()
+			require.NoError(t, err)
+			invoked, err = p.Value(0).GetBytesBase64()
+			require.NoError(t, err)
+			response = `{"id":1,"jsonrpc":"2.0","result":{"state":"HALT","gasconsumed":"50001234","script":"EQ==","stack":[],"tx":null}}`
+		case "invokefunction":
+			response = `{"id":1,"jsonrpc":"2.0","result":{"state":"HALT","gasconsumed":"1","script":"EQ==","stack":[{"type":"Integer","value":"1000"}],"tx":null}}`
+		}
+		requestHandler(t, r.In, w, response)
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := New(context.TODO(), srv.URL, Options{ValidUntilBlockOffset: 100})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	acc := wallet.NewAccountFromPrivateKey(priv)
+
+	contract := util.Uint160{1, 2, 3}
+	filter := "$.value"
+	req := OracleRequest{
+		URL:            "https://example.com",
+		Filter:         &filter,
+		Callback:       "callback",
+		UserData:       int64(42),
+		GasForResponse: 1000_0000,
+	}
+	tx, err := c.CreateOracleRequestTx(acc, contract, "request", req, 10)
+	require.NoError(t, err)
+
+	w := io.NewBufBinWriter()
+	emit.AppCall(w.BinWriter, contract, "request", callflag.All,
+		req.URL, filter, req.Callback, req.UserData, req.GasForResponse)
+	require.NoError(t, w.Err)
+	require.Equal(t, w.Bytes(), tx.Script)
+	require.Equal(t, w.Bytes(), invoked)
+	require.Equal(t, int64(50001234), tx.SystemFee)
+	require.Equal(t, uint32(110), tx.ValidUntilBlock)
+	require.Equal(t, 1, len(tx.Signers))
+	require.Equal(t, acc.Contract.ScriptHash(), tx.Signers[0].Account)
+	require.True(t, tx.NetworkFee > 10)
+
+	t.Run("no filter", func(t *testing.T) {
+		req.Filter = nil
+		req.UserData = nil
+		tx, err := c.CreateOracleRequestTx(acc, contract, "request", req, 0)
+		require.NoError(t, err)
+
+		w := io.NewBufBinWriter()
+		emit.AppCall(w.BinWriter, contract, "request", callflag.All,
+			req.URL, nil, req.Callback, nil, req.GasForResponse)
+		require.NoError(t, w.Err)
+		require.Equal(t, w.Bytes(), tx.Script)
+	})
+}
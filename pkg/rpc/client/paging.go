@@ -0,0 +1,119 @@
+package client
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// IterateNEP17Transfers requests NEP17 transfers of the address page by page
+// (using cursors returned by the server) and calls f for every page. Start,
+// stop and limit parameters are optional and have the same meaning as for
+// GetNEP17Transfers, limit is the maximum number of transfers per page.
+// Iteration stops when there are no more transfers, f returns false or an
+// error (which is returned then).
+func (c *Client) IterateNEP17Transfers(address string, start, stop *uint32, limit *int, f func(*result.NEP17Transfers) (bool, error)) error {
+	var next string
+	for {
+		resp := new(result.NEP17Transfers)
+		if err := c.performRequest("getnep17transfers", packPagedTransfersParams(address, start, stop, limit, next), resp); err != nil {
+			return err
+		}
+		cont, err := f(resp)
+		if err != nil || !cont || resp.Next == "" {
+			return err
+		}
+		next = resp.Next
+	}
+}
+
+// IterateNEP11Transfers is the same as IterateNEP17Transfers, but for NEP11
+// transfers.
+func (c *Client) IterateNEP11Transfers(address string, start, stop *uint32, limit *int, f func(*result.NEP11Transfers) (bool, error)) error {
+	var next string
+	for {
+		resp := new(result.NEP11Transfers)
+		if err := c.performRequest("getnep11transfers", packPagedTransfersParams(address, start, stop, limit, next), resp); err != nil {
+			return err
+		}
+		cont, err := f(resp)
+		if err != nil || !cont || resp.Next == "" {
+			return err
+		}
+		next = resp.Next
+	}
+}
+
+// packPagedTransfersParams creates parameters for getnep11transfers and
+// getnep17transfers calls using cursors, missing parameters are nulls.
+func packPagedTransfersParams(address string, start, stop *uint32, limit *int, next string) request.RawParams {
+	params := request.NewRawParams(address, nil, nil, nil, nil, nil)
+	if start != nil {
+		params.Values[1] = *start
+	}
+	if stop != nil {
+		params.Values[2] = *stop
+	}
+	if limit != nil {
+		params.Values[3] = *limit
+	}
+	if next != "" {
+		params.Values[5] = next
+	}
+	return params
+}
+
+// IterateStates requests storage items of the contract having the given
+// prefix at the state specified by stateroot page by page (using cursors
+// returned by the server) and calls f for every page, see FindStates for
+// details. maxCount is an optional maximum number of items per page.
+// Iteration stops when there are no more items, f returns false or an error
+// (which is returned then).
+func (c *Client) IterateStates(stateroot util.Uint256, contract util.Uint160, prefix []byte, maxCount *int, f func(*result.FindStates) (bool, error)) error {
+	var next string
+	for {
+		var resp result.FindStates
+		params := request.NewRawParams(stateroot.StringLE(), contract.StringLE(), prefix, nil, nil, nil)
+		if maxCount != nil {
+			params.Values[4] = *maxCount
+		}
+		if next != "" {
+			params.Values[5] = next
+		}
+		if err := c.performRequest("findstates", params, &resp); err != nil {
+			return err
+		}
+		cont, err := f(&resp)
+		if err != nil || !cont || resp.Next == "" {
+			return err
+		}
+		next = resp.Next
+	}
+}
+
+// IterateStorage requests current storage items of the contract having the
+// given prefix page by page (using cursors returned by the server) and calls f
+// for every page, see FindStorage for details. maxCount is an optional maximum
+// number of items per page. Iteration stops when there are no more items, f
+// returns false or an error (which is returned then).
+func (c *Client) IterateStorage(contract util.Uint160, prefix []byte, maxCount *int, f func(*result.FindStorage) (bool, error)) error {
+	var next string
+	for {
+		resp := new(result.FindStorage)
+		params := request.NewRawParams(contract.StringLE(), prefix, nil, nil)
+		if maxCount != nil {
+			params.Values[2] = *maxCount
+		}
+		if next != "" {
+			params.Values[3] = next
+		}
+		if err := c.performRequest("findstorage", params, resp); err != nil {
+			return err
+		}
+		cont, err := f(resp)
+		if err != nil || !cont || resp.Next == "" {
+			return err
+		}
+		next = resp.Next
+	}
+}
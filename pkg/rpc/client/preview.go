@@ -0,0 +1,64 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+)
+
+// TxPreview contains final transaction parameters predicted before signing.
+type TxPreview struct {
+	// Size is the serialized size of the transaction with all witnesses.
+	Size int
+	// SizeLimitExceeded is set if Size exceeds
+	// transaction.MaxTransactionSize, such transaction can't be accepted by
+	// the network.
+	SizeLimitExceeded bool
+	// SystemFee is the amount of GAS consumed by the transaction script test
+	// invocation.
+	SystemFee int64
+	// NetworkFee is the network fee required for the transaction, it's the
+	// sum of WitnessFees and SizeFee (extra fee is not included).
+	NetworkFee int64
+	// WitnessFees are verification fees of witnesses in signers order.
+	WitnessFees []int64
+	// SizeFee is the part of network fee paid for transaction size.
+	SizeFee int64
+}
+
+// PreviewTx returns predicted size and fees of the transaction that doesn't
+// have witnesses yet. `accs` are signers' accounts (one per signer) the same
+// way as for AddNetworkFee. Transaction script is test-invoked to get the
+// system fee, an error is returned if this invocation fails. Transaction
+// itself is not changed.
+func (c *Client) PreviewTx(tx *transaction.Transaction, accs ...*wallet.Account) (*TxPreview, error) {
+	calc, err := c.GetFeeCalculator()
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.InvokeScript(tx.Script, tx.Signers)
+	if err != nil {
+		return nil, fmt.Errorf("can't invoke script: %w", err)
+	}
+	if res.State != vm.HaltState.String() {
+		return nil, fmt.Errorf("script invocation failed: %s", res.FaultException)
+	}
+	fees, size, err := c.getWitnessFees(tx, calc.ExecFeeFactor, accs)
+	if err != nil {
+		return nil, err
+	}
+	p := &TxPreview{
+		Size:              size,
+		SizeLimitExceeded: size > transaction.MaxTransactionSize,
+		SystemFee:         res.GasConsumed,
+		WitnessFees:       fees,
+		SizeFee:           int64(size) * calc.FeePerByte,
+	}
+	p.NetworkFee = p.SizeFee
+	for _, f := range fees {
+		p.NetworkFee += f
+	}
+	return p, nil
+}
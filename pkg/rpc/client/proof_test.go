@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientProof(t *testing.T) {
+	tr := mpt.NewTrie(nil, false, storage.NewMemCachedStore(storage.NewMemoryStore()))
+	key := []byte{1, 0, 0, 0, 0xAB, 0xCD}
+	require.NoError(t, tr.Put(key, []byte{42}))
+	require.NoError(t, tr.Put([]byte{1, 0, 0, 0, 0xAB, 0xCE}, []byte{43}))
+	root := tr.StateRoot()
+	rawProof, err := tr.GetProof(key)
+	require.NoError(t, err)
+	proof := &result.ProofWithKey{Key: key, Proof: rawProof}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r := request.NewRequest()
+		err := r.DecodeData(req.Body)
+		require.NoErrorf(t, err, "Cannot decode request body: %s", req.Body)
+		var response string
+		switch r.In.Method {
+		case "getproof":
+			p, _ := r.In.Params()
+			h, err := p.Value(0).GetUint256()
+			require.NoError(t, err)
+			if h.Equals(root) {
+				response = `{"id":1,"jsonrpc":"2.0","result":{"proof":"` + proof.String() + `","success":true}}`
+			} else {
+				response = `{"id":1,"jsonrpc":"2.0","result":{"proof":"00","success":false}}`
+			}
+		case "verifyproof":
+			response = `{"id":1,"jsonrpc":"2.0","result":{"value":"2a"}}`
+		}
+		requestHandler(t, r.In, w, response)
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := New(context.TODO(), srv.URL, Options{})
+	require.NoError(t, err)
+
+	p, err := c.GetProof(root, util.Uint160{1, 2, 3}, key[4:])
+	require.NoError(t, err)
+	require.Equal(t, proof, p)
+
+	_, err = c.GetProof(util.Uint256{}, util.Uint160{1, 2, 3}, key[4:])
+	require.Error(t, err)
+
+	val, err := c.VerifyProof(root, p)
+	require.NoError(t, err)
+	require.Equal(t, []byte{42}, val)
+
+	val, err = VerifyProofLocally(root, p)
+	require.NoError(t, err)
+	require.Equal(t, []byte{42}, val)
+
+	_, err = VerifyProofLocally(util.Uint256{1, 2, 3}, p)
+	require.Error(t, err)
+}
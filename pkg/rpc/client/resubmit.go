@@ -0,0 +1,141 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// ResignFunc is used by Resubmitter to prepare restamped transactions for
+// sending. It receives a copy of the expired transaction with new nonce and
+// ValidUntilBlock values (see RestampTx) and must add witnesses to it (possibly
+// adjusting fees before that).
+type ResignFunc func(tx *transaction.Transaction) error
+
+// Resubmitter tracks transactions sent via it and resends them with new nonce
+// and ValidUntilBlock values when they expire without being included into the
+// chain, which gives at-least-once submission semantics. It doesn't do anything
+// by itself, Check method should be called periodically (like once per block)
+// to detect expired transactions. It's safe for concurrent use.
+type Resubmitter struct {
+	c      *Client
+	resign ResignFunc
+
+	lock sync.Mutex
+	txes map[util.Uint256]*transaction.Transaction
+}
+
+// NewResubmitter returns a new Resubmitter using the given function to sign
+// restamped transactions.
+func (c *Client) NewResubmitter(resign ResignFunc) *Resubmitter {
+	return &Resubmitter{
+		c:      c,
+		resign: resign,
+		txes:   make(map[util.Uint256]*transaction.Transaction),
+	}
+}
+
+// Send sends the given signed transaction and starts tracking it. The
+// transaction is tracked even if sending fails, so it will be resent after its
+// ValidUntilBlock height.
+func (r *Resubmitter) Send(tx *transaction.Transaction) (util.Uint256, error) {
+	r.Track(tx)
+	return r.c.SendRawTransaction(tx)
+}
+
+// Track starts tracking the given transaction that was sent before.
+func (r *Resubmitter) Track(tx *transaction.Transaction) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.txes[tx.Hash()] = tx
+}
+
+// Forget stops tracking the transaction with the given hash.
+func (r *Resubmitter) Forget(h util.Uint256) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.txes, h)
+}
+
+// Pending returns hashes of all transactions that are tracked (not yet
+// included into the chain).
+func (r *Resubmitter) Pending() []util.Uint256 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	res := make([]util.Uint256, 0, len(r.txes))
+	for h := range r.txes {
+		res = append(res, h)
+	}
+	return res
+}
+
+// Check checks the state of all tracked transactions. Transactions included
+// into the chain are no longer tracked, expired ones (when the chain has
+// reached their ValidUntilBlock height without including them) are restamped,
+// resigned and resent. It returns a map from expired transaction hashes to the
+// hashes of the transactions resent instead of them. In case of an error the
+// transaction is either left in place or replaced by the new one if it was
+// signed successfully, so the next Check will retry it.
+func (r *Resubmitter) Check() (map[util.Uint256]util.Uint256, error) {
+	blockCount, err := r.c.GetBlockCount()
+	if err != nil {
+		return nil, fmt.Errorf("can't get block count: %w", err)
+	}
+	height := blockCount - 1
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var expired []util.Uint256
+	for h, tx := range r.txes {
+		included, err := r.isIncluded(h)
+		if err != nil {
+			return nil, fmt.Errorf("can't check transaction %s: %w", h.StringLE(), err)
+		}
+		if included {
+			delete(r.txes, h)
+			continue
+		}
+		if height >= tx.ValidUntilBlock {
+			expired = append(expired, h)
+		}
+	}
+
+	resent := make(map[util.Uint256]util.Uint256)
+	for _, h := range expired {
+		tx := r.txes[h]
+		newTx, err := r.c.RestampTx(tx)
+		if err != nil {
+			return resent, fmt.Errorf("can't restamp transaction %s: %w", h.StringLE(), err)
+		}
+		err = r.resign(newTx)
+		if err != nil {
+			return resent, fmt.Errorf("can't resign transaction %s: %w", h.StringLE(), err)
+		}
+		delete(r.txes, h)
+		r.txes[newTx.Hash()] = newTx
+		resent[h] = newTx.Hash()
+		_, err = r.c.SendRawTransaction(newTx)
+		if err != nil {
+			return resent, fmt.Errorf("can't resend transaction %s: %w", h.StringLE(), err)
+		}
+	}
+	return resent, nil
+}
+
+// isIncluded returns true if the transaction with the given hash is included
+// into the chain.
+func (r *Resubmitter) isIncluded(h util.Uint256) (bool, error) {
+	_, err := r.c.GetTransactionHeight(h)
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, response.ErrNotFound) {
+		return false, nil
+	}
+	return false, err
+}
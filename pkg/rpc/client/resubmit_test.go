@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResubmitter(t *testing.T) {
+	var (
+		lock     sync.Mutex
+		height   uint32 = 10
+		included        = make(map[util.Uint256]bool)
+		sent     []*transaction.Transaction
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r := request.NewRequest()
+		err := r.DecodeData(req.Body)
+		require.NoErrorf(t, err, "Cannot decode request body: %s", req.Body)
+
+		lock.Lock()
+		defer lock.Unlock()
+		var response string
+		switch r.In.Method {
+		case "getblockcount":
+			response = fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"result":%d}`, height+1)
+		case "gettransactionheight":
+			p, err := r.In.Params()
+			require.NoError(t, err)
+			h, err := p.Value(0).GetUint256()
+			require.NoError(t, err)
+			if included[h] {
+				response = `{"jsonrpc":"2.0","id":1,"result":5}`
+			} else {
+				response = `{"jsonrpc":"2.0","id":1,"error":{"code":-100,"message":"unknown transaction"}}`
+			}
+		case "sendrawtransaction":
+			p, err := r.In.Params()
+			require.NoError(t, err)
+			raw, err := p.Value(0).GetBytesBase64()
+			require.NoError(t, err)
+			tx, err := transaction.NewTransactionFromBytes(42, raw)
+			require.NoError(t, err)
+			sent = append(sent, tx)
+			response = fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"result":{"hash":"0x%s"}}`, tx.Hash().StringLE())
+		}
+		requestHandler(t, r.In, w, response)
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := New(context.TODO(), srv.URL, Options{ValidUntilBlockOffset: 100})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	var resignErr error
+	r := c.NewResubmitter(func(tx *transaction.Transaction) error {
+		if resignErr != nil {
+			return resignErr
+		}
+		tx.Scripts = []transaction.Witness{{InvocationScript: []byte{1}, VerificationScript: []byte{2}}}
+		return nil
+	})
+
+	newTx := func(vub uint32) *transaction.Transaction {
+		tx := transaction.New(c.GetNetwork(), []byte{byte(opcode.PUSH1)}, 0)
+		tx.ValidUntilBlock = vub
+		tx.Signers = []transaction.Signer{{Account: util.Uint160{1, 2, 3}}}
+		tx.Scripts = []transaction.Witness{{InvocationScript: []byte{1}, VerificationScript: []byte{2}}}
+		return tx
+	}
+	tx1, tx2, tx3 := newTx(11), newTx(12), newTx(11)
+	for _, tx := range []*transaction.Transaction{tx1, tx2, tx3} {
+		h, err := r.Send(tx)
+		require.NoError(t, err)
+		require.Equal(t, tx.Hash(), h)
+	}
+	require.ElementsMatch(t, []util.Uint256{tx1.Hash(), tx2.Hash(), tx3.Hash()}, r.Pending())
+
+	t.Run("nothing expired", func(t *testing.T) {
+		resent, err := r.Check()
+		require.NoError(t, err)
+		require.Equal(t, 0, len(resent))
+		require.Equal(t, 3, len(sent))
+	})
+
+	t.Run("resign error", func(t *testing.T) {
+		lock.Lock()
+		height = 11
+		lock.Unlock()
+		resignErr = errors.New("bad")
+		_, err := r.Check()
+		require.Error(t, err)
+		require.Equal(t, 3, len(r.Pending()))
+		resignErr = nil
+	})
+
+	t.Run("expired and included", func(t *testing.T) {
+		lock.Lock()
+		included[tx3.Hash()] = true
+		lock.Unlock()
+
+		resent, err := r.Check()
+		require.NoError(t, err)
+		require.Equal(t, 1, len(resent))
+		require.Equal(t, 4, len(sent))
+		resentTx := sent[3]
+		require.Equal(t, resentTx.Hash(), resent[tx1.Hash()])
+		require.Equal(t, tx1.Script, resentTx.Script)
+		require.Equal(t, uint32(112), resentTx.ValidUntilBlock)
+		require.ElementsMatch(t, []util.Uint256{resentTx.Hash(), tx2.Hash()}, r.Pending())
+	})
+
+	t.Run("forget", func(t *testing.T) {
+		r.Forget(tx2.Hash())
+		require.Equal(t, 1, len(r.Pending()))
+	})
+}
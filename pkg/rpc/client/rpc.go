@@ -2,16 +2,21 @@ package client
 
 import (
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"math/rand"
 
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativeprices"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
@@ -28,6 +33,72 @@ import (
 
 var errNetworkNotInitialized = errors.New("RPC client network is not initialized")
 
+// ErrHashMismatch is returned when hash of the relayed transaction or block
+// returned by the server doesn't match the one cached locally which means that
+// the entity was changed after hashing without calling DirtyHash.
+var ErrHashMismatch = errors.New("relayed hash mismatch")
+
+// ErrNetworkMismatch is returned on attempt to sign or relay a transaction or
+// block made for a network other than the one of the node client is connected
+// to (as reported by getversion).
+var ErrNetworkMismatch = errors.New("network magic mismatch")
+
+// AddWatched adds script hashes to the set watched by the node in light wallet
+// mode (addwatched RPC, neo-go extension).
+func (c *Client) AddWatched(hashes ...util.Uint160) error {
+	return c.updateWatched("addwatched", hashes)
+}
+
+// RemoveWatched removes script hashes from the set watched by the node in light
+// wallet mode (removewatched RPC, neo-go extension).
+func (c *Client) RemoveWatched(hashes ...util.Uint160) error {
+	return c.updateWatched("removewatched", hashes)
+}
+
+// updateWatched performs addwatched or removewatched request.
+func (c *Client) updateWatched(method string, hashes []util.Uint160) error {
+	var (
+		params = request.NewRawParams()
+		resp   bool
+	)
+	for _, h := range hashes {
+		params.Values = append(params.Values, h.StringLE())
+	}
+	return c.performRequest(method, params, &resp)
+}
+
+// FindTransactions returns hashes of transactions having the given attribute
+// ordered by the height they were included at (findtransactions RPC, neo-go
+// extension). OracleResponse (by request ID), Conflicts and NotaryAssisted
+// attributes are supported. Limit and page parameters are optional, but page
+// can't be specified without limit.
+func (c *Client) FindTransactions(attr transaction.Attribute, limit, page *int) ([]util.Uint256, error) {
+	var params request.RawParams
+	switch attr.Type {
+	case transaction.OracleResponseT:
+		params = request.NewRawParams(attr.Type.String(), attr.Value.(*transaction.OracleResponse).ID)
+	case transaction.ConflictsT:
+		params = request.NewRawParams(attr.Type.String(), attr.Value.(*transaction.Conflicts).Hash.StringLE())
+	case transaction.NotaryAssistedT:
+		params = request.NewRawParams(attr.Type.String(), attr.Value.(*transaction.NotaryAssisted).NKeys)
+	default:
+		return nil, fmt.Errorf("unsupported attribute type: %s", attr.Type)
+	}
+	if limit != nil {
+		params.Values = append(params.Values, *limit)
+		if page != nil {
+			params.Values = append(params.Values, *page)
+		}
+	} else if page != nil {
+		return nil, errors.New("bad parameters")
+	}
+	var resp = []util.Uint256{}
+	if err := c.performRequest("findtransactions", params, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetApplicationLog returns the contract log based on the specified txid.
 func (c *Client) GetApplicationLog(hash util.Uint256, trig *trigger.Type) (*result.ApplicationLog, error) {
 	var (
@@ -43,6 +114,24 @@ func (c *Client) GetApplicationLog(hash util.Uint256, trig *trigger.Type) (*resu
 	return resp, nil
 }
 
+// GetApplicationLogEvents returns the contract log based on the specified txid
+// with only notifications of the given name included. Executions that have no
+// such notifications are omitted. Optional trigger can be used to further
+// filter executions.
+func (c *Client) GetApplicationLogEvents(hash util.Uint256, trig *trigger.Type, name string) (*result.ApplicationLog, error) {
+	var (
+		params = request.NewRawParams(hash.StringLE(), nil, name)
+		resp   = new(result.ApplicationLog)
+	)
+	if trig != nil {
+		params.Values[1] = trig.String()
+	}
+	if err := c.performRequest("getapplicationlog", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetBestBlockHash returns the hash of the tallest block in the main chain.
 func (c *Client) GetBestBlockHash() (util.Uint256, error) {
 	var resp = util.Uint256{}
@@ -70,6 +159,9 @@ func (c *Client) GetBlockByIndex(index uint32) (*block.Block, error) {
 // GetBlockByHash returns a block by its hash. You should initialize network magic
 // with Init before calling GetBlockByHash.
 func (c *Client) GetBlockByHash(hash util.Uint256) (*block.Block, error) {
+	if b := c.getCachedBlock(hash); b != nil {
+		return b, nil
+	}
 	return c.getBlock(request.NewRawParams(hash.StringLE()))
 }
 
@@ -91,6 +183,7 @@ func (c *Client) getBlock(params request.RawParams) (*block.Block, error) {
 	if r.Err != nil {
 		return nil, r.Err
 	}
+	c.cacheBlock(b)
 	return b, nil
 }
 
@@ -138,27 +231,65 @@ func (c *Client) GetBlockHash(index uint32) (util.Uint256, error) {
 // according to the specified script hash. You should initialize network magic
 // // with Init before calling GetBlockHeader.
 func (c *Client) GetBlockHeader(hash util.Uint256) (*block.Header, error) {
-	var (
-		params = request.NewRawParams(hash.StringLE())
-		resp   []byte
-		h      *block.Header
-	)
 	if !c.initDone {
 		return nil, errNetworkNotInitialized
 	}
+	if h := c.getCachedHeader(hash); h != nil {
+		return h, nil
+	}
+	return c.getBlockHeader(request.NewRawParams(hash.StringLE()))
+}
+
+// GetBlockHeaderByIndex returns block header by its height. You should
+// initialize network magic with Init before calling GetBlockHeaderByIndex.
+func (c *Client) GetBlockHeaderByIndex(index uint32) (*block.Header, error) {
+	if !c.initDone {
+		return nil, errNetworkNotInitialized
+	}
+	return c.getBlockHeader(request.NewRawParams(index))
+}
+
+func (c *Client) getBlockHeader(params request.RawParams) (*block.Header, error) {
+	var resp []byte
+
 	if err := c.performRequest("getblockheader", params, &resp); err != nil {
 		return nil, err
 	}
 	r := io.NewBinReaderFromBuf(resp)
-	h = new(block.Header)
+	h := new(block.Header)
 	h.Network = c.GetNetwork()
+	h.StateRootEnabled = c.StateRootInHeader()
 	h.DecodeBinary(r)
 	if r.Err != nil {
 		return nil, r.Err
 	}
+	c.cacheHeader(h)
 	return h, nil
 }
 
+// Headers returns count block headers starting from the given height. Headers
+// are checked to form a chain (each one references the previous one), so this
+// method can be used for light header synchronization without fetching full
+// blocks. It fails if some of requested headers are not yet available. You
+// should initialize network magic with Init before calling Headers.
+func (c *Client) Headers(start uint32, count uint32) ([]*block.Header, error) {
+	hdrs := make([]*block.Header, 0, count)
+	for i := uint32(0); i < count; i++ {
+		h, err := c.GetBlockHeaderByIndex(start + i)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get header %d: %w", start+i, err)
+		}
+		if h.Index != start+i {
+			return nil, fmt.Errorf("header %d has wrong index %d", start+i, h.Index)
+		}
+		if i > 0 && !h.PrevHash.Equals(hdrs[i-1].Hash()) {
+			return nil, fmt.Errorf("header %d doesn't reference the previous one", h.Index)
+		}
+		hdrs = append(hdrs, h)
+	}
+	return hdrs, nil
+}
+
 // GetBlockHeaderCount returns the number of headers in the main chain.
 func (c *Client) GetBlockHeaderCount() (uint32, error) {
 	var resp uint32
@@ -217,6 +348,36 @@ func (c *Client) GetCommittee() (keys.PublicKeys, error) {
 	return *resp, nil
 }
 
+// GetCommitteeAddress returns the script hash of the committee multisignature
+// account (majority of committee members' signatures) that is to sign
+// committee-only operations.
+func (c *Client) GetCommitteeAddress() (util.Uint160, error) {
+	pubs, err := c.GetCommittee()
+	if err != nil {
+		return util.Uint160{}, err
+	}
+	script, err := smartcontract.CreateMajorityMultiSigRedeemScript(pubs)
+	if err != nil {
+		return util.Uint160{}, fmt.Errorf("can't create committee script: %w", err)
+	}
+	return hash.Hash160(script), nil
+}
+
+// GetDesignation returns nodes designated for the given role at the given
+// height (see result.Designation) using `getdesignatedbyrole` call, unlike
+// GetDesignatedByRole it doesn't require any contract invocation and also
+// returns the height of the designation.
+func (c *Client) GetDesignation(role noderoles.Role, index uint32) (*result.Designation, error) {
+	var (
+		params = request.NewRawParams(int(role), index)
+		resp   = new(result.Designation)
+	)
+	if err := c.performRequest("getdesignatedbyrole", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetContractStateByHash queries contract information, according to the contract script hash.
 func (c *Client) GetContractStateByHash(hash util.Uint160) (*state.Contract, error) {
 	return c.getContractState(hash.StringLE())
@@ -241,9 +402,28 @@ func (c *Client) getContractState(param interface{}) (*state.Contract, error) {
 	if err := c.performRequest("getcontractstate", params, resp); err != nil {
 		return resp, err
 	}
+	c.cacheContractState(resp)
 	return resp, nil
 }
 
+// GetContractStateByUpdateCounter returns contract state with the given hash
+// and update counter. It's taken from the cache if it's enabled and the state
+// is there, otherwise current contract state is requested from the node and
+// an error is returned if its update counter doesn't match the given one.
+func (c *Client) GetContractStateByUpdateCounter(hash util.Uint160, updateCounter uint16) (*state.Contract, error) {
+	if cs := c.getCachedContractState(hash, updateCounter); cs != nil {
+		return cs, nil
+	}
+	cs, err := c.GetContractStateByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	if cs.UpdateCounter != updateCounter {
+		return nil, fmt.Errorf("contract %s has update counter %d, not %d", hash.StringLE(), cs.UpdateCounter, updateCounter)
+	}
+	return cs, nil
+}
+
 // GetNativeContracts queries information about native contracts.
 func (c *Client) GetNativeContracts() ([]state.NativeContract, error) {
 	var (
@@ -256,6 +436,61 @@ func (c *Client) GetNativeContracts() ([]state.NativeContract, error) {
 	return resp, nil
 }
 
+// GetNativePrices returns current prices and required call flags of all native
+// contract methods. Prices are specific to the server's chain state and can
+// change with policy updates, so they shouldn't be cached for long.
+func (c *Client) GetNativePrices() ([]result.NativePrices, error) {
+	var (
+		params = request.NewRawParams()
+		resp   []result.NativePrices
+	)
+	if err := c.performRequest("getnativeprices", params, &resp); err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+// GetNativePricesByHash returns current prices and required call flags of the
+// methods of the native contract with the given hash.
+func (c *Client) GetNativePricesByHash(h util.Uint160) (*result.NativePrices, error) {
+	var (
+		params = request.NewRawParams(h.StringLE())
+		resp   []result.NativePrices
+	)
+	if err := c.performRequest("getnativeprices", params, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp) != 1 {
+		return nil, fmt.Errorf("unexpected number of contracts returned: %d", len(resp))
+	}
+	return &resp[0], nil
+}
+
+// GetNEP11Balances is a wrapper for getnep11balances RPC.
+func (c *Client) GetNEP11Balances(address util.Uint160) (*result.NEP11Balances, error) {
+	params := request.NewRawParams(address.StringLE())
+	resp := new(result.NEP11Balances)
+	if err := c.performRequest("getnep11balances", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetNEP11Transfers is a wrapper for getnep11transfers RPC. Address parameter
+// is mandatory, while all the others are optional. These parameters are
+// positional in the JSON-RPC call the same way as for GetNEP17Transfers.
+func (c *Client) GetNEP11Transfers(address string, start, stop *uint32, limit, page *int) (*result.NEP11Transfers, error) {
+	params, err := packTransfersParams(address, start, stop, limit, page)
+	if err != nil {
+		return nil, err
+	}
+	resp := new(result.NEP11Transfers)
+	if err := c.performRequest("getnep11transfers", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetNEP17Balances is a wrapper for getnep17balances RPC.
 func (c *Client) GetNEP17Balances(address util.Uint160) (*result.NEP17Balances, error) {
 	params := request.NewRawParams(address.StringLE())
@@ -272,6 +507,20 @@ func (c *Client) GetNEP17Balances(address util.Uint160) (*result.NEP17Balances,
 // These parameters are positional in the JSON-RPC call, you can't specify limit
 // and not specify start/stop for example.
 func (c *Client) GetNEP17Transfers(address string, start, stop *uint32, limit, page *int) (*result.NEP17Transfers, error) {
+	params, err := packTransfersParams(address, start, stop, limit, page)
+	if err != nil {
+		return nil, err
+	}
+	resp := new(result.NEP17Transfers)
+	if err := c.performRequest("getnep17transfers", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// packTransfersParams creates parameters for getnep11transfers and
+// getnep17transfers calls, optional parameters are positional.
+func packTransfersParams(address string, start, stop *uint32, limit, page *int) (request.RawParams, error) {
 	params := request.NewRawParams(address)
 	if start != nil {
 		params.Values = append(params.Values, *start)
@@ -283,19 +532,15 @@ func (c *Client) GetNEP17Transfers(address string, start, stop *uint32, limit, p
 					params.Values = append(params.Values, *page)
 				}
 			} else if page != nil {
-				return nil, errors.New("bad parameters")
+				return params, errors.New("bad parameters")
 			}
 		} else if limit != nil || page != nil {
-			return nil, errors.New("bad parameters")
+			return params, errors.New("bad parameters")
 		}
 	} else if stop != nil || limit != nil || page != nil {
-		return nil, errors.New("bad parameters")
+		return params, errors.New("bad parameters")
 	}
-	resp := new(result.NEP17Transfers)
-	if err := c.performRequest("getnep17transfers", params, resp); err != nil {
-		return nil, err
-	}
-	return resp, nil
+	return params, nil
 }
 
 // GetPeers returns the list of nodes that the node is currently connected/disconnected from.
@@ -310,6 +555,20 @@ func (c *Client) GetPeers() (*result.GetPeers, error) {
 	return resp, nil
 }
 
+// GetPeersVerbose is similar to GetPeers, but connected peers are returned
+// with additional diagnostic data (user agent, height, capabilities,
+// connection direction and last message time).
+func (c *Client) GetPeersVerbose() (*result.GetPeers, error) {
+	var (
+		params = request.NewRawParams(true)
+		resp   = &result.GetPeers{}
+	)
+	if err := c.performRequest("getpeers", params, resp); err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
 // GetRawMemPool returns the list of unconfirmed transactions in memory.
 func (c *Client) GetRawMemPool() ([]util.Uint256, error) {
 	var (
@@ -322,6 +581,19 @@ func (c *Client) GetRawMemPool() ([]util.Uint256, error) {
 	return *resp, nil
 }
 
+// GetRawMemPoolDetails returns verified transactions from the memory pool
+// along with their senders, fees and the heights they were added at.
+func (c *Client) GetRawMemPoolDetails() (*result.RawMempool, error) {
+	var (
+		params = request.NewRawParams(1, 1)
+		resp   = new(result.RawMempool)
+	)
+	if err := c.performRequest("getrawmempool", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetRawTransaction returns a transaction by hash. You should initialize network magic
 // with Init before calling GetRawTransaction.
 func (c *Client) GetRawTransaction(hash util.Uint256) (*transaction.Transaction, error) {
@@ -333,6 +605,9 @@ func (c *Client) GetRawTransaction(hash util.Uint256) (*transaction.Transaction,
 	if !c.initDone {
 		return nil, errNetworkNotInitialized
 	}
+	if tx := c.getCachedTransaction(hash); tx != nil {
+		return tx, nil
+	}
 	if err = c.performRequest("getrawtransaction", params, &resp); err != nil {
 		return nil, err
 	}
@@ -340,6 +615,7 @@ func (c *Client) GetRawTransaction(hash util.Uint256) (*transaction.Transaction,
 	if err != nil {
 		return nil, err
 	}
+	c.cacheTransaction(tx)
 	return tx, nil
 }
 
@@ -363,6 +639,40 @@ func (c *Client) GetRawTransactionVerbose(hash util.Uint256) (*result.Transactio
 	return resp, nil
 }
 
+// GetStateHeight returns the current node's block height and the height of
+// the latest validated state root (it's the previous block height for
+// networks with state root in header).
+func (c *Client) GetStateHeight() (*result.StateHeight, error) {
+	var (
+		params = request.NewRawParams()
+		resp   = new(result.StateHeight)
+	)
+	if err := c.performRequest("getstateheight", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetStateRootByHeight returns state root for the block with the given index.
+// Witness is only present for roots validated by the state validators.
+func (c *Client) GetStateRootByHeight(height uint32) (*state.MPTRoot, error) {
+	return c.getStateRoot(request.NewRawParams(height))
+}
+
+// GetStateRootByBlockHash returns state root for the block with the given
+// hash. Witness is only present for roots validated by the state validators.
+func (c *Client) GetStateRootByBlockHash(hash util.Uint256) (*state.MPTRoot, error) {
+	return c.getStateRoot(request.NewRawParams(hash.StringLE()))
+}
+
+func (c *Client) getStateRoot(params request.RawParams) (*state.MPTRoot, error) {
+	var resp = new(state.MPTRoot)
+	if err := c.performRequest("getstateroot", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetStorageByID returns the stored value, according to the contract ID and the stored key.
 func (c *Client) GetStorageByID(id int32, key []byte) ([]byte, error) {
 	return c.getStorage(request.NewRawParams(id, base64.StdEncoding.EncodeToString(key)))
@@ -381,6 +691,107 @@ func (c *Client) getStorage(params request.RawParams) ([]byte, error) {
 	return resp, nil
 }
 
+// GetProof returns MPT proof of the storage item with the given key of the
+// contract with the given hash at the state specified by stateroot. Proof key
+// is the full storage key (contract ID followed by item key), it can be
+// verified locally with VerifyProofLocally or by the node with VerifyProof.
+func (c *Client) GetProof(stateroot util.Uint256, contract util.Uint160, key []byte) (*result.ProofWithKey, error) {
+	var (
+		params = request.NewRawParams(stateroot.StringLE(), contract.StringLE(), hex.EncodeToString(key))
+		resp   = new(result.GetProof)
+	)
+	if err := c.performRequest("getproof", params, resp); err != nil {
+		return nil, err
+	}
+	if !resp.Success {
+		return nil, errors.New("failed to get proof")
+	}
+	return &resp.Result, nil
+}
+
+// VerifyProof asks the node to verify proof against the given state root and
+// returns the value of the proven storage item (nil if proof is invalid). To
+// not trust the node, use VerifyProofLocally instead.
+func (c *Client) VerifyProof(stateroot util.Uint256, proof *result.ProofWithKey) ([]byte, error) {
+	var (
+		params = request.NewRawParams(stateroot.StringLE(), proof.String())
+		resp   = new(result.VerifyProof)
+	)
+	if err := c.performRequest("verifyproof", params, resp); err != nil {
+		return nil, err
+	}
+	return resp.Value, nil
+}
+
+// VerifyProofLocally verifies proof against the given trusted state root
+// without making any requests and returns the value of the proven storage
+// item. It returns an error if proof is invalid.
+func VerifyProofLocally(stateroot util.Uint256, proof *result.ProofWithKey) ([]byte, error) {
+	val, ok := mpt.VerifyProof(stateroot, proof.Key, proof.Proof)
+	if !ok {
+		return nil, errors.New("invalid proof")
+	}
+	return val, nil
+}
+
+// GetState returns the value of the storage item of the contract with the given
+// hash at the state specified by stateroot.
+func (c *Client) GetState(stateroot util.Uint256, contract util.Uint160, key []byte) ([]byte, error) {
+	var (
+		params = request.NewRawParams(stateroot.StringLE(), contract.StringLE(), key)
+		resp   []byte
+	)
+	if err := c.performRequest("getstate", params, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// FindStates returns storage items of the contract with the given hash having
+// the given prefix at the state specified by stateroot. Items are ordered by
+// key, start is an optional key to start after (it should have the prefix) and
+// maxCount is an optional number of items to return (it's limited by the
+// server). Proofs for the first and the last items are returned as well, the
+// result is truncated if there are more items to fetch.
+func (c *Client) FindStates(stateroot util.Uint256, contract util.Uint160, prefix []byte, start []byte, maxCount *int) (result.FindStates, error) {
+	if start == nil && maxCount != nil {
+		start = []byte{}
+	}
+	var (
+		params = request.NewRawParams(stateroot.StringLE(), contract.StringLE(), prefix)
+		resp   result.FindStates
+	)
+	if start != nil {
+		params.Values = append(params.Values, start)
+	}
+	if maxCount != nil {
+		params.Values = append(params.Values, *maxCount)
+	}
+	if err := c.performRequest("findstates", params, &resp); err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+// FindStorage returns current storage items of the contract with the given
+// hash having the given prefix. Items are ordered by key, maxCount is an
+// optional maximum number of items returned. If there are more items, the
+// result is truncated and its Next field can be used to get the next page,
+// see IterateStorage.
+func (c *Client) FindStorage(contract util.Uint160, prefix []byte, maxCount *int) (*result.FindStorage, error) {
+	var (
+		params = request.NewRawParams(contract.StringLE(), prefix)
+		resp   = new(result.FindStorage)
+	)
+	if maxCount != nil {
+		params.Values = append(params.Values, *maxCount)
+	}
+	if err := c.performRequest("findstorage", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetTransactionHeight returns the block index in which the transaction is found.
 func (c *Client) GetTransactionHeight(hash util.Uint256) (uint32, error) {
 	var (
@@ -405,6 +816,21 @@ func (c *Client) GetUnclaimedGas(address string) (result.UnclaimedGas, error) {
 	return resp, nil
 }
 
+// GetCandidatesInfo is a wrapper for getcandidates RPC (which is a neo-go
+// extension). Unlike GetCandidates it doesn't invoke NEO contract and it also
+// returns flags showing whether candidates are in the committee and in the
+// next block validators list.
+func (c *Client) GetCandidatesInfo() ([]result.Candidate, error) {
+	var (
+		params = request.NewRawParams()
+		resp   = new([]result.Candidate)
+	)
+	if err := c.performRequest("getcandidates", params, resp); err != nil {
+		return nil, err
+	}
+	return *resp, nil
+}
+
 // GetNextBlockValidators returns the current NEO consensus nodes information and voting status.
 func (c *Client) GetNextBlockValidators() ([]result.Validator, error) {
 	var (
@@ -429,6 +855,27 @@ func (c *Client) GetVersion() (*result.Version, error) {
 	return resp, nil
 }
 
+// GetWatched returns script hashes watched by the node in light wallet mode
+// (getwatched RPC, neo-go extension).
+func (c *Client) GetWatched() ([]util.Uint160, error) {
+	var (
+		params = request.NewRawParams()
+		resp   []string
+	)
+	if err := c.performRequest("getwatched", params, &resp); err != nil {
+		return nil, err
+	}
+	hashes := make([]util.Uint160, len(resp))
+	for i := range resp {
+		var err error
+		hashes[i], err = address.StringToUint160(resp[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid watched address %s: %w", resp[i], err)
+		}
+	}
+	return hashes, nil
+}
+
 // InvokeScript returns the result of the given script after running it true the VM.
 // NOTE: This is a test invoke and will not affect the blockchain.
 func (c *Client) InvokeScript(script []byte, signers []transaction.Signer) (*result.Invoke, error) {
@@ -444,6 +891,87 @@ func (c *Client) InvokeFunction(contract util.Uint160, operation string, params
 	return c.invokeSomething("invokefunction", p, signers)
 }
 
+// InvokeScriptWithGas is similar to InvokeScript, but allows to specify the
+// amount of GAS the script can spend. This amount is bounded by the server
+// configuration.
+// NOTE: this is test invoke and will not affect the blockchain.
+func (c *Client) InvokeScriptWithGas(script []byte, signers []transaction.Signer, gas int64) (*result.Invoke, error) {
+	var p = request.NewRawParams(script)
+	return c.invokeWithGas("invokescript", p, signers, gas)
+}
+
+// InvokeFunctionWithGas is similar to InvokeFunction, but allows to specify
+// the amount of GAS the invocation can spend. This amount is bounded by the
+// server configuration.
+// NOTE: this is test invoke and will not affect the blockchain.
+func (c *Client) InvokeFunctionWithGas(contract util.Uint160, operation string, params []smartcontract.Parameter, signers []transaction.Signer, gas int64) (*result.Invoke, error) {
+	var p = request.NewRawParams(contract.StringLE(), operation, params)
+	return c.invokeWithGas("invokefunction", p, signers, gas)
+}
+
+// InvokeScriptWithDiagnostics is similar to InvokeScript, but the result also
+// contains contract call tree and storage changes made by the script (see
+// result.InvokeDiag). Iterators are never expanded for these invocations.
+// NOTE: this is test invoke and will not affect the blockchain.
+func (c *Client) InvokeScriptWithDiagnostics(script []byte, signers []transaction.Signer) (*result.Invoke, error) {
+	var p = request.NewRawParams(script)
+	return c.invokeWithDiagnostics("invokescript", p, signers)
+}
+
+// InvokeFunctionWithDiagnostics is similar to InvokeFunction, but the result
+// also contains contract call tree and storage changes made by the invocation
+// (see result.InvokeDiag). Iterators are never expanded for these invocations.
+// NOTE: this is test invoke and will not affect the blockchain.
+func (c *Client) InvokeFunctionWithDiagnostics(contract util.Uint160, operation string, params []smartcontract.Parameter, signers []transaction.Signer) (*result.Invoke, error) {
+	var p = request.NewRawParams(contract.StringLE(), operation, params)
+	return c.invokeWithDiagnostics("invokefunction", p, signers)
+}
+
+// InvokeScriptAt is similar to InvokeScript, but executes the script against
+// the chain state as of the given block height. It requires the server to
+// keep historical states.
+// NOTE: this is test invoke and will not affect the blockchain.
+func (c *Client) InvokeScriptAt(height uint32, script []byte, signers []transaction.Signer) (*result.Invoke, error) {
+	var p = request.NewRawParams(height, script)
+	return c.invokeSomething("invokescripthistoric", p, signers)
+}
+
+// InvokeScriptWithState is similar to InvokeScript, but executes the script
+// against the chain state with the given state root. It requires the server
+// to keep historical states.
+// NOTE: this is test invoke and will not affect the blockchain.
+func (c *Client) InvokeScriptWithState(stateroot util.Uint256, script []byte, signers []transaction.Signer) (*result.Invoke, error) {
+	var p = request.NewRawParams(stateroot.StringLE(), script)
+	return c.invokeSomething("invokescripthistoric", p, signers)
+}
+
+// SimulateScript is similar to InvokeScript, but executes the script with
+// the given temporary state changes (balances, storage items and block
+// timestamp) applied. It requires the server to have state overrides enabled.
+// NOTE: this is test invoke and will not affect the blockchain.
+func (c *Client) SimulateScript(script []byte, ov request.StateOverride, signers []transaction.Signer) (*result.Invoke, error) {
+	var p = request.NewRawParams(script, ov)
+	return c.invokeSomething("simulatescript", p, signers)
+}
+
+// InvokeFunctionAt is similar to InvokeFunction, but executes the call against
+// the chain state as of the given block height. It requires the server to
+// keep historical states.
+// NOTE: this is test invoke and will not affect the blockchain.
+func (c *Client) InvokeFunctionAt(height uint32, contract util.Uint160, operation string, params []smartcontract.Parameter, signers []transaction.Signer) (*result.Invoke, error) {
+	var p = request.NewRawParams(height, contract.StringLE(), operation, params)
+	return c.invokeSomething("invokefunctionhistoric", p, signers)
+}
+
+// InvokeFunctionWithState is similar to InvokeFunction, but executes the call
+// against the chain state with the given state root. It requires the server
+// to keep historical states.
+// NOTE: this is test invoke and will not affect the blockchain.
+func (c *Client) InvokeFunctionWithState(stateroot util.Uint256, contract util.Uint160, operation string, params []smartcontract.Parameter, signers []transaction.Signer) (*result.Invoke, error) {
+	var p = request.NewRawParams(stateroot.StringLE(), contract.StringLE(), operation, params)
+	return c.invokeSomething("invokefunctionhistoric", p, signers)
+}
+
 // InvokeContractVerify returns the results after calling `verify` method of the smart contract
 // with the given parameters under verification trigger type.
 // NOTE: this is test invoke and will not affect the blockchain.
@@ -475,30 +1003,116 @@ func (c *Client) invokeSomething(method string, p request.RawParams, signers []t
 	if err := c.performRequest(method, p, resp); err != nil {
 		return nil, err
 	}
+	switch method {
+	case "invokescript", "invokefunction":
+		return c.expandIterators(resp, "invokescript", nil, signers, nil), nil
+	case "invokescripthistoric", "invokefunctionhistoric":
+		return c.expandIterators(resp, "invokescripthistoric", p.Values[:1], signers, nil), nil
+	}
+	return resp, nil
+}
+
+// invokeWithGas is an inner wrapper for Invoke*WithGas functions, gas limit
+// goes after signers, so they're always passed.
+func (c *Client) invokeWithGas(method string, p request.RawParams, signers []transaction.Signer, gas int64) (*result.Invoke, error) {
+	var resp = new(result.Invoke)
+	if signers == nil {
+		signers = []transaction.Signer{}
+	}
+	p.Values = append(p.Values, signers, gas)
+	if err := c.performRequest(method, p, resp); err != nil {
+		return nil, err
+	}
+	return c.expandIterators(resp, "invokescript", nil, signers, &gas), nil
+}
+
+// invokeWithDiagnostics is an inner wrapper for Invoke*WithDiagnostics
+// functions, diagnostics flag goes after signers and gas limit, so they're
+// always passed (default gas limit is used).
+func (c *Client) invokeWithDiagnostics(method string, p request.RawParams, signers []transaction.Signer) (*result.Invoke, error) {
+	var resp = new(result.Invoke)
+	if signers == nil {
+		signers = []transaction.Signer{}
+	}
+	p.Values = append(p.Values, signers, nil, 1)
+	if err := c.performRequest(method, p, resp); err != nil {
+		return nil, err
+	}
 	return resp, nil
 }
 
+// CheckNetwork returns ErrNetworkMismatch if the transaction is made for a
+// network other than the one of the node client is connected to. Client must
+// be initialized for this check to work.
+func (c *Client) CheckNetwork(tx *transaction.Transaction) error {
+	if !c.initDone {
+		return errNetworkNotInitialized
+	}
+	return c.checkNetwork(tx.Network, "transaction")
+}
+
+// checkNetwork compares the magic given with the one of the network client is
+// connected to.
+func (c *Client) checkNetwork(magic netmode.Magic, entity string) error {
+	if magic != c.network {
+		return fmt.Errorf("%w: %s is made for %s, node is on %s", ErrNetworkMismatch, entity, magic, c.network)
+	}
+	return nil
+}
+
+// SignTx signs the transaction with the account given after checking that it's
+// made for the network client is connected to (see CheckNetwork).
+func (c *Client) SignTx(acc *wallet.Account, tx *transaction.Transaction) error {
+	if err := c.CheckNetwork(tx); err != nil {
+		return err
+	}
+	return acc.SignTx(tx)
+}
+
 // SendRawTransaction broadcasts a transaction over the NEO network.
 // The given hex string needs to be signed with a keypair.
 // When the result of the response object is true, the TX has successfully
-// been broadcasted to the network.
+// been broadcasted to the network. ErrHashMismatch is returned if the hash
+// returned by the server differs from the transaction's one. If client is
+// initialized, ErrNetworkMismatch is returned for transactions made for
+// another network without sending them.
 func (c *Client) SendRawTransaction(rawTX *transaction.Transaction) (util.Uint256, error) {
 	var (
 		params = request.NewRawParams(rawTX.Bytes())
 		resp   = new(result.RelayResult)
 	)
+	if c.initDone {
+		if err := c.checkNetwork(rawTX.Network, "transaction"); err != nil {
+			return util.Uint256{}, err
+		}
+	}
+	if rawTX.HasAttribute(transaction.HighPriority) {
+		if err := c.checkHighPriority(rawTX); err != nil {
+			return util.Uint256{}, err
+		}
+	}
 	if err := c.performRequest("sendrawtransaction", params, resp); err != nil {
 		return util.Uint256{}, err
 	}
+	if h := rawTX.Hash(); !resp.Hash.Equals(h) {
+		return resp.Hash, fmt.Errorf("%w: expected %s, got %s", ErrHashMismatch, h.StringLE(), resp.Hash.StringLE())
+	}
 	return resp.Hash, nil
 }
 
-// SubmitBlock broadcasts a raw block over the NEO network.
+// SubmitBlock broadcasts a raw block over the NEO network. If client is
+// initialized, ErrNetworkMismatch is returned for blocks made for another
+// network without sending them.
 func (c *Client) SubmitBlock(b block.Block) (util.Uint256, error) {
 	var (
 		params request.RawParams
 		resp   = new(result.RelayResult)
 	)
+	if c.initDone {
+		if err := c.checkNetwork(b.Network, "block"); err != nil {
+			return util.Uint256{}, err
+		}
+	}
 	buf := io.NewBufBinWriter()
 	b.EncodeBinary(buf.BinWriter)
 	if err := buf.Err; err != nil {
@@ -509,6 +1123,9 @@ func (c *Client) SubmitBlock(b block.Block) (util.Uint256, error) {
 	if err := c.performRequest("submitblock", params, resp); err != nil {
 		return util.Uint256{}, err
 	}
+	if h := b.Hash(); !resp.Hash.Equals(h) {
+		return resp.Hash, fmt.Errorf("%w: expected %s, got %s", ErrHashMismatch, h.StringLE(), resp.Hash.StringLE())
+	}
 	return resp.Hash, nil
 }
 
@@ -545,7 +1162,8 @@ func (c *Client) SignAndPushInvocationTx(script []byte, acc *wallet.Account, sys
 
 // getSigners returns an array of transaction signers and corresponding accounts from
 // given sender and cosigners. If cosigners list already contains sender, the sender
-// will be placed at the start of the list.
+// will be placed at the start of the list (keeping the scopes, allowed contracts
+// and groups specified for it).
 func getSigners(sender *wallet.Account, cosigners []SignerAccount) ([]transaction.Signer, []*wallet.Account, error) {
 	var (
 		signers  []transaction.Signer
@@ -561,7 +1179,7 @@ func getSigners(sender *wallet.Account, cosigners []SignerAccount) ([]transactio
 	}
 	for _, c := range cosigners {
 		if c.Signer.Account == from {
-			s.Scopes = c.Signer.Scopes
+			s = c.Signer
 			continue
 		}
 		signers = append(signers, c.Signer)
@@ -622,6 +1240,7 @@ func (c *Client) SignAndPushP2PNotaryRequest(mainTx *transaction.Transaction, fa
 		return nil, fmt.Errorf("fallback transaction should be valid for not more than %d blocks", maxNVBDelta)
 	}
 	fallbackTx := transaction.New(c.GetNetwork(), fallbackScript, fallbackSysFee)
+	fallbackTx.Nonce = c.getNonce()
 	fallbackTx.Signers = signers
 	fallbackTx.ValidUntilBlock = mainTx.ValidUntilBlock
 	fallbackTx.Attributes = []transaction.Attribute{
@@ -729,6 +1348,25 @@ func (c *Client) ValidateAddress(address string) error {
 	return nil
 }
 
+// WatchTransactionCallback asks the server to POST finality.Event in JSON form to the
+// given callback URL when the transaction with the given hash is included into
+// a block, becomes conflicting with some included transaction or expires. The
+// transaction must be either in the server's memory pool or in its chain.
+// Finality watcher service must be enabled on the server and the callback URL
+// must be allowed by its configuration. WSClient has a version of this method
+// without callback URL that uses websocket events.
+func (c *Client) WatchTransactionCallback(h util.Uint256, callback string) error {
+	var resp bool
+
+	if err := c.performRequest("watchtransaction", request.NewRawParams(h.StringLE(), callback), &resp); err != nil {
+		return err
+	}
+	if !resp {
+		return errors.New("watchtransaction returned false")
+	}
+	return nil
+}
+
 // CalculateValidUntilBlock calculates ValidUntilBlock field for tx as
 // current blockchain height + number of validators. Number of validators
 // is the length of blockchain validators list got from GetNextBlockValidators()
@@ -759,54 +1397,193 @@ func (c *Client) CalculateValidUntilBlock() (uint32, error) {
 	return blockCount + validatorsCount + 1, nil
 }
 
+// getValidUntilBlock returns ValidUntilBlock value for new transactions
+// taking ValidUntilBlockOffset option into account.
+func (c *Client) getValidUntilBlock() (uint32, error) {
+	if c.opts.ValidUntilBlockOffset == 0 {
+		return c.CalculateValidUntilBlock()
+	}
+	blockCount, err := c.GetBlockCount()
+	if err != nil {
+		return 0, fmt.Errorf("can't get block count: %w", err)
+	}
+	return blockCount + c.opts.ValidUntilBlockOffset, nil
+}
+
+// getNonce returns nonce for new transactions using NonceGenerator option if
+// it's set.
+func (c *Client) getNonce() uint32 {
+	if c.opts.NonceGenerator != nil {
+		return c.opts.NonceGenerator()
+	}
+	return rand.Uint32()
+}
+
+// RestampTx returns a copy of the given transaction with new nonce and
+// ValidUntilBlock values, it can be used to resend transactions that were not
+// accepted before their ValidUntilBlock height. Witnesses are not copied, so
+// the transaction returned needs to be signed again. Fees are not recalculated
+// as they don't depend on the values changed.
+func (c *Client) RestampTx(tx *transaction.Transaction) (*transaction.Transaction, error) {
+	vub, err := c.getValidUntilBlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get validUntilBlock: %w", err)
+	}
+	return &transaction.Transaction{
+		Version:         tx.Version,
+		Nonce:           c.getNonce(),
+		SystemFee:       tx.SystemFee,
+		NetworkFee:      tx.NetworkFee,
+		ValidUntilBlock: vub,
+		Script:          tx.Script,
+		Attributes:      tx.Attributes,
+		Signers:         tx.Signers,
+		Scripts:         []transaction.Witness{},
+		Network:         tx.Network,
+	}, nil
+}
+
 // AddNetworkFee adds network fee for each witness script and optional extra
-// network fee to transaction. `accs` is an array signer's accounts.
+// network fee to transaction. `accs` is an array signer's accounts. Fees for
+// standard accounts are calculated locally, `verify` method is invoked via RPC
+// for deployed contract accounts. See FeeCalculator for the offline version.
+// If extraFee is DefaultExtraFee, it's obtained from the FeeOracle set in
+// client Options.
 func (c *Client) AddNetworkFee(tx *transaction.Transaction, extraFee int64, accs ...*wallet.Account) error {
+	calc, err := c.GetFeeCalculator()
+	if err != nil {
+		return err
+	}
+	fees, size, err := c.getWitnessFees(tx, calc.ExecFeeFactor, accs)
+	if err != nil {
+		return err
+	}
+	for _, f := range fees {
+		tx.NetworkFee += f
+	}
+	tx.NetworkFee += int64(size) * calc.FeePerByte
+	if extraFee == DefaultExtraFee {
+		extraFee, err = c.getExtraFee(size, tx.NetworkFee)
+		if err != nil {
+			return err
+		}
+	}
+	tx.NetworkFee += extraFee
+	return nil
+}
+
+// getWitnessFees returns verification fees of witnesses for the given signer
+// accounts of the transaction (that has no witnesses yet) and its size with
+// these witnesses. Fees for standard accounts are calculated locally, `verify`
+// method is invoked via RPC for deployed contract accounts.
+func (c *Client) getWitnessFees(tx *transaction.Transaction, execFeeFactor int64, accs []*wallet.Account) ([]int64, int, error) {
 	if len(tx.Signers) != len(accs) {
-		return errors.New("number of signers must match number of scripts")
+		return nil, 0, errors.New("number of signers must match number of scripts")
 	}
-	size := io.GetVarSize(tx)
-	var ef int64
+	var (
+		fees = make([]int64, len(accs))
+		size = io.GetVarSize(tx)
+	)
 	for i, cosigner := range tx.Signers {
+		if accs[i].Contract == nil {
+			return nil, 0, fmt.Errorf("signer #%d: account has no verification script", i)
+		}
 		if accs[i].Contract.Deployed {
-			res, err := c.InvokeContractVerify(cosigner.Account, smartcontract.Params{}, tx.Signers)
+			// Real arguments are not known yet, so dummy ones of the same
+			// size are used for the estimation.
+			args, invSize, err := getDummyVerifyArgs(accs[i].Contract.Parameters)
+			if err != nil {
+				return nil, 0, fmt.Errorf("signer #%d: %w", i, err)
+			}
+			res, err := c.InvokeContractVerify(cosigner.Account, args, tx.Signers)
 			if err != nil {
-				return fmt.Errorf("failed to invoke verify: %w", err)
+				return nil, 0, fmt.Errorf("failed to invoke verify: %w", err)
 			}
 			if res.State != "HALT" {
-				return fmt.Errorf("invalid VM state %s due to an error: %s", res.State, res.FaultException)
+				return nil, 0, fmt.Errorf("invalid VM state %s due to an error: %s", res.State, res.FaultException)
 			}
 			if l := len(res.Stack); l != 1 {
-				return fmt.Errorf("result stack length should be equal to 1, got %d", l)
+				return nil, 0, fmt.Errorf("result stack length should be equal to 1, got %d", l)
 			}
 			r, err := topIntFromStack(res.Stack)
 			if err != nil {
-				return fmt.Errorf("signer #%d: failed to get `verify` result from stack: %w", i, err)
+				return nil, 0, fmt.Errorf("signer #%d: failed to get `verify` result from stack: %w", i, err)
 			}
-			if r == 0 {
-				return fmt.Errorf("signer #%d: `verify` returned `false`", i)
+			// Dummy arguments are not expected to pass the verification.
+			if r == 0 && len(args) == 0 {
+				return nil, 0, fmt.Errorf("signer #%d: `verify` returned `false`", i)
 			}
-			tx.NetworkFee += res.GasConsumed
-			size += io.GetVarSize([]byte{}) * 2 // both scripts are empty
+			fees[i] = res.GasConsumed
+			size += io.GetVarSize([]byte{}) + invSize // verification script is empty
 			continue
 		}
 
-		if ef == 0 {
-			var err error
-			ef, err = c.GetExecFeeFactor()
-			if err != nil {
-				return fmt.Errorf("can't get `ExecFeeFactor`: %w", err)
-			}
-		}
-		netFee, sizeDelta := fee.Calculate(ef, accs[i].Contract.Script)
-		tx.NetworkFee += netFee
+		netFee, sizeDelta := fee.Calculate(execFeeFactor, accs[i].Contract.Script)
+		fees[i] = netFee
 		size += sizeDelta
 	}
-	fee, err := c.GetFeePerByte()
-	if err != nil {
+	return fees, size, nil
+}
+
+// CalculateNetworkFee returns network fee for the given transaction calculated
+// by the server with `calculatenetworkfee` call. Unlike AddNetworkFee it
+// supports any witnesses, but the transaction must have all of them with
+// verification scripts (empty for deployed contracts) and invocation scripts
+// of the proper size (see RPC server documentation).
+func (c *Client) CalculateNetworkFee(tx *transaction.Transaction) (int64, error) {
+	var (
+		params = request.NewRawParams(tx.Bytes())
+		resp   = new(result.NetworkFee)
+	)
+	if err := c.performRequest("calculatenetworkfee", params, resp); err != nil {
+		return 0, err
+	}
+	return resp.Value, nil
+}
+
+// EstimateFee returns fee per byte estimation based on the given percentile
+// (1-100) of fees per byte of transactions currently in the node's memory
+// pool (it's never lower than the Policy contract value). It's a neo-go
+// extension that is more efficient than MempoolFeeOracle, but it's not
+// supported by other nodes.
+func (c *Client) EstimateFee(percentile int) (*result.FeeEstimate, error) {
+	var (
+		params = request.NewRawParams(percentile)
+		resp   = new(result.FeeEstimate)
+	)
+	if err := c.performRequest("estimatefee", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// AddHighPriority adds HighPriority attribute to the given transaction (if it
+// doesn't have one already), such transactions are prioritized by nodes over
+// any other ones, but they can only be signed by the committee. It returns an
+// error if committee account is not among transaction signers. It must be
+// called before network fee calculation and signing. Transactions created with
+// CreateTxFromScript for committee account as a sender get this attribute
+// automatically.
+func (c *Client) AddHighPriority(tx *transaction.Transaction) error {
+	if err := c.checkHighPriority(tx); err != nil {
 		return err
 	}
-	tx.NetworkFee += int64(size)*fee + extraFee
+	if !tx.HasAttribute(transaction.HighPriority) {
+		tx.Attributes = append(tx.Attributes, transaction.Attribute{Type: transaction.HighPriority})
+	}
+	return nil
+}
+
+// checkHighPriority checks whether given transaction can have HighPriority
+// attribute.
+func (c *Client) checkHighPriority(tx *transaction.Transaction) error {
+	committee, err := c.GetCommitteeAddress()
+	if err != nil {
+		return fmt.Errorf("failed to get committee address: %w", err)
+	}
+	if !tx.HasSigner(committee) {
+		return errors.New("high priority transaction is not signed by committee")
+	}
 	return nil
 }
 
@@ -826,10 +1603,16 @@ func (c *Client) GetNativeContractHash(name string) (util.Uint160, error) {
 	if ok {
 		return hash, nil
 	}
+	hash, ok = c.getCachedNativeHash(name)
+	if ok {
+		c.cache.nativeHashes[name] = hash
+		return hash, nil
+	}
 	cs, err := c.GetContractStateByAddressOrName(name)
 	if err != nil {
 		return util.Uint160{}, err
 	}
 	c.cache.nativeHashes[name] = cs.Hash
+	c.cacheNativeHash(name, cs.Hash)
 	return cs.Hash, nil
 }
@@ -6,6 +6,7 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"net/http"
@@ -18,6 +19,7 @@ import (
 	"github.com/nspcc-dev/neo-go/internal/testserdes"
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nnsrecords"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
@@ -25,9 +27,12 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
+	"github.com/nspcc-dev/neo-go/pkg/network/capability"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
@@ -35,6 +40,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -110,6 +116,64 @@ func getTxMoveNeo() *result.TransactionOutputRaw {
 // published in official C# JSON-RPC API v2.10.3 reference
 // (see https://docs.neo.org/docs/en-us/reference/rpc/latest-version/api.html)
 var rpcClientTestCases = map[string][]rpcClientTestCase{
+	"addwatched": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return true, c.AddWatched(util.Uint160{1, 2, 3})
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":true}`,
+			result: func(c *Client) interface{} {
+				return true
+			},
+		},
+	},
+	"findtransactions": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.FindTransactions(transaction.Attribute{
+					Type:  transaction.OracleResponseT,
+					Value: &transaction.OracleResponse{ID: 42},
+				}, nil, nil)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":["0x8e7a19a0ac6bd2e6bd53b0ee1d0ec3c31bf8d2d1ee8ab1bb3ce6f7c3e7a4c521"]}`,
+			result: func(c *Client) interface{} {
+				h, err := util.Uint256DecodeStringLE("8e7a19a0ac6bd2e6bd53b0ee1d0ec3c31bf8d2d1ee8ab1bb3ce6f7c3e7a4c521")
+				if err != nil {
+					panic(err)
+				}
+				return []util.Uint256{h}
+			},
+		},
+	},
+	"calculatenetworkfee": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.CalculateNetworkFee(transaction.New(netmode.UnitTestNet, []byte{byte(opcode.PUSH1)}, 0))
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"networkfee":"1230610"}}`,
+			result: func(c *Client) interface{} {
+				return int64(1230610)
+			},
+		},
+	},
+	"estimatefee": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.EstimateFee(90)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"feeperbyte":"2000","mempoolsize":15}}`,
+			result: func(c *Client) interface{} {
+				return &result.FeeEstimate{
+					FeePerByte:  2000,
+					MempoolSize: 15,
+				}
+			},
+		},
+	},
 	"getapplicationlog": {
 		{
 			name: "positive",
@@ -136,6 +200,24 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 				}
 			},
 		},
+		{
+			name: "positive, events filter",
+			invoke: func(c *Client) (interface{}, error) {
+				trig := trigger.Application
+				return c.GetApplicationLogEvents(util.Uint256{}, &trig, "Transfer")
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"txid":"0x17145a039fca704fcdbeb46e6b210af98a1a9e5b9768e46ffc38f71c79ac2521","executions":[]}}`,
+			result: func(c *Client) interface{} {
+				txHash, err := util.Uint256DecodeStringLE("17145a039fca704fcdbeb46e6b210af98a1a9e5b9768e46ffc38f71c79ac2521")
+				if err != nil {
+					panic(err)
+				}
+				return &result.ApplicationLog{
+					Container:  txHash,
+					Executions: []state.Execution{},
+				}
+			},
+		},
 	},
 	"getbestblockhash": {
 		{
@@ -251,6 +333,17 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 				return &b.Header
 			},
 		},
+		{
+			name: "byIndex_positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetBlockHeaderByIndex(1)
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":"` + base64Header1 + `"}`,
+			result: func(c *Client) interface{} {
+				b := getResultBlock1()
+				return &b.Header
+			},
+		},
 		{
 			name: "verbose_positive",
 			invoke: func(c *Client) (i interface{}, err error) {
@@ -303,6 +396,26 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"getcandidates": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetCandidatesInfo()
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":[{"publickey":"02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2","votes":"1000","committee":true,"active":false}]}`,
+			result: func(c *Client) interface{} {
+				key, err := keys.NewPublicKeyFromString("02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2")
+				if err != nil {
+					panic(err)
+				}
+				return []result.Candidate{{
+					PublicKey: *key,
+					Votes:     1000,
+					Committee: true,
+				}}
+			},
+		},
+	},
 	"getcommittee": {
 		{
 			name: "positive",
@@ -319,6 +432,26 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"getdesignatedbyrole": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetDesignation(noderoles.Oracle, 10)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"role":8,"height":5,"nodes":["02103a7f7dd016558597f7960d27c516a4394fd968b9e65155eb4b013e4040406e"]}}`,
+			result: func(c *Client) interface{} {
+				member, err := keys.NewPublicKeyFromString("02103a7f7dd016558597f7960d27c516a4394fd968b9e65155eb4b013e4040406e")
+				if err != nil {
+					panic(fmt.Errorf("failed to decode public key: %w", err))
+				}
+				return &result.Designation{
+					Role:   noderoles.Oracle,
+					Height: 5,
+					Nodes:  keys.PublicKeys{member},
+				}
+			},
+		},
+	},
 	"getconnectioncount": {
 		{
 			name: "positive",
@@ -466,6 +599,111 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"nnsResolve": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.NNSResolve("neo.com", nnsrecords.A)
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"state":"HALT","gasconsumed":"2007390","script":"EMAMDWdldEZlZVBlckJ5dGUMFJphpG7sl7iTBtfOgfFbRiCR0AkyQWJ9W1I=","stack":[{"type":"ByteString","value":"MS4yLjMuNA=="}],"tx":null}}`,
+			result: func(c *Client) interface{} {
+				return "1.2.3.4"
+			},
+		},
+		{
+			name: "not found",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.NNSResolve("neo.com", nnsrecords.A)
+			},
+			fails:          true,
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"state":"HALT","gasconsumed":"2007390","script":"EMAMDWdldEZlZVBlckJ5dGUMFJphpG7sl7iTBtfOgfFbRiCR0AkyQWJ9W1I=","stack":[{"type":"Any"}],"tx":null}}`,
+		},
+	},
+	"nnsGetRecord": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.NNSGetRecord("neo.com", nnsrecords.A)
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"state":"HALT","gasconsumed":"2007390","script":"EMAMDWdldEZlZVBlckJ5dGUMFJphpG7sl7iTBtfOgfFbRiCR0AkyQWJ9W1I=","stack":[{"type":"ByteString","value":"MS4yLjMuNA=="}],"tx":null}}`,
+			result: func(c *Client) interface{} {
+				return "1.2.3.4"
+			},
+		},
+	},
+	"nnsOwnerOf": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.NNSOwnerOf("neo.com")
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"state":"HALT","gasconsumed":"2007390","script":"EMAMDWdldEZlZVBlckJ5dGUMFJphpG7sl7iTBtfOgfFbRiCR0AkyQWJ9W1I=","stack":[{"type":"ByteString","value":"AQIDBAUGBwgJCgsMDQ4PEBESExQ="}],"tx":null}}`,
+			result: func(c *Client) interface{} {
+				u, err := util.Uint160DecodeBytesBE([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20})
+				if err != nil {
+					panic(err)
+				}
+				return u
+			},
+		},
+	},
+	"nnsIsAvailable": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.NNSIsAvailable("neo.com")
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"state":"HALT","gasconsumed":"2007390","script":"EMAMDWdldEZlZVBlckJ5dGUMFJphpG7sl7iTBtfOgfFbRiCR0AkyQWJ9W1I=","stack":[{"type":"Boolean","value":true}],"tx":null}}`,
+			result: func(c *Client) interface{} {
+				return true
+			},
+		},
+	},
+	"resolveAddress": {
+		{
+			name: "address",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.ResolveAddress("NgEisvCqr2h8wpRxQb7bVPWUZdbVCY8Uo6")
+			},
+			serverResponse: `{}`,
+			result: func(c *Client) interface{} {
+				u, err := address.StringToUint160("NgEisvCqr2h8wpRxQb7bVPWUZdbVCY8Uo6")
+				if err != nil {
+					panic(err)
+				}
+				return u
+			},
+		},
+		{
+			name: "name",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.ResolveAddress("neo.com")
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"state":"HALT","gasconsumed":"2007390","script":"EMAMDWdldEZlZVBlckJ5dGUMFJphpG7sl7iTBtfOgfFbRiCR0AkyQWJ9W1I=","stack":[{"type":"ByteString","value":"TmdFaXN2Q3FyMmg4d3BSeFFiN2JWUFdVWmRiVkNZOFVvNg=="}],"tx":null}}`,
+			result: func(c *Client) interface{} {
+				u, err := address.StringToUint160("NgEisvCqr2h8wpRxQb7bVPWUZdbVCY8Uo6")
+				if err != nil {
+					panic(err)
+				}
+				return u
+			},
+		},
+	},
+	"nep11Properties": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.NEP11TokenProperties(util.Uint160{1, 2, 3}, []byte("neo.com"))
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"state":"HALT","gasconsumed":"2007390","script":"EMAMDWdldEZlZVBlckJ5dGUMFJphpG7sl7iTBtfOgfFbRiCR0AkyQWJ9W1I=","stack":[{"type":"Map","value":[{"key":{"type":"ByteString","value":"bmFtZQ=="},"value":{"type":"ByteString","value":"bmVvLmNvbQ=="}},{"key":{"type":"ByteString","value":"ZXhwaXJhdGlvbg=="},"value":{"type":"Integer","value":"1000"}}]}],"tx":null}}`,
+			result: func(c *Client) interface{} {
+				return &TokenProperties{
+					Name:  "neo.com",
+					Extra: map[string]string{"expiration": "1000"},
+				}
+			},
+		},
+	},
 	"getGasPerBlock": {
 		{
 			name: "positive",
@@ -524,6 +762,69 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"getnep11balances": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetNEP11Balances(util.Uint160{1, 2, 3})
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"balance":[{"assethash":"a48b6e1291ba24211ad11bb90ae2a10bf1fcd5a8","tokens":[{"tokenid":"0102","amount":"1","lastupdatedblock":251604}]}],"address":"AY6eqWjsUFCzsVELG7yG72XDukKvC34p2w"}}`,
+			result: func(c *Client) interface{} {
+				hash, err := util.Uint160DecodeStringLE("a48b6e1291ba24211ad11bb90ae2a10bf1fcd5a8")
+				if err != nil {
+					panic(err)
+				}
+				return &result.NEP11Balances{
+					Balances: []result.NEP11AssetBalance{{
+						Asset: hash,
+						Tokens: []result.NEP11TokenBalance{{
+							ID:          "0102",
+							Amount:      "1",
+							LastUpdated: 251604,
+						}},
+					}},
+					Address: "AY6eqWjsUFCzsVELG7yG72XDukKvC34p2w",
+				}
+			},
+		},
+	},
+	"getnep11transfers": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetNEP11Transfers("AbHgdBaWEnHkCiLtDZXjhvhaAK2cwFh5pF", nil, nil, nil, nil)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"sent":[],"received":[{"timestamp":1555651816,"assethash":"600c4f5200db36177e3e8a09e9f18e2fc7d12a0f","transferaddress":"AYwgBNMepiv5ocGcyNT4mA8zPLTQ8pDBis","amount":"1","blockindex":436036,"transfernotifyindex":0,"txhash":"df7683ece554ecfb85cf41492c5f143215dd43ef9ec61181a28f922da06aba58","tokenid":"0102"}],"address":"AbHgdBaWEnHkCiLtDZXjhvhaAK2cwFh5pF"}}`,
+			result: func(c *Client) interface{} {
+				assetHash, err := util.Uint160DecodeStringLE("600c4f5200db36177e3e8a09e9f18e2fc7d12a0f")
+				if err != nil {
+					panic(err)
+				}
+				txHash, err := util.Uint256DecodeStringLE("df7683ece554ecfb85cf41492c5f143215dd43ef9ec61181a28f922da06aba58")
+				if err != nil {
+					panic(err)
+				}
+				return &result.NEP11Transfers{
+					Sent: []result.NEP11Transfer{},
+					Received: []result.NEP11Transfer{
+						{
+							NEP17Transfer: result.NEP17Transfer{
+								Timestamp:   1555651816,
+								Asset:       assetHash,
+								Address:     "AYwgBNMepiv5ocGcyNT4mA8zPLTQ8pDBis",
+								Amount:      "1",
+								Index:       436036,
+								NotifyIndex: 0,
+								TxHash:      txHash,
+							},
+							ID: "0102",
+						},
+					},
+					Address: "AbHgdBaWEnHkCiLtDZXjhvhaAK2cwFh5pF",
+				}
+			},
+		},
+	},
 	"getnep17balances": {
 		{
 			name: "positive",
@@ -584,6 +885,26 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 				}
 			},
 		},
+		{
+			name: "iterate",
+			invoke: func(c *Client) (interface{}, error) {
+				var pages []*result.NEP17Transfers
+				limit := 10
+				err := c.IterateNEP17Transfers("AbHgdBaWEnHkCiLtDZXjhvhaAK2cwFh5pF", nil, nil, &limit, func(res *result.NEP17Transfers) (bool, error) {
+					pages = append(pages, res)
+					return true, nil
+				})
+				return pages, err
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"sent":[],"received":[],"address":"AbHgdBaWEnHkCiLtDZXjhvhaAK2cwFh5pF"}}`,
+			result: func(c *Client) interface{} {
+				return []*result.NEP17Transfers{{
+					Sent:     []result.NEP17Transfer{},
+					Received: []result.NEP17Transfer{},
+					Address:  "AbHgdBaWEnHkCiLtDZXjhvhaAK2cwFh5pF",
+				}}
+			},
+		},
 	},
 	"getpeers": {
 		{
@@ -615,6 +936,36 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 				}
 			},
 		},
+		{
+			name: "verbose",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetPeersVerbose()
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"unconnected":[],"connected":[{"address":"127.0.0.1","port":"20335","useragent":"/NEO-GO:0.95.0/","height":1234,"capabilities":[{"type":1,"port":20335},{"type":16,"startheight":1200}],"inbound":true,"lastseen":1617003333000}],"bad":[]}}`,
+			result: func(c *Client) interface{} {
+				height := uint32(1234)
+				inbound := true
+				lastSeen := int64(1617003333000)
+				return &result.GetPeers{
+					Unconnected: result.Peers{},
+					Connected: result.Peers{
+						{
+							Address:   "127.0.0.1",
+							Port:      "20335",
+							UserAgent: "/NEO-GO:0.95.0/",
+							Height:    &height,
+							Capabilities: []result.PeerCapability{
+								{Type: capability.TCPServer, Port: 20335},
+								{Type: capability.FullNode, StartHeight: 1200},
+							},
+							Inbound:  &inbound,
+							LastSeen: &lastSeen,
+						},
+					},
+					Bad: result.Peers{},
+				}
+			},
+		},
 	},
 	"getrawmempool": {
 		{
@@ -631,6 +982,32 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 				return []util.Uint256{hash}
 			},
 		},
+		{
+			name: "details",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetRawMemPoolDetails()
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"height":5,"verified":["0x9786cce0dddb524c40ddbdd5e31a41ed1f6b5c8a683c122f627ca4a007a7cf4e"],"unverified":[],"transactions":[{"hash":"0x9786cce0dddb524c40ddbdd5e31a41ed1f6b5c8a683c122f627ca4a007a7cf4e","sender":"NiXgSLtGUjNMuW3ZHXnmRMYJ4G8evz1aXh","sysfee":"100","netfee":"1200000","feeperbyte":"1000","blockstamp":4}]}}`,
+			result: func(c *Client) interface{} {
+				hash, err := util.Uint256DecodeStringLE("9786cce0dddb524c40ddbdd5e31a41ed1f6b5c8a683c122f627ca4a007a7cf4e")
+				if err != nil {
+					panic(err)
+				}
+				return &result.RawMempool{
+					Height:     5,
+					Verified:   []util.Uint256{hash},
+					Unverified: []util.Uint256{},
+					Transactions: []result.MempoolTransaction{{
+						Hash:       hash,
+						Sender:     "NiXgSLtGUjNMuW3ZHXnmRMYJ4G8evz1aXh",
+						SystemFee:  100,
+						NetworkFee: 1200000,
+						FeePerByte: 1000,
+						BlockStamp: 4,
+					}},
+				}
+			},
+		},
 	},
 	"getrawtransaction": {
 		{
@@ -668,6 +1045,112 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"getstate": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				root, _ := util.Uint256DecodeStringLE("252e9d73d49c95c7618d40650da504e05183a1b2eed0685e42c360413c329170")
+				cHash, _ := util.Uint160DecodeStringLE("5c9e40a12055c6b9e3f72271c9779958c842135d")
+				return c.GetState(root, cHash, []byte("testkey"))
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":"dGVzdHZhbHVl"}`,
+			result: func(c *Client) interface{} {
+				return []byte("testvalue")
+			},
+		},
+	},
+	"findstates": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				root, _ := util.Uint256DecodeStringLE("252e9d73d49c95c7618d40650da504e05183a1b2eed0685e42c360413c329170")
+				cHash, _ := util.Uint160DecodeStringLE("5c9e40a12055c6b9e3f72271c9779958c842135d")
+				count := 1
+				return c.FindStates(root, cHash, []byte("aa"), nil, &count)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"results":[{"key":"YWEx","value":"djE="}],"firstProof":"070100000061613100","truncated":true}}`,
+			result: func(c *Client) interface{} {
+				return result.FindStates{
+					Results:    []result.KeyValue{{Key: []byte("aa1"), Value: []byte("v1")}},
+					FirstProof: &result.ProofWithKey{Key: []byte{1, 0, 0, 0, 'a', 'a', '1'}},
+					Truncated:  true,
+				}
+			},
+		},
+	},
+	"findstorage": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				cHash, _ := util.Uint160DecodeStringLE("5c9e40a12055c6b9e3f72271c9779958c842135d")
+				count := 1
+				return c.FindStorage(cHash, []byte("aa"), &count)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"results":[{"key":"YWEx","value":"djE="}],"truncated":true,"next":"AAAAAAAAAAAAA2FhMQ"}}`,
+			result: func(c *Client) interface{} {
+				return &result.FindStorage{
+					Results:   []result.KeyValue{{Key: []byte("aa1"), Value: []byte("v1")}},
+					Truncated: true,
+					Next:      "AAAAAAAAAAAAA2FhMQ",
+				}
+			},
+		},
+	},
+	"getstateheight": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetStateHeight()
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"blockHeight":208,"stateHeight":207}}`,
+			result: func(c *Client) interface{} {
+				return &result.StateHeight{
+					BlockHeight: 208,
+					StateHeight: 207,
+				}
+			},
+		},
+	},
+	"getstateroot": {
+		{
+			name: "by height",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetStateRootByHeight(5)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"version":0,"index":5,"stateroot":"0x1ec3c5b0ab7f6e28bc7f0fd3ab70c2b0b5fa0fb2ac1a8a1e3be5e1f1e0e46a6f","witness":{"invocation":"DEA=","verification":"EQ=="}}}`,
+			result: func(c *Client) interface{} {
+				root, err := util.Uint256DecodeStringLE("1ec3c5b0ab7f6e28bc7f0fd3ab70c2b0b5fa0fb2ac1a8a1e3be5e1f1e0e46a6f")
+				if err != nil {
+					panic(err)
+				}
+				return &state.MPTRoot{
+					Index: 5,
+					Root:  root,
+					Witness: &transaction.Witness{
+						InvocationScript:   []byte{0x0c, 0x40},
+						VerificationScript: []byte{0x11},
+					},
+				}
+			},
+		},
+		{
+			name: "by hash, not validated",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetStateRootByBlockHash(util.Uint256{1, 2, 3})
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"version":0,"index":5,"stateroot":"0x1ec3c5b0ab7f6e28bc7f0fd3ab70c2b0b5fa0fb2ac1a8a1e3be5e1f1e0e46a6f"}}`,
+			result: func(c *Client) interface{} {
+				root, err := util.Uint256DecodeStringLE("1ec3c5b0ab7f6e28bc7f0fd3ab70c2b0b5fa0fb2ac1a8a1e3be5e1f1e0e46a6f")
+				if err != nil {
+					panic(err)
+				}
+				return &state.MPTRoot{
+					Index: 5,
+					Root:  root,
+				}
+			},
+		},
+	},
 	"getstorage": {
 		{
 			name: "by hash, positive",
@@ -778,6 +1261,22 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"getwatched": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetWatched()
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":["NgEisvCqr2h8wpRxQb7bVPWUZdbVCY8Uo6"]}`,
+			result: func(c *Client) interface{} {
+				h, err := address.StringToUint160("NgEisvCqr2h8wpRxQb7bVPWUZdbVCY8Uo6")
+				if err != nil {
+					panic(err)
+				}
+				return []util.Uint160{h}
+			},
+		},
+	},
 	"invokefunction": {
 		{
 			name: "positive, by scripthash",
@@ -894,6 +1393,151 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 				}
 			},
 		},
+		{
+			name: "positive, diagnostics",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.InvokeScriptWithDiagnostics([]byte{byte(opcode.PUSH1)}, nil)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"script":"EQ==","state":"HALT","gasconsumed":"30","stack":[{"type":"Integer","value":"1"}],"diagnostics":{"invokedcontracts":[{"hash":"0x0102030000000000000000000000000000000000","callflags":15,"call":[{"hash":"0x0405060000000000000000000000000000000000","callflags":5}]}],"storagechanges":[{"state":"Added","id":1,"contract":"0x0405060000000000000000000000000000000000","key":"a2V5","value":"dmFsdWU="}]}}}`,
+			result: func(c *Client) interface{} {
+				script, _ := util.Uint160DecodeStringLE("0102030000000000000000000000000000000000")
+				contract, _ := util.Uint160DecodeStringLE("0405060000000000000000000000000000000000")
+				return &result.Invoke{
+					State:       "HALT",
+					GasConsumed: 30,
+					Script:      []byte{byte(opcode.PUSH1)},
+					Stack:       []stackitem.Item{stackitem.NewBigInteger(big.NewInt(1))},
+					Diagnostics: &result.InvokeDiag{
+						Invocations: []*vm.InvocationTree{{
+							Current: script,
+							Flags:   callflag.All,
+							Calls: []*vm.InvocationTree{{
+								Current: contract,
+								Flags:   callflag.ReadOnly,
+							}},
+						}},
+						Changes: []result.StorageChange{{
+							State:    "Added",
+							ID:       1,
+							Contract: &contract,
+							Key:      []byte("key"),
+							Value:    []byte("value"),
+						}},
+					},
+				}
+			},
+		},
+	},
+	"simulatescript": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.SimulateScript([]byte{byte(opcode.PUSH1)}, request.StateOverride{Timestamp: 42}, nil)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"script":"EQ==","state":"HALT","gasconsumed":"30","stack":[{"type":"Integer","value":"1"}],"tx":null}}`,
+			result: func(c *Client) interface{} {
+				return &result.Invoke{
+					State:       "HALT",
+					GasConsumed: 30,
+					Script:      []byte{byte(opcode.PUSH1)},
+					Stack:       []stackitem.Item{stackitem.Make(1)},
+				}
+			},
+		},
+	},
+	"invokescripthistoric": {
+		{
+			name: "positive, by height",
+			invoke: func(c *Client) (interface{}, error) {
+				script, err := base64.StdEncoding.DecodeString("AARuYW1lZyQFjl4bYAiEfNZicoVJCIqe6CGR")
+				if err != nil {
+					panic(err)
+				}
+				return c.InvokeScriptAt(5, script, nil)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"script":"AARuYW1lZyQFjl4bYAiEfNZicoVJCIqe6CGR","state":"HALT","gasconsumed":"16100000","stack":[{"type":"Integer","value":"42"}],"tx":null}}`,
+			result: func(c *Client) interface{} {
+				script, err := base64.StdEncoding.DecodeString("AARuYW1lZyQFjl4bYAiEfNZicoVJCIqe6CGR")
+				if err != nil {
+					panic(err)
+				}
+				return &result.Invoke{
+					State:       "HALT",
+					GasConsumed: 16100000,
+					Script:      script,
+					Stack:       []stackitem.Item{stackitem.NewBigInteger(big.NewInt(42))},
+				}
+			},
+		},
+		{
+			name: "positive, by state root",
+			invoke: func(c *Client) (interface{}, error) {
+				script, err := base64.StdEncoding.DecodeString("AARuYW1lZyQFjl4bYAiEfNZicoVJCIqe6CGR")
+				if err != nil {
+					panic(err)
+				}
+				return c.InvokeScriptWithState(util.Uint256{1, 2, 3}, script, nil)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"script":"AARuYW1lZyQFjl4bYAiEfNZicoVJCIqe6CGR","state":"HALT","gasconsumed":"16100000","stack":[{"type":"Integer","value":"42"}],"tx":null}}`,
+			result: func(c *Client) interface{} {
+				script, err := base64.StdEncoding.DecodeString("AARuYW1lZyQFjl4bYAiEfNZicoVJCIqe6CGR")
+				if err != nil {
+					panic(err)
+				}
+				return &result.Invoke{
+					State:       "HALT",
+					GasConsumed: 16100000,
+					Script:      script,
+					Stack:       []stackitem.Item{stackitem.NewBigInteger(big.NewInt(42))},
+				}
+			},
+		},
+	},
+	"invokefunctionhistoric": {
+		{
+			name: "positive, by height",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.InvokeFunctionAt(5, util.Uint160{1, 2, 3}, "balanceOf", []smartcontract.Parameter{{
+					Type:  smartcontract.Hash160Type,
+					Value: util.Uint160{3, 2, 1},
+				}}, nil)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"script":"AARuYW1lZyQFjl4bYAiEfNZicoVJCIqe6CGR","state":"HALT","gasconsumed":"16100000","stack":[{"type":"Integer","value":"42"}],"tx":null}}`,
+			result: func(c *Client) interface{} {
+				script, err := base64.StdEncoding.DecodeString("AARuYW1lZyQFjl4bYAiEfNZicoVJCIqe6CGR")
+				if err != nil {
+					panic(err)
+				}
+				return &result.Invoke{
+					State:       "HALT",
+					GasConsumed: 16100000,
+					Script:      script,
+					Stack:       []stackitem.Item{stackitem.NewBigInteger(big.NewInt(42))},
+				}
+			},
+		},
+		{
+			name: "positive, by state root",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.InvokeFunctionWithState(util.Uint256{1, 2, 3}, util.Uint160{1, 2, 3}, "balanceOf", []smartcontract.Parameter{{
+					Type:  smartcontract.Hash160Type,
+					Value: util.Uint160{3, 2, 1},
+				}}, nil)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"script":"AARuYW1lZyQFjl4bYAiEfNZicoVJCIqe6CGR","state":"HALT","gasconsumed":"16100000","stack":[{"type":"Integer","value":"42"}],"tx":null}}`,
+			result: func(c *Client) interface{} {
+				script, err := base64.StdEncoding.DecodeString("AARuYW1lZyQFjl4bYAiEfNZicoVJCIqe6CGR")
+				if err != nil {
+					panic(err)
+				}
+				return &result.Invoke{
+					State:       "HALT",
+					GasConsumed: 16100000,
+					Script:      script,
+					Stack:       []stackitem.Item{stackitem.NewBigInteger(big.NewInt(42))},
+				}
+			},
+		},
 	},
 	"invokecontractverify": {
 		{
@@ -935,21 +1579,47 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			fails: true,
 		},
 	},
+	"removewatched": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return true, c.RemoveWatched(util.Uint160{1, 2, 3})
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":true}`,
+			result: func(c *Client) interface{} {
+				return true
+			},
+		},
+	},
 	"sendrawtransaction": {
 		{
 			name: "positive",
 			invoke: func(c *Client) (interface{}, error) {
-				return c.SendRawTransaction(transaction.New(netmode.UnitTestNet, []byte{byte(opcode.PUSH1)}, 0))
+				tx := transaction.New(netmode.UnitTestNet, []byte{byte(opcode.PUSH1)}, 0)
+				tx.Nonce = 1
+				return c.SendRawTransaction(tx)
 			},
-			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"hash":"0x72159b0cf1221110daad6e1df6ef4ff03012173b63c86910bd7134deb659c875"}}`,
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"hash":"0xf39c2ef19736376e30a9cdbb9fdfbb98ee62282e12fb6d4d6aad69cc47648cea"}}`,
 			result: func(c *Client) interface{} {
-				h, err := util.Uint256DecodeStringLE("72159b0cf1221110daad6e1df6ef4ff03012173b63c86910bd7134deb659c875")
+				h, err := util.Uint256DecodeStringLE("f39c2ef19736376e30a9cdbb9fdfbb98ee62282e12fb6d4d6aad69cc47648cea")
 				if err != nil {
 					panic(fmt.Errorf("can't decode `sendrawtransaction` result hash: %w", err))
 				}
 				return h
 			},
 		},
+		{
+			name: "hash mismatch",
+			invoke: func(c *Client) (interface{}, error) {
+				tx := transaction.New(netmode.UnitTestNet, []byte{byte(opcode.PUSH1)}, 0)
+				tx.Nonce = 0
+				_ = tx.Hash()
+				tx.Nonce = 1 // Changed after hashing without DirtyHash.
+				return c.SendRawTransaction(tx)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"hash":"0xf39c2ef19736376e30a9cdbb9fdfbb98ee62282e12fb6d4d6aad69cc47648cea"}}`,
+			fails:          true,
+		},
 	},
 	"submitblock": {
 		{
@@ -961,9 +1631,9 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 					Trimmed:      false,
 				})
 			},
-			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"hash":"0x1bdea8f80eb5bd97fade38d5e7fb93b02c9d3e01394e9f4324218132293f7ea6"}}`,
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"hash":"0x4c5e523d5780a0da2e685962d9a85fbc07cc77a9a20e84126eb1ed2e96d98de0"}}`,
 			result: func(c *Client) interface{} {
-				h, err := util.Uint256DecodeStringLE("1bdea8f80eb5bd97fade38d5e7fb93b02c9d3e01394e9f4324218132293f7ea6")
+				h, err := util.Uint256DecodeStringLE("4c5e523d5780a0da2e685962d9a85fbc07cc77a9a20e84126eb1ed2e96d98de0")
 				if err != nil {
 					panic(fmt.Errorf("can't decode `submitblock` result hash: %w", err))
 				}
@@ -984,6 +1654,19 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"watchtransaction": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return nil, c.WatchTransactionCallback(util.Uint256{1, 2, 3}, "https://example.com/")
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":true}`,
+			result: func(c *Client) interface{} {
+				// no error expected
+				return nil
+			},
+		},
+	},
 }
 
 type rpcClientErrorCase struct {
@@ -1169,6 +1852,13 @@ var rpcClientErrorCases = map[string][]rpcClientErrorCase{
 				return c.GetContractStateByHash(util.Uint160{})
 			},
 		},
+		{
+			name: "getnep11transfers_invalid_params_error",
+			invoke: func(c *Client) (interface{}, error) {
+				var stop uint32
+				return c.GetNEP11Transfers("NTh9TnZTstvAePEYWDGLLxidBikJE24uTo", nil, &stop, nil, nil)
+			},
+		},
 		{
 			name: "getnep17balances_invalid_params_error",
 			invoke: func(c *Client) (interface{}, error) {
@@ -1651,6 +2341,169 @@ func TestCalculateValidUntilBlock(t *testing.T) {
 	assert.Equal(t, 1, getValidatorsCalled)
 }
 
+func TestRestampTx(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r := request.NewRequest()
+		err := r.DecodeData(req.Body)
+		require.NoErrorf(t, err, "Cannot decode request body: %s", req.Body)
+		var response string
+		if r.In.Method == "getblockcount" {
+			response = `{"jsonrpc":"2.0","id":1,"result":50}`
+		}
+		requestHandler(t, r.In, w, response)
+	}))
+	t.Cleanup(srv.Close)
+
+	_, err := New(context.TODO(), srv.URL, Options{ValidUntilBlockOffset: transaction.MaxValidUntilBlockIncrement + 1})
+	require.Error(t, err)
+
+	var nonce uint32
+	c, err := New(context.TODO(), srv.URL, Options{
+		ValidUntilBlockOffset: 100,
+		NonceGenerator: func() uint32 {
+			nonce++
+			return nonce
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	tx := transaction.New(c.GetNetwork(), []byte{byte(opcode.PUSH1)}, 123)
+	tx.NetworkFee = 456
+	tx.ValidUntilBlock = 10
+	tx.Signers = []transaction.Signer{{Account: util.Uint160{1, 2, 3}}}
+	tx.Scripts = []transaction.Witness{{InvocationScript: []byte{1}, VerificationScript: []byte{2}}}
+
+	newTx, err := c.RestampTx(tx)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), newTx.Nonce)
+	require.Equal(t, uint32(150), newTx.ValidUntilBlock)
+	require.Equal(t, tx.Script, newTx.Script)
+	require.Equal(t, tx.SystemFee, newTx.SystemFee)
+	require.Equal(t, tx.NetworkFee, newTx.NetworkFee)
+	require.Equal(t, tx.Signers, newTx.Signers)
+	require.Equal(t, 0, len(newTx.Scripts))
+	require.NotEqual(t, tx.Hash(), newTx.Hash())
+}
+
+func TestHeaders(t *testing.T) {
+	var hdrs []*block.Header
+	for i := uint32(0); i < 5; i++ {
+		h := &block.Header{
+			Index:     i,
+			Timestamp: uint64(i),
+			Network:   42,
+			Script:    transaction.Witness{InvocationScript: []byte{}, VerificationScript: []byte{byte(opcode.PUSH1)}},
+		}
+		if i > 0 {
+			h.PrevHash = hdrs[i-1].Hash()
+		}
+		hdrs = append(hdrs, h)
+	}
+	// Header 4 is broken.
+	hdrs[4].PrevHash = util.Uint256{1, 2, 3}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r := request.NewRequest()
+		err := r.DecodeData(req.Body)
+		require.NoErrorf(t, err, "Cannot decode request body: %s", req.Body)
+		var response string
+		if r.In.Method == "getblockheader" {
+			p, err := r.In.Params()
+			require.NoError(t, err)
+			i, err := p.Value(0).GetInt()
+			require.NoError(t, err)
+			if i < len(hdrs) {
+				data, err := testserdes.EncodeBinary(hdrs[i])
+				require.NoError(t, err)
+				response = `{"id":1,"jsonrpc":"2.0","result":"` + base64.StdEncoding.EncodeToString(data) + `"}`
+			} else {
+				response = `{"jsonrpc":"2.0","id":1,"error":{"code":-100,"message":"Unknown block"}}`
+			}
+		}
+		requestHandler(t, r.In, w, response)
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := New(context.TODO(), srv.URL, Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	actual, err := c.Headers(1, 3)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(actual))
+	for i := range actual {
+		require.Equal(t, hdrs[i+1].Hash(), actual[i].Hash())
+	}
+
+	_, err = c.Headers(3, 2)
+	require.Error(t, err)
+
+	_, err = c.Headers(5, 1)
+	require.True(t, errors.Is(err, response.ErrNotFound))
+}
+
+func TestErrorClasses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r := request.NewRequest()
+		err := r.DecodeData(req.Body)
+		require.NoErrorf(t, err, "Cannot decode request body: %s", req.Body)
+		var resp string
+		switch r.In.Method {
+		case "sendrawtransaction":
+			resp = `{"jsonrpc":"2.0","id":1,"error":{"code":-511,"message":"Insufficient funds to pay fees.","data":"insufficient funds"}}`
+		case "gettransactionheight":
+			resp = `{"jsonrpc":"2.0","id":1,"error":{"code":-100,"message":"Unknown transaction"}}`
+		}
+		requestHandler(t, r.In, w, resp)
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := New(context.TODO(), srv.URL, Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	_, err = c.SendRawTransaction(transaction.New(c.GetNetwork(), []byte{byte(opcode.PUSH1)}, 0))
+	require.True(t, errors.Is(err, response.ErrInsufficientFunds))
+	require.False(t, errors.Is(err, response.ErrNotFound))
+
+	_, err = c.GetTransactionHeight(util.Uint256{})
+	require.True(t, errors.Is(err, response.ErrNotFound))
+}
+
+func TestNetworkMismatch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r := request.NewRequest()
+		err := r.DecodeData(req.Body)
+		require.NoErrorf(t, err, "Cannot decode request body: %s", req.Body)
+		require.NotEqual(t, "sendrawtransaction", r.In.Method)
+		require.NotEqual(t, "submitblock", r.In.Method)
+		requestHandler(t, r.In, w, "")
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := New(context.TODO(), srv.URL, Options{})
+	require.NoError(t, err)
+	acc, err := wallet.NewAccount()
+	require.NoError(t, err)
+	tx := transaction.New(netmode.MainNet, []byte{byte(opcode.PUSH1)}, 0)
+	require.True(t, errors.Is(c.CheckNetwork(tx), errNetworkNotInitialized))
+
+	require.NoError(t, c.Init())
+	require.True(t, errors.Is(c.CheckNetwork(tx), ErrNetworkMismatch))
+	require.True(t, errors.Is(c.SignTx(acc, tx), ErrNetworkMismatch))
+	require.Equal(t, 0, len(tx.Scripts))
+	_, err = c.SendRawTransaction(tx)
+	require.True(t, errors.Is(err, ErrNetworkMismatch))
+	_, err = c.SubmitBlock(block.Block{Header: block.Header{Network: netmode.MainNet}})
+	require.True(t, errors.Is(err, ErrNetworkMismatch))
+
+	tx = transaction.New(c.GetNetwork(), []byte{byte(opcode.PUSH1)}, 0)
+	require.NoError(t, c.CheckNetwork(tx))
+	require.NoError(t, c.SignTx(acc, tx))
+	require.Equal(t, 1, len(tx.Scripts))
+}
+
 func TestGetNetwork(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		r := request.NewRequest()
@@ -1685,6 +2538,30 @@ func TestGetNetwork(t *testing.T) {
 	})
 }
 
+func TestInvokeWithGas(t *testing.T) {
+	var params []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r := request.NewRequest()
+		err := r.DecodeData(req.Body)
+		require.NoErrorf(t, err, "Cannot decode request body: %s", req.Body)
+		params = append(params, string(r.In.RawParams))
+		requestHandler(t, r.In, w, `{"id":1,"jsonrpc":"2.0","result":{"state":"HALT","gasconsumed":"1","script":"EQ==","stack":[],"tx":null}}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := New(context.TODO(), srv.URL, Options{})
+	require.NoError(t, err)
+
+	_, err = c.InvokeScriptWithGas([]byte{byte(opcode.PUSH1)}, nil, 42)
+	require.NoError(t, err)
+	_, err = c.InvokeFunctionWithGas(util.Uint160{}, "test", nil, nil, 100)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		`["EQ==",[],42]`,
+		`["0000000000000000000000000000000000000000","test",null,[],100]`,
+	}, params)
+}
+
 func TestUninitedClient(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		r := request.NewRequest()
@@ -1724,3 +2601,28 @@ func newTestNEF(script []byte) nef.File {
 	ne.Checksum = ne.CalculateChecksum()
 	return ne
 }
+
+func TestGetSigners(t *testing.T) {
+	priv1, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	priv2, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	sender := wallet.NewAccountFromPrivateKey(priv1)
+	cosigner := wallet.NewAccountFromPrivateKey(priv2)
+
+	senderSA, err := NewSignerAccount(sender, transaction.CalledByEntry)
+	require.NoError(t, err)
+	senderSA.AllowContracts(util.Uint160{1, 2, 3})
+	senderSA.AllowGroups(priv2.PublicKey())
+	require.Equal(t, transaction.CalledByEntry|transaction.CustomContracts|transaction.CustomGroups, senderSA.Signer.Scopes)
+
+	cosignerSA, err := NewSignerAccount(cosigner, transaction.None)
+	require.NoError(t, err)
+	cosignerSA.AllowContracts(util.Uint160{4, 5, 6}, util.Uint160{7, 8, 9})
+	require.Equal(t, transaction.CustomContracts, cosignerSA.Signer.Scopes)
+
+	signers, accounts, err := getSigners(sender, []SignerAccount{cosignerSA, senderSA})
+	require.NoError(t, err)
+	require.Equal(t, []transaction.Signer{senderSA.Signer, cosignerSA.Signer}, signers)
+	require.Equal(t, []*wallet.Account{sender, cosigner}, accounts)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -12,6 +14,8 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/nspcc-dev/neo-go/pkg/services/finality"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 )
 
@@ -26,25 +30,62 @@ type WSClient struct {
 	// it wants to use subscription mechanism, failing to do so will cause
 	// WSClient to block even regular requests. This channel is not buffered.
 	// In case of protocol error or upon connection closure this channel will
-	// be closed, so make sure to handle this.
+	// be closed (unless the client is able to reconnect, see
+	// Options.ReconnectAttempts), so make sure to handle this.
 	Notifications chan Notification
 
-	ws            *websocket.Conn
-	done          chan struct{}
-	responses     chan *response.Raw
-	requests      chan *request.Raw
-	shutdown      chan struct{}
-	subscriptions map[string]bool
+	wsEndpoint string
+	dialer     websocket.Dialer
+
+	// connDone is closed when the current connection is lost.
+	connDone  chan struct{}
+	connLock  sync.RWMutex
+	done      chan struct{}
+	responses chan *response.Raw
+	requests  chan *request.Raw
+	shutdown  chan struct{}
+	// reqLock serializes requests, responses are not matched by ID.
+	reqLock sync.Mutex
+
+	subsLock      sync.Mutex
+	subscriptions map[string]*wsSubscription
+	watches       map[util.Uint256]bool
+	// lastBlock is the highest block index known to the client, it's used
+	// to determine reconnection gaps.
+	lastBlock uint32
 }
 
 // Notification represents server-generated notification for client subscriptions.
-// Value can be one of block.Block, result.ApplicationLog, result.NotificationEvent
-// or transaction.Transaction based on Type.
+// Value can be one of block.Block, result.ApplicationLog, result.NotificationEvent,
+// transaction.Transaction, result.MempoolEvent, state.MPTRoot, finality.Event or Gap
+// based on Type.
 type Notification struct {
 	Type  response.EventID
 	Value interface{}
 }
 
+// Gap is sent with ReconnectEventID notification after WSClient reconnects to
+// the server. Events generated while the client was disconnected are lost, so
+// blocks from From (exclusive) to To (inclusive) should be checked by other
+// means if needed.
+type Gap struct {
+	// From is the highest block index known to the client before
+	// disconnection (either received via block_added event or fetched
+	// upon connection).
+	From uint32
+	// To is server's chain height after reconnection.
+	To uint32
+}
+
+// wsSubscription is an active subscription that is restored after
+// reconnection.
+type wsSubscription struct {
+	params request.RawParams
+	// serverID is the subscription ID for the current connection, it
+	// differs from the one returned to the user after reconnection.
+	serverID string
+}
+
 // requestResponse is a combined type for request and response since we can get
 // any of them here.
 type requestResponse struct {
@@ -65,6 +106,12 @@ const (
 
 	// Write deadline.
 	wsWriteLimit = wsPingPeriod / 2
+
+	// Default initial delay between reconnection attempts.
+	defaultReconnectDelay = time.Second
+
+	// Default maximum delay between reconnection attempts.
+	defaultMaxReconnectDelay = time.Minute
 )
 
 // NewWS returns a new WSClient ready to use (with established websocket
@@ -79,25 +126,43 @@ func NewWS(ctx context.Context, endpoint string, opts Options) (*WSClient, error
 
 	cl.cli = nil
 
-	dialer := websocket.Dialer{HandshakeTimeout: opts.DialTimeout}
-	ws, _, err := dialer.Dial(endpoint, nil)
+	dialer := websocket.Dialer{
+		HandshakeTimeout: cl.opts.DialTimeout,
+		TLSClientConfig:  cl.tlsConfig,
+	}
+	ws, _, err := dialer.Dial(endpoint, cl.header)
 	if err != nil {
 		return nil, err
 	}
+	if cl.opts.ReconnectDelay <= 0 {
+		cl.opts.ReconnectDelay = defaultReconnectDelay
+	}
+	if cl.opts.MaxReconnectDelay <= 0 {
+		cl.opts.MaxReconnectDelay = defaultMaxReconnectDelay
+	}
 	wsc := &WSClient{
 		Client:        *cl,
 		Notifications: make(chan Notification),
 
-		ws:            ws,
+		wsEndpoint:    endpoint,
+		dialer:        dialer,
+		connDone:      make(chan struct{}),
 		shutdown:      make(chan struct{}),
 		done:          make(chan struct{}),
 		responses:     make(chan *response.Raw),
 		requests:      make(chan *request.Raw),
-		subscriptions: make(map[string]bool),
+		subscriptions: make(map[string]*wsSubscription),
+		watches:       make(map[util.Uint256]bool),
 	}
-	go wsc.wsReader()
-	go wsc.wsWriter()
 	wsc.requestF = wsc.makeWsRequest
+	go wsc.wsLoop(ws, wsc.connDone)
+	if wsc.opts.ReconnectAttempts != 0 {
+		// Initial height is needed to report reconnection gaps.
+		if _, err := wsc.updateHeight(); err != nil {
+			wsc.Close()
+			return nil, err
+		}
+	}
 	return wsc, nil
 }
 
@@ -112,14 +177,216 @@ func (c *WSClient) Close() {
 	<-c.done
 }
 
-func (c *WSClient) wsReader() {
-	c.ws.SetReadLimit(wsReadLimit)
-	c.ws.SetPongHandler(func(string) error { c.ws.SetReadDeadline(time.Now().Add(wsPongLimit)); return nil })
+// wsLoop runs reader and writer routines for the given connection and
+// reconnects to the server if it's lost (when allowed by options).
+func (c *WSClient) wsLoop(ws *websocket.Conn, connDone chan struct{}) {
+	var restoreDone chan struct{}
+	for {
+		writerDone := make(chan struct{})
+		go func(ws *websocket.Conn, connDone, writerDone chan struct{}) {
+			c.wsWriter(ws, connDone)
+			close(writerDone)
+		}(ws, connDone, writerDone)
+		c.wsReader(ws)
+		close(connDone)
+		// Both routines using the old connection must finish before
+		// switching to the new one.
+		<-writerDone
+		if restoreDone != nil {
+			<-restoreDone
+		}
+
+		if c.opts.ReconnectAttempts == 0 || c.isShutdown() {
+			break
+		}
+		from := atomic.LoadUint32(&c.lastBlock)
+		ws = c.reconnect()
+		if ws == nil {
+			break
+		}
+		connDone = make(chan struct{})
+		c.connLock.Lock()
+		c.connDone = connDone
+		c.connLock.Unlock()
+		restoreDone = make(chan struct{})
+		go func(ws *websocket.Conn, connDone, restoreDone chan struct{}) {
+			c.restore(ws, connDone, from)
+			close(restoreDone)
+		}(ws, connDone, restoreDone)
+	}
+	close(c.done)
+	close(c.responses)
+	close(c.Notifications)
+}
+
+func (c *WSClient) isShutdown() bool {
+	select {
+	case <-c.shutdown:
+		return true
+	default:
+		return false
+	}
+}
+
+// reconnect tries to establish a new connection to the server with
+// exponentially increasing delays between attempts. It returns nil if it
+// fails or the client is closed.
+func (c *WSClient) reconnect() *websocket.Conn {
+	delay := c.opts.ReconnectDelay
+	for i := 0; c.opts.ReconnectAttempts < 0 || i < c.opts.ReconnectAttempts; i++ {
+		t := time.NewTimer(delay)
+		select {
+		case <-c.shutdown:
+			t.Stop()
+			return nil
+		case <-t.C:
+		}
+		ws, _, err := c.dialer.Dial(c.wsEndpoint, c.header)
+		if err == nil {
+			return ws
+		}
+		delay *= 2
+		if delay > c.opts.MaxReconnectDelay {
+			delay = c.opts.MaxReconnectDelay
+		}
+	}
+	return nil
+}
+
+// restore replays active subscriptions and transaction watches using a new
+// connection and sends a Gap notification. The connection is closed (to be
+// reestablished again) if any of this fails.
+func (c *WSClient) restore(ws *websocket.Conn, connDone chan struct{}, from uint32) {
+	var (
+		height uint32
+		err    = c.resubscribe(from)
+	)
+	if err == nil {
+		height, err = c.updateHeight()
+	}
+	if err != nil {
+		ws.Close()
+		return
+	}
+	gap := &Gap{From: from, To: height}
+	select {
+	case c.Notifications <- Notification{response.ReconnectEventID, gap}:
+	case <-connDone:
+	}
+}
+
+// resubscribe restores subscriptions and transaction watches using the new
+// connection, events are replayed starting from the block following the
+// given one if ReplayOnReconnect option is set.
+func (c *WSClient) resubscribe(from uint32) error {
+	c.subsLock.Lock()
+	subs := make([]*wsSubscription, 0, len(c.subscriptions))
+	for _, sub := range c.subscriptions {
+		subs = append(subs, sub)
+	}
+	watches := make([]util.Uint256, 0, len(c.watches))
+	for h := range c.watches {
+		watches = append(watches, h)
+	}
+	c.subsLock.Unlock()
+
+	for _, sub := range subs {
+		var (
+			id  string
+			err error
+		)
+		if c.opts.ReplayOnReconnect && canReplay(sub.params) {
+			err = c.performRequest("subscribe", replayParams(sub.params, from+1), &id)
+			var rpcErr *response.Error
+			if errors.As(err, &rpcErr) {
+				// Server can't replay events, subscribe without replay.
+				err = c.performRequest("subscribe", sub.params, &id)
+			}
+		} else {
+			err = c.performRequest("subscribe", sub.params, &id)
+		}
+		if err != nil {
+			return err
+		}
+		c.subsLock.Lock()
+		sub.serverID = id
+		c.subsLock.Unlock()
+	}
+	for _, h := range watches {
+		var resp bool
+		err := c.performRequest("watchtransaction", request.NewRawParams(h.StringLE()), &resp)
+		if err != nil {
+			var rpcErr *response.Error
+			if !errors.As(err, &rpcErr) {
+				return err
+			}
+			// The transaction is not known to the server anymore, there
+			// won't be any event for it.
+			c.subsLock.Lock()
+			delete(c.watches, h)
+			c.subsLock.Unlock()
+		}
+	}
+	return nil
+}
+
+// canReplay checks whether past events can be requested for the subscription
+// with the given parameters.
+func canReplay(params request.RawParams) bool {
+	switch params.Values[0] {
+	case "block_added", "transaction_added", "notification_from_execution", "transaction_executed":
+		return true
+	}
+	return false
+}
+
+// replayParams returns subscription parameters requesting replay of events
+// starting from the given block.
+func replayParams(params request.RawParams, from uint32) request.RawParams {
+	var filter interface{}
+	if len(params.Values) > 1 {
+		filter = params.Values[1]
+	}
+	return request.NewRawParams(params.Values[0], filter, from)
+}
+
+// updateHeight updates lastBlock using server's current chain height and
+// returns this height.
+func (c *WSClient) updateHeight() (uint32, error) {
+	count, err := c.GetBlockCount()
+	if err != nil {
+		return 0, err
+	}
+	if count == 0 {
+		return 0, nil
+	}
+	c.updateLastBlock(count - 1)
+	return count - 1, nil
+}
+
+func (c *WSClient) updateLastBlock(index uint32) {
+	for {
+		last := atomic.LoadUint32(&c.lastBlock)
+		if index <= last || atomic.CompareAndSwapUint32(&c.lastBlock, last, index) {
+			return
+		}
+	}
+}
+
+func (c *WSClient) getConnDone() chan struct{} {
+	c.connLock.RLock()
+	defer c.connLock.RUnlock()
+	return c.connDone
+}
+
+func (c *WSClient) wsReader(ws *websocket.Conn) {
+	ws.SetReadLimit(wsReadLimit)
+	ws.SetPongHandler(func(string) error { ws.SetReadDeadline(time.Now().Add(wsPongLimit)); return nil })
 readloop:
 	for {
 		rr := new(requestResponse)
-		c.ws.SetReadDeadline(time.Now().Add(wsPongLimit))
-		err := c.ws.ReadJSON(rr)
+		ws.SetReadDeadline(time.Now().Add(wsPongLimit))
+		err := ws.ReadJSON(rr)
 		if err != nil {
 			// Timeout/connection loss/malformed response.
 			break
@@ -146,6 +413,12 @@ readloop:
 				val = new(state.NotificationEvent)
 			case response.ExecutionEventID:
 				val = new(state.AppExecResult)
+			case response.MempoolEventID:
+				val = &result.MempoolEvent{Transaction: &transaction.Transaction{Network: c.GetNetwork()}}
+			case response.StateRootEventID:
+				val = new(state.MPTRoot)
+			case response.TransactionFinalityEventID:
+				val = new(finality.Event)
 			case response.MissedEventID:
 				// No value.
 			default:
@@ -159,6 +432,14 @@ readloop:
 					break
 				}
 			}
+			switch v := val.(type) {
+			case *block.Block:
+				c.updateLastBlock(v.Index)
+			case *finality.Event:
+				c.subsLock.Lock()
+				delete(c.watches, v.Hash)
+				c.subsLock.Unlock()
+			}
 			c.Notifications <- Notification{event, val}
 		} else if rr.RawID != nil && (rr.Error != nil || rr.Result != nil) {
 			resp := new(response.Raw)
@@ -172,32 +453,29 @@ readloop:
 			break
 		}
 	}
-	close(c.done)
-	close(c.responses)
-	close(c.Notifications)
 }
 
-func (c *WSClient) wsWriter() {
+func (c *WSClient) wsWriter(ws *websocket.Conn, connDone chan struct{}) {
 	pingTicker := time.NewTicker(wsPingPeriod)
-	defer c.ws.Close()
+	defer ws.Close()
 	defer pingTicker.Stop()
 	for {
 		select {
 		case <-c.shutdown:
 			return
-		case <-c.done:
+		case <-connDone:
 			return
 		case req, ok := <-c.requests:
 			if !ok {
 				return
 			}
-			c.ws.SetWriteDeadline(time.Now().Add(c.opts.RequestTimeout))
-			if err := c.ws.WriteJSON(req); err != nil {
+			ws.SetWriteDeadline(time.Now().Add(c.opts.RequestTimeout))
+			if err := ws.WriteJSON(req); err != nil {
 				return
 			}
 		case <-pingTicker.C:
-			c.ws.SetWriteDeadline(time.Now().Add(wsWriteLimit))
-			if err := c.ws.WriteMessage(websocket.PingMessage, []byte{}); err != nil {
+			ws.SetWriteDeadline(time.Now().Add(wsWriteLimit))
+			if err := ws.WriteMessage(websocket.PingMessage, []byte{}); err != nil {
 				return
 			}
 		}
@@ -206,13 +484,17 @@ func (c *WSClient) wsWriter() {
 }
 
 func (c *WSClient) makeWsRequest(r *request.Raw) (*response.Raw, error) {
+	c.reqLock.Lock()
+	defer c.reqLock.Unlock()
+
+	connDone := c.getConnDone()
 	select {
-	case <-c.done:
+	case <-connDone:
 		return nil, errors.New("connection lost")
 	case c.requests <- r:
 	}
 	select {
-	case <-c.done:
+	case <-connDone:
 		return nil, errors.New("connection lost")
 	case resp := <-c.responses:
 		return resp, nil
@@ -225,23 +507,30 @@ func (c *WSClient) performSubscription(params request.RawParams) (string, error)
 	if err := c.performRequest("subscribe", params, &resp); err != nil {
 		return "", err
 	}
-	c.subscriptions[resp] = true
+	c.subsLock.Lock()
+	c.subscriptions[resp] = &wsSubscription{params: params, serverID: resp}
+	c.subsLock.Unlock()
 	return resp, nil
 }
 
 func (c *WSClient) performUnsubscription(id string) error {
 	var resp bool
 
-	if !c.subscriptions[id] {
+	c.subsLock.Lock()
+	sub, ok := c.subscriptions[id]
+	c.subsLock.Unlock()
+	if !ok {
 		return errors.New("no subscription with this ID")
 	}
-	if err := c.performRequest("unsubscribe", request.NewRawParams(id), &resp); err != nil {
+	if err := c.performRequest("unsubscribe", request.NewRawParams(sub.serverID), &resp); err != nil {
 		return err
 	}
 	if !resp {
 		return errors.New("unsubscribe method returned false result")
 	}
+	c.subsLock.Lock()
 	delete(c.subscriptions, id)
+	c.subsLock.Unlock()
 	return nil
 }
 
@@ -267,14 +556,45 @@ func (c *WSClient) SubscribeForNewTransactions(sender *util.Uint160, signer *uti
 	return c.performSubscription(params)
 }
 
+// SubscribeForMempoolEvents adds subscription for memory pool events (addition
+// of new transactions and removal of existing ones) to this instance of client.
+// It can be filtered by transaction sender and/or signer, nil value is treated
+// as missing filter.
+func (c *WSClient) SubscribeForMempoolEvents(sender *util.Uint160, signer *util.Uint160) (string, error) {
+	params := request.NewRawParams("mempool_event")
+	if sender != nil || signer != nil {
+		params.Values = append(params.Values, request.TxFilter{Sender: sender, Signer: signer})
+	}
+	return c.performSubscription(params)
+}
+
+// SubscribeForStateRoots adds subscription for validated (signed) state roots
+// to this instance of client.
+func (c *WSClient) SubscribeForStateRoots() (string, error) {
+	params := request.NewRawParams("state_root_validated")
+	return c.performSubscription(params)
+}
+
 // SubscribeForExecutionNotifications adds subscription for notifications
 // generated during transaction execution to this instance of client. It can be
 // filtered by contract's hash (that emits notifications), nil value puts no such
 // restrictions.
 func (c *WSClient) SubscribeForExecutionNotifications(contract *util.Uint160, name *string) (string, error) {
+	if contract == nil && name == nil {
+		return c.SubscribeForExecutionNotificationsWithFilter(nil)
+	}
+	return c.SubscribeForExecutionNotificationsWithFilter(&request.NotificationFilter{Contract: contract, Name: name})
+}
+
+// SubscribeForExecutionNotificationsWithFilter adds subscription for
+// notifications generated during transaction execution to this instance of
+// client using all filters supported by the server (contract hash,
+// notification name, transfer sender and receiver), nil value means no
+// filtering.
+func (c *WSClient) SubscribeForExecutionNotificationsWithFilter(filter *request.NotificationFilter) (string, error) {
 	params := request.NewRawParams("notification_from_execution")
-	if contract != nil || name != nil {
-		params.Values = append(params.Values, request.NotificationFilter{Contract: contract, Name: name})
+	if filter != nil {
+		params.Values = append(params.Values, *filter)
 	}
 	return c.performSubscription(params)
 }
@@ -284,12 +604,26 @@ func (c *WSClient) SubscribeForExecutionNotifications(contract *util.Uint160, na
 // be filtered by state (HALT/FAULT) to check for successful or failing
 // transactions, nil value means no filtering.
 func (c *WSClient) SubscribeForTransactionExecutions(state *string) (string, error) {
+	if state == nil {
+		return c.SubscribeForTransactionExecutionsWithFilter(nil)
+	}
+	return c.SubscribeForTransactionExecutionsWithFilter(&request.ExecutionFilter{State: *state})
+}
+
+// SubscribeForTransactionExecutionsWithFilter adds subscription for application
+// execution results to this instance of client. It can be filtered by state
+// (HALT/FAULT) and/or container (transaction or block) hash, nil value means no
+// filtering.
+func (c *WSClient) SubscribeForTransactionExecutionsWithFilter(filter *request.ExecutionFilter) (string, error) {
 	params := request.NewRawParams("transaction_executed")
-	if state != nil {
-		if *state != "HALT" && *state != "FAULT" {
+	if filter != nil {
+		if filter.State != "" && filter.State != "HALT" && filter.State != "FAULT" {
 			return "", errors.New("bad state parameter")
 		}
-		params.Values = append(params.Values, request.ExecutionFilter{State: *state})
+		if filter.State == "" && filter.Container == nil {
+			return "", errors.New("empty execution filter")
+		}
+		params.Values = append(params.Values, *filter)
 	}
 	return c.performSubscription(params)
 }
@@ -299,9 +633,40 @@ func (c *WSClient) Unsubscribe(id string) error {
 	return c.performUnsubscription(id)
 }
 
+// WatchTransaction asks the server to send transaction_finality event (with
+// finality.Event value) when the transaction with the given hash is included
+// into a block, becomes conflicting with some included transaction or expires.
+// The transaction must be either in the server's memory pool or in its chain.
+// Finality watcher service must be enabled on the server.
+func (c *WSClient) WatchTransaction(h util.Uint256) error {
+	var resp bool
+
+	// The event can be sent right away, so the watch is to be remembered
+	// before the request.
+	c.subsLock.Lock()
+	c.watches[h] = true
+	c.subsLock.Unlock()
+	err := c.performRequest("watchtransaction", request.NewRawParams(h.StringLE()), &resp)
+	if err == nil && !resp {
+		err = errors.New("watchtransaction method returned false result")
+	}
+	if err != nil {
+		c.subsLock.Lock()
+		delete(c.watches, h)
+		c.subsLock.Unlock()
+	}
+	return err
+}
+
 // UnsubscribeAll removes all active subscriptions of current client.
 func (c *WSClient) UnsubscribeAll() error {
+	c.subsLock.Lock()
+	ids := make([]string, 0, len(c.subscriptions))
 	for id := range c.subscriptions {
+		ids = append(ids, id)
+	}
+	c.subsLock.Unlock()
+	for _, id := range ids {
 		err := c.performUnsubscription(id)
 		if err != nil {
 			return err
@@ -6,12 +6,14 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/stretchr/testify/require"
 )
@@ -37,6 +39,12 @@ func TestWSClientSubscription(t *testing.T) {
 		"executions": func(wsc *WSClient) (string, error) {
 			return wsc.SubscribeForTransactionExecutions(nil)
 		},
+		"mempool": func(wsc *WSClient) (string, error) {
+			return wsc.SubscribeForMempoolEvents(nil, nil)
+		},
+		"state roots": func(wsc *WSClient) (string, error) {
+			return wsc.SubscribeForStateRoots()
+		},
 	}
 	t.Run("good", func(t *testing.T) {
 		for name, f := range cases {
@@ -73,13 +81,13 @@ func TestWSClientUnsubscription(t *testing.T) {
 	var cases = map[string]responseCheck{
 		"good": {`{"jsonrpc": "2.0", "id": 1, "result": true}`, func(t *testing.T, wsc *WSClient) {
 			// We can't really subscribe using this stub server, so set up wsc internals.
-			wsc.subscriptions["0"] = true
+			wsc.subscriptions["0"] = &wsSubscription{serverID: "0"}
 			err := wsc.Unsubscribe("0")
 			require.NoError(t, err)
 		}},
 		"all": {`{"jsonrpc": "2.0", "id": 1, "result": true}`, func(t *testing.T, wsc *WSClient) {
 			// We can't really subscribe using this stub server, so set up wsc internals.
-			wsc.subscriptions["0"] = true
+			wsc.subscriptions["0"] = &wsSubscription{serverID: "0"}
 			err := wsc.UnsubscribeAll()
 			require.NoError(t, err)
 			require.Equal(t, 0, len(wsc.subscriptions))
@@ -90,13 +98,13 @@ func TestWSClientUnsubscription(t *testing.T) {
 		}},
 		"error returned": {`{"jsonrpc": "2.0", "id": 1, "error":{"code":-32602,"message":"Invalid Params"}}`, func(t *testing.T, wsc *WSClient) {
 			// We can't really subscribe using this stub server, so set up wsc internals.
-			wsc.subscriptions["0"] = true
+			wsc.subscriptions["0"] = &wsSubscription{serverID: "0"}
 			err := wsc.Unsubscribe("0")
 			require.Error(t, err)
 		}},
 		"false returned": {`{"jsonrpc": "2.0", "id": 1, "result": false}`, func(t *testing.T, wsc *WSClient) {
 			// We can't really subscribe using this stub server, so set up wsc internals.
-			wsc.subscriptions["0"] = true
+			wsc.subscriptions["0"] = &wsSubscription{serverID: "0"}
 			err := wsc.Unsubscribe("0")
 			require.Error(t, err)
 		}},
@@ -120,6 +128,8 @@ func TestWSClientEvents(t *testing.T) {
 		`{"jsonrpc":"2.0","method":"notification_from_execution","params":[{"contract":"0x1b4357bff5a01bdf2a6581247cf9ed1e24629176","eventname":"contract call","state":{"type":"Array","value":[{"type":"ByteString","value":"dHJhbnNmZXI="},{"type":"Array","value":[{"type":"ByteString","value":"dpFiJB7t+XwkgWUq3xug9b9XQxs="},{"type":"ByteString","value":"MW6FEDkBnTnfwsN9bD/uGf1YCYc="},{"type":"Integer","value":"1000"}]}]}}]}`,
 		`{"jsonrpc":"2.0","method":"transaction_executed","params":[{"container":"0xf97a72b7722c109f909a8bc16c22368c5023d85828b09b127b237aace33cf099","trigger":"Application","vmstate":"HALT","gasconsumed":"6042610","stack":[],"notifications":[{"contract":"0xe65ff7b3a02d207b584a5c27057d4e9862ef01da","eventname":"contract call","state":{"type":"Array","value":[{"type":"ByteString","value":"dHJhbnNmZXI="},{"type":"Array","value":[{"type":"ByteString","value":"MW6FEDkBnTnfwsN9bD/uGf1YCYc="},{"type":"ByteString","value":"IHKCdK+vw29DoHHTKM+j5inZy7A="},{"type":"Integer","value":"123"}]}]}},{"contract":"0xe65ff7b3a02d207b584a5c27057d4e9862ef01da","eventname":"transfer","state":{"type":"Array","value":[{"type":"ByteString","value":"MW6FEDkBnTnfwsN9bD/uGf1YCYc="},{"type":"ByteString","value":"IHKCdK+vw29DoHHTKM+j5inZy7A="},{"type":"Integer","value":"123"}]}}]}]}`,
 		fmt.Sprintf(`{"jsonrpc":"2.0","method":"block_added","params":[%s]}`, b1Verbose),
+		`{"jsonrpc":"2.0","method":"transaction_finality","params":[{"hash":"0xf97a72b7722c109f909a8bc16c22368c5023d85828b09b127b237aace33cf099","status":"included","height":1}]}`,
+		`{"jsonrpc":"2.0","method":"state_root_validated","params":[{"version":0,"index":1,"stateroot":"0x8d9e4f6d3c8ad1bcce6c1b29a3ecdcb4ebc5e1f8f9a4b1a6ff12cbd3a4b0f5c1","witness":{"invocation":"AQ==","verification":"Ag=="}}]}`,
 		`{"jsonrpc":"2.0","method":"event_missed","params":[]}`,
 	}
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
@@ -241,6 +251,23 @@ func TestWSFilteredSubscriptions(t *testing.T) {
 				require.Equal(t, util.Uint160{0, 42}, *filt.Signer)
 			},
 		},
+		{"mempool events sender and signer",
+			func(t *testing.T, wsc *WSClient) {
+				sender := util.Uint160{1, 2, 3, 4, 5}
+				signer := util.Uint160{0, 42}
+				_, err := wsc.SubscribeForMempoolEvents(&sender, &signer)
+				require.NoError(t, err)
+			},
+			func(t *testing.T, p *request.Params) {
+				param := p.Value(1)
+				require.NotNil(t, param)
+				require.Equal(t, request.TxFilterT, param.Type)
+				filt, ok := param.Value.(request.TxFilter)
+				require.Equal(t, true, ok)
+				require.Equal(t, util.Uint160{1, 2, 3, 4, 5}, *filt.Sender)
+				require.Equal(t, util.Uint160{0, 42}, *filt.Signer)
+			},
+		},
 		{"notifications contract hash",
 			func(t *testing.T, wsc *WSClient) {
 				contract := util.Uint160{1, 2, 3, 4, 5}
@@ -305,6 +332,40 @@ func TestWSFilteredSubscriptions(t *testing.T) {
 				require.Equal(t, "FAULT", filt.State)
 			},
 		},
+		{"notifications with filter",
+			func(t *testing.T, wsc *WSClient) {
+				from := util.Uint160{1, 2, 3}
+				to := util.Uint160{4, 5, 6}
+				_, err := wsc.SubscribeForExecutionNotificationsWithFilter(&request.NotificationFilter{From: &from, To: &to})
+				require.NoError(t, err)
+			},
+			func(t *testing.T, p *request.Params) {
+				param := p.Value(1)
+				require.NotNil(t, param)
+				require.Equal(t, request.NotificationFilterT, param.Type)
+				filt, ok := param.Value.(request.NotificationFilter)
+				require.Equal(t, true, ok)
+				require.Nil(t, filt.Contract)
+				require.Equal(t, util.Uint160{1, 2, 3}, *filt.From)
+				require.Equal(t, util.Uint160{4, 5, 6}, *filt.To)
+			},
+		},
+		{"executions with filter",
+			func(t *testing.T, wsc *WSClient) {
+				container := util.Uint256{1, 2, 3}
+				_, err := wsc.SubscribeForTransactionExecutionsWithFilter(&request.ExecutionFilter{Container: &container})
+				require.NoError(t, err)
+			},
+			func(t *testing.T, p *request.Params) {
+				param := p.Value(1)
+				require.NotNil(t, param)
+				require.Equal(t, request.ExecutionFilterT, param.Type)
+				filt, ok := param.Value.(request.ExecutionFilter)
+				require.Equal(t, true, ok)
+				require.Equal(t, "", filt.State)
+				require.Equal(t, util.Uint256{1, 2, 3}, *filt.Container)
+			},
+		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
@@ -348,3 +409,106 @@ func TestNewWS(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestWSClientReconnect(t *testing.T) {
+	var (
+		conns   int32
+		reject  int32
+		unsubCh = make(chan string, 1)
+		lastWS  = make(chan *websocket.Conn, 2)
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if atomic.LoadInt32(&reject) != 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		n := atomic.AddInt32(&conns, 1)
+		var upgrader = websocket.Upgrader{}
+		ws, err := upgrader.Upgrade(w, req, nil)
+		require.NoError(t, err)
+		defer ws.Close()
+		lastWS <- ws
+		for {
+			ws.SetReadDeadline(time.Now().Add(2 * time.Second))
+			r := request.NewIn()
+			if err := ws.ReadJSON(r); err != nil {
+				return
+			}
+			p, err := r.Params()
+			require.NoError(t, err)
+			var res string
+			switch r.Method {
+			case "getblockcount":
+				res = fmt.Sprint(n * 10)
+			case "subscribe":
+				res = fmt.Sprintf(`"%d"`, n)
+			case "unsubscribe":
+				id, err := p.Value(0).GetString()
+				require.NoError(t, err)
+				unsubCh <- id
+				res = "true"
+			}
+			ws.SetWriteDeadline(time.Now().Add(2 * time.Second))
+			resp := fmt.Sprintf(`{"jsonrpc": "2.0", "id": %s, "result": %s}`, r.RawID, res)
+			if err := ws.WriteMessage(websocket.TextMessage, []byte(resp)); err != nil {
+				return
+			}
+			if n == 1 && r.Method == "subscribe" {
+				// Connection loss.
+				return
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	wsc, err := NewWS(context.TODO(), httpURLtoWS(srv.URL), Options{
+		ReconnectAttempts: 3,
+		ReconnectDelay:    10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	t.Cleanup(wsc.Close)
+
+	id, err := wsc.SubscribeForNewBlocks(nil)
+	require.NoError(t, err)
+	require.Equal(t, "1", id)
+
+	select {
+	case n, ok := <-wsc.Notifications:
+		require.True(t, ok)
+		require.Equal(t, response.ReconnectEventID, n.Type)
+		require.Equal(t, &Gap{From: 9, To: 19}, n.Value)
+	case <-time.After(2 * time.Second):
+		t.Fatal("no reconnection notification")
+	}
+	require.Equal(t, int32(2), atomic.LoadInt32(&conns))
+
+	// The subscription is known by its original ID.
+	require.NoError(t, wsc.Unsubscribe(id))
+	require.Equal(t, "2", <-unsubCh)
+
+	t.Run("give up", func(t *testing.T) {
+		atomic.StoreInt32(&reject, 1)
+		<-lastWS
+		(<-lastWS).Close()
+		select {
+		case _, ok := <-wsc.Notifications:
+			require.False(t, ok)
+		case <-time.After(2 * time.Second):
+			t.Fatal("notifications channel is not closed")
+		}
+		_, err := wsc.GetBlockCount()
+		require.Error(t, err)
+	})
+}
+
+func TestReplayParams(t *testing.T) {
+	require.False(t, canReplay(request.NewRawParams("mempool_event")))
+	require.True(t, canReplay(request.NewRawParams("block_added")))
+
+	p := replayParams(request.NewRawParams("block_added"), 10)
+	require.Equal(t, []interface{}{"block_added", nil, uint32(10)}, p.Values)
+
+	filt := request.BlockFilter{Primary: 1}
+	p = replayParams(request.NewRawParams("block_added", filt), 10)
+	require.Equal(t, []interface{}{"block_added", filt, uint32(10)}, p.Values)
+}
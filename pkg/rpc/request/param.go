@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
@@ -46,16 +47,30 @@ type (
 	}
 	// NotificationFilter is a wrapper structure representing filter used for
 	// notifications generated during transaction execution. Notifications can
-	// be filtered by contract hash and by name.
+	// be filtered by contract hash, by name and by the first two parameters
+	// which are sender and receiver for transfer-like events (like NEP17 and
+	// NEP11 `Transfer`), notifications with other parameters never match
+	// From and To filters.
 	NotificationFilter struct {
 		Contract *util.Uint160 `json:"contract,omitempty"`
 		Name     *string       `json:"name,omitempty"`
+		From     *util.Uint160 `json:"from,omitempty"`
+		To       *util.Uint160 `json:"to,omitempty"`
 	}
 	// ExecutionFilter is a wrapper structure used for transaction execution
 	// events. It allows to choose failing or successful transactions based
-	// on their VM state.
+	// on their VM state and/or executions of the specific container
+	// (transaction or block).
 	ExecutionFilter struct {
-		State string `json:"state"`
+		State     string        `json:"state,omitempty"`
+		Container *util.Uint256 `json:"container,omitempty"`
+	}
+	// StateOverride is a wrapper structure for temporary state changes used
+	// by the simulatescript call. Apart from balances and storage it allows
+	// to change the timestamp of the block script is executed in.
+	StateOverride struct {
+		state.Override
+		Timestamp uint64 `json:"timestamp,omitempty"`
 	}
 	// SignerWithWitness represents transaction's signer with the corresponding witness.
 	SignerWithWitness struct {
@@ -76,6 +91,7 @@ const (
 	NotificationFilterT
 	ExecutionFilterT
 	SignerWithWitnessT
+	StateOverrideT
 )
 
 var errMissingParameter = errors.New("parameter is missing")
@@ -84,6 +100,11 @@ func (p Param) String() string {
 	return fmt.Sprintf("%v", p.Value)
 }
 
+// IsNull returns true if the parameter is missing or is JSON null.
+func (p *Param) IsNull() bool {
+	return p == nil || p.Type == defaultT && p.Value == nil
+}
+
 // GetString returns string value of the parameter.
 func (p *Param) GetString() (string, error) {
 	if p == nil {
@@ -215,6 +236,18 @@ func (p *Param) GetBytesBase64() ([]byte, error) {
 	return base64.StdEncoding.DecodeString(s)
 }
 
+// GetStateOverride returns StateOverride value of the parameter.
+func (p *Param) GetStateOverride() (StateOverride, error) {
+	if p == nil {
+		return StateOverride{}, errMissingParameter
+	}
+	ov, ok := p.Value.(StateOverride)
+	if !ok {
+		return StateOverride{}, errors.New("not a state override")
+	}
+	return ov, nil
+}
+
 // GetSignerWithWitness returns SignerWithWitness value of the parameter.
 func (p Param) GetSignerWithWitness() (SignerWithWitness, error) {
 	c, ok := p.Value.(SignerWithWitness)
@@ -226,7 +259,8 @@ func (p Param) GetSignerWithWitness() (SignerWithWitness, error) {
 
 // GetSignersWithWitnesses returns a slice of SignerWithWitness with global scope from
 // array of Uint160 or array of serialized transaction.Signer stored in the
-// parameter.
+// parameter. Signer scopes are checked to be consistent with allowed contracts
+// and groups specified.
 func (p Param) GetSignersWithWitnesses() ([]transaction.Signer, []transaction.Witness, error) {
 	hashes, err := p.GetArray()
 	if err != nil {
@@ -252,6 +286,9 @@ func (p Param) GetSignersWithWitnesses() ([]transaction.Signer, []transaction.Wi
 			if err != nil {
 				return nil, nil, err
 			}
+			if err := checkSigner(&signerWithWitness.Signer); err != nil {
+				return nil, nil, fmt.Errorf("signer #%d: %w", i, err)
+			}
 			signers[i] = signerWithWitness.Signer
 			witnesses[i] = signerWithWitness.Witness
 		}
@@ -259,6 +296,27 @@ func (p Param) GetSignersWithWitnesses() ([]transaction.Signer, []transaction.Wi
 	return signers, witnesses, nil
 }
 
+// checkSigner checks that signer's scopes are consistent with its allowed
+// contracts and groups lists.
+func checkSigner(s *transaction.Signer) error {
+	if s.Scopes&transaction.Global != 0 && s.Scopes != transaction.Global {
+		return errors.New("global scope can not be combined with other scopes")
+	}
+	if len(s.AllowedContracts) != 0 && s.Scopes&transaction.CustomContracts == 0 {
+		return errors.New("allowed contracts are specified without CustomContracts scope")
+	}
+	if len(s.AllowedGroups) != 0 && s.Scopes&transaction.CustomGroups == 0 {
+		return errors.New("allowed groups are specified without CustomGroups scope")
+	}
+	if len(s.AllowedContracts) > transaction.MaxSubitems {
+		return fmt.Errorf("too many allowed contracts: %d > %d", len(s.AllowedContracts), transaction.MaxSubitems)
+	}
+	if len(s.AllowedGroups) > transaction.MaxSubitems {
+		return fmt.Errorf("too many allowed groups: %d > %d", len(s.AllowedGroups), transaction.MaxSubitems)
+	}
+	return nil
+}
+
 // UnmarshalJSON implements json.Unmarshaler interface.
 func (p *Param) UnmarshalJSON(data []byte) error {
 	var s string
@@ -273,6 +331,7 @@ func (p *Param) UnmarshalJSON(data []byte) error {
 		{NotificationFilterT, &NotificationFilter{}},
 		{ExecutionFilterT, &ExecutionFilter{}},
 		{SignerWithWitnessT, &signerWithWitnessAux{}},
+		{StateOverrideT, &StateOverride{}},
 		{ArrayT, &[]Param{}},
 	}
 
@@ -302,7 +361,8 @@ func (p *Param) UnmarshalJSON(data []byte) error {
 			case *NotificationFilter:
 				p.Value = *val
 			case *ExecutionFilter:
-				if (*val).State == "HALT" || (*val).State == "FAULT" {
+				if (*val).State == "HALT" || (*val).State == "FAULT" ||
+					((*val).State == "" && (*val).Container != nil) {
 					p.Value = *val
 				} else {
 					continue
@@ -321,6 +381,8 @@ func (p *Param) UnmarshalJSON(data []byte) error {
 						VerificationScript: aux.VerificationScript,
 					},
 				}
+			case *StateOverride:
+				p.Value = *val
 			case *[]Param:
 				p.Value = *val
 			}
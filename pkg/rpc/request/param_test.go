@@ -6,7 +6,9 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -24,11 +26,17 @@ func TestParam_UnmarshalJSON(t *testing.T) {
                  {"name": "my_pretty_notification"},
                  {"contract": "f84d6a337fbc3d3a201d41da99e86b479e7a2554", "name":"my_pretty_notification"},
                  {"state": "HALT"},
+                 {"from": "f84d6a337fbc3d3a201d41da99e86b479e7a2554", "to": "f84d6a337fbc3d3a201d41da99e86b479e7a2554"},
+                 {"container": "0000000000000000000000000000000000000000000000000000000000000001"},
+                 {"state": "FAULT", "container": "0000000000000000000000000000000000000000000000000000000000000001"},
                  {"account": "0xcadb3dc2faa3ef14a13b619c9a43124755aa2569"},
-                 [{"account": "0xcadb3dc2faa3ef14a13b619c9a43124755aa2569", "scopes": "Global"}]]`
+                 [{"account": "0xcadb3dc2faa3ef14a13b619c9a43124755aa2569", "scopes": "Global"}],
+                 {"timestamp": 5, "balances": [{"account": "0xcadb3dc2faa3ef14a13b619c9a43124755aa2569", "asset": "f84d6a337fbc3d3a201d41da99e86b479e7a2554", "amount": "10"}],
+                  "storage": [{"contract": "f84d6a337fbc3d3a201d41da99e86b479e7a2554", "key": "AQI=", "value": null}]}]`
 	contr, err := util.Uint160DecodeStringLE("f84d6a337fbc3d3a201d41da99e86b479e7a2554")
 	require.NoError(t, err)
 	name := "my_pretty_notification"
+	container := util.Uint256{1}
 	accountHash, err := util.Uint160DecodeStringLE("cadb3dc2faa3ef14a13b619c9a43124755aa2569")
 	require.NoError(t, err)
 	expected := Params{
@@ -103,6 +111,18 @@ func TestParam_UnmarshalJSON(t *testing.T) {
 			Type:  ExecutionFilterT,
 			Value: ExecutionFilter{State: "HALT"},
 		},
+		{
+			Type:  NotificationFilterT,
+			Value: NotificationFilter{From: &contr, To: &contr},
+		},
+		{
+			Type:  ExecutionFilterT,
+			Value: ExecutionFilter{Container: &container},
+		},
+		{
+			Type:  ExecutionFilterT,
+			Value: ExecutionFilter{State: "FAULT", Container: &container},
+		},
 		{
 			Type: SignerWithWitnessT,
 			Value: SignerWithWitness{
@@ -126,6 +146,16 @@ func TestParam_UnmarshalJSON(t *testing.T) {
 				},
 			},
 		},
+		{
+			Type: StateOverrideT,
+			Value: StateOverride{
+				Override: state.Override{
+					Balances: []state.BalanceOverride{{Account: accountHash, Asset: contr, Amount: 10}},
+					Storage:  []state.StorageOverride{{Contract: contr, Key: []byte{1, 2}}},
+				},
+				Timestamp: 5,
+			},
+		},
 	}
 
 	var ps Params
@@ -390,4 +420,45 @@ func TestParamGetSigners(t *testing.T) {
 		_, _, err := p.GetSignersWithWitnesses()
 		require.Error(t, err)
 	})
+
+	t.Run("inconsistent scopes", func(t *testing.T) {
+		priv, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+		manyHashes := make([]util.Uint160, transaction.MaxSubitems+1)
+		manyKeys := make([]*keys.PublicKey, transaction.MaxSubitems+1)
+		for i := range manyKeys {
+			manyKeys[i] = priv.PublicKey()
+		}
+		for _, s := range []transaction.Signer{
+			{Account: u1, Scopes: transaction.Global | transaction.CalledByEntry},
+			{Account: u1, Scopes: transaction.CalledByEntry, AllowedContracts: []util.Uint160{u2}},
+			{Account: u1, Scopes: transaction.CustomContracts, AllowedGroups: []*keys.PublicKey{priv.PublicKey()}},
+			{Account: u1, Scopes: transaction.CustomContracts, AllowedContracts: manyHashes},
+			{Account: u1, Scopes: transaction.CustomGroups, AllowedGroups: manyKeys},
+		} {
+			p := Param{ArrayT, []Param{
+				{Type: SignerWithWitnessT, Value: SignerWithWitness{Signer: s}},
+			}}
+			_, _, err := p.GetSignersWithWitnesses()
+			require.Error(t, err)
+		}
+	})
+
+	t.Run("custom contracts and groups", func(t *testing.T) {
+		priv, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+		s := transaction.Signer{
+			Account:          u1,
+			Scopes:           transaction.CustomContracts | transaction.CustomGroups,
+			AllowedContracts: []util.Uint160{u2},
+			AllowedGroups:    []*keys.PublicKey{priv.PublicKey()},
+		}
+		data, err := json.Marshal([]*SignerWithWitness{{Signer: s}})
+		require.NoError(t, err)
+		var p Param
+		require.NoError(t, json.Unmarshal(data, &p))
+		actual, _, err := p.GetSignersWithWitnesses()
+		require.NoError(t, err)
+		require.Equal(t, []transaction.Signer{s}, actual)
+	})
 }
@@ -12,8 +12,9 @@ const (
 	// JSONRPCVersion is the only JSON-RPC protocol version supported.
 	JSONRPCVersion = "2.0"
 
-	// maxBatchSize is the maximum number of request per batch.
-	maxBatchSize = 100
+	// DefaultMaxBatchSize is the default maximum number of requests per
+	// batch.
+	DefaultMaxBatchSize = 100
 )
 
 // RawParams is just a slice of abstract values, used to represent parameters
@@ -46,6 +47,9 @@ type Raw struct {
 type Request struct {
 	In    *In
 	Batch Batch
+	// MaxBatchSize is the maximum number of requests per batch accepted
+	// by UnmarshalJSON, DefaultMaxBatchSize is used if it's not set.
+	MaxBatchSize int `json:"-"`
 }
 
 // In represents a standard JSON-RPC 2.0
@@ -89,9 +93,13 @@ func (r *Request) UnmarshalJSON(data []byte) error {
 	if t != json.Delim('[') {
 		return fmt.Errorf("`[` expected, got %s", t)
 	}
+	maxBatchSize := r.MaxBatchSize
+	if maxBatchSize <= 0 {
+		maxBatchSize = DefaultMaxBatchSize
+	}
 	count := 0
 	for decoder.More() {
-		if count > maxBatchSize {
+		if count >= maxBatchSize {
 			return fmt.Errorf("the number of requests in batch shouldn't exceed %d", maxBatchSize)
 		}
 		in = &In{}
@@ -1,6 +1,7 @@
 package response
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 )
@@ -17,9 +18,15 @@ type (
 	}
 )
 
+// Errors below can be used as error classes, any error with the same code
+// matches them with errors.Is, so RPC client users can check server errors
+// without relying on their messages.
 var (
 	// ErrInvalidParams represents a generic 'invalid parameters' error.
 	ErrInvalidParams = NewInvalidParamsError("", nil)
+	// ErrNotFound represents a generic 'unknown entity' error (unknown block,
+	// transaction, contract, state root etc.) with code -100.
+	ErrNotFound = NewRPCError("Unknown entity", "", nil)
 	// ErrAlreadyExists represents SubmitError with code -501
 	ErrAlreadyExists = NewSubmitError(-501, "Block or transaction already exists and cannot be sent repeatedly.")
 	// ErrOutOfMemory represents SubmitError with code -502
@@ -30,8 +37,21 @@ var (
 	ErrValidationFailed = NewSubmitError(-504, "Block or transaction validation failed.")
 	// ErrPolicyFail represents SubmitError with code -505
 	ErrPolicyFail = NewSubmitError(-505, "One of the Policy filters failed.")
+	// ErrVerificationFailed represents SubmitError with code -508
+	ErrVerificationFailed = NewSubmitError(-508, "Witness verification failed.")
+	// ErrInsufficientFunds represents SubmitError with code -511
+	ErrInsufficientFunds = NewSubmitError(-511, "Insufficient funds to pay fees.")
 	// ErrUnknown represents SubmitError with code -500
 	ErrUnknown = NewSubmitError(-500, "Unknown error.")
+	// ErrLimitExceeded is returned with code -32005 when client exceeds
+	// request rate limit of the server.
+	ErrLimitExceeded = NewError(-32005, http.StatusTooManyRequests, "Request limit exceeded", "", nil)
+	// ErrUnauthorized is returned with code -32001 for methods requiring
+	// authentication when client credentials are missing or invalid.
+	ErrUnauthorized = NewError(-32001, http.StatusUnauthorized, "Unauthorized", "", nil)
+	// ErrServerBusy is returned with code -32006 when the server already
+	// processes the maximum allowed number of requests.
+	ErrServerBusy = NewError(-32006, http.StatusServiceUnavailable, "Server is busy", "", nil)
 )
 
 // NewError is an Error constructor that takes Error contents from its
@@ -94,6 +114,16 @@ func (e *Error) Error() string {
 	return fmt.Sprintf("%s (%d) - %s - %s", e.Message, e.Code, e.Data, e.Cause)
 }
 
+// Is implements errors.Is interface, the error matches any other *Error with
+// the same code.
+func (e *Error) Is(target error) bool {
+	var t *Error
+	if !errors.As(target, &t) {
+		return false
+	}
+	return e.Code == t.Code
+}
+
 // WrapErrorWithData returns copy of the given error with specified data and cause.
 // It does not modify the source error.
 func WrapErrorWithData(e *Error, data error) *Error {
@@ -0,0 +1,20 @@
+package response
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorIs(t *testing.T) {
+	err := WrapErrorWithData(ErrAlreadyExists, errors.New("tx is in the pool"))
+	require.True(t, errors.Is(err, ErrAlreadyExists))
+	require.False(t, errors.Is(err, ErrInsufficientFunds))
+
+	wrapped := fmt.Errorf("sending failed: %w", NewRPCError("Unknown transaction", "", nil))
+	require.True(t, errors.Is(wrapped, ErrNotFound))
+	require.False(t, errors.Is(wrapped, ErrInvalidParams))
+	require.False(t, errors.Is(wrapped, errors.New("Unknown transaction")))
+}
@@ -22,6 +22,18 @@ const (
 	NotificationEventID
 	// ExecutionEventID is used for `transaction_executed` events.
 	ExecutionEventID
+	// TransactionFinalityEventID is used for `transaction_finality` events
+	// sent for transactions watched via watchtransaction method.
+	TransactionFinalityEventID
+	// MempoolEventID is used for `mempool_event` events sent when
+	// transactions are added to or removed from the memory pool.
+	MempoolEventID
+	// StateRootEventID is used for `state_root_validated` events sent when
+	// validated (signed) state root is received.
+	StateRootEventID
+	// ReconnectEventID is generated by WSClient after reconnection to the
+	// server, it's never sent by the server.
+	ReconnectEventID EventID = 254
 	// MissedEventID notifies user of missed events.
 	MissedEventID EventID = 255
 )
@@ -37,6 +49,14 @@ func (e EventID) String() string {
 		return "notification_from_execution"
 	case ExecutionEventID:
 		return "transaction_executed"
+	case TransactionFinalityEventID:
+		return "transaction_finality"
+	case MempoolEventID:
+		return "mempool_event"
+	case StateRootEventID:
+		return "state_root_validated"
+	case ReconnectEventID:
+		return "reconnected"
 	case MissedEventID:
 		return "event_missed"
 	default:
@@ -55,6 +75,12 @@ func GetEventIDFromString(s string) (EventID, error) {
 		return NotificationEventID, nil
 	case "transaction_executed":
 		return ExecutionEventID, nil
+	case "transaction_finality":
+		return TransactionFinalityEventID, nil
+	case "mempool_event":
+		return MempoolEventID, nil
+	case "state_root_validated":
+		return StateRootEventID, nil
 	case "event_missed":
 		return MissedEventID, nil
 	default:
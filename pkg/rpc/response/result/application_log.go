@@ -82,3 +82,23 @@ func NewApplicationLog(hash util.Uint256, aers []state.AppExecResult, trig trigg
 	}
 	return result
 }
+
+// FilterEvents leaves only notifications with the specified name in the log
+// dropping executions that have no such notifications.
+func (l *ApplicationLog) FilterEvents(name string) {
+	var execs = l.Executions[:0]
+	for _, e := range l.Executions {
+		var events = make([]state.NotificationEvent, 0, len(e.Events))
+		for _, ev := range e.Events {
+			if ev.Name == name {
+				events = append(events, ev)
+			}
+		}
+		if len(events) == 0 {
+			continue
+		}
+		e.Events = events
+		execs = append(execs, e)
+	}
+	l.Executions = execs
+}
@@ -0,0 +1,91 @@
+package result
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// CanonicalJSON returns canonical JSON representation of v: its regular JSON
+// form with no insignificant whitespace, object keys sorted, numbers in
+// the shortest form and no HTML escaping in strings. Identical values always
+// produce byte-identical output, so it can be used to cache or sign results.
+func CanonicalJSON(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	var raw interface{}
+	if err := d.Decode(&raw); err != nil {
+		return nil, err
+	}
+	raw, err = canonicalizeNumbers(raw)
+	if err != nil {
+		return nil, err
+	}
+	buf := new(bytes.Buffer)
+	e := json.NewEncoder(buf)
+	e.SetEscapeHTML(false)
+	// Maps are always encoded with sorted keys.
+	if err := e.Encode(raw); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte{'\n'}), nil
+}
+
+// canonicalizeNumbers replaces all numbers in the decoded JSON value with
+// their shortest representation.
+func canonicalizeNumbers(v interface{}) (interface{}, error) {
+	var err error
+	switch t := v.(type) {
+	case json.Number:
+		return canonicalNumber(t)
+	case []interface{}:
+		for i := range t {
+			t[i], err = canonicalizeNumbers(t[i])
+			if err != nil {
+				return nil, err
+			}
+		}
+	case map[string]interface{}:
+		for k := range t {
+			t[k], err = canonicalizeNumbers(t[k])
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return v, nil
+}
+
+func canonicalNumber(n json.Number) (json.Number, error) {
+	if i, ok := new(big.Int).SetString(n.String(), 10); ok {
+		return json.Number(i.String()), nil
+	}
+	f, ok := new(big.Float).SetString(n.String())
+	if !ok {
+		return "", fmt.Errorf("invalid number: %s", n)
+	}
+	if f.IsInt() {
+		i, _ := f.Int(nil)
+		return json.Number(i.String()), nil
+	}
+	f64, _ := f.Float64()
+	return json.Number(strconv.FormatFloat(f64, 'g', -1, 64)), nil
+}
+
+// MarshalCanonicalJSON returns canonical JSON representation of the invocation
+// result (see CanonicalJSON).
+func (r Invoke) MarshalCanonicalJSON() ([]byte, error) {
+	return CanonicalJSON(r)
+}
+
+// MarshalCanonicalJSON returns canonical JSON representation of the
+// application log (see CanonicalJSON).
+func (l ApplicationLog) MarshalCanonicalJSON() ([]byte, error) {
+	return CanonicalJSON(l)
+}
@@ -0,0 +1,61 @@
+package result
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalJSON(t *testing.T) {
+	t.Run("keys and numbers", func(t *testing.T) {
+		data, err := CanonicalJSON(json.RawMessage(`{ "b": 1.50, "a": [1e2, -0, "<&>"], "c": {"y": 10, "x": null}}`))
+		require.NoError(t, err)
+		require.Equal(t, `{"a":[100,0,"<&>"],"b":1.5,"c":{"x":null,"y":10}}`, string(data))
+	})
+	t.Run("invoke", func(t *testing.T) {
+		res := Invoke{
+			State:       "HALT",
+			GasConsumed: 42,
+			Script:      []byte{1, 2, 3},
+			Stack:       []stackitem.Item{stackitem.NewBigInteger(big.NewInt(1))},
+		}
+		data, err := res.MarshalCanonicalJSON()
+		require.NoError(t, err)
+		require.Equal(t, `{"gasconsumed":"42","script":"AQID","stack":[{"type":"Integer","value":"1"}],"state":"HALT"}`, string(data))
+
+		actual := new(Invoke)
+		require.NoError(t, json.Unmarshal(data, actual))
+		require.Equal(t, res, *actual)
+	})
+	t.Run("application log", func(t *testing.T) {
+		m := stackitem.NewMap()
+		m.Add(stackitem.Make(2), stackitem.Make("two"))
+		m.Add(stackitem.Make(1), stackitem.Make("one"))
+		log := ApplicationLog{
+			Container: util.Uint256{1, 2, 3},
+			Executions: []state.Execution{{
+				Trigger:     trigger.Application,
+				VMState:     vm.HaltState,
+				GasConsumed: 1,
+				Stack:       []stackitem.Item{m},
+				Events:      []state.NotificationEvent{},
+			}},
+		}
+		data1, err := log.MarshalCanonicalJSON()
+		require.NoError(t, err)
+		data2, err := log.MarshalCanonicalJSON()
+		require.NoError(t, err)
+		require.Equal(t, data1, data2)
+
+		actual := new(ApplicationLog)
+		require.NoError(t, json.Unmarshal(data1, actual))
+		require.Equal(t, log, *actual)
+	})
+}
@@ -0,0 +1,16 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+)
+
+// Designation is a set of nodes designated for some role by RoleManagement
+// native contract.
+type Designation struct {
+	Role noderoles.Role `json:"role"`
+	// Height is the index of the block nodes are active starting from (the
+	// designation itself is made in the previous block).
+	Height uint32          `json:"height"`
+	Nodes  keys.PublicKeys `json:"nodes"`
+}
@@ -0,0 +1,11 @@
+package result
+
+// FeeEstimate represents a result of estimatefee RPC call.
+type FeeEstimate struct {
+	// FeePerByte is the estimated fee per transaction byte, it's never
+	// lower than the current Policy contract value.
+	FeePerByte int64 `json:"feeperbyte,string"`
+	// MempoolSize is the number of transactions in the memory pool the
+	// estimation is based on.
+	MempoolSize int `json:"mempoolsize"`
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 )
 
@@ -21,6 +23,54 @@ type Invoke struct {
 	FaultException string
 	// Transaction represents transaction bytes. Use GetTransaction method to decode it.
 	Transaction []byte
+	// Session is an ID of the server-side session iterators from Stack
+	// belong to, it's only set if sessions are enabled on the server and
+	// there are any iterators.
+	Session string
+	// Diagnostics contains contract call tree and storage changes, it's
+	// only set if diagnostics were requested.
+	Diagnostics *InvokeDiag
+}
+
+// InvokeDiag is an additional diagnostic data for invocation.
+type InvokeDiag struct {
+	// Invocations is a list of calls made by the script, every one of
+	// them contains calls it has made.
+	Invocations []*vm.InvocationTree `json:"invokedcontracts"`
+	// Changes is a list of storage changes the script would have made
+	// ordered by contract ID and key.
+	Changes []StorageChange `json:"storagechanges"`
+}
+
+// StorageChange is a single contract storage item change.
+type StorageChange struct {
+	// State is either "Added", "Changed" or "Deleted".
+	State string `json:"state"`
+	// ID is the ID of the contract the item belongs to.
+	ID int32 `json:"id"`
+	// Contract is the hash of the contract the item belongs to, it's
+	// not set if the contract is not deployed (like the one deployed by
+	// the script itself).
+	Contract *util.Uint160 `json:"contract,omitempty"`
+	Key      []byte        `json:"key"`
+	// Value is the new item value, it's nil for deleted items.
+	Value []byte `json:"value,omitempty"`
+}
+
+// Iterator is a reference to the iterator kept in the server-side session,
+// it's used as a value of InteropInterface stack items.
+type Iterator struct {
+	ID string
+}
+
+// iteratorInterfaceName is an interface name used in the JSON representation
+// of iterator stack items.
+const iteratorInterfaceName = "IIterator"
+
+type iteratorAux struct {
+	Type      string `json:"type"`
+	Interface string `json:"interface"`
+	ID        string `json:"id"`
 }
 
 type invokeAux struct {
@@ -30,6 +80,8 @@ type invokeAux struct {
 	Stack          json.RawMessage `json:"stack"`
 	FaultException string          `json:"exception,omitempty"`
 	Transaction    []byte          `json:"tx,omitempty"`
+	Session        string          `json:"session,omitempty"`
+	Diagnostics    *InvokeDiag     `json:"diagnostics,omitempty"`
 }
 
 // MarshalJSON implements json.Marshaler.
@@ -37,6 +89,18 @@ func (r Invoke) MarshalJSON() ([]byte, error) {
 	var st json.RawMessage
 	arr := make([]json.RawMessage, len(r.Stack))
 	for i := range arr {
+		if it, ok := r.Stack[i].Value().(Iterator); ok && r.Stack[i].Type() == stackitem.InteropT {
+			data, err := json.Marshal(iteratorAux{
+				Type:      stackitem.InteropT.String(),
+				Interface: iteratorInterfaceName,
+				ID:        it.ID,
+			})
+			if err != nil {
+				return nil, err
+			}
+			arr[i] = data
+			continue
+		}
 		data, err := stackitem.ToJSONWithTypes(r.Stack[i])
 		if err != nil {
 			st = []byte(`"error: recursive reference"`)
@@ -60,6 +124,8 @@ func (r Invoke) MarshalJSON() ([]byte, error) {
 		Stack:          st,
 		FaultException: r.FaultException,
 		Transaction:    r.Transaction,
+		Session:        r.Session,
+		Diagnostics:    r.Diagnostics,
 	})
 }
 
@@ -73,6 +139,11 @@ func (r *Invoke) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(aux.Stack, &arr); err == nil {
 		st := make([]stackitem.Item, len(arr))
 		for i := range arr {
+			var it iteratorAux
+			if json.Unmarshal(arr[i], &it) == nil && it.Interface == iteratorInterfaceName && it.ID != "" {
+				st[i] = stackitem.NewInterop(Iterator{ID: it.ID})
+				continue
+			}
 			st[i], err = stackitem.FromJSONWithTypes(arr[i])
 			if err != nil {
 				break
@@ -87,6 +158,8 @@ func (r *Invoke) UnmarshalJSON(data []byte) error {
 	r.State = aux.State
 	r.FaultException = aux.FaultException
 	r.Transaction = aux.Transaction
+	r.Session = aux.Session
+	r.Diagnostics = aux.Diagnostics
 	return nil
 }
 
@@ -38,3 +38,34 @@ func TestInvoke_MarshalJSON(t *testing.T) {
 	require.NoError(t, json.Unmarshal(data, actual))
 	require.Equal(t, result, actual)
 }
+
+func TestInvoke_MarshalJSONWithIterators(t *testing.T) {
+	result := &Invoke{
+		State:       "HALT",
+		GasConsumed: 1,
+		Script:      []byte{10},
+		Stack: []stackitem.Item{
+			stackitem.NewInterop(Iterator{ID: "a3f1"}),
+			stackitem.NewBigInteger(big.NewInt(1)),
+		},
+		Session: "5e7c",
+	}
+
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	expected := `{
+		"state":"HALT",
+		"gasconsumed":"1",
+		"script":"` + base64.StdEncoding.EncodeToString(result.Script) + `",
+		"stack":[
+			{"type":"InteropInterface","interface":"IIterator","id":"a3f1"},
+			{"type":"Integer","value":"1"}
+		],
+		"session":"5e7c"
+}`
+	require.JSONEq(t, expected, string(data))
+
+	actual := new(Invoke)
+	require.NoError(t, json.Unmarshal(data, actual))
+	require.Equal(t, result, actual)
+}
@@ -0,0 +1,15 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+)
+
+// MempoolEvent represents a `mempool_event` notification payload: transaction
+// added to or removed from the node's memory pool. Reason is only present for
+// removal events.
+type MempoolEvent struct {
+	Type        mempool.EventType        `json:"type"`
+	Transaction *transaction.Transaction `json:"transaction"`
+	Reason      mempool.RemovalReason    `json:"reason,omitempty"`
+}
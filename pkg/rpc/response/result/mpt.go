@@ -27,6 +27,23 @@ type GetProof struct {
 	Success bool         `json:"success"`
 }
 
+// FindStates is a result of findstates RPC.
+type FindStates struct {
+	Results    []KeyValue    `json:"results"`
+	FirstProof *ProofWithKey `json:"firstProof,omitempty"`
+	LastProof  *ProofWithKey `json:"lastProof,omitempty"`
+	Truncated  bool          `json:"truncated"`
+	// Next is a cursor to get the next page of results, it's set only if
+	// results are truncated.
+	Next string `json:"next,omitempty"`
+}
+
+// KeyValue represents key-value pair of contract storage.
+type KeyValue struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
 // VerifyProof is a result of verifyproof RPC.
 // nil Value is considered invalid.
 type VerifyProof struct {
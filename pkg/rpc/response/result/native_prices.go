@@ -0,0 +1,22 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// NativePrices is a result for the getnativeprices RPC call, it contains
+// method prices of a single native contract.
+type NativePrices struct {
+	Hash    util.Uint160        `json:"hash"`
+	Name    string              `json:"name"`
+	Methods []NativeMethodPrice `json:"methods"`
+}
+
+// NativeMethodPrice is a native contract method descriptor with the price (in
+// GAS fractions) of its invocation computed using current execution fee factor
+// and storage price.
+type NativeMethodPrice struct {
+	state.NativeMethod
+	Price int64 `json:"price"`
+}
@@ -0,0 +1,42 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// NEP11Balances is a result for the getnep11balances RPC call.
+type NEP11Balances struct {
+	Balances []NEP11AssetBalance `json:"balance"`
+	Address  string              `json:"address"`
+}
+
+// NEP11AssetBalance represents balances of tokens of the single NEP11
+// contract.
+type NEP11AssetBalance struct {
+	Asset  util.Uint160        `json:"assethash"`
+	Tokens []NEP11TokenBalance `json:"tokens"`
+}
+
+// NEP11TokenBalance represents balance of the single NEP11 token.
+type NEP11TokenBalance struct {
+	ID          string `json:"tokenid"`
+	Amount      string `json:"amount"`
+	LastUpdated uint32 `json:"lastupdatedblock"`
+}
+
+// NEP11Transfers is a result for the getnep11transfers RPC.
+type NEP11Transfers struct {
+	Sent     []NEP11Transfer `json:"sent"`
+	Received []NEP11Transfer `json:"received"`
+	Address  string          `json:"address"`
+	// Next is a cursor to get the next page of transfers, it's empty if
+	// there are no more transfers.
+	Next string `json:"next,omitempty"`
+}
+
+// NEP11Transfer represents single NEP11 transfer event, ID is a hex-encoded
+// token ID.
+type NEP11Transfer struct {
+	NEP17Transfer
+	ID string `json:"tokenid"`
+}
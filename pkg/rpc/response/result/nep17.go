@@ -22,6 +22,9 @@ type NEP17Transfers struct {
 	Sent     []NEP17Transfer `json:"sent"`
 	Received []NEP17Transfer `json:"received"`
 	Address  string          `json:"address"`
+	// Next is a cursor to get the next page of transfers, it's empty if
+	// there are no more transfers.
+	Next string `json:"next,omitempty"`
 }
 
 // NEP17Transfer represents single NEP17 transfer event.
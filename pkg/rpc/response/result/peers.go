@@ -2,6 +2,8 @@ package result
 
 import (
 	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/network/capability"
 )
 
 type (
@@ -15,13 +17,56 @@ type (
 	// Peers represent a slice of peers.
 	Peers []Peer
 
-	// Peer represents the peer.
+	// Peer represents the peer. Fields other than Address and Port are only
+	// filled in for connected peers in verbose mode.
 	Peer struct {
-		Address string `json:"address"`
-		Port    string `json:"port"`
+		Address      string           `json:"address"`
+		Port         string           `json:"port"`
+		UserAgent    string           `json:"useragent,omitempty"`
+		Height       *uint32          `json:"height,omitempty"`
+		Capabilities []PeerCapability `json:"capabilities,omitempty"`
+		Inbound      *bool            `json:"inbound,omitempty"`
+		// LastSeen is the time of the last message received from the peer
+		// in milliseconds since epoch.
+		LastSeen *int64 `json:"lastseen,omitempty"`
+		// Traffic is the number of bytes sent to and received from the
+		// peer per message type.
+		Traffic *PeerTraffic `json:"traffic,omitempty"`
+	}
+
+	// PeerTraffic contains the number of bytes sent to and received from
+	// the peer (as they're transmitted, after compression) per message type.
+	PeerTraffic struct {
+		Sent     map[string]uint64 `json:"sent"`
+		Received map[string]uint64 `json:"received"`
+	}
+
+	// PeerCapability represents peer's network capability. Port is only set
+	// for server capabilities and StartHeight for full node ones.
+	PeerCapability struct {
+		Type        capability.Type `json:"type"`
+		Port        uint16          `json:"port,omitempty"`
+		StartHeight uint32          `json:"startheight,omitempty"`
 	}
 )
 
+// NewPeerCapabilities converts the given network capabilities into their RPC
+// representation.
+func NewPeerCapabilities(cs capability.Capabilities) []PeerCapability {
+	res := make([]PeerCapability, 0, len(cs))
+	for _, c := range cs {
+		pc := PeerCapability{Type: c.Type}
+		switch d := c.Data.(type) {
+		case *capability.Server:
+			pc.Port = d.Port
+		case *capability.Node:
+			pc.StartHeight = d.StartHeight
+		}
+		res = append(res, pc)
+	}
+	return res
+}
+
 // NewGetPeers creates a new GetPeers structure.
 func NewGetPeers() GetPeers {
 	return GetPeers{
@@ -41,6 +86,12 @@ func (g *GetPeers) AddConnected(addrs []string) {
 	g.Connected.addPeers(addrs)
 }
 
+// AddConnectedVerbose adds a set of peers with detailed information to the
+// connected peers slice.
+func (g *GetPeers) AddConnectedVerbose(peers []Peer) {
+	g.Connected = append(g.Connected, peers...)
+}
+
 // AddBad adds a set of peers to the bad peers slice.
 func (g *GetPeers) AddBad(addrs []string) {
 	g.Bad.addPeers(addrs)
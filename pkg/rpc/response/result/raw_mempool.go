@@ -7,4 +7,18 @@ type RawMempool struct {
 	Height     uint32         `json:"height"`
 	Verified   []util.Uint256 `json:"verified"`
 	Unverified []util.Uint256 `json:"unverified"`
+	// Transactions contains details of verified transactions, it's only
+	// returned if requested.
+	Transactions []MempoolTransaction `json:"transactions,omitempty"`
+}
+
+// MempoolTransaction contains fee details of the pooled transaction.
+type MempoolTransaction struct {
+	Hash       util.Uint256 `json:"hash"`
+	Sender     string       `json:"sender"`
+	SystemFee  int64        `json:"sysfee,string"`
+	NetworkFee int64        `json:"netfee,string"`
+	FeePerByte int64        `json:"feeperbyte,string"`
+	// BlockStamp is the chain height transaction was added to the pool at.
+	BlockStamp uint32 `json:"blockstamp"`
 }
@@ -0,0 +1,10 @@
+package result
+
+// FindStorage is a result of findstorage RPC.
+type FindStorage struct {
+	Results   []KeyValue `json:"results"`
+	Truncated bool       `json:"truncated"`
+	// Next is a cursor to get the next page of results, it's set only if
+	// results are truncated.
+	Next string `json:"next,omitempty"`
+}
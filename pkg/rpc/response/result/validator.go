@@ -11,3 +11,13 @@ type Validator struct {
 	Votes     int64          `json:"votes,string"`
 	Active    bool           `json:"active"`
 }
+
+// Candidate represents registered NEO candidate with the number of votes for
+// it and flags showing whether it's a member of the current committee and
+// whether it's a validator of the next block.
+type Candidate struct {
+	PublicKey keys.PublicKey `json:"publickey"`
+	Votes     int64          `json:"votes,string"`
+	Committee bool           `json:"committee"`
+	Active    bool           `json:"active"`
+}
@@ -1,20 +1,190 @@
 package rpc
 
 import (
+	"time"
+
 	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
 )
 
 type (
 	// Config is an RPC service configuration information
 	Config struct {
-		Address              string `yaml:"Address"`
-		Enabled              bool   `yaml:"Enabled"`
-		EnableCORSWorkaround bool   `yaml:"EnableCORSWorkaround"`
+		Address string `yaml:"Address"`
+		// Audit configures audit log of calls changing node state.
+		Audit AuditConfig `yaml:"Audit"`
+		// Auth configures client authentication for some or all methods.
+		Auth AuthConfig `yaml:"Auth"`
+		// BatchConcurrency is a maximum number of requests from a single
+		// batch processed concurrently, 4 is used if it's not set.
+		BatchConcurrency int `yaml:"BatchConcurrency"`
+		// CanonicalJSON makes invocation results and application logs
+		// returned in canonical JSON form, so that they can be cached
+		// or signed by gateways.
+		CanonicalJSON bool `yaml:"CanonicalJSON"`
+		// Compression configures gzip compression of HTTP responses.
+		Compression CompressionConfig `yaml:"Compression"`
+		// CORS configures cross-origin requests handling.
+		CORS    CORSConfig `yaml:"CORS"`
+		Enabled bool       `yaml:"Enabled"`
+		// EnableCORSWorkaround allows cross-origin requests from any
+		// origin, it's the same as "*" in CORS.AllowedOrigins.
+		EnableCORSWorkaround bool `yaml:"EnableCORSWorkaround"`
+		// EnableStateOverride enables simulatescript method allowing to
+		// run test invocations with temporary state changes.
+		EnableStateOverride bool `yaml:"EnableStateOverride"`
+		// FinalityWatcher configures transaction finality watching
+		// service (watchtransaction method).
+		FinalityWatcher FinalityWatcherConfig `yaml:"FinalityWatcher"`
+		// MaxBatchSize is a maximum number of requests in a single
+		// batch, 100 is used if it's not set.
+		MaxBatchSize int `yaml:"MaxBatchSize"`
+		// MaxConcurrentRequests is a maximum number of requests (including
+		// every request of a batch) processed simultaneously, requests
+		// exceeding it are rejected, 0 means no limit.
+		MaxConcurrentRequests int `yaml:"MaxConcurrentRequests"`
+		// MaxFindResultItems is a maximum number of storage items
+		// returned by a single findstates call, 100 is used if it's not
+		// set.
+		MaxFindResultItems int `yaml:"MaxFindResultItems"`
 		// MaxGasInvoke is a maximum amount of gas which
 		// can be spent during RPC call.
 		MaxGasInvoke fixedn.Fixed8 `yaml:"MaxGasInvoke"`
-		Port         uint16        `yaml:"Port"`
-		TLSConfig    TLSConfig     `yaml:"TLSConfig"`
+		// MaxGasInvokeBudget is a maximum amount of gas which can be
+		// requested by client for a single test invocation. MaxGasInvoke
+		// is used instead if it's lower.
+		MaxGasInvokeBudget fixedn.Fixed8 `yaml:"MaxGasInvokeBudget"`
+		// MaxInvokeDuration is a maximum wall-clock time a single test
+		// invocation can take irrespective of GAS spent, 0 means no limit.
+		MaxInvokeDuration time.Duration `yaml:"MaxInvokeDuration"`
+		// MaxRequestBodySize is a maximum size of HTTP request body in
+		// bytes, 0 means no limit.
+		MaxRequestBodySize int64 `yaml:"MaxRequestBodySize"`
+		// MaxTransfersLimit is a maximum number of entries returned by
+		// a single getnep11transfers, getnep17transfers or
+		// findtransactions call, 1000 is used if it's not set.
+		MaxTransfersLimit int `yaml:"MaxTransfersLimit"`
+		// MaxVerificationGas is a maximum amount of gas which can be
+		// spent by a single non-standard witness verification during
+		// network fee calculation (calculatenetworkfee), MaxGasInvoke is
+		// used if it's not set. Policy contract MaxVerificationGas limit
+		// is always applied.
+		MaxVerificationGas fixedn.Fixed8 `yaml:"MaxVerificationGas"`
+		Port               uint16        `yaml:"Port"`
+		// RateLimit configures per-client request rate limiting.
+		RateLimit RateLimitConfig `yaml:"RateLimit"`
+		// ReadTimeout is a maximum duration for reading the entire HTTP
+		// request including the body, 0 means no timeout.
+		ReadTimeout time.Duration `yaml:"ReadTimeout"`
+		// REST configures REST gateway for some JSON-RPC methods.
+		REST RESTConfig `yaml:"REST"`
+		// Sessions configures iterator sessions (traverseiterator and
+		// terminatesession methods).
+		Sessions  SessionsConfig `yaml:"Sessions"`
+		TLSConfig TLSConfig      `yaml:"TLSConfig"`
+	}
+
+	// AuditConfig describes audit log configuration. Calls to
+	// sendrawtransaction, submitblock and submitnotaryrequest methods are
+	// recorded into the log file as JSON lines.
+	AuditConfig struct {
+		Enabled bool `yaml:"Enabled"`
+		// Filename is the path to the audit log file.
+		Filename string `yaml:"Filename"`
+		// MaxSize is the size of the log file in bytes it's rotated at,
+		// 0 disables rotation.
+		MaxSize int64 `yaml:"MaxSize"`
+		// MaxBackups is the number of rotated log files kept, the oldest
+		// ones are removed.
+		MaxBackups int `yaml:"MaxBackups"`
+	}
+
+	// AuthConfig describes client authentication configuration. Clients
+	// can use either bearer tokens or HTTP basic authentication.
+	AuthConfig struct {
+		Enabled bool `yaml:"Enabled"`
+		// BearerTokens is a list of valid bearer tokens.
+		BearerTokens []string `yaml:"BearerTokens"`
+		// BasicAuth is a map of user names to their passwords.
+		BasicAuth map[string]string `yaml:"BasicAuth"`
+		// Methods is a list of methods requiring authentication, all
+		// methods require it if it's empty.
+		Methods []string `yaml:"Methods"`
+	}
+
+	// CompressionConfig describes HTTP response compression configuration.
+	// Responses are compressed with gzip if client supports it (via
+	// Accept-Encoding header).
+	CompressionConfig struct {
+		Enabled bool `yaml:"Enabled"`
+		// MinSize is the minimum size of response to be compressed,
+		// 1024 bytes is used if it's not set.
+		MinSize int `yaml:"MinSize"`
+	}
+
+	// CORSConfig describes cross-origin requests configuration.
+	CORSConfig struct {
+		// AllowedOrigins is a list of origins allowed to make
+		// cross-origin requests, "*" allows any origin.
+		AllowedOrigins []string `yaml:"AllowedOrigins"`
+		// MaxAge is the time preflight request results can be cached
+		// for by clients.
+		MaxAge time.Duration `yaml:"MaxAge"`
+	}
+
+	// FinalityWatcherConfig describes transaction finality watcher
+	// configuration.
+	FinalityWatcherConfig struct {
+		Enabled bool `yaml:"Enabled"`
+		// MaxWatches is a maximum number of simultaneously watched
+		// transactions, 10000 is used if it's not set.
+		MaxWatches int `yaml:"MaxWatches"`
+		// AllowedCallbacks is a list of URL prefixes callback URLs must
+		// match (the same scheme and host and path starting with the
+		// prefix path), callbacks are disabled if it's empty.
+		AllowedCallbacks []string `yaml:"AllowedCallbacks"`
+		// CallbackTimeout is a timeout for callback URL requests.
+		CallbackTimeout time.Duration `yaml:"CallbackTimeout"`
+	}
+
+	// RateLimitConfig describes per-client (per-IP) request rate limiter
+	// configuration.
+	RateLimitConfig struct {
+		Enabled bool `yaml:"Enabled"`
+		// RequestsPerSecond is the rate at which request tokens are
+		// given to every client.
+		RequestsPerSecond float64 `yaml:"RequestsPerSecond"`
+		// Burst is the maximum number of tokens client can accumulate,
+		// RequestsPerSecond is used if it's not set.
+		Burst int `yaml:"Burst"`
+		// MethodWeights is the number of tokens taken by requests to
+		// the given methods (1 for methods not listed here).
+		MethodWeights map[string]int `yaml:"MethodWeights"`
+		// TrustForwardedFor enables using X-Forwarded-For header to get
+		// client address, it must only be used when the server is
+		// behind a reverse proxy setting this header.
+		TrustForwardedFor bool `yaml:"TrustForwardedFor"`
+	}
+
+	// RESTConfig describes REST gateway configuration. The gateway serves
+	// GET /blocks/{index or hash}, GET /transactions/{hash},
+	// GET /accounts/{address}/nep17balances and POST /transactions
+	// requests on the same port as JSON-RPC server.
+	RESTConfig struct {
+		Enabled bool `yaml:"Enabled"`
+	}
+
+	// SessionsConfig describes iterator sessions configuration.
+	SessionsConfig struct {
+		Enabled bool `yaml:"Enabled"`
+		// ExpirationTime is the time a session is kept for since the
+		// last access to it.
+		ExpirationTime time.Duration `yaml:"ExpirationTime"`
+		// MaxSessions is a maximum number of simultaneously opened
+		// sessions, 20 is used if it's not set.
+		MaxSessions int `yaml:"MaxSessions"`
+		// MaxIteratorResultItems is a maximum number of items returned
+		// by a single traverseiterator call.
+		MaxIteratorResultItems int `yaml:"MaxIteratorResultItems"`
 	}
 
 	// TLSConfig describes SSL/TLS configuration.
@@ -24,5 +194,10 @@ type (
 		Enabled  bool   `yaml:"Enabled"`
 		Port     uint16 `yaml:"Port"`
 		KeyFile  string `yaml:"KeyFile"`
+		// ReloadInterval is the interval certificate and key files are
+		// checked for changes at, they're reloaded automatically when
+		// changed. 0 disables checks, files can still be reloaded with
+		// SIGHUP signal then.
+		ReloadInterval time.Duration `yaml:"ReloadInterval"`
 	}
 )
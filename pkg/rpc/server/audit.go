@@ -0,0 +1,226 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/rpc"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// auditedMethods is a set of methods changing node state, calls to them are
+// recorded in the audit log.
+var auditedMethods = map[string]bool{
+	"sendrawtransaction":  true,
+	"submitblock":         true,
+	"submitnotaryrequest": true,
+}
+
+// auditLog records calls to audited methods as JSON lines into a file that is
+// rotated when it reaches the configured size.
+type auditLog struct {
+	file *rotatingFile
+	log  *zap.Logger
+}
+
+// newAuditLog opens audit log file described by the given configuration.
+func newAuditLog(cfg rpc.AuditConfig) (*auditLog, error) {
+	f, err := newRotatingFile(cfg.Filename, cfg.MaxSize, cfg.MaxBackups)
+	if err != nil {
+		return nil, err
+	}
+	encCfg := zap.NewProductionEncoderConfig()
+	encCfg.TimeKey = "time"
+	encCfg.EncodeTime = zapcore.ISO8601TimeEncoder
+	encCfg.EncodeDuration = zapcore.StringDurationEncoder
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(encCfg), f, zapcore.InfoLevel)
+	return &auditLog{
+		file: f,
+		log:  zap.New(core),
+	}, nil
+}
+
+// record writes a single audit log entry for the call of the given method
+// made by the client at addr.
+func (a *auditLog) record(method, addr string, hash *util.Uint256, resErr *response.Error, took time.Duration) {
+	fields := []zap.Field{
+		zap.String("method", method),
+		zap.String("origin", addr),
+	}
+	if hash != nil {
+		fields = append(fields, zap.String("hash", hash.StringLE()))
+	}
+	if resErr == nil {
+		fields = append(fields, zap.String("result", "success"))
+	} else {
+		fields = append(fields,
+			zap.String("result", "error"),
+			zap.Int64("code", resErr.Code),
+			zap.String("error", resErr.Message))
+		if resErr.Data != "" {
+			fields = append(fields, zap.String("data", resErr.Data))
+		}
+	}
+	fields = append(fields, zap.Duration("duration", took))
+	a.log.Info("rpc call", fields...)
+}
+
+// close flushes and closes audit log file.
+func (a *auditLog) close() error {
+	_ = a.log.Sync()
+	return a.file.Close()
+}
+
+// getAuditHash returns the hash of the transaction or block passed to the
+// audited method, it's nil if the parameter can't be decoded.
+func (s *Server) getAuditHash(method string, ps request.Params) *util.Uint256 {
+	data, err := ps.Value(0).GetBytesBase64()
+	if err != nil {
+		return nil
+	}
+	var h util.Uint256
+	switch method {
+	case "sendrawtransaction":
+		tx, err := transaction.NewTransactionFromBytes(s.network, data)
+		if err != nil {
+			return nil
+		}
+		h = tx.Hash()
+	case "submitblock":
+		b := block.New(s.network, s.stateRootEnabled)
+		r := io.NewBinReaderFromBuf(data)
+		b.DecodeBinary(r)
+		if r.Err != nil {
+			return nil
+		}
+		h = b.Hash()
+	case "submitnotaryrequest":
+		p, err := payload.NewP2PNotaryRequestFromBytes(s.network, data)
+		if err != nil {
+			return nil
+		}
+		// The same hash is returned in the call result.
+		h = p.FallbackTransaction.Hash()
+	default:
+		return nil
+	}
+	return &h
+}
+
+// rotatingFile is an append-only file that is renamed to name.1 (shifting
+// older backups to name.2 and so on) and reopened when its size exceeds
+// maxSize. Only maxBackups rotated files are kept.
+type rotatingFile struct {
+	lock       sync.Mutex
+	name       string
+	maxSize    int64
+	maxBackups int
+	size       int64
+	f          *os.File
+}
+
+func newRotatingFile(name string, maxSize int64, maxBackups int) (*rotatingFile, error) {
+	if name == "" {
+		return nil, fmt.Errorf("audit log file name is not specified")
+	}
+	r := &rotatingFile{
+		name:       name,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *rotatingFile) open() error {
+	f, err := os.OpenFile(r.name, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0640)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	r.f = f
+	r.size = fi.Size()
+	return nil
+}
+
+// Write implements io.Writer interface. Entries are never split between
+// files, so the file is rotated before the write if it's needed.
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.f == nil {
+		return 0, os.ErrClosed
+	}
+	if r.maxSize > 0 && r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.f.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// rotate closes the current file, shifts backups and opens a new file.
+func (r *rotatingFile) rotate() error {
+	if err := r.f.Close(); err != nil {
+		return err
+	}
+	r.f = nil
+	if r.maxBackups > 0 {
+		for i := r.maxBackups - 1; i > 0; i-- {
+			err := os.Rename(r.backupName(i), r.backupName(i+1))
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+		if err := os.Rename(r.name, r.backupName(1)); err != nil {
+			return err
+		}
+	} else if err := os.Remove(r.name); err != nil {
+		return err
+	}
+	return r.open()
+}
+
+func (r *rotatingFile) backupName(i int) string {
+	return fmt.Sprintf("%s.%d", r.name, i)
+}
+
+// Sync implements zapcore.WriteSyncer interface.
+func (r *rotatingFile) Sync() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.f == nil {
+		return nil
+	}
+	return r.f.Sync()
+}
+
+// Close closes the file, subsequent writes fail.
+func (r *rotatingFile) Close() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.f == nil {
+		return nil
+	}
+	err := r.f.Close()
+	r.f = nil
+	return err
+}
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotatingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "neogo.audit")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	name := filepath.Join(dir, "audit.log")
+	_, err = newRotatingFile("", 10, 1)
+	require.Error(t, err)
+
+	f, err := newRotatingFile(name, 10, 2)
+	require.NoError(t, err)
+	for i := 0; i < 4; i++ {
+		_, err := f.Write([]byte(fmt.Sprintf("entry %d\n", i)))
+		require.NoError(t, err)
+	}
+	require.NoError(t, f.Close())
+	_, err = f.Write([]byte("closed\n"))
+	require.Error(t, err)
+
+	check := func(t *testing.T, name, expected string) {
+		data, err := ioutil.ReadFile(name)
+		require.NoError(t, err)
+		require.Equal(t, expected, string(data))
+	}
+	check(t, name, "entry 3\n")
+	check(t, name+".1", "entry 2\n")
+	check(t, name+".2", "entry 1\n")
+	_, err = os.Stat(name + ".3")
+	require.True(t, os.IsNotExist(err))
+
+	t.Run("reopen", func(t *testing.T) {
+		f, err := newRotatingFile(name, 20, 2)
+		require.NoError(t, err)
+		_, err = f.Write([]byte("entry 4\n"))
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+		check(t, name, "entry 3\nentry 4\n")
+	})
+}
+
+func TestAuditLog(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	dir, err := ioutil.TempDir("", "neogo.audit")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	name := filepath.Join(dir, "audit.log")
+	rpcSrv.audit, err = newAuditLog(rpc.AuditConfig{Enabled: true, Filename: name})
+	require.NoError(t, err)
+
+	b, err := chain.GetBlock(chain.GetHeaderHash(1))
+	require.NoError(t, err)
+	for _, req := range []string{
+		`{"jsonrpc": "2.0", "id": 1, "method": "sendrawtransaction", "params": ["garbage"]}`,
+		`{"jsonrpc": "2.0", "id": 1, "method": "getblockcount", "params": []}`,
+		fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "submitblock", "params": ["%s"]}`, encodeBlock(t, b)),
+	} {
+		doRPCCallOverHTTP(req, httpSrv.URL, t)
+	}
+	require.NoError(t, rpcSrv.audit.log.Sync())
+
+	f, err := os.Open(name)
+	require.NoError(t, err)
+	defer f.Close()
+	var entries []map[string]interface{}
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var e map[string]interface{}
+		require.NoError(t, json.Unmarshal(sc.Bytes(), &e))
+		entries = append(entries, e)
+	}
+	require.NoError(t, sc.Err())
+	require.Equal(t, 2, len(entries))
+
+	require.Equal(t, "sendrawtransaction", entries[0]["method"])
+	require.Equal(t, "127.0.0.1", entries[0]["origin"])
+	require.Equal(t, "error", entries[0]["result"])
+	require.NotContains(t, entries[0], "hash")
+	require.Contains(t, entries[0], "duration")
+	require.Contains(t, entries[0], "time")
+
+	require.Equal(t, "submitblock", entries[1]["method"])
+	require.Equal(t, b.Hash().StringLE(), entries[1]["hash"])
+	require.Equal(t, "error", entries[1]["result"])
+}
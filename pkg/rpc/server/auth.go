@@ -0,0 +1,110 @@
+package server
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// clientInfo describes the client making requests.
+type clientInfo struct {
+	// addr is client IP address.
+	addr string
+	// authorized is set if the client has provided valid credentials.
+	authorized bool
+}
+
+// newClientInfo returns clientInfo for the given HTTP request (it's websocket
+// upgrade request for websocket clients).
+func (s *Server) newClientInfo(r *http.Request) *clientInfo {
+	return &clientInfo{
+		addr:       clientAddr(r, s.config.RateLimit.TrustForwardedFor),
+		authorized: s.checkCredentials(r),
+	}
+}
+
+// checkCredentials checks bearer token or basic authentication credentials of
+// the given request against the configured ones.
+func (s *Server) checkCredentials(r *http.Request) bool {
+	cfg := s.config.Auth
+	if !cfg.Enabled {
+		return true
+	}
+	if user, password, ok := r.BasicAuth(); ok {
+		expected, ok := cfg.BasicAuth[user]
+		return ok && subtle.ConstantTimeCompare([]byte(password), []byte(expected)) == 1
+	}
+	const bearer = "Bearer "
+	h := r.Header.Get("Authorization")
+	if !strings.HasPrefix(h, bearer) {
+		return false
+	}
+	token := []byte(strings.TrimPrefix(h, bearer))
+	var valid bool
+	for _, t := range cfg.BearerTokens {
+		if subtle.ConstantTimeCompare(token, []byte(t)) == 1 {
+			valid = true
+		}
+	}
+	return valid
+}
+
+// authRequired checks whether the given method requires authentication.
+func (s *Server) authRequired(method string) bool {
+	cfg := s.config.Auth
+	if !cfg.Enabled {
+		return false
+	}
+	if len(cfg.Methods) == 0 {
+		return true
+	}
+	for _, m := range cfg.Methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// allowedOrigin returns the value of Access-Control-Allow-Origin header for the
+// given request origin, an empty string is returned for origins not allowed.
+func (s *Server) allowedOrigin(origin string) string {
+	if s.config.EnableCORSWorkaround {
+		return "*"
+	}
+	for _, o := range s.config.CORS.AllowedOrigins {
+		if o == "*" {
+			return "*"
+		}
+		if origin != "" && o == origin {
+			return origin
+		}
+	}
+	return ""
+}
+
+// setCORSHeaders sets CORS headers for the given request if its origin is
+// allowed.
+func (s *Server) setCORSHeaders(w http.ResponseWriter, r *http.Request) {
+	origin := s.allowedOrigin(r.Header.Get("Origin"))
+	if origin == "" {
+		return
+	}
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Access-Control-Allow-Headers, Authorization, X-Requested-With")
+	if origin != "*" {
+		w.Header().Add("Vary", "Origin")
+	}
+}
+
+// handlePreflight responds to CORS preflight request.
+func (s *Server) handlePreflight(w http.ResponseWriter, r *http.Request) {
+	if s.allowedOrigin(r.Header.Get("Origin")) != "" {
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+		if age := s.config.CORS.MaxAge; age > 0 {
+			w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(age.Seconds())))
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,113 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/rpc"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuth(t *testing.T) {
+	chain, rpcSrv, httpSrv := initClearServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	rpcSrv.config.Auth = rpc.AuthConfig{
+		Enabled:      true,
+		BearerTokens: []string{"secret"},
+		BasicAuth:    map[string]string{"user": "pass"},
+		Methods:      []string{"getblockcount"},
+	}
+	call := func(t *testing.T, method string, f func(r *http.Request)) *response.Error {
+		body := `{"jsonrpc": "2.0", "id": 1, "method": "` + method + `", "params": []}`
+		req, err := http.NewRequest("POST", httpSrv.URL, strings.NewReader(body))
+		require.NoError(t, err)
+		if f != nil {
+			f(req)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var raw response.Raw
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&raw))
+		return raw.Error
+	}
+	t.Run("not protected", func(t *testing.T) {
+		require.Nil(t, call(t, "getversion", nil))
+	})
+	t.Run("no credentials", func(t *testing.T) {
+		err := call(t, "getblockcount", nil)
+		require.True(t, errors.Is(err, response.ErrUnauthorized))
+	})
+	t.Run("bearer", func(t *testing.T) {
+		require.Nil(t, call(t, "getblockcount", func(r *http.Request) {
+			r.Header.Set("Authorization", "Bearer secret")
+		}))
+		err := call(t, "getblockcount", func(r *http.Request) {
+			r.Header.Set("Authorization", "Bearer wrong")
+		})
+		require.True(t, errors.Is(err, response.ErrUnauthorized))
+	})
+	t.Run("basic", func(t *testing.T) {
+		require.Nil(t, call(t, "getblockcount", func(r *http.Request) {
+			r.SetBasicAuth("user", "pass")
+		}))
+		err := call(t, "getblockcount", func(r *http.Request) {
+			r.SetBasicAuth("user", "wrong")
+		})
+		require.True(t, errors.Is(err, response.ErrUnauthorized))
+	})
+}
+
+func TestCORS(t *testing.T) {
+	s := &Server{config: rpc.Config{CORS: rpc.CORSConfig{
+		AllowedOrigins: []string{"https://example.com"},
+		MaxAge:         time.Minute,
+	}}}
+	newRequest := func(method, origin string) *http.Request {
+		r := httptest.NewRequest(method, "/", nil)
+		if origin != "" {
+			r.Header.Set("Origin", origin)
+		}
+		return r
+	}
+
+	t.Run("allowed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.setCORSHeaders(w, newRequest("POST", "https://example.com"))
+		require.Equal(t, "https://example.com", w.Header().Get("Access-Control-Allow-Origin"))
+		require.Equal(t, "Origin", w.Header().Get("Vary"))
+	})
+	t.Run("not allowed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.setCORSHeaders(w, newRequest("POST", "https://evil.com"))
+		require.Equal(t, "", w.Header().Get("Access-Control-Allow-Origin"))
+	})
+	t.Run("preflight", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := newRequest("OPTIONS", "https://example.com")
+		s.setCORSHeaders(w, r)
+		s.handlePreflight(w, r)
+		require.Equal(t, http.StatusNoContent, w.Code)
+		require.Equal(t, "GET, POST, OPTIONS", w.Header().Get("Access-Control-Allow-Methods"))
+		require.Equal(t, "60", w.Header().Get("Access-Control-Max-Age"))
+
+		w = httptest.NewRecorder()
+		s.handlePreflight(w, newRequest("OPTIONS", "https://evil.com"))
+		require.Equal(t, http.StatusNoContent, w.Code)
+		require.Equal(t, "", w.Header().Get("Access-Control-Allow-Methods"))
+	})
+	t.Run("workaround", func(t *testing.T) {
+		s := &Server{config: rpc.Config{EnableCORSWorkaround: true}}
+		w := httptest.NewRecorder()
+		s.setCORSHeaders(w, newRequest("POST", ""))
+		require.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+	})
+}
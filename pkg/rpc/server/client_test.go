@@ -6,20 +6,30 @@ import (
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/testchain"
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/core/native"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/rpc"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/client"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/stretchr/testify/require"
 )
@@ -135,6 +145,46 @@ func TestAddNetworkFee(t *testing.T) {
 		cFeeM, _ := fee.Calculate(chain.GetBaseExecFee(), accs[1].Contract.Script)
 		require.Equal(t, int64(io.GetVarSize(tx))*feePerByte+cFee+cFeeM+extraFee, tx.NetworkFee)
 	})
+	t.Run("FeeOracle", func(t *testing.T) {
+		var oracleSize int
+		oc, err := client.New(context.Background(), httpSrv.URL, client.Options{
+			FeeOracle: client.FeeOracleFunc(func(size int, netFee int64) (int64, error) {
+				oracleSize = size
+				return 42, nil
+			}),
+		})
+		require.NoError(t, err)
+		require.NoError(t, oc.Init())
+
+		tx := transaction.New(testchain.Network(), []byte{byte(opcode.PUSH1)}, 0)
+		accs := getAccounts(t, 1)
+		tx.Signers = []transaction.Signer{{
+			Account: accs[0].PrivateKey().GetScriptHash(),
+			Scopes:  transaction.CalledByEntry,
+		}}
+		require.NoError(t, oc.AddNetworkFee(tx, client.DefaultExtraFee, accs[0]))
+		require.NoError(t, accs[0].SignTx(tx))
+		require.Equal(t, io.GetVarSize(tx), oracleSize)
+		cFee, _ := fee.Calculate(chain.GetBaseExecFee(), accs[0].Contract.Script)
+		require.Equal(t, int64(io.GetVarSize(tx))*feePerByte+cFee+42, tx.NetworkFee)
+
+		t.Run("no oracle", func(t *testing.T) {
+			tx := transaction.New(testchain.Network(), []byte{byte(opcode.PUSH1)}, 0)
+			tx.Signers = []transaction.Signer{{
+				Account: accs[0].PrivateKey().GetScriptHash(),
+				Scopes:  transaction.CalledByEntry,
+			}}
+			require.NoError(t, c.AddNetworkFee(tx, client.DefaultExtraFee, accs[0]))
+			require.NoError(t, accs[0].SignTx(tx))
+			require.Equal(t, int64(io.GetVarSize(tx))*feePerByte+cFee, tx.NetworkFee)
+		})
+		t.Run("mempool", func(t *testing.T) {
+			o := &client.MempoolFeeOracle{Client: c}
+			extra, err := o.ExtraFee(100, 0)
+			require.NoError(t, err)
+			require.Equal(t, int64(0), extra) // Empty mempool.
+		})
+	})
 	t.Run("Contract", func(t *testing.T) {
 		h, err := util.Uint160DecodeStringLE(verifyContractHash)
 		require.NoError(t, err)
@@ -142,6 +192,7 @@ func TestAddNetworkFee(t *testing.T) {
 		acc0 := wallet.NewAccountFromPrivateKey(priv)
 		acc1 := wallet.NewAccountFromPrivateKey(priv) // contract account
 		acc1.Contract.Deployed = true
+		acc1.Contract.Parameters = nil // `verify` has no parameters.
 		acc1.Contract.Script, err = base64.StdEncoding.DecodeString(verifyContractAVM)
 
 		newTx := func(t *testing.T) *transaction.Transaction {
@@ -219,6 +270,96 @@ func TestAddNetworkFee(t *testing.T) {
 			}
 			require.Error(t, c.AddNetworkFee(tx, 10, acc0, acc1))
 		})
+		t.Run("ContractAccount", func(t *testing.T) {
+			_, err := c.NewContractAccount(util.Uint160{})
+			require.Error(t, err)
+
+			acc, err := c.NewContractAccount(h)
+			require.NoError(t, err)
+			require.True(t, acc.Contract.Deployed)
+			require.Equal(t, 0, len(acc.Contract.Parameters))
+
+			tx := newTx(t)
+			tx.Signers = []transaction.Signer{
+				{
+					Account: acc0.PrivateKey().GetScriptHash(),
+					Scopes:  transaction.CalledByEntry,
+				},
+				{
+					Account: h,
+					Scopes:  transaction.Global,
+				},
+			}
+			require.NoError(t, c.AddNetworkFee(tx, 0, acc0, acc))
+			require.NoError(t, acc0.SignTx(tx))
+			require.NoError(t, acc.SignTx(tx))
+			require.NoError(t, chain.VerifyTx(tx))
+
+			t.Run("with arguments", func(t *testing.T) {
+				hArgs, err := util.Uint160DecodeStringLE(verifyWithArgsContractHash)
+				require.NoError(t, err)
+				acc, err := c.NewContractAccount(hArgs)
+				require.NoError(t, err)
+				require.Equal(t, 3, len(acc.Contract.Parameters))
+
+				tx := newTx(t)
+				tx.Signers = []transaction.Signer{
+					{
+						Account: acc0.PrivateKey().GetScriptHash(),
+						Scopes:  transaction.CalledByEntry,
+					},
+					{
+						Account: hArgs,
+						Scopes:  transaction.Global,
+					},
+				}
+				require.NoError(t, c.AddNetworkFee(tx, 0, acc0, acc))
+				require.True(t, tx.NetworkFee > 0)
+				require.Error(t, acc.SignTx(tx)) // Arguments are required.
+			})
+		})
+	})
+}
+
+func TestPreviewTx(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	acc, err := wallet.NewAccount()
+	require.NoError(t, err)
+	tx := transaction.New(testchain.Network(), []byte{byte(opcode.PUSH1)}, 0)
+	tx.Signers = []transaction.Signer{{
+		Account: acc.PrivateKey().GetScriptHash(),
+		Scopes:  transaction.CalledByEntry,
+	}}
+
+	p, err := c.PreviewTx(tx, acc)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), tx.NetworkFee)
+	require.True(t, p.SystemFee > 0)
+	require.False(t, p.SizeLimitExceeded)
+	cFee, _ := fee.Calculate(chain.GetBaseExecFee(), acc.Contract.Script)
+	require.Equal(t, []int64{cFee}, p.WitnessFees)
+
+	require.NoError(t, c.AddNetworkFee(tx, 0, acc))
+	require.NoError(t, acc.SignTx(tx))
+	require.Equal(t, io.GetVarSize(tx), p.Size)
+	require.Equal(t, int64(p.Size)*chain.FeePerByte(), p.SizeFee)
+	require.Equal(t, tx.NetworkFee, p.NetworkFee)
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := c.PreviewTx(tx)
+		require.Error(t, err)
+
+		tx := transaction.New(testchain.Network(), []byte{byte(opcode.ABORT)}, 0)
+		tx.Signers = []transaction.Signer{{Account: acc.PrivateKey().GetScriptHash()}}
+		_, err = c.PreviewTx(tx, acc)
+		require.Error(t, err)
 	})
 }
 
@@ -399,6 +540,64 @@ func TestCreateTxFromScript(t *testing.T) {
 	})
 }
 
+func TestHighPriority(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	committee, err := c.GetCommitteeAddress()
+	require.NoError(t, err)
+	require.Equal(t, testchain.CommitteeScriptHash(), committee)
+
+	committeeAcc := &wallet.Account{
+		Address:  testchain.CommitteeAddress(),
+		Contract: &wallet.Contract{Script: testchain.CommitteeVerificationScript()},
+	}
+	validatorsAcc := &wallet.Account{
+		Address:  testchain.MultisigAddress(),
+		Contract: &wallet.Contract{Script: testchain.MultisigVerificationScript()},
+	}
+	simpleAcc := wallet.NewAccountFromPrivateKey(testchain.PrivateKey(0))
+
+	t.Run("automatic", func(t *testing.T) {
+		tx, err := c.CreateTxFromScript([]byte{byte(opcode.PUSH1)}, committeeAcc, 123, 0, nil)
+		require.NoError(t, err)
+		require.True(t, tx.HasAttribute(transaction.HighPriority))
+
+		tx, err = c.CreateTxFromScript([]byte{byte(opcode.PUSH1)}, validatorsAcc, 123, 0, nil)
+		require.NoError(t, err)
+		require.False(t, tx.HasAttribute(transaction.HighPriority))
+
+		tx, err = c.CreateTxFromScript([]byte{byte(opcode.PUSH1)}, simpleAcc, 123, 0, nil)
+		require.NoError(t, err)
+		require.False(t, tx.HasAttribute(transaction.HighPriority))
+	})
+	t.Run("manual", func(t *testing.T) {
+		tx := transaction.New(testchain.Network(), []byte{byte(opcode.PUSH1)}, 0)
+		tx.Signers = []transaction.Signer{{Account: simpleAcc.Contract.ScriptHash()}}
+		require.Error(t, c.AddHighPriority(tx))
+		require.Equal(t, 0, len(tx.Attributes))
+
+		tx.Signers = append(tx.Signers, transaction.Signer{Account: committee})
+		require.NoError(t, c.AddHighPriority(tx))
+		require.NoError(t, c.AddHighPriority(tx))
+		require.Equal(t, 1, len(tx.Attributes))
+	})
+	t.Run("send without committee", func(t *testing.T) {
+		tx, err := c.CreateTxFromScript([]byte{byte(opcode.PUSH1)}, simpleAcc, 123, 0, nil)
+		require.NoError(t, err)
+		tx.Attributes = append(tx.Attributes, transaction.Attribute{Type: transaction.HighPriority})
+		require.NoError(t, simpleAcc.SignTx(tx))
+		_, err = c.SendRawTransaction(tx)
+		require.Error(t, err)
+		require.False(t, chain.GetMemPool().ContainsKey(tx.Hash()))
+	})
+}
+
 func TestCreateNEP17TransferTx(t *testing.T) {
 	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
 	defer chain.Close()
@@ -418,11 +617,78 @@ func TestCreateNEP17TransferTx(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, acc.SignTx(tx))
 	require.NoError(t, chain.VerifyTx(tx))
-	v := chain.GetTestVM(trigger.Application, tx, nil)
+	v, _ := chain.GetTestVM(trigger.Application, tx, nil)
 	v.LoadScriptWithFlags(tx.Script, callflag.All)
 	require.NoError(t, v.Run())
 }
 
+func TestClaimGas(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	acc := wallet.NewAccountFromPrivateKey(testchain.PrivateKeyByID(0))
+	neoHash, err := c.GetNativeContractHash(nativenames.Neo)
+	require.NoError(t, err)
+
+	h, err := c.ClaimGas(acc, 0)
+	require.NoError(t, err)
+	tx, ok := chain.GetMemPool().TryGetValue(h)
+	require.True(t, ok)
+
+	expected, err := c.CreateNEP17TransferTx(acc, acc.Contract.ScriptHash(), neoHash, 0, 0, nil)
+	require.NoError(t, err)
+	require.Equal(t, expected.Script, tx.Script)
+	require.Equal(t, acc.Contract.ScriptHash(), tx.Sender())
+}
+
+func TestNEOGovernance(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	acc := wallet.NewAccountFromPrivateKey(testchain.PrivateKeyByID(0))
+
+	t.Run("candidates", func(t *testing.T) {
+		expected, err := chain.GetEnrollments()
+		require.NoError(t, err)
+		cs, err := c.GetCandidates()
+		require.NoError(t, err)
+		require.Equal(t, len(expected), len(cs))
+		for i := range expected {
+			require.Equal(t, expected[i].Key, cs[i].PublicKey)
+			require.Equal(t, expected[i].Votes, cs[i].Votes)
+		}
+	})
+	t.Run("register price", func(t *testing.T) {
+		price, err := c.GetCandidateRegisterPrice()
+		require.NoError(t, err)
+		require.Equal(t, int64(native.DefaultRegisterPrice), price)
+	})
+	t.Run("vote", func(t *testing.T) {
+		h, err := c.Vote(acc, nil, 0)
+		require.NoError(t, err)
+		require.True(t, chain.GetMemPool().ContainsKey(h))
+	})
+	t.Run("unregister", func(t *testing.T) {
+		h, err := c.UnregisterCandidate(acc, 0)
+		require.NoError(t, err)
+		require.True(t, chain.GetMemPool().ContainsKey(h))
+	})
+	t.Run("not decrypted", func(t *testing.T) {
+		_, err := c.RegisterCandidate(&wallet.Account{Address: acc.Address}, 0)
+		require.Error(t, err)
+	})
+}
+
 func TestInvokeVerify(t *testing.T) {
 	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
 	defer chain.Close()
@@ -478,3 +744,212 @@ func TestClient_GetNativeContracts(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, chain.GetNatives(), cs)
 }
+
+func TestClient_GetNativePrices(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	all, err := c.GetNativePrices()
+	require.NoError(t, err)
+	require.Equal(t, len(chain.GetNatives()), len(all))
+
+	gasHash, err := c.GetNativeContractHash(nativenames.Gas)
+	require.NoError(t, err)
+	prices, err := c.GetNativePricesByHash(gasHash)
+	require.NoError(t, err)
+	require.Equal(t, gasHash, prices.Hash)
+	require.Equal(t, nativenames.Gas, prices.Name)
+	methods := chain.GetNativeMethods(gasHash)
+	require.Equal(t, len(methods), len(prices.Methods))
+	for i := range methods {
+		require.Equal(t, methods[i], prices.Methods[i].NativeMethod)
+	}
+
+	_, err = c.GetNativePricesByHash(util.Uint160{1, 2, 3})
+	require.Error(t, err)
+}
+
+func TestDeployContract(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	acc := wallet.NewAccountFromPrivateKey(testchain.PrivateKeyByID(0))
+	newContract := func(t *testing.T, name string) (*nef.File, *manifest.Manifest) {
+		nefFile, err := nef.NewFile([]byte{byte(opcode.PUSH1), byte(opcode.RET)})
+		require.NoError(t, err)
+		m := manifest.NewManifest(name)
+		m.ABI.Methods = []manifest.Method{{
+			Name:       "main",
+			ReturnType: smartcontract.IntegerType,
+			Parameters: []manifest.Parameter{},
+			Safe:       true,
+		}}
+		return nefFile, m
+	}
+
+	t.Run("good", func(t *testing.T) {
+		nefFile, m := newContract(t, "Good")
+		h, txHash, err := c.DeployContract(acc, nefFile, m, nil, 0)
+		require.NoError(t, err)
+		require.Equal(t, state.CreateContractHash(acc.Contract.ScriptHash(), nefFile.Checksum, m.Name), h)
+		require.True(t, chain.GetMemPool().ContainsKey(txHash))
+	})
+	t.Run("bad checksum", func(t *testing.T) {
+		nefFile, m := newContract(t, "BadChecksum")
+		nefFile.Checksum++
+		_, _, err := c.DeployContract(acc, nefFile, m, nil, 0)
+		require.Error(t, err)
+	})
+	t.Run("invalid manifest", func(t *testing.T) {
+		nefFile, m := newContract(t, "NoMethods")
+		m.ABI.Methods = nil
+		_, _, err := c.DeployContract(acc, nefFile, m, nil, 0)
+		require.Error(t, err)
+	})
+	t.Run("non-compliant standard", func(t *testing.T) {
+		nefFile, m := newContract(t, "FakeToken")
+		m.SupportedStandards = []string{manifest.NEP17StandardName}
+		_, _, err := c.DeployContract(acc, nefFile, m, nil, 0)
+		require.Error(t, err)
+	})
+	t.Run("bad method offset", func(t *testing.T) {
+		nefFile, m := newContract(t, "BadOffset")
+		m.ABI.Methods[0].Offset = 2
+		_, _, err := c.DeployContract(acc, nefFile, m, nil, 0)
+		require.Error(t, err)
+	})
+	t.Run("update without update method", func(t *testing.T) {
+		h, err := util.Uint160DecodeStringLE(testContractHash)
+		require.NoError(t, err)
+		nefFile, m := newContract(t, "Update")
+		_, err = c.UpdateContract(acc, h, nefFile, m, nil, 0)
+		require.Error(t, err)
+	})
+}
+
+func TestClient_IteratorExpansion(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	w := io.NewBufBinWriter()
+	emit.Int(w.BinWriter, 5)
+	emit.Array(w.BinWriter, int64(1), int64(2), int64(3))
+	emit.Syscall(w.BinWriter, interopnames.SystemIteratorCreate)
+	emit.Bool(w.BinWriter, true)
+	require.NoError(t, w.Err)
+	script := w.Bytes()
+
+	t.Run("disabled", func(t *testing.T) {
+		c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+		require.NoError(t, err)
+		require.NoError(t, c.Init())
+
+		res, err := c.InvokeScript(script, nil)
+		require.NoError(t, err)
+		require.Equal(t, "HALT", res.State)
+		require.Equal(t, 3, len(res.Stack))
+		require.Equal(t, stackitem.InteropT, res.Stack[1].Type())
+	})
+	check := func(t *testing.T, res *result.Invoke, values []stackitem.Item) {
+		require.Equal(t, "HALT", res.State)
+		require.Equal(t, script, res.Script)
+		require.Equal(t, []stackitem.Item{
+			stackitem.Make(5),
+			stackitem.NewArray(values),
+			stackitem.Make(true),
+		}, res.Stack)
+	}
+	t.Run("all items", func(t *testing.T) {
+		c, err := client.New(context.Background(), httpSrv.URL, client.Options{MaxIteratorItems: 10})
+		require.NoError(t, err)
+		require.NoError(t, c.Init())
+
+		res, err := c.InvokeScript(script, nil)
+		require.NoError(t, err)
+		check(t, res, []stackitem.Item{stackitem.Make(1), stackitem.Make(2), stackitem.Make(3)})
+	})
+	t.Run("limited", func(t *testing.T) {
+		c, err := client.New(context.Background(), httpSrv.URL, client.Options{MaxIteratorItems: 2})
+		require.NoError(t, err)
+		require.NoError(t, c.Init())
+
+		res, err := c.InvokeScriptWithGas(script, nil, 1_0000_0000)
+		require.NoError(t, err)
+		check(t, res, []stackitem.Item{stackitem.Make(1), stackitem.Make(2)})
+	})
+}
+
+func TestClient_IteratorSessions(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithCustomConfig(t, func(cfg *config.Config) {
+		cfg.ApplicationConfiguration.RPC.Sessions = rpc.SessionsConfig{
+			Enabled:                true,
+			MaxSessions:            1,
+			MaxIteratorResultItems: 2,
+		}
+	})
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	c, err := client.New(context.Background(), httpSrv.URL, client.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	w := io.NewBufBinWriter()
+	emit.Array(w.BinWriter, int64(1), int64(2), int64(3))
+	emit.Syscall(w.BinWriter, interopnames.SystemIteratorCreate)
+	emit.Int(w.BinWriter, 5)
+	require.NoError(t, w.Err)
+	script := w.Bytes()
+
+	res, err := c.InvokeScript(script, nil)
+	require.NoError(t, err)
+	require.Equal(t, "HALT", res.State)
+	require.NotEqual(t, "", res.Session)
+	require.Equal(t, 2, len(res.Stack))
+	require.Equal(t, stackitem.Make(5), res.Stack[1])
+	it, ok := res.Stack[0].Value().(result.Iterator)
+	require.True(t, ok)
+
+	t.Run("session limit", func(t *testing.T) {
+		_, err := c.InvokeScript(script, nil)
+		require.Error(t, err)
+	})
+	t.Run("bad parameters", func(t *testing.T) {
+		_, err := c.TraverseIterator(res.Session, it.ID, 3)
+		require.Error(t, err)
+		_, err = c.TraverseIterator("unknown", it.ID, 1)
+		require.Error(t, err)
+		_, err = c.TraverseIterator(res.Session, "unknown", 1)
+		require.Error(t, err)
+	})
+
+	items, err := c.TraverseIterator(res.Session, it.ID, 2)
+	require.NoError(t, err)
+	require.Equal(t, []stackitem.Item{stackitem.Make(1), stackitem.Make(2)}, items)
+	items, err = c.TraverseIterator(res.Session, it.ID, 2)
+	require.NoError(t, err)
+	require.Equal(t, []stackitem.Item{stackitem.Make(3)}, items)
+	items, err = c.TraverseIterator(res.Session, it.ID, 2)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(items))
+
+	ok, err = c.TerminateSession(res.Session)
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = c.TerminateSession(res.Session)
+	require.NoError(t, err)
+	require.False(t, ok)
+	_, err = c.TraverseIterator(res.Session, it.ID, 1)
+	require.Error(t, err)
+}
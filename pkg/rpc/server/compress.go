@@ -0,0 +1,109 @@
+package server
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strings"
+)
+
+// defaultCompressionMinSize is the minimum size of response compressed if
+// MinSize is not set in the configuration.
+const defaultCompressionMinSize = 1024
+
+// gzipResponseWriter is an http.ResponseWriter compressing responses bigger
+// than minSize with gzip. It buffers response data until minSize is reached
+// to decide whether compression is needed, so Close must be called to flush
+// it.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	minSize int
+	code    int
+	buf     []byte
+	gz      *gzip.Writer
+}
+
+// acceptsGzip checks whether client accepts gzip encoding via Accept-Encoding
+// header.
+func acceptsGzip(r *http.Request) bool {
+	for _, h := range r.Header.Values("Accept-Encoding") {
+		for _, enc := range strings.Split(h, ",") {
+			var params string
+			if i := strings.IndexByte(enc, ';'); i >= 0 {
+				enc, params = enc[:i], enc[i+1:]
+			}
+			enc = strings.ToLower(strings.TrimSpace(enc))
+			if enc != "gzip" && enc != "*" {
+				continue
+			}
+			params = strings.ReplaceAll(params, " ", "")
+			if params == "q=0" || strings.HasPrefix(params, "q=0.") && strings.Trim(params[4:], "0") == "" {
+				continue
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// newCompressingWriter wraps w into gzipResponseWriter if compression is
+// enabled and supported by the client. Returned function must be called
+// after the response is written.
+func (s *Server) newCompressingWriter(w http.ResponseWriter, r *http.Request) (http.ResponseWriter, func()) {
+	if !s.config.Compression.Enabled || !acceptsGzip(r) {
+		return w, func() {}
+	}
+	w.Header().Add("Vary", "Accept-Encoding")
+	minSize := s.config.Compression.MinSize
+	if minSize <= 0 {
+		minSize = defaultCompressionMinSize
+	}
+	gw := &gzipResponseWriter{ResponseWriter: w, minSize: minSize}
+	return gw, gw.Close
+}
+
+// WriteHeader implements http.ResponseWriter interface, the header is
+// written along with the first portion of data.
+func (w *gzipResponseWriter) WriteHeader(code int) {
+	if w.code == 0 {
+		w.code = code
+	}
+}
+
+// Write implements http.ResponseWriter interface.
+func (w *gzipResponseWriter) Write(p []byte) (int, error) {
+	if w.gz != nil {
+		return w.gz.Write(p)
+	}
+	w.buf = append(w.buf, p...)
+	if len(w.buf) < w.minSize {
+		return len(p), nil
+	}
+	w.Header().Set("Content-Encoding", "gzip")
+	w.Header().Del("Content-Length")
+	w.writeHeader()
+	w.gz = gzip.NewWriter(w.ResponseWriter)
+	buf := w.buf
+	w.buf = nil
+	if _, err := w.gz.Write(buf); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close flushes buffered data to the underlying writer.
+func (w *gzipResponseWriter) Close() {
+	if w.gz != nil {
+		_ = w.gz.Close()
+		return
+	}
+	w.writeHeader()
+	if len(w.buf) != 0 {
+		_, _ = w.ResponseWriter.Write(w.buf)
+	}
+}
+
+func (w *gzipResponseWriter) writeHeader() {
+	if w.code != 0 {
+		w.ResponseWriter.WriteHeader(w.code)
+	}
+}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcceptsGzip(t *testing.T) {
+	testCases := map[string]bool{
+		"":                    false,
+		"gzip":                true,
+		"GZIP":                true,
+		"deflate, gzip":       true,
+		"deflate":             false,
+		"*":                   true,
+		"gzip;q=0":            false,
+		"gzip; q=0.000":       false,
+		"gzip;q=0.5, deflate": true,
+	}
+	for h, expected := range testCases {
+		r, err := http.NewRequest(http.MethodPost, "/", nil)
+		require.NoError(t, err)
+		if h != "" {
+			r.Header.Set("Accept-Encoding", h)
+		}
+		require.Equal(t, expected, acceptsGzip(r), h)
+	}
+}
+
+func TestCompression(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	doRequest := func(t *testing.T, rpc string, gz bool) []byte {
+		req, err := http.NewRequest(http.MethodPost, httpSrv.URL, strings.NewReader(rpc))
+		require.NoError(t, err)
+		// Setting the header explicitly disables transparent decompression.
+		req.Header.Set("Accept-Encoding", "gzip")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		data, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		if !gz {
+			require.Equal(t, "", resp.Header.Get("Content-Encoding"))
+			return data
+		}
+		require.Equal(t, "gzip", resp.Header.Get("Content-Encoding"))
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		require.NoError(t, err)
+		data, err = ioutil.ReadAll(r)
+		require.NoError(t, err)
+		return data
+	}
+	blockRPC := `{"jsonrpc": "2.0", "id": 1, "method": "getblock", "params": [1, 1]}`
+	countRPC := `{"jsonrpc": "2.0", "id": 1, "method": "getblockcount", "params": []}`
+
+	expected := doRequest(t, blockRPC, false)
+	require.True(t, len(expected) > defaultCompressionMinSize)
+
+	rpcSrv.config.Compression.Enabled = true
+	require.Equal(t, expected, doRequest(t, blockRPC, true))
+	checkErrGetResult(t, doRequest(t, countRPC, false), false)
+
+	rpcSrv.config.Compression.MinSize = 1
+	checkErrGetResult(t, doRequest(t, countRPC, true), false)
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"encoding/base64"
+	"errors"
+
+	"github.com/nspcc-dev/neo-go/pkg/io"
+)
+
+// cursorVersion is the first byte of every encoded cursor, it allows to
+// change cursor format in future.
+const cursorVersion = 0
+
+// cursor is a position in a paged list returned by RPC methods. Clients get
+// it in the `next` field of the result and pass it back as is to get the
+// next page, so its encoding is opaque for them. Block and Index identify
+// the position in lists ordered by block (like transfer logs) with Index
+// being the number of items of this block already returned, Key is the last
+// returned key for lists ordered by key (like storage items).
+type cursor struct {
+	Block uint32
+	Index uint32
+	Key   []byte
+}
+
+// String returns URL-safe base64 encoding of the cursor.
+func (c *cursor) String() string {
+	w := io.NewBufBinWriter()
+	w.WriteB(cursorVersion)
+	w.WriteU32LE(c.Block)
+	w.WriteU32LE(c.Index)
+	w.WriteVarBytes(c.Key)
+	return base64.RawURLEncoding.EncodeToString(w.Bytes())
+}
+
+// parseCursor decodes the cursor from the string returned by String.
+func parseCursor(s string) (*cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.New("invalid cursor encoding")
+	}
+	r := io.NewBinReaderFromBuf(data)
+	c := new(cursor)
+	if v := r.ReadB(); r.Err == nil && v != cursorVersion {
+		return nil, errors.New("unsupported cursor version")
+	}
+	c.Block = r.ReadU32LE()
+	c.Index = r.ReadU32LE()
+	c.Key = r.ReadVarBytes()
+	if r.Err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	if r.ReadB(); r.Err == nil {
+		return nil, errors.New("invalid cursor: trailing data")
+	}
+	return c, nil
+}
@@ -0,0 +1,28 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	for _, c := range []*cursor{
+		{},
+		{Block: 150, Index: 1},
+		{Key: []byte{1, 2, 3}},
+	} {
+		actual, err := parseCursor(c.String())
+		require.NoError(t, err)
+		if c.Key == nil {
+			c.Key = []byte{}
+		}
+		require.Equal(t, c, actual)
+	}
+	require.Equal(t, "AJYAAAABAAAAAA", (&cursor{Block: 150, Index: 1}).String())
+
+	for _, s := range []string{"", "bad!", "AJYAAAABAAAA", "AJYAAAABAAAAAAA", "AZYAAAABAAAAAA"} {
+		_, err := parseCursor(s)
+		require.Error(t, err, s)
+	}
+}
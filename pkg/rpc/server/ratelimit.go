@@ -0,0 +1,129 @@
+package server
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/rpc"
+)
+
+type (
+	// rateLimiter is a per-client token bucket request rate limiter. Every
+	// client has a bucket of Burst tokens refilled at RequestsPerSecond rate,
+	// every request takes the number of tokens equal to its method weight.
+	rateLimiter struct {
+		rate    float64
+		burst   float64
+		weights map[string]int
+
+		lock        sync.Mutex
+		buckets     map[string]*tokenBucket
+		lastCleanup time.Time
+	}
+
+	// tokenBucket is a state of the single client bucket.
+	tokenBucket struct {
+		tokens  float64
+		updated time.Time
+	}
+)
+
+// newRateLimiter returns a new rate limiter for the given configuration or nil
+// if rate limiting is disabled.
+func newRateLimiter(cfg rpc.RateLimitConfig) *rateLimiter {
+	if !cfg.Enabled || cfg.RequestsPerSecond <= 0 {
+		return nil
+	}
+	burst := cfg.Burst
+	if burst <= 0 {
+		burst = int(cfg.RequestsPerSecond)
+		if burst < 1 {
+			burst = 1
+		}
+	}
+	return &rateLimiter{
+		rate:        cfg.RequestsPerSecond,
+		burst:       float64(burst),
+		weights:     cfg.MethodWeights,
+		buckets:     make(map[string]*tokenBucket),
+		lastCleanup: time.Now(),
+	}
+}
+
+// allow checks whether the client with the given address can make a call to
+// the given method now and takes tokens for it from the client's bucket.
+func (l *rateLimiter) allow(addr string, method string, now time.Time) bool {
+	weight := 1.0
+	if w, ok := l.weights[method]; ok && w >= 0 {
+		weight = float64(w)
+	}
+	// Requests heavier than the bucket can't be made otherwise.
+	if weight > l.burst {
+		weight = l.burst
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.cleanup(now)
+	b, ok := l.buckets[addr]
+	if !ok {
+		b = &tokenBucket{tokens: l.burst, updated: now}
+		l.buckets[addr] = b
+	} else {
+		b.refill(now, l.rate, l.burst)
+	}
+	if b.tokens < weight {
+		return false
+	}
+	b.tokens -= weight
+	return true
+}
+
+// cleanup drops buckets that are full already (it's done no more often than
+// it takes to fill an empty bucket), so that the number of buckets doesn't
+// grow indefinitely.
+func (l *rateLimiter) cleanup(now time.Time) {
+	if now.Sub(l.lastCleanup).Seconds() < l.burst/l.rate {
+		return
+	}
+	for addr, b := range l.buckets {
+		b.refill(now, l.rate, l.burst)
+		if b.tokens >= l.burst {
+			delete(l.buckets, addr)
+		}
+	}
+	l.lastCleanup = now
+}
+
+// refill adds tokens accumulated since the last update to the bucket.
+func (b *tokenBucket) refill(now time.Time, rate, burst float64) {
+	if elapsed := now.Sub(b.updated).Seconds(); elapsed > 0 {
+		b.tokens += elapsed * rate
+		if b.tokens > burst {
+			b.tokens = burst
+		}
+		b.updated = now
+	}
+}
+
+// clientAddr returns client IP address for the given request. If trustProxy
+// is set, the last address from X-Forwarded-For header (which is the one
+// added by the proxy) is used if it's present.
+func clientAddr(r *http.Request, trustProxy bool) string {
+	if trustProxy {
+		if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+			addrs := strings.Split(fwd, ",")
+			if addr := strings.TrimSpace(addrs[len(addrs)-1]); addr != "" {
+				return addr
+			}
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
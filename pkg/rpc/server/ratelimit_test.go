@@ -0,0 +1,56 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	require.Nil(t, newRateLimiter(rpc.RateLimitConfig{}))
+	require.Nil(t, newRateLimiter(rpc.RateLimitConfig{Enabled: true}))
+
+	l := newRateLimiter(rpc.RateLimitConfig{
+		Enabled:           true,
+		RequestsPerSecond: 2,
+		Burst:             4,
+		MethodWeights:     map[string]int{"invokescript": 3, "getversion": 0, "huge": 10},
+	})
+	now := time.Now()
+	require.True(t, l.allow("a", "invokescript", now))
+	require.True(t, l.allow("a", "getblockcount", now))
+	require.False(t, l.allow("a", "getblockcount", now))
+	require.True(t, l.allow("a", "getversion", now))
+	// Other clients have their own buckets.
+	require.True(t, l.allow("b", "invokescript", now))
+
+	now = now.Add(500 * time.Millisecond)
+	require.True(t, l.allow("a", "getblockcount", now))
+	require.False(t, l.allow("a", "getblockcount", now))
+
+	// Bucket never exceeds burst and heavy requests take all of it.
+	now = now.Add(time.Hour)
+	require.True(t, l.allow("a", "huge", now))
+	require.False(t, l.allow("a", "getblockcount", now))
+
+	// Full buckets are dropped.
+	now = now.Add(time.Hour)
+	require.True(t, l.allow("c", "getblockcount", now))
+	require.Equal(t, 1, len(l.buckets))
+}
+
+func TestClientAddr(t *testing.T) {
+	r := &http.Request{RemoteAddr: "10.0.0.1:12345", Header: http.Header{}}
+	require.Equal(t, "10.0.0.1", clientAddr(r, false))
+	require.Equal(t, "10.0.0.1", clientAddr(r, true))
+
+	r.Header.Set("X-Forwarded-For", "1.1.1.1, 2.2.2.2")
+	require.Equal(t, "10.0.0.1", clientAddr(r, false))
+	require.Equal(t, "2.2.2.2", clientAddr(r, true))
+
+	r.RemoteAddr = "pipe"
+	require.Equal(t, "pipe", clientAddr(r, false))
+}
@@ -0,0 +1,204 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gorilla/websocket"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"go.uber.org/zap"
+)
+
+// maxReplayBlocks is the maximum number of blocks events can be replayed for
+// upon subscription.
+const maxReplayBlocks = 100
+
+// canReplay checks whether past events of the given type can be replayed
+// (they're the ones stored in blocks and application logs).
+func canReplay(event response.EventID) bool {
+	switch event {
+	case response.BlockEventID, response.TransactionEventID,
+		response.NotificationEventID, response.ExecutionEventID:
+		return true
+	}
+	return false
+}
+
+// replayEvents sends past events to the subscriber for all of its feeds
+// waiting for replay and activates these feeds. It's called after the
+// subscription response is sent, so replayed events always follow it. Blocks
+// are read without s.subsLock held, so live events are still dispatched to
+// other subscribers during replay. Feeds waiting for replay don't get live
+// events, they're activated under the lock only when replay catches up with
+// the chain, so there are no gaps between replayed and live events, but
+// events of the latest block can be delivered twice.
+func (s *Server) replayEvents(sub *subscriber) {
+	var (
+		from    uint32
+		pending []*feed
+	)
+	// Feeds are only changed by the subscriber's own reader routine, which
+	// is the one calling replayEvents, so they can be read without the lock.
+	for i := range sub.feeds {
+		f := &sub.feeds[i]
+		if f.replayFrom == nil {
+			continue
+		}
+		if len(pending) == 0 || *f.replayFrom < from {
+			from = *f.replayFrom
+		}
+		pending = append(pending, f)
+	}
+	if len(pending) == 0 {
+		return
+	}
+	for {
+		height := s.chain.BlockHeight()
+		ok := s.replayBlocks(sub, pending, from, height)
+		s.subsLock.Lock()
+		if !ok || s.chain.BlockHeight() == height {
+			for _, f := range pending {
+				f.replayFrom = nil
+			}
+			s.subsLock.Unlock()
+			return
+		}
+		s.subsLock.Unlock()
+		// Events of new blocks were not dispatched to pending feeds.
+		from = height + 1
+	}
+}
+
+// replayBlocks sends events of blocks in the [from, to] range to the
+// subscriber. It returns false if replay can't be continued.
+func (s *Server) replayBlocks(sub *subscriber, feeds []*feed, from uint32, to uint32) bool {
+	for i := from; i <= to; i++ {
+		b, err := s.chain.GetBlock(s.chain.GetHeaderHash(int(i)))
+		if err != nil {
+			s.log.Warn("failed to replay events", zap.Uint32("block", i), zap.Error(err))
+			return false
+		}
+		events, err := s.getBlockEvents(b)
+		if err != nil {
+			s.log.Warn("failed to replay events", zap.Uint32("block", i), zap.Error(err))
+			return false
+		}
+		for j := range events {
+			if !s.replayEvent(sub, feeds, b.Index, &events[j]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// replayEvent sends single event to the subscriber if it matches any of the
+// given feeds. It returns false if the subscriber's buffer is overflown and
+// no more events can be sent to it.
+func (s *Server) replayEvent(sub *subscriber, feeds []*feed, index uint32, resp *response.Notification) bool {
+	for _, f := range feeds {
+		if *f.replayFrom > index || !f.Matches(resp) {
+			continue
+		}
+		msg, err := prepareNotification(resp)
+		if err != nil {
+			s.log.Error("failed to prepare notification message",
+				zap.Error(err),
+				zap.String("type", resp.Event.String()))
+			return false
+		}
+		select {
+		case sub.writer <- msg:
+		default:
+			overflowMsg, err := prepareNotification(&response.Notification{
+				JSONRPC: request.JSONRPCVersion,
+				Event:   response.MissedEventID,
+				Payload: make([]interface{}, 0),
+			})
+			if err != nil {
+				s.log.Error("failed to prepare overflow message", zap.Error(err))
+				return false
+			}
+			sub.overflown.Store(true)
+			// MissedEvent is to be delivered eventually.
+			go func(sub *subscriber) {
+				sub.writer <- overflowMsg
+				sub.overflown.Store(false)
+			}(sub)
+			return false
+		}
+		// The message is sent only once per subscriber.
+		break
+	}
+	return true
+}
+
+// getBlockEvents returns all events generated for the given block in the same
+// order they're announced by the chain.
+func (s *Server) getBlockEvents(b *block.Block) ([]response.Notification, error) {
+	var events []response.Notification
+	add := func(e response.EventID, payload interface{}) {
+		events = append(events, response.Notification{
+			JSONRPC: request.JSONRPCVersion,
+			Event:   e,
+			Payload: []interface{}{payload},
+		})
+	}
+	addExecution := func(aer *state.AppExecResult) {
+		add(response.ExecutionEventID, aer)
+		if aer.VMState == vm.HaltState {
+			for i := range aer.Events {
+				add(response.NotificationEventID, &aer.Events[i])
+			}
+		}
+	}
+	getAppExecResult := func(container util.Uint256, aers []state.AppExecResult, err error) (*state.AppExecResult, error) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to get application log for %s: %w", container.StringLE(), err)
+		}
+		if len(aers) == 0 {
+			return nil, fmt.Errorf("no application log for %s", container.StringLE())
+		}
+		return &aers[0], nil
+	}
+
+	h := b.Hash()
+	aers, err := s.chain.GetAppExecResults(h, trigger.OnPersist)
+	aer, err := getAppExecResult(h, aers, err)
+	if err != nil {
+		return nil, err
+	}
+	addExecution(aer)
+	for _, tx := range b.Transactions {
+		aers, err := s.chain.GetAppExecResults(tx.Hash(), trigger.Application)
+		aer, err := getAppExecResult(tx.Hash(), aers, err)
+		if err != nil {
+			return nil, err
+		}
+		addExecution(aer)
+		add(response.TransactionEventID, tx)
+	}
+	aers, err = s.chain.GetAppExecResults(h, trigger.PostPersist)
+	aer, err = getAppExecResult(h, aers, err)
+	if err != nil {
+		return nil, err
+	}
+	addExecution(aer)
+	add(response.BlockEventID, b)
+	return events, nil
+}
+
+// prepareNotification marshals the given notification into websocket message.
+func prepareNotification(resp *response.Notification) (*websocket.PreparedMessage, error) {
+	b, err := json.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+	return websocket.NewPreparedMessage(websocket.TextMessage, b)
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"go.uber.org/zap"
+)
+
+// maxRESTBodySize is the maximum size of REST request body (which is a
+// transaction), it's enough for any valid base64-encoded transaction.
+const maxRESTBodySize = 256 * 1024
+
+// restRoute maps REST request to JSON-RPC method and its parameters. It returns
+// false if the request doesn't match any route.
+func restRoute(r *http.Request) (string, []interface{}, bool) {
+	path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case r.Method == http.MethodGet && len(path) == 2 && path[0] == "blocks":
+		// Both block index and hash are accepted by getblock.
+		if index, err := strconv.Atoi(path[1]); err == nil {
+			return "getblock", []interface{}{index, 1}, true
+		}
+		return "getblock", []interface{}{path[1], 1}, true
+	case r.Method == http.MethodGet && len(path) == 2 && path[0] == "transactions":
+		return "getrawtransaction", []interface{}{path[1], 1}, true
+	case r.Method == http.MethodGet && len(path) == 3 && path[0] == "accounts" && path[2] == "nep17balances":
+		return "getnep17balances", []interface{}{path[1]}, true
+	case r.Method == http.MethodPost && len(path) == 1 && path[0] == "transactions":
+		return "sendrawtransaction", nil, true
+	}
+	return "", nil, false
+}
+
+// handleREST handles REST gateway requests mapping them onto JSON-RPC methods.
+// Successful results are returned as is (without JSON-RPC envelope), errors
+// are returned as JSON-RPC error objects. It returns false if the request is
+// not a REST one.
+func (s *Server) handleREST(w http.ResponseWriter, r *http.Request, client *clientInfo) bool {
+	method, params, ok := restRoute(r)
+	if !ok {
+		return false
+	}
+	if method == "sendrawtransaction" {
+		tx, err := readRESTTransaction(w, r)
+		if err != nil {
+			s.writeRESTResponse(w, method, response.Abstract{HeaderAndError: response.HeaderAndError{
+				Error: response.NewInvalidParamsError("can't read transaction", err),
+			}})
+			return true
+		}
+		params = []interface{}{tx}
+	}
+	rawParams, err := json.Marshal(params)
+	if err != nil {
+		s.writeRESTResponse(w, method, response.Abstract{HeaderAndError: response.HeaderAndError{
+			Error: response.NewInternalServerError("can't marshal parameters", err),
+		}})
+		return true
+	}
+	in := request.NewIn()
+	in.Method = method
+	in.RawParams = rawParams
+	s.writeRESTResponse(w, method, s.handleIn(in, nil, client))
+	return true
+}
+
+// readRESTTransaction returns base64-encoded transaction from the request body
+// which is either a raw transaction (for application/octet-stream content type)
+// or a base64-encoded one.
+func readRESTTransaction(w http.ResponseWriter, r *http.Request) (string, error) {
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxRESTBodySize))
+	if err != nil {
+		return "", err
+	}
+	if r.Header.Get("Content-Type") == "application/octet-stream" {
+		return base64.StdEncoding.EncodeToString(body), nil
+	}
+	tx := strings.TrimSpace(string(body))
+	if tx == "" {
+		return "", errors.New("empty request body")
+	}
+	return tx, nil
+}
+
+// writeRESTResponse writes REST gateway response, unknown entities are
+// reported with 404 status code.
+func (s *Server) writeRESTResponse(w http.ResponseWriter, method string, resp response.Abstract) {
+	var data interface{} = resp.Result
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if resp.Error != nil {
+		s.log.Error("Error encountered with REST request",
+			zap.String("method", method),
+			zap.Error(resp.Error.Cause))
+		code := resp.Error.HTTPCode
+		if errors.Is(resp.Error, response.ErrNotFound) {
+			code = http.StatusNotFound
+		}
+		w.WriteHeader(code)
+		data = resp.Error
+	}
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		s.log.Error("Error encountered while encoding REST response",
+			zap.String("err", err.Error()),
+			zap.String("method", method))
+	}
+}
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/testchain"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/stretchr/testify/require"
+)
+
+func TestREST(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	doREST := func(t *testing.T, method string, path string, body string, code int) []byte {
+		req, err := http.NewRequest(method, httpSrv.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, code, resp.StatusCode)
+		data, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return data
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		doREST(t, http.MethodGet, "/blocks/1", "", http.StatusUnprocessableEntity)
+	})
+	rpcSrv.config.REST.Enabled = true
+
+	t.Run("block", func(t *testing.T) {
+		expected, err := chain.GetBlock(chain.GetHeaderHash(1))
+		require.NoError(t, err)
+		for _, id := range []string{"1", expected.Hash().StringLE()} {
+			data := doREST(t, http.MethodGet, "/blocks/"+id, "", http.StatusOK)
+			res := new(result.Block)
+			res.Network = testchain.Network()
+			require.NoError(t, json.Unmarshal(data, res))
+			require.Equal(t, expected.Hash(), res.Hash())
+			require.Equal(t, uint32(1), res.Index)
+		}
+	})
+	t.Run("unknown block", func(t *testing.T) {
+		data := doREST(t, http.MethodGet, "/blocks/100500", "", http.StatusNotFound)
+		res := new(response.Error)
+		require.NoError(t, json.Unmarshal(data, res))
+		require.Equal(t, response.ErrNotFound.Code, res.Code)
+	})
+	t.Run("transaction", func(t *testing.T) {
+		b, err := chain.GetBlock(chain.GetHeaderHash(1))
+		require.NoError(t, err)
+		tx := b.Transactions[0]
+		data := doREST(t, http.MethodGet, "/transactions/"+tx.Hash().StringLE(), "", http.StatusOK)
+		res := result.TransactionOutputRaw{Transaction: transaction.Transaction{Network: testchain.Network()}}
+		require.NoError(t, json.Unmarshal(data, &res))
+		require.Equal(t, tx.Hash(), res.Hash())
+		require.Equal(t, b.Hash(), res.Blockhash)
+	})
+	t.Run("nep17balances", func(t *testing.T) {
+		addr := testchain.PrivateKeyByID(0).Address()
+		data := doREST(t, http.MethodGet, "/accounts/"+addr+"/nep17balances", "", http.StatusOK)
+		res := new(result.NEP17Balances)
+		require.NoError(t, json.Unmarshal(data, res))
+		require.Equal(t, addr, res.Address)
+		require.NotEqual(t, 0, len(res.Balances))
+	})
+	t.Run("send invalid transaction", func(t *testing.T) {
+		doREST(t, http.MethodPost, "/transactions", "", http.StatusUnprocessableEntity)
+		doREST(t, http.MethodPost, "/transactions", "notabase64", http.StatusUnprocessableEntity)
+	})
+	t.Run("JSON-RPC", func(t *testing.T) {
+		rpc := `{"jsonrpc": "2.0", "id": 1, "method": "getblockcount", "params": []}`
+		body := doRPCCallOverHTTP(rpc, httpSrv.URL, t)
+		checkErrGetResult(t, body, false)
+	})
+}
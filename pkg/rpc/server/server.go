@@ -1,9 +1,12 @@
 package server
 
 import (
+	"bytes"
 	"context"
 	"crypto/elliptic"
+	"crypto/tls"
 	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -11,7 +14,10 @@ import (
 	"math/big"
 	"net"
 	"net/http"
+	"net/url"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -20,8 +26,14 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/blockchainer"
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
+	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
 	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/core/native"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
@@ -33,11 +45,13 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/nspcc-dev/neo-go/pkg/services/finality"
 	"github.com/nspcc-dev/neo-go/pkg/services/oracle"
 	"github.com/nspcc-dev/neo-go/pkg/services/oracle/broadcaster"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"go.uber.org/zap"
 )
@@ -52,10 +66,18 @@ type (
 		stateRootEnabled bool
 		coreServer       *network.Server
 		oracle           *oracle.Oracle
+		finality         *finality.Watcher
+		limiter          *rateLimiter
+		inFlight         chan struct{}
+		audit            *auditLog
 		log              *zap.Logger
 		https            *http.Server
+		tlsCerts         *certReloader
 		shutdown         chan struct{}
 
+		sessionsLock sync.Mutex
+		sessions     map[string]*session
+
 		subsLock         sync.RWMutex
 		subscribers      map[*subscriber]bool
 		subsGroup        sync.WaitGroup
@@ -63,10 +85,14 @@ type (
 		executionSubs    int
 		notificationSubs int
 		transactionSubs  int
+		mempoolSubs      int
+		stateRootSubs    int
 		blockCh          chan *block.Block
 		executionCh      chan *state.AppExecResult
 		notificationCh   chan *state.NotificationEvent
 		transactionCh    chan *transaction.Transaction
+		mempoolCh        chan mempool.Event
+		stateRootCh      chan *state.MPTRoot
 	}
 )
 
@@ -88,11 +114,26 @@ const (
 	// connections.
 	maxSubscribers = 64
 
-	// Maximum number of elements for get*transfers requests.
-	maxTransfersLimit = 1000
+	// Default maximum number of elements for get*transfers requests.
+	defaultMaxTransfersLimit = 1000
+	// defaultMaxFindResultItems is the default number of storage items
+	// returned by findstates.
+	defaultMaxFindResultItems = 100
+	// defaultBatchConcurrency is the default number of batch requests
+	// processed concurrently.
+	defaultBatchConcurrency = 4
+	// defaultFeePercentile is the default percentile of pooled
+	// transactions fees returned by estimatefee.
+	defaultFeePercentile = 50
 )
 
 var rpcHandlers = map[string]func(*Server, request.Params) (interface{}, *response.Error){
+	"addwatched":             (*Server).addWatched,
+	"calculatenetworkfee":    (*Server).calculateNetworkFee,
+	"estimatefee":            (*Server).estimateFee,
+	"findstates":             (*Server).findStates,
+	"findstorage":            (*Server).findStorage,
+	"findtransactions":       (*Server).findTransactions,
 	"getapplicationlog":      (*Server).getApplicationLog,
 	"getbestblockhash":       (*Server).getBestBlockHash,
 	"getblock":               (*Server).getBlock,
@@ -101,16 +142,22 @@ var rpcHandlers = map[string]func(*Server, request.Params) (interface{}, *respon
 	"getblockheader":         (*Server).getBlockHeader,
 	"getblockheadercount":    (*Server).getBlockHeaderCount,
 	"getblocksysfee":         (*Server).getBlockSysFee,
+	"getcandidates":          (*Server).getCandidates,
 	"getcommittee":           (*Server).getCommittee,
 	"getconnectioncount":     (*Server).getConnectionCount,
 	"getcontractstate":       (*Server).getContractState,
+	"getdesignatedbyrole":    (*Server).getDesignatedByRole,
 	"getnativecontracts":     (*Server).getNativeContracts,
+	"getnativeprices":        (*Server).getNativePrices,
+	"getnep11balances":       (*Server).getNEP11Balances,
+	"getnep11transfers":      (*Server).getNEP11Transfers,
 	"getnep17balances":       (*Server).getNEP17Balances,
 	"getnep17transfers":      (*Server).getNEP17Transfers,
 	"getpeers":               (*Server).getPeers,
 	"getproof":               (*Server).getProof,
 	"getrawmempool":          (*Server).getRawMempool,
 	"getrawtransaction":      (*Server).getrawtransaction,
+	"getstate":               (*Server).getState,
 	"getstateheight":         (*Server).getStateHeight,
 	"getstateroot":           (*Server).getStateRoot,
 	"getstorage":             (*Server).getStorage,
@@ -118,20 +165,30 @@ var rpcHandlers = map[string]func(*Server, request.Params) (interface{}, *respon
 	"getunclaimedgas":        (*Server).getUnclaimedGas,
 	"getnextblockvalidators": (*Server).getNextBlockValidators,
 	"getversion":             (*Server).getVersion,
+	"getwatched":             (*Server).getWatched,
 	"invokefunction":         (*Server).invokeFunction,
+	"invokefunctionhistoric": (*Server).invokeFunctionHistoric,
 	"invokescript":           (*Server).invokescript,
+	"invokescripthistoric":   (*Server).invokeScriptHistoric,
 	"invokecontractverify":   (*Server).invokeContractVerify,
+	"removewatched":          (*Server).removeWatched,
 	"sendrawtransaction":     (*Server).sendrawtransaction,
+	"simulatescript":         (*Server).simulateScript,
 	"submitblock":            (*Server).submitBlock,
 	"submitnotaryrequest":    (*Server).submitNotaryRequest,
 	"submitoracleresponse":   (*Server).submitOracleResponse,
+	"terminatesession":       (*Server).terminateSession,
+	"traverseiterator":       (*Server).traverseIterator,
 	"validateaddress":        (*Server).validateAddress,
 	"verifyproof":            (*Server).verifyProof,
+	"watchtransaction":       (*Server).watchTransactionHTTP,
 }
 
 var rpcWsHandlers = map[string]func(*Server, request.Params, *subscriber) (interface{}, *response.Error){
-	"subscribe":   (*Server).subscribe,
-	"unsubscribe": (*Server).unsubscribe,
+	"subscribe":          (*Server).subscribe,
+	"unsubscribe":        (*Server).unsubscribe,
+	"watchtransaction":   (*Server).watchTransaction,
+	"unwatchtransaction": (*Server).unwatchTransaction,
 }
 
 var invalidBlockHeightError = func(index int, height int) *response.Error {
@@ -146,19 +203,39 @@ var upgrader = websocket.Upgrader{}
 func New(chain blockchainer.Blockchainer, conf rpc.Config, coreServer *network.Server,
 	orc *oracle.Oracle, log *zap.Logger) Server {
 	httpServer := &http.Server{
-		Addr: conf.Address + ":" + strconv.FormatUint(uint64(conf.Port), 10),
+		Addr:        conf.Address + ":" + strconv.FormatUint(uint64(conf.Port), 10),
+		ReadTimeout: conf.ReadTimeout,
 	}
 
-	var tlsServer *http.Server
+	var (
+		tlsServer *http.Server
+		tlsCerts  *certReloader
+	)
 	if cfg := conf.TLSConfig; cfg.Enabled {
+		tlsCerts = newCertReloader(cfg.CertFile, cfg.KeyFile)
 		tlsServer = &http.Server{
-			Addr: net.JoinHostPort(cfg.Address, strconv.FormatUint(uint64(cfg.Port), 10)),
+			Addr:        net.JoinHostPort(cfg.Address, strconv.FormatUint(uint64(cfg.Port), 10)),
+			ReadTimeout: conf.ReadTimeout,
+			TLSConfig:   &tls.Config{GetCertificate: tlsCerts.getCertificate},
 		}
 	}
 
 	if orc != nil {
 		orc.SetBroadcaster(broadcaster.New(orc.MainCfg, log))
 	}
+	var inFlight chan struct{}
+	if conf.MaxConcurrentRequests > 0 {
+		inFlight = make(chan struct{}, conf.MaxConcurrentRequests)
+	}
+	var fw *finality.Watcher
+	if conf.Enabled && conf.FinalityWatcher.Enabled {
+		fw = finality.New(finality.Config{
+			Chain:           chain,
+			MaxWatches:      conf.FinalityWatcher.MaxWatches,
+			CallbackTimeout: conf.FinalityWatcher.CallbackTimeout,
+			Log:             log,
+		})
+	}
 	return Server{
 		Server:           httpServer,
 		chain:            chain,
@@ -168,15 +245,23 @@ func New(chain blockchainer.Blockchainer, conf rpc.Config, coreServer *network.S
 		coreServer:       coreServer,
 		log:              log,
 		oracle:           orc,
+		finality:         fw,
+		limiter:          newRateLimiter(conf.RateLimit),
+		inFlight:         inFlight,
 		https:            tlsServer,
+		tlsCerts:         tlsCerts,
 		shutdown:         make(chan struct{}),
 
+		sessions: make(map[string]*session),
+
 		subscribers: make(map[*subscriber]bool),
 		// These are NOT buffered to preserve original order of events.
 		blockCh:        make(chan *block.Block),
 		executionCh:    make(chan *state.AppExecResult),
 		notificationCh: make(chan *state.NotificationEvent),
 		transactionCh:  make(chan *transaction.Transaction),
+		mempoolCh:      make(chan mempool.Event),
+		stateRootCh:    make(chan *state.MPTRoot),
 	}
 }
 
@@ -188,21 +273,40 @@ func (s *Server) Start(errChan chan error) {
 		s.log.Info("RPC server is not enabled")
 		return
 	}
+	if s.config.Audit.Enabled {
+		audit, err := newAuditLog(s.config.Audit)
+		if err != nil {
+			errChan <- err
+			return
+		}
+		s.audit = audit
+	}
 	s.Handler = http.HandlerFunc(s.handleHTTPRequest)
 	s.log.Info("starting rpc-server", zap.String("endpoint", s.Addr))
 
 	go s.handleSubEvents()
+	if s.finality != nil {
+		go s.finality.Run()
+	}
 	if cfg := s.config.TLSConfig; cfg.Enabled {
 		s.https.Handler = http.HandlerFunc(s.handleHTTPRequest)
 		s.log.Info("starting rpc-server (https)", zap.String("endpoint", s.https.Addr))
 		go func() {
+			if err := s.tlsCerts.reload(); err != nil {
+				errChan <- err
+				return
+			}
+			if cfg.ReloadInterval > 0 {
+				go s.watchTLSFiles(cfg.ReloadInterval)
+			}
 			ln, err := net.Listen("tcp", s.https.Addr)
 			if err != nil {
 				errChan <- err
 				return
 			}
 			s.https.Addr = ln.Addr().String()
-			err = s.https.ServeTLS(ln, cfg.CertFile, cfg.KeyFile)
+			// Certificate is provided by TLSConfig.GetCertificate.
+			err = s.https.ServeTLS(ln, "", "")
 			if err != http.ErrServerClosed {
 				s.log.Error("failed to start TLS RPC server", zap.Error(err))
 				errChan <- err
@@ -243,6 +347,16 @@ func (s *Server) Shutdown() error {
 	// Wait for handleSubEvents to finish.
 	<-s.executionCh
 
+	if s.finality != nil {
+		s.finality.Stop()
+	}
+	s.dropSessions()
+	if s.audit != nil {
+		if err := s.audit.close(); err != nil {
+			s.log.Warn("failed to close audit log", zap.Error(err))
+		}
+	}
+
 	if err == nil {
 		return httpsErr
 	}
@@ -250,7 +364,9 @@ func (s *Server) Shutdown() error {
 }
 
 func (s *Server) handleHTTPRequest(w http.ResponseWriter, httpRequest *http.Request) {
-	req := request.NewRequest()
+	req := s.newRequest()
+	client := s.newClientInfo(httpRequest)
+	s.setCORSHeaders(w, httpRequest)
 
 	if httpRequest.URL.Path == "/ws" && httpRequest.Method == "GET" {
 		// Technically there is a race between this check and
@@ -280,7 +396,28 @@ func (s *Server) handleHTTPRequest(w http.ResponseWriter, httpRequest *http.Requ
 		s.subscribers[subscr] = true
 		s.subsLock.Unlock()
 		go s.handleWsWrites(ws, resChan, subChan)
-		s.handleWsReads(ws, resChan, subscr)
+		s.handleWsReads(ws, resChan, subscr, client)
+		return
+	}
+
+	w, flush := s.newCompressingWriter(w, httpRequest)
+	defer flush()
+
+	if httpRequest.Method == "OPTIONS" {
+		s.handlePreflight(w, httpRequest)
+		return
+	}
+
+	if limit := s.config.MaxRequestBodySize; limit > 0 {
+		if httpRequest.ContentLength > limit {
+			s.writeHTTPErrorResponse(request.NewIn(), w, response.NewError(-32600, http.StatusRequestEntityTooLarge,
+				"Invalid Request", fmt.Sprintf("request body is bigger than %d bytes", limit), nil))
+			return
+		}
+		httpRequest.Body = http.MaxBytesReader(w, httpRequest.Body, limit)
+	}
+
+	if s.config.REST.Enabled && s.handleREST(w, httpRequest, client) {
 		return
 	}
 
@@ -301,22 +438,45 @@ func (s *Server) handleHTTPRequest(w http.ResponseWriter, httpRequest *http.Requ
 		return
 	}
 
-	resp := s.handleRequest(req, nil)
+	resp := s.handleRequest(req, nil, client)
 	s.writeHTTPServerResponse(req, w, resp)
 }
 
-func (s *Server) handleRequest(req *request.Request, sub *subscriber) response.AbstractResult {
+// handleRequest handles single or batch request from the given client.
+func (s *Server) handleRequest(req *request.Request, sub *subscriber, client *clientInfo) response.AbstractResult {
 	if req.In != nil {
-		return s.handleIn(req.In, sub)
+		return s.handleIn(req.In, sub, client)
 	}
 	resp := make(response.AbstractBatch, len(req.Batch))
-	for i, in := range req.Batch {
-		resp[i] = s.handleIn(&in, sub)
-	}
+	workers := s.config.BatchConcurrency
+	if workers <= 0 {
+		workers = defaultBatchConcurrency
+	}
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, workers)
+	)
+	for i := range req.Batch {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp[i] = s.handleIn(&req.Batch[i], sub, client)
+			<-sem
+		}(i)
+	}
+	wg.Wait()
 	return resp
 }
 
-func (s *Server) handleIn(req *request.In, sub *subscriber) response.Abstract {
+// newRequest creates a new request respecting configured batch size limit.
+func (s *Server) newRequest() *request.Request {
+	req := request.NewRequest()
+	req.MaxBatchSize = s.config.MaxBatchSize
+	return req
+}
+
+func (s *Server) handleIn(req *request.In, sub *subscriber, client *clientInfo) response.Abstract {
 	var res interface{}
 	var resErr *response.Error
 	if req.JSONRPC != request.JSONRPCVersion {
@@ -334,19 +494,61 @@ func (s *Server) handleIn(req *request.In, sub *subscriber) response.Abstract {
 
 	incCounter(req.Method)
 
+	if s.limiter != nil && !s.limiter.allow(client.addr, req.Method, time.Now()) {
+		return s.packResponse(req, nil, response.ErrLimitExceeded)
+	}
+	if !client.authorized && s.authRequired(req.Method) {
+		return s.packResponse(req, nil, response.ErrUnauthorized)
+	}
+	if s.inFlight != nil {
+		select {
+		case s.inFlight <- struct{}{}:
+			defer func() { <-s.inFlight }()
+		default:
+			return s.packResponse(req, nil, response.ErrServerBusy)
+		}
+	}
+
 	resErr = response.NewMethodNotFoundError(fmt.Sprintf("Method '%s' not supported", req.Method), nil)
-	handler, ok := rpcHandlers[req.Method]
-	if ok {
+	start := time.Now()
+	// Websocket handlers go first as some methods have extended websocket
+	// versions.
+	wsHandler, ok := rpcWsHandlers[req.Method]
+	if ok && sub != nil {
+		res, resErr = wsHandler(s, *reqParams, sub)
+	} else if handler, ok := rpcHandlers[req.Method]; ok {
 		res, resErr = handler(s, *reqParams)
-	} else if sub != nil {
-		handler, ok := rpcWsHandlers[req.Method]
-		if ok {
-			res, resErr = handler(s, *reqParams, sub)
-		}
+	}
+	if s.audit != nil && auditedMethods[req.Method] {
+		s.audit.record(req.Method, client.addr, s.getAuditHash(req.Method, *reqParams), resErr, time.Since(start))
+	}
+	if resErr == nil && s.config.CanonicalJSON {
+		res, resErr = canonicalResult(res)
 	}
 	return s.packResponse(req, res, resErr)
 }
 
+// canonicalResult converts invocation results and application logs to their
+// canonical JSON form, other results are returned as is.
+func canonicalResult(res interface{}) (interface{}, *response.Error) {
+	var (
+		data []byte
+		err  error
+	)
+	switch r := res.(type) {
+	case *result.Invoke:
+		data, err = r.MarshalCanonicalJSON()
+	case result.ApplicationLog:
+		data, err = r.MarshalCanonicalJSON()
+	default:
+		return res, nil
+	}
+	if err != nil {
+		return nil, response.NewInternalServerError("can't marshal result", err)
+	}
+	return json.RawMessage(data), nil
+}
+
 func (s *Server) handleWsWrites(ws *websocket.Conn, resChan <-chan response.AbstractResult, subChan <-chan *websocket.PreparedMessage) {
 	pingTicker := time.NewTicker(wsPingPeriod)
 eventloop:
@@ -394,18 +596,18 @@ drainloop:
 	}
 }
 
-func (s *Server) handleWsReads(ws *websocket.Conn, resChan chan<- response.AbstractResult, subscr *subscriber) {
+func (s *Server) handleWsReads(ws *websocket.Conn, resChan chan<- response.AbstractResult, subscr *subscriber, client *clientInfo) {
 	ws.SetReadLimit(wsReadLimit)
 	ws.SetReadDeadline(time.Now().Add(wsPongLimit))
 	ws.SetPongHandler(func(string) error { ws.SetReadDeadline(time.Now().Add(wsPongLimit)); return nil })
 requestloop:
 	for {
-		req := request.NewRequest()
+		req := s.newRequest()
 		err := ws.ReadJSON(req)
 		if err != nil {
 			break
 		}
-		res := s.handleRequest(req, subscr)
+		res := s.handleRequest(req, subscr, client)
 		res.RunForErrors(func(jsonErr *response.Error) {
 			s.logRequestError(req, jsonErr)
 		})
@@ -414,6 +616,8 @@ requestloop:
 			break requestloop
 		case resChan <- res:
 		}
+		// Replayed events must follow subscription response.
+		s.replayEvents(subscr)
 
 	}
 	s.subsLock.Lock()
@@ -424,6 +628,9 @@ requestloop:
 		}
 	}
 	s.subsLock.Unlock()
+	if s.finality != nil {
+		s.finality.Unwatch(subscr, nil)
+	}
 	close(resChan)
 	ws.Close()
 }
@@ -479,7 +686,7 @@ func (s *Server) getBlock(reqParams request.Params) (interface{}, *response.Erro
 
 	block, err := s.chain.GetBlock(hash)
 	if err != nil {
-		return nil, response.NewInternalServerError(fmt.Sprintf("Problem locating block with hash: %s", hash), err)
+		return nil, response.NewRPCError("Unknown block", fmt.Sprintf("Problem locating block with hash: %s", hash), err)
 	}
 
 	if reqParams.Value(1).GetBoolean() {
@@ -516,21 +723,56 @@ func (s *Server) getVersion(_ request.Params) (interface{}, *response.Error) {
 	}, nil
 }
 
-func (s *Server) getPeers(_ request.Params) (interface{}, *response.Error) {
+func (s *Server) getPeers(reqParams request.Params) (interface{}, *response.Error) {
 	peers := result.NewGetPeers()
 	peers.AddUnconnected(s.coreServer.UnconnectedPeers())
-	peers.AddConnected(s.coreServer.ConnectedPeers())
+	if reqParams.Value(0).GetBoolean() {
+		peers.AddConnectedVerbose(newVerbosePeers(s.coreServer.ConnectedPeersInfo()))
+	} else {
+		peers.AddConnected(s.coreServer.ConnectedPeers())
+	}
 	peers.AddBad(s.coreServer.BadPeers())
 	return peers, nil
 }
 
+// newVerbosePeers converts network peers information to getpeers RPC format.
+func newVerbosePeers(infos []network.PeerInfo) []result.Peer {
+	peers := make([]result.Peer, 0, len(infos))
+	for i := range infos {
+		host, port, _ := net.SplitHostPort(infos[i].Address)
+		height := infos[i].Height
+		inbound := infos[i].Inbound
+		p := result.Peer{
+			Address:      host,
+			Port:         port,
+			UserAgent:    infos[i].UserAgent,
+			Height:       &height,
+			Capabilities: result.NewPeerCapabilities(infos[i].Capabilities),
+			Inbound:      &inbound,
+		}
+		sent, received := infos[i].Traffic.ByName()
+		p.Traffic = &result.PeerTraffic{Sent: sent, Received: received}
+		if !infos[i].LastMessage.IsZero() {
+			lastSeen := infos[i].LastMessage.UnixNano() / int64(time.Millisecond)
+			p.LastSeen = &lastSeen
+		}
+		peers = append(peers, p)
+	}
+	return peers
+}
+
 func (s *Server) getRawMempool(reqParams request.Params) (interface{}, *response.Error) {
 	verbose := reqParams.Value(0).GetBoolean()
+	details := reqParams.Value(1).GetBoolean()
 	mp := s.chain.GetMemPool()
-	hashList := make([]util.Uint256, 0)
-	for _, item := range mp.GetVerifiedTransactions() {
-		hashList = append(hashList, item.Hash())
+	if details {
+		return s.getRawMempoolDetails(mp.GetVerifiedTransactionsWithStamps()), nil
 	}
+	hashList := make([]util.Uint256, 0, mp.Count())
+	mp.IterateVerifiedTransactions(func(tx *transaction.Transaction) bool {
+		hashList = append(hashList, tx.Hash())
+		return true
+	})
 	if !verbose {
 		return hashList, nil
 	}
@@ -540,6 +782,54 @@ func (s *Server) getRawMempool(reqParams request.Params) (interface{}, *response
 	}, nil
 }
 
+// estimateFee returns fee per byte estimation based on the memory pool contents:
+// the given percentile (median by default) of pooled transactions fees per
+// byte, but not less than the Policy contract value.
+func (s *Server) estimateFee(reqParams request.Params) (interface{}, *response.Error) {
+	percentile := defaultFeePercentile
+	if p := reqParams.Value(0); !p.IsNull() {
+		var err error
+		percentile, err = p.GetInt()
+		if err != nil {
+			return nil, response.ErrInvalidParams
+		}
+	}
+	mp := s.chain.GetMemPool()
+	feePerByte, err := mp.EstimateFee(percentile)
+	if err != nil {
+		return nil, response.NewInvalidParamsError(err.Error(), err)
+	}
+	if min := s.chain.FeePerByte(); feePerByte < min {
+		feePerByte = min
+	}
+	return result.FeeEstimate{
+		FeePerByte:  feePerByte,
+		MempoolSize: mp.Count(),
+	}, nil
+}
+
+// getRawMempoolDetails returns verbose getrawmempool result with fee details
+// of the given pooled transactions.
+func (s *Server) getRawMempoolDetails(txes []mempool.TxWithStamp) result.RawMempool {
+	res := result.RawMempool{
+		Height:       s.chain.BlockHeight(),
+		Verified:     make([]util.Uint256, len(txes)),
+		Transactions: make([]result.MempoolTransaction, len(txes)),
+	}
+	for i, item := range txes {
+		res.Verified[i] = item.Tx.Hash()
+		res.Transactions[i] = result.MempoolTransaction{
+			Hash:       item.Tx.Hash(),
+			Sender:     address.Uint160ToString(item.Tx.Sender()),
+			SystemFee:  item.Tx.SystemFee,
+			NetworkFee: item.Tx.NetworkFee,
+			FeePerByte: item.Tx.FeePerByte(),
+			BlockStamp: item.BlockStamp,
+		}
+	}
+	return res
+}
+
 func (s *Server) validateAddress(reqParams request.Params) (interface{}, *response.Error) {
 	param := reqParams.Value(0)
 	if param == nil {
@@ -548,7 +838,8 @@ func (s *Server) validateAddress(reqParams request.Params) (interface{}, *respon
 	return validateAddress(param.Value), nil
 }
 
-// getApplicationLog returns the contract log based on the specified txid or blockid.
+// getApplicationLog returns the contract log based on the specified txid or blockid,
+// optionally filtered by trigger and notification name.
 func (s *Server) getApplicationLog(reqParams request.Params) (interface{}, *response.Error) {
 	hash, err := reqParams.Value(0).GetUint256()
 	if err != nil {
@@ -556,7 +847,7 @@ func (s *Server) getApplicationLog(reqParams request.Params) (interface{}, *resp
 	}
 
 	trig := trigger.All
-	if len(reqParams) > 1 {
+	if p := reqParams.Value(1); !p.IsNull() {
 		trigString := reqParams.ValueWithType(1, request.StringT)
 		if trigString == nil {
 			return nil, response.ErrInvalidParams
@@ -566,12 +857,87 @@ func (s *Server) getApplicationLog(reqParams request.Params) (interface{}, *resp
 			return nil, response.ErrInvalidParams
 		}
 	}
+	var eventName *string
+	if p := reqParams.Value(2); !p.IsNull() {
+		name, err := p.GetString()
+		if err != nil {
+			return nil, response.NewInvalidParamsError("invalid event name", err)
+		}
+		eventName = &name
+	}
 
 	appExecResults, err := s.chain.GetAppExecResults(hash, trigger.All)
 	if err != nil {
 		return nil, response.NewRPCError("Unknown transaction or block", "", err)
 	}
-	return result.NewApplicationLog(hash, appExecResults, trig), nil
+	appLog := result.NewApplicationLog(hash, appExecResults, trig)
+	if eventName != nil {
+		appLog.FilterEvents(*eventName)
+	}
+	return appLog, nil
+}
+
+// checkTracked returns an error if balances and transfers of the account are
+// not tracked by the node, that's the case for accounts that are not watched
+// in light wallet mode.
+func (s *Server) checkTracked(u util.Uint160) *response.Error {
+	if s.chain.IsTracked(u) {
+		return nil
+	}
+	return response.NewRPCError("Account is not watched",
+		fmt.Sprintf("%s is not watched in light wallet mode", address.Uint160ToString(u)), nil)
+}
+
+// getWatched returns the list of addresses watched in light wallet mode.
+func (s *Server) getWatched(_ request.Params) (interface{}, *response.Error) {
+	hashes, err := s.chain.GetWatched()
+	if err != nil {
+		return nil, response.NewRPCError("Light wallet mode is disabled", "", err)
+	}
+	res := make([]string, len(hashes))
+	for i := range hashes {
+		res[i] = address.Uint160ToString(hashes[i])
+	}
+	return res, nil
+}
+
+// addWatched adds addresses or script hashes given to the set watched in
+// light wallet mode.
+func (s *Server) addWatched(ps request.Params) (interface{}, *response.Error) {
+	return s.updateWatched("addwatched", ps, s.chain.AddWatched)
+}
+
+// removeWatched removes addresses or script hashes given from the set watched
+// in light wallet mode.
+func (s *Server) removeWatched(ps request.Params) (interface{}, *response.Error) {
+	return s.updateWatched("removewatched", ps, s.chain.RemoveWatched)
+}
+
+// updateWatched parses addresses or script hashes from parameters and updates
+// watch list with them using f. Watch list can only be changed if the method
+// requires authentication.
+func (s *Server) updateWatched(method string, ps request.Params, f func(...util.Uint160) error) (interface{}, *response.Error) {
+	if !s.authRequired(method) {
+		return nil, response.NewRPCError("Method is disabled", fmt.Sprintf("%s requires authentication to be enabled for it", method), nil)
+	}
+	if len(ps) == 0 {
+		return nil, response.ErrInvalidParams
+	}
+	hashes := make([]util.Uint160, len(ps))
+	for i := range ps {
+		var err error
+		hashes[i], err = ps[i].GetUint160FromAddressOrHex()
+		if err != nil {
+			return nil, response.NewInvalidParamsError(fmt.Sprintf("invalid address or script hash #%d", i), err)
+		}
+	}
+	if err := f(hashes...); err != nil {
+		if errors.Is(err, core.ErrLightWalletDisabled) {
+			return nil, response.NewRPCError("Light wallet mode is disabled", "", err)
+		}
+		return nil, response.NewInternalServerError("can't update watch list", err)
+	}
+	return true, nil
 }
 
 func (s *Server) getNEP17Balances(ps request.Params) (interface{}, *response.Error) {
@@ -579,6 +945,9 @@ func (s *Server) getNEP17Balances(ps request.Params) (interface{}, *response.Err
 	if err != nil {
 		return nil, response.ErrInvalidParams
 	}
+	if rerr := s.checkTracked(u); rerr != nil {
+		return nil, rerr
+	}
 
 	as := s.chain.GetNEP17Balances(u)
 	bs := &result.NEP17Balances{
@@ -602,36 +971,98 @@ func (s *Server) getNEP17Balances(ps request.Params) (interface{}, *response.Err
 	return bs, nil
 }
 
-func getTimestampsAndLimit(ps request.Params, index int) (uint64, uint64, int, int, error) {
-	var start, end uint64
+func (s *Server) getNEP11Balances(ps request.Params) (interface{}, *response.Error) {
+	u, err := ps.Value(0).GetUint160FromAddressOrHex()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	if rerr := s.checkTracked(u); rerr != nil {
+		return nil, rerr
+	}
+
+	as := s.chain.GetNEP11Balances(u)
+	bs := &result.NEP11Balances{
+		Address:  address.Uint160ToString(u),
+		Balances: []result.NEP11AssetBalance{},
+	}
+	if as != nil {
+		cache := make(map[int32]util.Uint160)
+		for id, tokens := range as.Trackers {
+			h, err := s.getHash(id, cache)
+			if err != nil {
+				continue
+			}
+			asset := result.NEP11AssetBalance{
+				Asset:  h,
+				Tokens: make([]result.NEP11TokenBalance, 0, len(tokens)),
+			}
+			for tokenID, bal := range tokens {
+				asset.Tokens = append(asset.Tokens, result.NEP11TokenBalance{
+					ID:          hex.EncodeToString([]byte(tokenID)),
+					Amount:      bal.Balance.String(),
+					LastUpdated: bal.LastUpdatedBlock,
+				})
+			}
+			sort.Slice(asset.Tokens, func(i, j int) bool {
+				return asset.Tokens[i].ID < asset.Tokens[j].ID
+			})
+			bs.Balances = append(bs.Balances, asset)
+		}
+	}
+	return bs, nil
+}
+
+// maxTransfersLimit returns configured maximum number of elements returned
+// by get*transfers and findtransactions requests.
+func (s *Server) maxTransfersLimit() int {
+	if l := s.config.MaxTransfersLimit; l > 0 {
+		return l
+	}
+	return defaultMaxTransfersLimit
+}
+
+// getLimitAndPage returns optional limit and page parameters starting at the
+// given index, limit is maxTransfersLimit by default and it can't exceed it.
+func (s *Server) getLimitAndPage(ps request.Params, index int) (int, int, error) {
 	var limit, page int
 
-	limit = maxTransfersLimit
-	pStart, pEnd, pLimit, pPage := ps.Value(index), ps.Value(index+1), ps.Value(index+2), ps.Value(index+3)
-	if pPage != nil {
+	limit = s.maxTransfersLimit()
+	pLimit, pPage := ps.Value(index), ps.Value(index+1)
+	if !pPage.IsNull() {
 		p, err := pPage.GetInt()
 		if err != nil {
-			return 0, 0, 0, 0, err
+			return 0, 0, err
 		}
 		if p < 0 {
-			return 0, 0, 0, 0, errors.New("can't use negative page")
+			return 0, 0, errors.New("can't use negative page")
 		}
 		page = p
 	}
-	if pLimit != nil {
+	if !pLimit.IsNull() {
 		l, err := pLimit.GetInt()
 		if err != nil {
-			return 0, 0, 0, 0, err
+			return 0, 0, err
 		}
 		if l <= 0 {
-			return 0, 0, 0, 0, errors.New("can't use negative or zero limit")
+			return 0, 0, errors.New("can't use negative or zero limit")
 		}
-		if l > maxTransfersLimit {
-			return 0, 0, 0, 0, errors.New("too big limit requested")
+		if l > s.maxTransfersLimit() {
+			return 0, 0, errors.New("too big limit requested")
 		}
 		limit = l
 	}
-	if pEnd != nil {
+	return limit, page, nil
+}
+
+func (s *Server) getTimestampsAndLimit(ps request.Params, index int) (uint64, uint64, int, int, error) {
+	var start, end uint64
+
+	limit, page, err := s.getLimitAndPage(ps, index+2)
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+	pStart, pEnd := ps.Value(index), ps.Value(index+1)
+	if !pEnd.IsNull() {
 		val, err := pEnd.GetInt()
 		if err != nil {
 			return 0, 0, 0, 0, err
@@ -640,7 +1071,7 @@ func getTimestampsAndLimit(ps request.Params, index int) (uint64, uint64, int, i
 	} else {
 		end = uint64(time.Now().Unix() * 1000)
 	}
-	if pStart != nil {
+	if !pStart.IsNull() {
 		val, err := pStart.GetInt()
 		if err != nil {
 			return 0, 0, 0, 0, err
@@ -652,13 +1083,46 @@ func getTimestampsAndLimit(ps request.Params, index int) (uint64, uint64, int, i
 	return start, end, limit, page, nil
 }
 
+// getCursor returns optional cursor parameter, it's nil if the parameter is
+// missing.
+func getCursor(p *request.Param) (*cursor, error) {
+	if p.IsNull() {
+		return nil, nil
+	}
+	s, err := p.GetString()
+	if err != nil {
+		return nil, err
+	}
+	return parseCursor(s)
+}
+
+// getTransfersPager returns pager for transfers requested by getnep11transfers
+// or getnep17transfers call.
+func (s *Server) getTransfersPager(ps request.Params) (*transferPager, error) {
+	start, end, limit, page, err := s.getTimestampsAndLimit(ps, 1)
+	if err != nil {
+		return nil, err
+	}
+	from, err := getCursor(ps.Value(5))
+	if err != nil {
+		return nil, err
+	}
+	if from != nil && page != 0 {
+		return nil, errors.New("can't use both page and cursor")
+	}
+	return &transferPager{start: start, end: end, limit: limit, page: page, from: from}, nil
+}
+
 func (s *Server) getNEP17Transfers(ps request.Params) (interface{}, *response.Error) {
 	u, err := ps.Value(0).GetUint160FromAddressOrHex()
 	if err != nil {
 		return nil, response.ErrInvalidParams
 	}
+	if rerr := s.checkTracked(u); rerr != nil {
+		return nil, rerr
+	}
 
-	start, end, limit, page, err := getTimestampsAndLimit(ps, 1)
+	pager, err := s.getTransfersPager(ps)
 	if err != nil {
 		return nil, response.NewInvalidParamsError(err.Error(), err)
 	}
@@ -669,62 +1133,164 @@ func (s *Server) getNEP17Transfers(ps request.Params) (interface{}, *response.Er
 		Sent:     []result.NEP17Transfer{},
 	}
 	cache := make(map[int32]util.Uint160)
-	var resCount, frameCount int
 	err = s.chain.ForEachNEP17Transfer(u, func(tr *state.NEP17Transfer) (bool, error) {
-		// Iterating from newest to oldest, not yet reached required
-		// time frame, continue looping.
-		if tr.Timestamp > end {
-			return true, nil
+		include, cont := pager.next(tr)
+		if !include {
+			return cont, nil
 		}
-		// Iterating from newest to oldest, moved past required
-		// time frame, stop looping.
-		if tr.Timestamp < start {
-			return false, nil
-		}
-		frameCount++
-		// Using limits, not yet reached required page.
-		if limit != 0 && page*limit >= frameCount {
-			return true, nil
-		}
-
-		h, err := s.getHash(tr.Asset, cache)
+		transfer, received, err := s.makeTransfer(tr, cache)
 		if err != nil {
 			return false, err
 		}
-
-		transfer := result.NEP17Transfer{
-			Timestamp: tr.Timestamp,
-			Asset:     h,
-			Index:     tr.Block,
-			TxHash:    tr.Tx,
-		}
-		if tr.Amount.Sign() > 0 { // token was received
-			transfer.Amount = tr.Amount.String()
-			if !tr.From.Equals(util.Uint160{}) {
-				transfer.Address = address.Uint160ToString(tr.From)
-			}
+		if received {
 			bs.Received = append(bs.Received, transfer)
 		} else {
-			transfer.Amount = new(big.Int).Neg(&tr.Amount).String()
-			if !tr.To.Equals(util.Uint160{}) {
-				transfer.Address = address.Uint160ToString(tr.To)
-			}
 			bs.Sent = append(bs.Sent, transfer)
 		}
+		return cont, nil
+	})
+	if err != nil {
+		return nil, response.NewInternalServerError("invalid NEP17 transfer log", err)
+	}
+	if pager.nextCursor != nil {
+		bs.Next = pager.nextCursor.String()
+	}
+	return bs, nil
+}
 
-		resCount++
-		// Using limits, reached limit.
-		if limit != 0 && resCount >= limit {
-			return false, nil
+func (s *Server) getNEP11Transfers(ps request.Params) (interface{}, *response.Error) {
+	u, err := ps.Value(0).GetUint160FromAddressOrHex()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	if rerr := s.checkTracked(u); rerr != nil {
+		return nil, rerr
+	}
+
+	pager, err := s.getTransfersPager(ps)
+	if err != nil {
+		return nil, response.NewInvalidParamsError(err.Error(), err)
+	}
+
+	bs := &result.NEP11Transfers{
+		Address:  address.Uint160ToString(u),
+		Received: []result.NEP11Transfer{},
+		Sent:     []result.NEP11Transfer{},
+	}
+	cache := make(map[int32]util.Uint160)
+	err = s.chain.ForEachNEP11Transfer(u, func(tr *state.NEP11Transfer) (bool, error) {
+		include, cont := pager.next(&tr.NEP17Transfer)
+		if !include {
+			return cont, nil
+		}
+		transfer, received, err := s.makeTransfer(&tr.NEP17Transfer, cache)
+		if err != nil {
+			return false, err
+		}
+		nep11 := result.NEP11Transfer{
+			NEP17Transfer: transfer,
+			ID:            hex.EncodeToString(tr.ID),
 		}
-		return true, nil
+		if received {
+			bs.Received = append(bs.Received, nep11)
+		} else {
+			bs.Sent = append(bs.Sent, nep11)
+		}
+		return cont, nil
 	})
 	if err != nil {
-		return nil, response.NewInternalServerError("invalid NEP17 transfer log", err)
+		return nil, response.NewInternalServerError("invalid NEP11 transfer log", err)
+	}
+	if pager.nextCursor != nil {
+		bs.Next = pager.nextCursor.String()
 	}
 	return bs, nil
 }
 
+// transferPager selects transfers within the time frame and page (or after
+// the cursor) requested from the ones iterated over from the newest to the
+// oldest.
+type transferPager struct {
+	start, end  uint64
+	limit, page int
+	from        *cursor
+	frameCount  int
+	resCount    int
+
+	// block and blockIndex are the position of the current transfer.
+	block      uint32
+	blockIndex uint32
+	started    bool
+	// nextCursor is the position of the first transfer that didn't fit
+	// into the results.
+	nextCursor *cursor
+}
+
+// next returns whether the transfer should be included into results and
+// whether iteration should be continued.
+func (p *transferPager) next(tr *state.NEP17Transfer) (bool, bool) {
+	if p.started && tr.Block == p.block {
+		p.blockIndex++
+	} else {
+		p.block, p.blockIndex, p.started = tr.Block, 0, true
+	}
+	// Iterating from newest to oldest, not yet reached required
+	// time frame, continue looping.
+	if tr.Timestamp > p.end {
+		return false, true
+	}
+	// Iterating from newest to oldest, moved past required
+	// time frame, stop looping.
+	if tr.Timestamp < p.start {
+		return false, false
+	}
+	// Not yet reached the cursor.
+	if p.from != nil && (p.block > p.from.Block ||
+		p.block == p.from.Block && p.blockIndex < p.from.Index) {
+		return false, true
+	}
+	// Using limits, reached limit and there are more transfers.
+	if p.limit != 0 && p.resCount == p.limit {
+		p.nextCursor = &cursor{Block: p.block, Index: p.blockIndex}
+		return false, false
+	}
+	p.frameCount++
+	// Using limits, not yet reached required page.
+	if p.limit != 0 && p.page*p.limit >= p.frameCount {
+		return false, true
+	}
+	p.resCount++
+	return true, true
+}
+
+// makeTransfer converts transfer log entry into RPC result, it also returns
+// true if tokens were received and false if they were sent.
+func (s *Server) makeTransfer(tr *state.NEP17Transfer, cache map[int32]util.Uint160) (result.NEP17Transfer, bool, error) {
+	h, err := s.getHash(tr.Asset, cache)
+	if err != nil {
+		return result.NEP17Transfer{}, false, err
+	}
+
+	transfer := result.NEP17Transfer{
+		Timestamp: tr.Timestamp,
+		Asset:     h,
+		Index:     tr.Block,
+		TxHash:    tr.Tx,
+	}
+	if tr.Amount.Sign() > 0 { // token was received
+		transfer.Amount = tr.Amount.String()
+		if !tr.From.Equals(util.Uint160{}) {
+			transfer.Address = address.Uint160ToString(tr.From)
+		}
+		return transfer, true, nil
+	}
+	transfer.Amount = new(big.Int).Neg(&tr.Amount).String()
+	if !tr.To.Equals(util.Uint160{}) {
+		transfer.Address = address.Uint160ToString(tr.To)
+	}
+	return transfer, false, nil
+}
+
 // getHash returns the hash of the contract by its ID using cache.
 func (s *Server) getHash(contractID int32, cache map[int32]util.Uint160) (util.Uint160, error) {
 	if d, ok := cache[contractID]; ok {
@@ -867,6 +1433,166 @@ func (s *Server) verifyProof(ps request.Params) (interface{}, *response.Error) {
 	return vp, nil
 }
 
+// checkStateRoot checks whether the state with the given root can be accessed,
+// only the current one is kept with KeepOnlyLatestState setting enabled.
+func (s *Server) checkStateRoot(method string, root util.Uint256) *response.Error {
+	if s.chain.GetConfig().KeepOnlyLatestState && !root.Equals(s.chain.GetStateModule().CurrentLocalStateRoot()) {
+		return response.NewInvalidRequestError(fmt.Sprintf("'%s' is only supported for the latest state", method), errKeepOnlyLatestState)
+	}
+	return nil
+}
+
+// getHistoricalContractState returns the state of the contract as it was in
+// the state with the given root.
+func (s *Server) getHistoricalContractState(root util.Uint256, h util.Uint160) (*state.Contract, *response.Error) {
+	mgmt := s.chain.GetContractState(s.chain.ManagementContractHash())
+	csBytes, err := s.chain.GetStateModule().GetState(root, makeStorageKey(mgmt.ID, native.MakeContractKey(h)))
+	if err != nil {
+		return nil, response.NewRPCError("Unknown contract", "", err)
+	}
+	cs := new(state.Contract)
+	r := io.NewBinReaderFromBuf(csBytes)
+	cs.DecodeBinary(r)
+	if r.Err != nil {
+		return nil, response.NewInternalServerError("can't decode contract state", r.Err)
+	}
+	return cs, nil
+}
+
+// getState implements the `getstate` RPC call returning the value of contract
+// storage item in the state with the given root.
+func (s *Server) getState(ps request.Params) (interface{}, *response.Error) {
+	root, err := ps.Value(0).GetUint256()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	if respErr := s.checkStateRoot("getstate", root); respErr != nil {
+		return nil, respErr
+	}
+	sc, err := ps.Value(1).GetUint160FromHex()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	key, err := ps.Value(2).GetBytesBase64()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	cs, respErr := s.getHistoricalContractState(root, sc)
+	if respErr != nil {
+		return nil, respErr
+	}
+	val, err := s.chain.GetStateModule().GetState(root, makeStorageKey(cs.ID, key))
+	if err != nil {
+		return nil, response.NewRPCError("Unknown storage item", "", err)
+	}
+	return val, nil
+}
+
+// findStates implements the `findstates` RPC call returning contract storage
+// items with the given prefix in the state with the given root. Items are
+// ordered by key, the ones with keys not greater than the optional start key
+// are skipped. Proofs are provided for the first and the last items returned.
+func (s *Server) findStates(ps request.Params) (interface{}, *response.Error) {
+	root, err := ps.Value(0).GetUint256()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	if respErr := s.checkStateRoot("findstates", root); respErr != nil {
+		return nil, respErr
+	}
+	sc, err := ps.Value(1).GetUint160FromHex()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	prefix, err := ps.Value(2).GetBytesBase64()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	var start []byte
+	if p := ps.Value(3); !p.IsNull() {
+		start, err = p.GetBytesBase64()
+		if err != nil {
+			return nil, response.ErrInvalidParams
+		}
+	}
+	from, err := getCursor(ps.Value(5))
+	if err != nil {
+		return nil, response.NewInvalidParamsError(err.Error(), err)
+	}
+	if from != nil {
+		if len(start) != 0 {
+			return nil, response.NewInvalidParamsError("can't use both start key and cursor", nil)
+		}
+		start = from.Key
+	}
+	if len(start) != 0 && !bytes.HasPrefix(start, prefix) {
+		return nil, response.NewInvalidParamsError("start key doesn't have the prefix", nil)
+	}
+	max := s.config.MaxFindResultItems
+	if max <= 0 {
+		max = defaultMaxFindResultItems
+	}
+	if p := ps.Value(4); !p.IsNull() {
+		count, err := p.GetInt()
+		if err != nil || count <= 0 || count > max {
+			return nil, response.NewInvalidParamsError(fmt.Sprintf("count should be in [1, %d] range", max), err)
+		}
+		max = count
+	}
+	cs, respErr := s.getHistoricalContractState(root, sc)
+	if respErr != nil {
+		return nil, respErr
+	}
+	pKey := makeStorageKey(cs.ID, prefix)
+	var sKey []byte
+	if len(start) != 0 {
+		sKey = makeStorageKey(cs.ID, start)
+	}
+	// One more item is requested to check whether results are truncated.
+	kvs, err := s.chain.GetStateModule().FindStates(root, pKey, sKey, max+1)
+	if err != nil {
+		return nil, response.NewInternalServerError("failed to find state items", err)
+	}
+	res := &result.FindStates{Results: make([]result.KeyValue, 0, len(kvs))}
+	if len(kvs) > max {
+		res.Truncated = true
+		kvs = kvs[:max]
+		res.Next = (&cursor{Key: kvs[max-1].Key[4:]}).String()
+	}
+	for _, kv := range kvs {
+		res.Results = append(res.Results, result.KeyValue{
+			Key:   kv.Key[4:], // Contract ID is stripped.
+			Value: kv.Value,
+		})
+	}
+	if len(kvs) > 0 {
+		res.FirstProof, err = s.getStateProof(root, kvs[0].Key)
+		if err != nil {
+			return nil, response.NewInternalServerError("failed to get first proof", err)
+		}
+		if len(kvs) > 1 {
+			res.LastProof, err = s.getStateProof(root, kvs[len(kvs)-1].Key)
+			if err != nil {
+				return nil, response.NewInternalServerError("failed to get last proof", err)
+			}
+		}
+	}
+	return res, nil
+}
+
+// getStateProof returns proof of the given MPT key in the state with the given
+// root.
+func (s *Server) getStateProof(root util.Uint256, key []byte) (*result.ProofWithKey, error) {
+	proof, err := s.chain.GetStateModule().GetStateProof(root, key)
+	if err != nil {
+		return nil, err
+	}
+	return &result.ProofWithKey{
+		Key:   key,
+		Proof: proof,
+	}, nil
+}
+
 func (s *Server) getStateHeight(_ request.Params) (interface{}, *response.Error) {
 	var height = s.chain.BlockHeight()
 	var stateHeight = s.chain.GetStateModule().CurrentValidatedHeight()
@@ -882,7 +1608,7 @@ func (s *Server) getStateHeight(_ request.Params) (interface{}, *response.Error)
 func (s *Server) getStateRoot(ps request.Params) (interface{}, *response.Error) {
 	p := ps.Value(0)
 	if p == nil {
-		return nil, response.NewRPCError("Invalid parameter.", "", nil)
+		return nil, response.ErrInvalidParams
 	}
 	var rt *state.MPTRoot
 	var h util.Uint256
@@ -924,6 +1650,71 @@ func (s *Server) getStorage(ps request.Params) (interface{}, *response.Error) {
 	return []byte(item), nil
 }
 
+// findStorage implements the `findstorage` RPC call returning current contract
+// storage items with the given prefix page by page. Items are ordered by key,
+// optional cursor allows to continue from the last item of the previous page.
+func (s *Server) findStorage(ps request.Params) (interface{}, *response.Error) {
+	id, rErr := s.contractIDFromParam(ps.Value(0))
+	if rErr == response.ErrUnknown {
+		return nil, response.NewRPCError("Unknown contract", "", nil)
+	}
+	if rErr != nil {
+		return nil, rErr
+	}
+	prefix, err := ps.Value(1).GetBytesBase64()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	max := s.config.MaxFindResultItems
+	if max <= 0 {
+		max = defaultMaxFindResultItems
+	}
+	if p := ps.Value(2); !p.IsNull() {
+		count, err := p.GetInt()
+		if err != nil || count <= 0 || count > max {
+			return nil, response.NewInvalidParamsError(fmt.Sprintf("count should be in [1, %d] range", max), err)
+		}
+		max = count
+	}
+	from, err := getCursor(ps.Value(3))
+	if err != nil {
+		return nil, response.NewInvalidParamsError(err.Error(), err)
+	}
+	// Returned keys don't include the prefix, so the cursor is trimmed too.
+	var start *string
+	if from != nil {
+		if !bytes.HasPrefix(from.Key, prefix) {
+			return nil, response.NewInvalidParamsError("cursor doesn't match the prefix", nil)
+		}
+		k := string(from.Key[len(prefix):])
+		start = &k
+	}
+	items, err := s.chain.GetStorageItemsWithPrefix(id, prefix)
+	if err != nil {
+		return nil, response.NewInternalServerError("failed to get storage items", err)
+	}
+	keys := make([]string, 0, len(items))
+	for k := range items {
+		if start == nil || k > *start {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	res := &result.FindStorage{Results: make([]result.KeyValue, 0, len(keys))}
+	for _, k := range keys {
+		if len(res.Results) == max {
+			res.Truncated = true
+			res.Next = (&cursor{Key: res.Results[max-1].Key}).String()
+			break
+		}
+		res.Results = append(res.Results, result.KeyValue{
+			Key:   append(prefix[:len(prefix):len(prefix)], k...),
+			Value: items[k],
+		})
+	}
+	return res, nil
+}
+
 func (s *Server) getrawtransaction(reqParams request.Params) (interface{}, *response.Error) {
 	txHash, err := reqParams.Value(0).GetUint256()
 	if err != nil {
@@ -941,14 +1732,14 @@ func (s *Server) getrawtransaction(reqParams request.Params) (interface{}, *resp
 		_header := s.chain.GetHeaderHash(int(height))
 		header, err := s.chain.GetHeader(_header)
 		if err != nil {
-			return nil, response.NewRPCError("Failed to get header for the transaction", err.Error(), err)
+			return nil, response.NewInternalServerError("Failed to get header for the transaction", err)
 		}
 		aers, err := s.chain.GetAppExecResults(txHash, trigger.Application)
 		if err != nil {
-			return nil, response.NewRPCError("Failed to get application log for the transaction", err.Error(), err)
+			return nil, response.NewInternalServerError("Failed to get application log for the transaction", err)
 		}
 		if len(aers) == 0 {
-			return nil, response.NewRPCError("Application log for the transaction is empty", "", nil)
+			return nil, response.NewInternalServerError("Application log for the transaction is empty", nil)
 		}
 		return result.NewTransactionOutputRaw(tx, header, &aers[0], s.chain), nil
 	}
@@ -969,6 +1760,52 @@ func (s *Server) getTransactionHeight(ps request.Params) (interface{}, *response
 	return height, nil
 }
 
+// findTransactions returns hashes of transactions having the given
+// attribute. Attribute type and value are mandatory, while limit and page
+// parameters are optional.
+func (s *Server) findTransactions(ps request.Params) (interface{}, *response.Error) {
+	typ, err := ps.Value(0).GetString()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	var attr transaction.Attribute
+	switch typ {
+	case transaction.OracleResponseT.String():
+		id, err := ps.Value(1).GetInt()
+		if err != nil || id < 0 {
+			return nil, response.NewInvalidParamsError("invalid oracle request ID", err)
+		}
+		attr.Type = transaction.OracleResponseT
+		attr.Value = &transaction.OracleResponse{ID: uint64(id)}
+	case transaction.ConflictsT.String():
+		h, err := ps.Value(1).GetUint256()
+		if err != nil {
+			return nil, response.NewInvalidParamsError("invalid conflicting transaction hash", err)
+		}
+		attr.Type = transaction.ConflictsT
+		attr.Value = &transaction.Conflicts{Hash: h}
+	case transaction.NotaryAssistedT.String():
+		n, err := ps.Value(1).GetInt()
+		if err != nil || n < 0 || n > math.MaxUint8 {
+			return nil, response.NewInvalidParamsError("invalid number of keys", err)
+		}
+		attr.Type = transaction.NotaryAssistedT
+		attr.Value = &transaction.NotaryAssisted{NKeys: uint8(n)}
+	default:
+		return nil, response.NewInvalidParamsError(fmt.Sprintf("unsupported attribute type: %s", typ), nil)
+	}
+	limit, page, err := s.getLimitAndPage(ps, 2)
+	if err != nil {
+		return nil, response.NewInvalidParamsError(err.Error(), err)
+	}
+
+	hashes, err := s.chain.GetTransactionsByAttribute(&attr, page*limit, limit)
+	if err != nil {
+		return nil, response.NewInternalServerError("failed to get transactions", err)
+	}
+	return hashes, nil
+}
+
 // getContractState returns contract state (contract information, according to the contract script hash,
 // contract id or native contract name).
 func (s *Server) getContractState(reqParams request.Params) (interface{}, *response.Error) {
@@ -987,6 +1824,49 @@ func (s *Server) getNativeContracts(_ request.Params) (interface{}, *response.Er
 	return s.chain.GetNatives(), nil
 }
 
+// getNativePrices returns current method prices and required call flags of all
+// native contracts or of the one specified.
+func (s *Server) getNativePrices(ps request.Params) (interface{}, *response.Error) {
+	var (
+		filter  *util.Uint160
+		natives = s.chain.GetNatives()
+	)
+	if len(ps) > 0 {
+		h, err := s.contractScriptHashFromParam(ps.Value(0))
+		if err != nil {
+			return nil, err
+		}
+		filter = &h
+	}
+	var (
+		execFee      = s.chain.GetPolicer().GetBaseExecFee()
+		storagePrice = s.chain.GetPolicer().GetStoragePrice()
+		res          = make([]result.NativePrices, 0, len(natives))
+	)
+	for i := range natives {
+		if filter != nil && !natives[i].Hash.Equals(*filter) {
+			continue
+		}
+		methods := s.chain.GetNativeMethods(natives[i].Hash)
+		prices := result.NativePrices{
+			Hash:    natives[i].Hash,
+			Name:    natives[i].Manifest.Name,
+			Methods: make([]result.NativeMethodPrice, len(methods)),
+		}
+		for j := range methods {
+			prices.Methods[j] = result.NativeMethodPrice{
+				NativeMethod: methods[j],
+				Price:        methods[j].CPUFee*execFee + methods[j].StorageFee*storagePrice,
+			}
+		}
+		res = append(res, prices)
+	}
+	if filter != nil && len(res) == 0 {
+		return nil, response.NewRPCError("Unknown native contract", "", nil)
+	}
+	return res, nil
+}
+
 // getBlockSysFee returns the system fees of the block, based on the specified index.
 func (s *Server) getBlockSysFee(reqParams request.Params) (interface{}, *response.Error) {
 	param := reqParams.ValueWithType(0, request.NumberT)
@@ -1068,11 +1948,11 @@ func (s *Server) getNextBlockValidators(_ request.Params) (interface{}, *respons
 
 	validators, err := s.chain.GetNextBlockValidators()
 	if err != nil {
-		return nil, response.NewRPCError("can't get next block validators", "", err)
+		return nil, response.NewInternalServerError("can't get next block validators", err)
 	}
 	enrollments, err := s.chain.GetEnrollments()
 	if err != nil {
-		return nil, response.NewRPCError("can't get enrollments", "", err)
+		return nil, response.NewInternalServerError("can't get enrollments", err)
 	}
 	var res = make([]result.Validator, 0)
 	for _, v := range enrollments {
@@ -1085,6 +1965,81 @@ func (s *Server) getNextBlockValidators(_ request.Params) (interface{}, *respons
 	return res, nil
 }
 
+// getCandidates returns all registered NEO candidates with their votes,
+// committee membership and next block validator flags.
+func (s *Server) getCandidates(_ request.Params) (interface{}, *response.Error) {
+	candidates, err := s.chain.GetEnrollments()
+	if err != nil {
+		return nil, response.NewInternalServerError("can't get candidates", err)
+	}
+	committee, err := s.chain.GetCommittee()
+	if err != nil {
+		return nil, response.NewInternalServerError("can't get committee", err)
+	}
+	validators, err := s.chain.GetNextBlockValidators()
+	if err != nil {
+		return nil, response.NewInternalServerError("can't get next block validators", err)
+	}
+	var res = make([]result.Candidate, 0, len(candidates))
+	for _, c := range candidates {
+		res = append(res, result.Candidate{
+			PublicKey: *c.Key,
+			Votes:     c.Votes.Int64(),
+			Committee: committee.Contains(c.Key),
+			Active:    keys.PublicKeys(validators).Contains(c.Key),
+		})
+	}
+	return res, nil
+}
+
+// getDesignatedByRole returns nodes designated for the given role (specified
+// by its name or number) at the given height (the next block by default).
+func (s *Server) getDesignatedByRole(ps request.Params) (interface{}, *response.Error) {
+	p := ps.Value(0)
+	if p == nil {
+		return nil, response.ErrInvalidParams
+	}
+	var role noderoles.Role
+	if num, err := p.GetInt(); err == nil {
+		if num < 0 || num > math.MaxUint8 {
+			return nil, response.NewInvalidParamsError("invalid role", nil)
+		}
+		role = noderoles.Role(num)
+	} else {
+		name, err := p.GetString()
+		if err != nil {
+			return nil, response.ErrInvalidParams
+		}
+		role, err = noderoles.FromString(name)
+		if err != nil {
+			return nil, response.NewInvalidParamsError(err.Error(), err)
+		}
+	}
+	index := s.chain.BlockHeight() + 1
+	if p := ps.Value(1); p != nil {
+		num, err := p.GetInt()
+		if err != nil || num < 0 || uint32(num) > index {
+			return nil, response.NewInvalidParamsError("invalid index", err)
+		}
+		index = uint32(num)
+	}
+	nodes, height, err := s.chain.GetDesignatedByRole(role, index)
+	if err != nil {
+		if errors.Is(err, native.ErrInvalidRole) {
+			return nil, response.NewInvalidParamsError(err.Error(), err)
+		}
+		return nil, response.NewInternalServerError("can't get designated nodes", err)
+	}
+	if nodes == nil {
+		nodes = keys.PublicKeys{}
+	}
+	return result.Designation{
+		Role:   role,
+		Height: height,
+		Nodes:  nodes,
+	}, nil
+}
+
 // getCommittee returns the current list of NEO committee members
 func (s *Server) getCommittee(_ request.Params) (interface{}, *response.Error) {
 	keys, err := s.chain.GetCommittee()
@@ -1096,16 +2051,37 @@ func (s *Server) getCommittee(_ request.Params) (interface{}, *response.Error) {
 
 // invokeFunction implements the `invokeFunction` RPC call.
 func (s *Server) invokeFunction(reqParams request.Params) (interface{}, *response.Error) {
+	return s.invokeFunctionWithState(reqParams, nil)
+}
+
+// invokeFunctionHistoric implements the `invokefunctionhistoric` RPC call.
+func (s *Server) invokeFunctionHistoric(reqParams request.Params) (interface{}, *response.Error) {
+	st, respErr := s.getHistoricState(reqParams.Value(0))
+	if respErr != nil {
+		return nil, respErr
+	}
+	return s.invokeFunctionWithState(reqParams[1:], st)
+}
+
+// invokeFunctionWithState performs invokefunction call against the given state.
+func (s *Server) invokeFunctionWithState(reqParams request.Params, st *invocationState) (interface{}, *response.Error) {
 	scriptHash, responseErr := s.contractScriptHashFromParam(reqParams.Value(0))
 	if responseErr != nil {
 		return nil, responseErr
 	}
 	tx := &transaction.Transaction{}
 	checkWitnessHashesIndex := len(reqParams)
+	gasLimit, respErr := s.getGasLimit(reqParams.Value(4))
+	if respErr != nil {
+		return nil, respErr
+	}
+	if checkWitnessHashesIndex > 4 {
+		checkWitnessHashesIndex = 4
+	}
 	if checkWitnessHashesIndex > 3 {
 		signers, _, err := reqParams[3].GetSignersWithWitnesses()
 		if err != nil {
-			return nil, response.ErrInvalidParams
+			return nil, response.NewInvalidParamsError(err.Error(), err)
 		}
 		tx.Signers = signers
 		checkWitnessHashesIndex--
@@ -1118,11 +2094,26 @@ func (s *Server) invokeFunction(reqParams request.Params) (interface{}, *respons
 		return nil, response.NewInternalServerError("can't create invocation script", err)
 	}
 	tx.Script = script
-	return s.runScriptInVM(trigger.Application, script, util.Uint160{}, tx)
+	diag := reqParams.Value(5).GetBoolean()
+	return s.runWitnessInVM(trigger.Application, script, []byte{}, util.Uint160{}, tx, gasLimit, st, diag)
 }
 
 // invokescript implements the `invokescript` RPC call.
 func (s *Server) invokescript(reqParams request.Params) (interface{}, *response.Error) {
+	return s.invokeScriptWithState(reqParams, nil)
+}
+
+// invokeScriptHistoric implements the `invokescripthistoric` RPC call.
+func (s *Server) invokeScriptHistoric(reqParams request.Params) (interface{}, *response.Error) {
+	st, respErr := s.getHistoricState(reqParams.Value(0))
+	if respErr != nil {
+		return nil, respErr
+	}
+	return s.invokeScriptWithState(reqParams[1:], st)
+}
+
+// invokeScriptWithState performs invokescript call against the given state.
+func (s *Server) invokeScriptWithState(reqParams request.Params, st *invocationState) (interface{}, *response.Error) {
 	if len(reqParams) < 1 {
 		return nil, response.ErrInvalidParams
 	}
@@ -1136,15 +2127,84 @@ func (s *Server) invokescript(reqParams request.Params) (interface{}, *response.
 	if len(reqParams) > 1 {
 		signers, _, err := reqParams[1].GetSignersWithWitnesses()
 		if err != nil {
-			return nil, response.ErrInvalidParams
+			return nil, response.NewInvalidParamsError(err.Error(), err)
 		}
 		tx.Signers = signers
 	}
 	if len(tx.Signers) == 0 {
 		tx.Signers = []transaction.Signer{{Account: util.Uint160{}, Scopes: transaction.None}}
 	}
+	gasLimit, respErr := s.getGasLimit(reqParams.Value(2))
+	if respErr != nil {
+		return nil, respErr
+	}
 	tx.Script = script
-	return s.runScriptInVM(trigger.Application, script, util.Uint160{}, tx)
+	diag := reqParams.Value(3).GetBoolean()
+	return s.runWitnessInVM(trigger.Application, script, []byte{}, util.Uint160{}, tx, gasLimit, st, diag)
+}
+
+// getHistoricState returns the state for historic invocations specified either
+// by block height or by state root.
+func (s *Server) getHistoricState(param *request.Param) (*invocationState, *response.Error) {
+	if param == nil {
+		return nil, response.ErrInvalidParams
+	}
+	if s.chain.GetConfig().KeepOnlyLatestState {
+		return nil, response.NewInvalidRequestError("historic invocations are not supported, only latest state is kept", nil)
+	}
+	st := &invocationState{height: s.chain.BlockHeight()}
+	if height, err := param.GetInt(); err == nil {
+		if height < 0 || height > int(st.height) {
+			return nil, invalidBlockHeightError(0, height)
+		}
+		sr, err := s.chain.GetStateModule().GetStateRoot(uint32(height))
+		if err != nil {
+			return nil, response.NewInternalServerError("can't get state root", err)
+		}
+		st.height = uint32(height)
+		st.root = &sr.Root
+		return st, nil
+	}
+	root, err := param.GetUint256()
+	if err != nil {
+		return nil, response.NewInvalidParamsError("invalid block height or state root", err)
+	}
+	st.root = &root
+	return st, nil
+}
+
+// simulateScript implements the `simulatescript` RPC call. It's the same as
+// invokescript, but the script is executed with temporary state changes
+// specified in the second parameter.
+func (s *Server) simulateScript(reqParams request.Params) (interface{}, *response.Error) {
+	if !s.config.EnableStateOverride {
+		return nil, response.NewRPCError("State override is disabled", "", nil)
+	}
+	script, err := reqParams.Value(0).GetBytesBase64()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	ov, err := reqParams.Value(1).GetStateOverride()
+	if err != nil {
+		return nil, response.NewInvalidParamsError(err.Error(), err)
+	}
+
+	tx := &transaction.Transaction{Script: script}
+	if len(reqParams) > 2 {
+		signers, _, err := reqParams[2].GetSignersWithWitnesses()
+		if err != nil {
+			return nil, response.NewInvalidParamsError(err.Error(), err)
+		}
+		tx.Signers = signers
+	}
+	if len(tx.Signers) == 0 {
+		tx.Signers = []transaction.Signer{{Account: util.Uint160{}, Scopes: transaction.None}}
+	}
+	gasLimit, respErr := s.getGasLimit(reqParams.Value(3))
+	if respErr != nil {
+		return nil, respErr
+	}
+	return s.runWitnessInVM(trigger.Application, script, []byte{}, util.Uint160{}, tx, gasLimit, &invocationState{override: &ov}, false)
 }
 
 // invokeContractVerify implements the `invokecontractverify` RPC call.
@@ -1163,7 +2223,7 @@ func (s *Server) invokeContractVerify(reqParams request.Params) (interface{}, *r
 		if len(args) > 0 {
 			err := request.ExpandArrayIntoScript(bw.BinWriter, args)
 			if err != nil {
-				return nil, response.NewRPCError("can't create witness invocation script", err.Error(), err)
+				return nil, response.NewInternalServerError("can't create witness invocation script", err)
 			}
 		}
 	}
@@ -1173,7 +2233,7 @@ func (s *Server) invokeContractVerify(reqParams request.Params) (interface{}, *r
 	if len(reqParams) > 2 {
 		signers, witnesses, err := reqParams[2].GetSignersWithWitnesses()
 		if err != nil {
-			return nil, response.ErrInvalidParams
+			return nil, response.NewInvalidParamsError(err.Error(), err)
 		}
 		tx.Signers = signers
 		tx.Scripts = witnesses
@@ -1182,62 +2242,251 @@ func (s *Server) invokeContractVerify(reqParams request.Params) (interface{}, *r
 		tx.Scripts = []transaction.Witness{{InvocationScript: invocationScript, VerificationScript: []byte{}}}
 	}
 
-	return s.runScriptInVM(trigger.Verification, invocationScript, scriptHash, tx)
+	return s.runScriptInVM(trigger.Verification, invocationScript, scriptHash, tx, int64(s.config.MaxGasInvoke))
+}
+
+// calculateNetworkFee implements the `calculatenetworkfee` RPC call. It
+// accepts a transaction with witnesses that have verification scripts (or
+// empty ones for deployed contracts) and invocation scripts (which can contain
+// dummy signatures and are only used to estimate witness size, unless the
+// witness is not a standard one). Non-standard witnesses are executed under
+// MaxVerificationGas limit, their results are not checked since signatures
+// can't be valid yet.
+func (s *Server) calculateNetworkFee(reqParams request.Params) (interface{}, *response.Error) {
+	txBytes, err := reqParams.ValueWithType(0, request.StringT).GetBytesBase64()
+	if err != nil {
+		return nil, response.WrapErrorWithData(response.ErrInvalidParams, err)
+	}
+	tx, err := transaction.NewTransactionFromBytes(s.network, txBytes)
+	if err != nil {
+		return nil, response.WrapErrorWithData(response.ErrInvalidParams, err)
+	}
+	gasLimit := int64(s.config.MaxVerificationGas)
+	if gasLimit <= 0 {
+		gasLimit = int64(s.config.MaxGasInvoke)
+	}
+	var (
+		netFee  int64
+		scripts = tx.Scripts
+		execFee = s.chain.GetPolicer().GetBaseExecFee()
+	)
+	tx.Scripts = nil
+	size := io.GetVarSize(tx)
+	tx.Scripts = scripts
+	for i, signer := range tx.Signers {
+		w := &tx.Scripts[i]
+		if len(w.VerificationScript) != 0 {
+			if !w.ScriptHash().Equals(signer.Account) {
+				return nil, response.NewInvalidParamsError(fmt.Sprintf("signer #%d: verification script hash mismatch", i), nil)
+			}
+			if scriptFee, sizeDelta := fee.Calculate(execFee, w.VerificationScript); sizeDelta != 0 {
+				netFee += scriptFee
+				size += sizeDelta
+				continue
+			}
+		}
+		res, respErr := s.runWitnessInVM(trigger.Verification, w.InvocationScript, w.VerificationScript, signer.Account, tx, gasLimit, nil, false)
+		if respErr != nil {
+			return nil, respErr
+		}
+		if res.State != vm.HaltState.String() {
+			return nil, response.NewRPCError("Witness verification failed",
+				fmt.Sprintf("signer #%d: VM state %s: %s", i, res.State, res.FaultException), nil)
+		}
+		netFee += res.GasConsumed
+		size += io.GetVarSize(w.InvocationScript) + io.GetVarSize(w.VerificationScript)
+	}
+	netFee += int64(size) * s.chain.FeePerByte()
+	return result.NetworkFee{Value: netFee}, nil
+}
+
+// getGasLimit returns gas limit for test invocation. It's MaxGasInvoke by
+// default, but clients can request a different one which is bounded by
+// MaxGasInvokeBudget (or MaxGasInvoke if it's not set).
+func (s *Server) getGasLimit(param *request.Param) (int64, *response.Error) {
+	limit := int64(s.config.MaxGasInvoke)
+	if param.IsNull() {
+		return limit, nil
+	}
+	budget, err := param.GetInt()
+	if err != nil || budget <= 0 {
+		return 0, response.WrapErrorWithData(response.ErrInvalidParams, errors.New("invalid gas budget"))
+	}
+	if max := int64(s.config.MaxGasInvokeBudget); max > limit {
+		limit = max
+	}
+	if int64(budget) > limit {
+		return 0, response.WrapErrorWithData(response.ErrInvalidParams, fmt.Errorf("gas budget exceeds %d limit", limit))
+	}
+	return int64(budget), nil
 }
 
 // runScriptInVM runs given script in a new test VM and returns the invocation
 // result. The script is either a simple script in case of `application` trigger
 // witness invocation script in case of `verification` trigger (it pushes `verify`
 // arguments on stack before verification). In case of contract verification
-// contractScriptHash should be specified.
-func (s *Server) runScriptInVM(t trigger.Type, script []byte, contractScriptHash util.Uint160, tx *transaction.Transaction) (*result.Invoke, *response.Error) {
+// contractScriptHash should be specified. gasLimit is the maximum amount of GAS
+// the script can spend, MaxInvokeDuration setting limits its execution time.
+func (s *Server) runScriptInVM(t trigger.Type, script []byte, contractScriptHash util.Uint160, tx *transaction.Transaction, gasLimit int64) (*result.Invoke, *response.Error) {
+	return s.runWitnessInVM(t, script, []byte{}, contractScriptHash, tx, gasLimit, nil, false)
+}
+
+// invocationState describes the chain state test invocation is performed
+// against if it's not the current one.
+type invocationState struct {
+	// override contains temporary state changes (see simulatescript).
+	override *request.StateOverride
+	// root is the historic state root (see invoke*historic calls).
+	root *util.Uint256
+	// height is the height of the block invocation is performed after,
+	// it's only used along with root.
+	height uint32
+}
+
+// runWitnessInVM is the same as runScriptInVM, but it also accepts
+// verification script to run for `verification` trigger, deployed contract's
+// `verify` method is used if it's empty. If st is not nil, the script is run
+// against the state it describes. If diag is set, the result contains contract
+// call tree and storage changes made by the script.
+func (s *Server) runWitnessInVM(t trigger.Type, script []byte, verificationScript []byte, contractScriptHash util.Uint160, tx *transaction.Transaction, gasLimit int64, st *invocationState, diag bool) (*result.Invoke, *response.Error) {
+	height := s.chain.BlockHeight()
+	if st != nil && st.root != nil {
+		height = st.height
+	}
 	// When transferring funds, script execution does no auto GAS claim,
 	// because it depends on persisting tx height.
 	// This is why we provide block here.
 	b := block.New(s.network, s.stateRootEnabled)
-	b.Index = s.chain.BlockHeight() + 1
-	hdr, err := s.chain.GetHeader(s.chain.GetHeaderHash(int(s.chain.BlockHeight())))
+	b.Index = height + 1
+	hdr, err := s.chain.GetHeader(s.chain.GetHeaderHash(int(height)))
 	if err != nil {
 		return nil, response.NewInternalServerError("can't get last block", err)
 	}
 	b.Timestamp = hdr.Timestamp + uint64(s.chain.GetConfig().SecondsPerBlock*int(time.Second/time.Millisecond))
 
-	vm := s.chain.GetTestVM(t, tx, b)
-	vm.GasLimit = int64(s.config.MaxGasInvoke)
+	var (
+		v *vm.VM
+		d dao.DAO
+	)
+	switch {
+	case st == nil:
+		v, d = s.chain.GetTestVM(t, tx, b)
+	case st.root != nil:
+		v, d, err = s.chain.GetTestHistoricVM(t, tx, b, *st.root)
+		if err != nil {
+			return nil, response.NewInvalidParamsError("can't use historic state", err)
+		}
+	default:
+		if st.override.Timestamp != 0 {
+			b.Timestamp = st.override.Timestamp
+		}
+		v, d, err = s.chain.GetTestVMWithOverride(t, tx, b, &st.override.Override)
+		if err != nil {
+			return nil, response.NewInvalidParamsError("can't apply state override", err)
+		}
+	}
+	if diag {
+		v.EnableInvocationTree()
+	}
+	v.GasLimit = gasLimit
 	if t == trigger.Verification {
 		// We need this special case because witnesses verification is not the simple System.Contract.Call,
 		// and we need to define exactly the amount of gas consumed for a contract witness verification.
 		gasPolicy := s.chain.GetPolicer().GetMaxVerificationGAS()
-		if vm.GasLimit > gasPolicy {
-			vm.GasLimit = gasPolicy
+		if v.GasLimit > gasPolicy {
+			v.GasLimit = gasPolicy
 		}
 
-		err := s.chain.InitVerificationVM(vm, func(h util.Uint160) (*state.Contract, error) {
+		err := s.chain.InitVerificationVM(v, func(h util.Uint160) (*state.Contract, error) {
 			res := s.chain.GetContractState(h)
 			if res == nil {
 				return nil, fmt.Errorf("unknown contract: %s", h.StringBE())
 			}
 			return res, nil
-		}, contractScriptHash, &transaction.Witness{InvocationScript: script, VerificationScript: []byte{}})
+		}, contractScriptHash, &transaction.Witness{InvocationScript: script, VerificationScript: verificationScript})
 		if err != nil {
 			return nil, response.NewInternalServerError("can't prepare verification VM", err)
 		}
 	} else {
-		vm.LoadScriptWithFlags(script, callflag.All)
+		v.LoadScriptWithFlags(script, callflag.All)
+	}
+	if d := s.config.MaxInvokeDuration; d > 0 {
+		timer := time.AfterFunc(d, v.Interrupt)
+		defer timer.Stop()
 	}
-	err = vm.Run()
+	err = v.Run()
 	var faultException string
-	if err != nil {
+	if errors.Is(err, vm.ErrInterrupted) {
+		faultException = fmt.Sprintf("execution time limit of %s exceeded", s.config.MaxInvokeDuration)
+	} else if err != nil {
 		faultException = err.Error()
 	}
-	result := &result.Invoke{
-		State:          vm.State().String(),
-		GasConsumed:    vm.GasConsumed(),
+	res := &result.Invoke{
+		State:          v.State().String(),
+		GasConsumed:    v.GasConsumed(),
 		Script:         script,
-		Stack:          vm.Estack().ToArray(),
+		Stack:          v.Estack().ToArray(),
 		FaultException: faultException,
 	}
-	return result, nil
+	if diag {
+		res.Diagnostics = &result.InvokeDiag{
+			Invocations: v.GetInvocationTree().Calls,
+			Changes:     s.getStorageChanges(d.GetBatch()),
+		}
+	}
+	if t != trigger.Verification && v.State() == vm.HaltState {
+		if err := s.registerSession(res); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// getStorageChanges returns contract storage changes from the given DAO batch
+// ordered by contract ID and key.
+func (s *Server) getStorageChanges(b *storage.MemBatch) []result.StorageChange {
+	res := make([]result.StorageChange, 0, len(b.Put)+len(b.Deleted))
+	add := func(kv storage.KeyValue, deleted bool) {
+		// Prefix byte and contract ID go first.
+		if len(kv.Key) < 5 || kv.Key[0] != byte(storage.STStorage) {
+			return
+		}
+		ch := result.StorageChange{
+			ID:  int32(binary.LittleEndian.Uint32(kv.Key[1:])),
+			Key: kv.Key[5:],
+		}
+		switch {
+		case deleted:
+			if !kv.Exists {
+				// Item was added and deleted by the script.
+				return
+			}
+			ch.State = "Deleted"
+		case kv.Exists:
+			ch.State = "Changed"
+			ch.Value = kv.Value
+		default:
+			ch.State = "Added"
+			ch.Value = kv.Value
+		}
+		if h, err := s.chain.GetContractScriptHash(ch.ID); err == nil {
+			ch.Contract = &h
+		}
+		res = append(res, ch)
+	}
+	for _, kv := range b.Put {
+		add(kv, false)
+	}
+	for _, kv := range b.Deleted {
+		add(kv, true)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].ID != res[j].ID {
+			return res[i].ID < res[j].ID
+		}
+		return bytes.Compare(res[i].Key, res[j].Key) < 0
+	})
+	return res
 }
 
 // submitBlock broadcasts a raw block over the NEO network.
@@ -1299,6 +2548,10 @@ func getRelayResult(err error, hash util.Uint256) (interface{}, *response.Error)
 		return nil, response.WrapErrorWithData(response.ErrOutOfMemory, err)
 	case errors.Is(err, core.ErrPolicy):
 		return nil, response.WrapErrorWithData(response.ErrPolicyFail, err)
+	case errors.Is(err, core.ErrInsufficientFunds):
+		return nil, response.WrapErrorWithData(response.ErrInsufficientFunds, err)
+	case errors.Is(err, core.ErrVerificationFailed):
+		return nil, response.WrapErrorWithData(response.ErrVerificationFailed, err)
 	default:
 		return nil, response.WrapErrorWithData(response.ErrValidationFailed, err)
 	}
@@ -1330,7 +2583,7 @@ func (s *Server) submitOracleResponse(ps request.Params) (interface{}, *response
 	}
 	data := broadcaster.GetMessage(pubBytes, uint64(reqID), txSig)
 	if !pub.Verify(msgSig, hash.Sha256(data).BytesBE()) {
-		return nil, response.NewRPCError("Invalid sign", "", nil)
+		return nil, response.NewInvalidParamsError("invalid sign", nil)
 	}
 	s.oracle.AddResponse(pub, uint64(reqID), txSig)
 	return json.RawMessage([]byte("{}")), nil
@@ -1358,18 +2611,18 @@ func (s *Server) subscribe(reqParams request.Params, sub *subscriber) (interface
 		return nil, response.ErrInvalidParams
 	}
 	event, err := response.GetEventIDFromString(streamName)
-	if err != nil || event == response.MissedEventID {
+	if err != nil || event == response.MissedEventID || event == response.TransactionFinalityEventID {
 		return nil, response.ErrInvalidParams
 	}
 	// Optional filter.
 	var filter interface{}
-	if p := reqParams.Value(1); p != nil {
+	if p := reqParams.Value(1); !p.IsNull() {
 		switch event {
 		case response.BlockEventID:
 			if p.Type != request.BlockFilterT {
 				return nil, response.ErrInvalidParams
 			}
-		case response.TransactionEventID:
+		case response.TransactionEventID, response.MempoolEventID:
 			if p.Type != request.TxFilterT {
 				return nil, response.ErrInvalidParams
 			}
@@ -1381,9 +2634,25 @@ func (s *Server) subscribe(reqParams request.Params, sub *subscriber) (interface
 			if p.Type != request.ExecutionFilterT {
 				return nil, response.ErrInvalidParams
 			}
+		default:
+			// Filters are not supported for other events.
+			return nil, response.ErrInvalidParams
 		}
 		filter = p.Value
 	}
+	// Optional block index to replay past events from.
+	var replayFrom *uint32
+	if p := reqParams.Value(2); p != nil {
+		from, err := p.GetInt()
+		if err != nil || from < 0 || !canReplay(event) {
+			return nil, response.ErrInvalidParams
+		}
+		if height := int(s.chain.BlockHeight()); height-from >= maxReplayBlocks {
+			return nil, response.NewInvalidParamsError(fmt.Sprintf("can't replay events for more than %d blocks", maxReplayBlocks), nil)
+		}
+		index := uint32(from)
+		replayFrom = &index
+	}
 
 	s.subsLock.Lock()
 	defer s.subsLock.Unlock()
@@ -1403,6 +2672,7 @@ func (s *Server) subscribe(reqParams request.Params, sub *subscriber) (interface
 	}
 	sub.feeds[id].event = event
 	sub.feeds[id].filter = filter
+	sub.feeds[id].replayFrom = replayFrom
 	s.subscribeToChannel(event)
 	return strconv.FormatInt(int64(id), 10), nil
 }
@@ -1432,6 +2702,132 @@ func (s *Server) subscribeToChannel(event response.EventID) {
 			s.chain.SubscribeForExecutions(s.executionCh)
 		}
 		s.executionSubs++
+	case response.MempoolEventID:
+		if s.mempoolSubs == 0 {
+			mp := s.chain.GetMemPool()
+			mp.RunSubscriptions()
+			mp.SubscribeForTransactions(s.mempoolCh)
+		}
+		s.mempoolSubs++
+	case response.StateRootEventID:
+		if s.stateRootSubs == 0 {
+			s.chain.GetStateModule().SubscribeForStateRoots(s.stateRootCh)
+		}
+		s.stateRootSubs++
+	}
+}
+
+// watchTransactionHTTP handles watchtransaction requests made via HTTP, they
+// can only use callback URLs.
+func (s *Server) watchTransactionHTTP(reqParams request.Params) (interface{}, *response.Error) {
+	return s.watchTransaction(reqParams, nil)
+}
+
+// watchTransaction registers transaction finality watch, notifications are
+// either POSTed to the callback URL given or sent to the websocket client as
+// transaction_finality events.
+func (s *Server) watchTransaction(reqParams request.Params, sub *subscriber) (interface{}, *response.Error) {
+	if s.finality == nil {
+		return nil, response.NewInternalServerError("finality watcher is not enabled", nil)
+	}
+	h, err := reqParams.Value(0).GetUint256()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	var callback string
+	if p := reqParams.Value(1); p != nil {
+		callback, err = p.GetString()
+		if err != nil {
+			return nil, response.ErrInvalidParams
+		}
+	}
+	var (
+		notify finality.NotifyFunc
+		owner  interface{}
+	)
+	switch {
+	case callback != "":
+		u, err := url.Parse(callback)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return nil, response.NewInvalidParamsError("invalid callback URL", err)
+		}
+		if !s.callbackAllowed(u) {
+			return nil, response.NewInvalidParamsError("callback URL is not allowed", nil)
+		}
+		notify = s.finality.HTTPNotifier(callback)
+	case sub != nil:
+		notify = func(ev finality.Event) { s.sendFinalityEvent(sub, ev) }
+		owner = sub
+	default:
+		return nil, response.NewInvalidParamsError("callback URL is required", nil)
+	}
+	err = s.finality.Watch(h, 0, owner, notify)
+	switch {
+	case errors.Is(err, finality.ErrUnknownTransaction):
+		return nil, response.NewRPCError("Unknown transaction", "", err)
+	case err != nil:
+		return nil, response.NewInternalServerError("can't watch transaction", err)
+	}
+	return true, nil
+}
+
+// callbackAllowed checks whether the callback URL matches one of the allowed
+// URL prefixes from the configuration.
+func (s *Server) callbackAllowed(u *url.URL) bool {
+	for _, a := range s.config.FinalityWatcher.AllowedCallbacks {
+		allowed, err := url.Parse(a)
+		if err != nil {
+			continue
+		}
+		if u.Scheme == allowed.Scheme && u.Host == allowed.Host && u.User == nil &&
+			strings.HasPrefix(u.Path, allowed.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// unwatchTransaction drops finality watches for the given transaction made by
+// the websocket client, watches are also dropped when the client disconnects.
+func (s *Server) unwatchTransaction(reqParams request.Params, sub *subscriber) (interface{}, *response.Error) {
+	if s.finality == nil {
+		return nil, response.NewInternalServerError("finality watcher is not enabled", nil)
+	}
+	h, err := reqParams.Value(0).GetUint256()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	return s.finality.Unwatch(sub, &h) != 0, nil
+}
+
+// sendFinalityEvent sends transaction finality event to the given websocket
+// client if it's still connected. Events are dropped if client's buffer is
+// full.
+func (s *Server) sendFinalityEvent(sub *subscriber, ev finality.Event) {
+	b, err := json.Marshal(response.Notification{
+		JSONRPC: request.JSONRPCVersion,
+		Event:   response.TransactionFinalityEventID,
+		Payload: []interface{}{ev},
+	})
+	if err != nil {
+		s.log.Error("failed to marshal finality event", zap.Error(err))
+		return
+	}
+	msg, err := websocket.NewPreparedMessage(websocket.TextMessage, b)
+	if err != nil {
+		s.log.Error("failed to prepare finality event message", zap.Error(err))
+		return
+	}
+	s.subsLock.RLock()
+	defer s.subsLock.RUnlock()
+	if !s.subscribers[sub] {
+		return
+	}
+	select {
+	case sub.writer <- msg:
+	default:
+		s.log.Warn("dropping finality event for overflown websocket client",
+			zap.String("hash", ev.Hash.StringLE()))
 	}
 }
 
@@ -1449,6 +2845,7 @@ func (s *Server) unsubscribe(reqParams request.Params, sub *subscriber) (interfa
 	event := sub.feeds[id].event
 	sub.feeds[id].event = response.InvalidEventID
 	sub.feeds[id].filter = nil
+	sub.feeds[id].replayFrom = nil
 	s.unsubscribeFromChannel(event)
 	return true, nil
 }
@@ -1478,6 +2875,16 @@ func (s *Server) unsubscribeFromChannel(event response.EventID) {
 		if s.executionSubs == 0 {
 			s.chain.UnsubscribeFromExecutions(s.executionCh)
 		}
+	case response.MempoolEventID:
+		s.mempoolSubs--
+		if s.mempoolSubs == 0 {
+			s.chain.GetMemPool().UnsubscribeFromTransactions(s.mempoolCh)
+		}
+	case response.StateRootEventID:
+		s.stateRootSubs--
+		if s.stateRootSubs == 0 {
+			s.chain.GetStateModule().UnsubscribeFromStateRoots(s.stateRootCh)
+		}
 	}
 }
 
@@ -1518,6 +2925,12 @@ chloop:
 		case tx := <-s.transactionCh:
 			resp.Event = response.TransactionEventID
 			resp.Payload[0] = tx
+		case e := <-s.mempoolCh:
+			resp.Event = response.MempoolEventID
+			resp.Payload[0] = &result.MempoolEvent{Type: e.Type, Transaction: e.Tx, Reason: e.Reason}
+		case sr := <-s.stateRootCh:
+			resp.Event = response.StateRootEventID
+			resp.Payload[0] = sr
 		}
 		s.subsLock.RLock()
 	subloop:
@@ -1526,7 +2939,7 @@ chloop:
 				continue
 			}
 			for i := range sub.feeds {
-				if sub.feeds[i].Matches(&resp) {
+				if sub.feeds[i].replayFrom == nil && sub.feeds[i].Matches(&resp) {
 					if msg == nil {
 						b, err = json.Marshal(resp)
 						if err != nil {
@@ -1570,6 +2983,8 @@ chloop:
 	s.chain.UnsubscribeFromTransactions(s.transactionCh)
 	s.chain.UnsubscribeFromNotifications(s.notificationCh)
 	s.chain.UnsubscribeFromExecutions(s.executionCh)
+	s.chain.GetMemPool().UnsubscribeFromTransactions(s.mempoolCh)
+	s.chain.GetStateModule().UnsubscribeFromStateRoots(s.stateRootCh)
 	s.subsLock.Unlock()
 drainloop:
 	for {
@@ -1578,6 +2993,8 @@ drainloop:
 		case <-s.executionCh:
 		case <-s.notificationCh:
 		case <-s.transactionCh:
+		case <-s.mempoolCh:
+		case <-s.stateRootCh:
 		default:
 			break drainloop
 		}
@@ -1588,6 +3005,8 @@ drainloop:
 	close(s.transactionCh)
 	close(s.notificationCh)
 	close(s.executionCh)
+	close(s.mempoolCh)
+	close(s.stateRootCh)
 }
 
 func (s *Server) blockHeightFromParam(param *request.Param) (int, *response.Error) {
@@ -1654,10 +3073,6 @@ func (s *Server) writeHTTPServerResponse(r *request.Request, w http.ResponseWrit
 		}
 	}
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	if s.config.EnableCORSWorkaround {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Access-Control-Allow-Headers, Authorization, X-Requested-With")
-	}
 
 	encoder := json.NewEncoder(w)
 	err := encoder.Encode(resp)
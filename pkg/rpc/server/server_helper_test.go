@@ -6,6 +6,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
@@ -99,7 +100,10 @@ func getTestBlocks(t *testing.T) []*block.Block {
 
 func initClearServerWithServices(t *testing.T, needOracle bool, needNotary bool) (*core.Blockchain, *Server, *httptest.Server) {
 	chain, orc, cfg, logger := getUnitTestChain(t, needOracle, needNotary)
+	return wrapUnitTestChain(t, chain, orc, cfg, logger)
+}
 
+func wrapUnitTestChain(t *testing.T, chain *core.Blockchain, orc *oracle.Oracle, cfg config.Config, logger *zap.Logger) (*core.Blockchain, *Server, *httptest.Server) {
 	serverConfig := network.NewServerConfig(cfg)
 	server, err := network.NewServer(serverConfig, chain, logger)
 	require.NoError(t, err)
@@ -123,6 +127,19 @@ func initServerWithInMemoryChain(t *testing.T) (*core.Blockchain, *Server, *http
 	for _, b := range getTestBlocks(t) {
 		require.NoError(t, chain.AddBlock(b))
 	}
+	waitTransferLog(t, chain)
+	return chain, rpcServer, srv
+}
+
+func initServerWithCustomConfig(t *testing.T, f func(*config.Config)) (*core.Blockchain, *Server, *httptest.Server) {
+	chain, orc, cfg, logger := getUnitTestChain(t, false, false)
+	f(&cfg)
+	chain, rpcServer, srv := wrapUnitTestChain(t, chain, orc, cfg, logger)
+
+	for _, b := range getTestBlocks(t) {
+		require.NoError(t, chain.AddBlock(b))
+	}
+	waitTransferLog(t, chain)
 	return chain, rpcServer, srv
 }
 
@@ -132,9 +149,17 @@ func initServerWithInMemoryChainAndServices(t *testing.T, needOracle bool, needN
 	for _, b := range getTestBlocks(t) {
 		require.NoError(t, chain.AddBlock(b))
 	}
+	waitTransferLog(t, chain)
 	return chain, rpcServer, srv
 }
 
+// waitTransferLog waits for transfer logs to be written for all blocks in the
+// chain.
+func waitTransferLog(t *testing.T, chain *core.Blockchain) {
+	require.Eventually(t, func() bool { return chain.TransferLogHeight() == chain.BlockHeight() },
+		time.Second, 10*time.Millisecond)
+}
+
 type FeerStub struct{}
 
 func (fs *FeerStub) FeePerByte() int64 {
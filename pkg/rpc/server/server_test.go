@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"math/big"
@@ -19,19 +20,27 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/nspcc-dev/neo-go/internal/testchain"
 	"github.com/nspcc-dev/neo-go/internal/testserdes"
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/rpc"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
 	rpc2 "github.com/nspcc-dev/neo-go/pkg/services/oracle/broadcaster"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
@@ -40,6 +49,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
 )
 
 type executor struct {
@@ -127,11 +137,43 @@ var rpcTestCases = map[string][]rpcTestCase{
 				assert.Equal(t, vm.HaltState, res.Executions[0].VMState)
 			},
 		},
+		{
+			name:   "positive, genesis block, events filter",
+			params: `["` + genesisBlockHash + `", null, "Transfer"]`,
+			result: func(e *executor) interface{} { return &result.ApplicationLog{} },
+			check: func(t *testing.T, e *executor, acc interface{}) {
+				res, ok := acc.(*result.ApplicationLog)
+				require.True(t, ok)
+				assert.Equal(t, genesisBlockHash, res.Container.StringLE())
+				require.NotEqual(t, 0, len(res.Executions))
+				for _, exec := range res.Executions {
+					require.NotEqual(t, 0, len(exec.Events))
+					for _, ev := range exec.Events {
+						assert.Equal(t, "Transfer", ev.Name)
+					}
+				}
+			},
+		},
+		{
+			name:   "positive, events filter, no matches",
+			params: `["` + deploymentTxHash + `", "Application", "NoSuchEvent"]`,
+			result: func(e *executor) interface{} { return &result.ApplicationLog{} },
+			check: func(t *testing.T, e *executor, acc interface{}) {
+				res, ok := acc.(*result.ApplicationLog)
+				require.True(t, ok)
+				assert.Equal(t, 0, len(res.Executions))
+			},
+		},
 		{
 			name:   "invalid trigger (not a string)",
 			params: `["` + genesisBlockHash + `", 1]`,
 			fail:   true,
 		},
+		{
+			name:   "invalid event name (not a string)",
+			params: `["` + genesisBlockHash + `", "All", 1]`,
+			fail:   true,
+		},
 		{
 			name:   "no params",
 			params: `[]`,
@@ -216,6 +258,61 @@ var rpcTestCases = map[string][]rpcTestCase{
 		},
 	},
 
+	"getnep11balances": {
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid address",
+			params: `["notahex"]`,
+			fail:   true,
+		},
+		{
+			name:   "positive",
+			params: `["` + testchain.PrivateKeyByID(0).Address() + `"]`,
+			result: func(e *executor) interface{} {
+				return &result.NEP11Balances{
+					Address:  testchain.PrivateKeyByID(0).Address(),
+					Balances: []result.NEP11AssetBalance{},
+				}
+			},
+		},
+	},
+	"getnep11transfers": {
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid address",
+			params: `["notahex"]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid timestamp",
+			params: `["` + testchain.PrivateKeyByID(0).Address() + `", "notanumber"]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid limit",
+			params: `["` + testchain.PrivateKeyByID(0).Address() + `", "1", "2", "0"]`,
+			fail:   true,
+		},
+		{
+			name:   "positive",
+			params: `["` + testchain.PrivateKeyByID(0).GetScriptHash().StringLE() + `", 0]`,
+			result: func(e *executor) interface{} {
+				return &result.NEP11Transfers{
+					Address:  testchain.PrivateKeyByID(0).Address(),
+					Received: []result.NEP11Transfer{},
+					Sent:     []result.NEP11Transfer{},
+				}
+			},
+		},
+	},
 	"getnep17balances": {
 		{
 			name:   "no params",
@@ -548,6 +645,52 @@ var rpcTestCases = map[string][]rpcTestCase{
 			},
 		},
 	},
+	"getdesignatedbyrole": {
+		{
+			name:   "positive, by name",
+			params: `["P2PNotary"]`,
+			result: func(e *executor) interface{} { return &result.Designation{} },
+			check: func(t *testing.T, e *executor, res interface{}) {
+				d, ok := res.(*result.Designation)
+				require.True(t, ok)
+				require.Equal(t, noderoles.P2PNotary, d.Role)
+				require.Equal(t, 1, len(d.Nodes))
+				require.NotEqual(t, uint32(0), d.Height)
+				nodes, height, err := e.chain.GetDesignatedByRole(noderoles.P2PNotary, e.chain.BlockHeight()+1)
+				require.NoError(t, err)
+				require.Equal(t, 1, len(nodes))
+				require.Equal(t, nodes[0].Bytes(), d.Nodes[0].Bytes())
+				require.Equal(t, height, d.Height)
+			},
+		},
+		{
+			name:   "positive, historic",
+			params: `[128, 1]`,
+			result: func(e *executor) interface{} {
+				return &result.Designation{Role: noderoles.P2PNotary, Nodes: keys.PublicKeys{}}
+			},
+		},
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "unknown role name",
+			params: `["Validator"]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid role number",
+			params: `[5]`,
+			fail:   true,
+		},
+		{
+			name:   "index is too big",
+			params: `["Oracle", 100500]`,
+			fail:   true,
+		},
+	},
 	"getconnectioncount": {
 		{
 			params: "[]",
@@ -574,6 +717,58 @@ var rpcTestCases = map[string][]rpcTestCase{
 			},
 		},
 	},
+	"getnativeprices": {
+		{
+			name:   "all",
+			params: "[]",
+			result: func(e *executor) interface{} {
+				return new([]result.NativePrices)
+			},
+			check: func(t *testing.T, e *executor, res interface{}) {
+				lst := res.(*[]result.NativePrices)
+				require.Equal(t, len(e.chain.GetNatives()), len(*lst))
+				for _, c := range *lst {
+					cs := e.chain.GetContractState(c.Hash)
+					require.NotNil(t, cs)
+					require.Equal(t, cs.Manifest.Name, c.Name)
+					require.Equal(t, len(cs.Manifest.ABI.Methods), len(c.Methods))
+				}
+			},
+		},
+		{
+			name:   "by name",
+			params: `["NeoToken"]`,
+			result: func(e *executor) interface{} {
+				return new([]result.NativePrices)
+			},
+			check: func(t *testing.T, e *executor, res interface{}) {
+				lst := res.(*[]result.NativePrices)
+				require.Equal(t, 1, len(*lst))
+				require.Equal(t, "NeoToken", (*lst)[0].Name)
+				var found bool
+				for _, m := range (*lst)[0].Methods {
+					if m.Name == "transfer" {
+						found = true
+						require.Equal(t, 4, m.ParamCount)
+						require.NotEqual(t, 0, m.StorageFee)
+						require.Equal(t, m.CPUFee*e.chain.GetBaseExecFee()+m.StorageFee*e.chain.GetStoragePrice(), m.Price)
+						require.True(t, m.RequiredFlags&callflag.WriteStates != 0)
+					}
+				}
+				require.True(t, found)
+			},
+		},
+		{
+			name:   "unknown contract",
+			params: `["0000000000000000000000000000000000000001"]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid param",
+			params: `[[]]`,
+			fail:   true,
+		},
+	},
 	"getpeers": {
 		{
 			params: "[]",
@@ -585,6 +780,17 @@ var rpcTestCases = map[string][]rpcTestCase{
 				}
 			},
 		},
+		{
+			name:   "verbose",
+			params: "[true]",
+			result: func(*executor) interface{} {
+				return &result.GetPeers{
+					Unconnected: []result.Peer{},
+					Connected:   []result.Peer{},
+					Bad:         []result.Peer{},
+				}
+			},
+		},
 	},
 	"getrawtransaction": {
 		{
@@ -603,6 +809,52 @@ var rpcTestCases = map[string][]rpcTestCase{
 			fail:   true,
 		},
 	},
+	"findtransactions": {
+		{
+			name:   "positive, no transactions",
+			params: `["Conflicts", "` + deploymentTxHash + `"]`,
+			result: func(e *executor) interface{} {
+				return &[]util.Uint256{}
+			},
+		},
+		{
+			name:   "positive, with limit and page",
+			params: `["NotaryAssisted", 1, 10, 1]`,
+			result: func(e *executor) interface{} {
+				return &[]util.Uint256{}
+			},
+		},
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "unsupported attribute",
+			params: `["HighPriority", 1]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid oracle ID",
+			params: `["OracleResponse", -1]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid hash",
+			params: `["Conflicts", "notahex"]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid number of keys",
+			params: `["NotaryAssisted", 256]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid limit",
+			params: `["NotaryAssisted", 1, 0]`,
+			fail:   true,
+		},
+	},
 	"gettransactionheight": {
 		{
 			name:   "positive",
@@ -661,6 +913,15 @@ var rpcTestCases = map[string][]rpcTestCase{
 			},
 		},
 	},
+	"getcandidates": {
+		{
+			params: "[]",
+			result: func(*executor) interface{} {
+				// There are no registered candidates in the test chain.
+				return &[]result.Candidate{}
+			},
+		},
+	},
 	"getnextblockvalidators": {
 		{
 			params: "[]",
@@ -709,6 +970,39 @@ var rpcTestCases = map[string][]rpcTestCase{
 				assert.NotNil(t, res.Script)
 				assert.NotEqual(t, "", res.State)
 				assert.NotEqual(t, 0, res.GasConsumed)
+				assert.Nil(t, res.Diagnostics)
+			},
+		},
+		{
+			name: "positive, diagnostics",
+			params: fmt.Sprintf(`["%s", "putValue", [{"type": "String", "value": "diagkey"}, {"type": "String", "value": "diagvalue"}], [], null, 1]`,
+				testContractHash),
+			result: func(e *executor) interface{} { return &result.Invoke{} },
+			check: func(t *testing.T, e *executor, inv interface{}) {
+				res, ok := inv.(*result.Invoke)
+				require.True(t, ok)
+				require.Equal(t, "HALT", res.State)
+				require.NotNil(t, res.Diagnostics)
+
+				cHash, err := util.Uint160DecodeStringLE(testContractHash)
+				require.NoError(t, err)
+				require.Equal(t, 1, len(res.Diagnostics.Invocations))
+				script := res.Diagnostics.Invocations[0]
+				require.Equal(t, hash.Hash160(res.Script), script.Current)
+				require.Equal(t, 1, len(script.Calls))
+				require.Equal(t, cHash, script.Calls[0].Current)
+				require.Equal(t, callflag.All, script.Calls[0].Flags)
+
+				cs := e.chain.GetContractState(cHash)
+				require.NotNil(t, cs)
+				require.Equal(t, []result.StorageChange{{
+					State:    "Added",
+					ID:       cs.ID,
+					Contract: &cHash,
+					Key:      []byte("diagkey"),
+					Value:    []byte("diagvalue"),
+				}}, res.Diagnostics.Changes)
+				require.Nil(t, e.chain.GetStorageItem(cs.ID, []byte("diagkey")))
 			},
 		},
 		{
@@ -726,6 +1020,41 @@ var rpcTestCases = map[string][]rpcTestCase{
 			params: `["qwerty", "test", []]`,
 			fail:   true,
 		},
+		{
+			name:   "positive, gas budget",
+			params: `["50befd26fdf6e4d957c11e078b24ebce6291456f", "test", [], [], 1]`,
+			result: func(e *executor) interface{} { return &result.Invoke{} },
+			check: func(t *testing.T, e *executor, inv interface{}) {
+				res, ok := inv.(*result.Invoke)
+				require.True(t, ok)
+				assert.Equal(t, "FAULT", res.State)
+			},
+		},
+		{
+			name:   "gas budget exceeds limit",
+			params: `["50befd26fdf6e4d957c11e078b24ebce6291456f", "test", [], [], 100000000000]`,
+			fail:   true,
+		},
+		{
+			name:   "positive, custom contracts and groups signer",
+			params: `["50befd26fdf6e4d957c11e078b24ebce6291456f", "test", [], [{"account": "0xcadb3dc2faa3ef14a13b619c9a43124755aa2569", "scopes": "CustomContracts, CustomGroups", "allowedcontracts": ["0x50befd26fdf6e4d957c11e078b24ebce6291456f"], "allowedgroups": ["02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2"]}]]`,
+			result: func(e *executor) interface{} { return &result.Invoke{} },
+			check: func(t *testing.T, e *executor, inv interface{}) {
+				res, ok := inv.(*result.Invoke)
+				require.True(t, ok)
+				assert.NotEqual(t, "", res.State)
+			},
+		},
+		{
+			name:   "allowed contracts without CustomContracts scope",
+			params: `["50befd26fdf6e4d957c11e078b24ebce6291456f", "test", [], [{"account": "0xcadb3dc2faa3ef14a13b619c9a43124755aa2569", "scopes": "CalledByEntry", "allowedcontracts": ["0x50befd26fdf6e4d957c11e078b24ebce6291456f"]}]]`,
+			fail:   true,
+		},
+		{
+			name:   "allowed groups without CustomGroups scope",
+			params: `["50befd26fdf6e4d957c11e078b24ebce6291456f", "test", [], [{"account": "0xcadb3dc2faa3ef14a13b619c9a43124755aa2569", "scopes": "CustomContracts", "allowedgroups": ["02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2"]}]]`,
+			fail:   true,
+		},
 		{
 			name:   "bad params",
 			params: `["50befd26fdf6e4d957c11e078b24ebce6291456f", "test", [{"type": "Integer", "value": "qwerty"}]]`,
@@ -794,6 +1123,26 @@ var rpcTestCases = map[string][]rpcTestCase{
 				assert.Equal(t, big.NewInt(1), res.Stack[0].Value())
 			},
 		},
+		{
+			name:   "positive, gas budget",
+			params: fmt.Sprintf(`["%s", [], 1]`, invokescriptContractAVM),
+			result: func(e *executor) interface{} { return &result.Invoke{} },
+			check: func(t *testing.T, e *executor, inv interface{}) {
+				res, ok := inv.(*result.Invoke)
+				require.True(t, ok)
+				assert.Equal(t, "FAULT", res.State)
+			},
+		},
+		{
+			name:   "gas budget exceeds limit",
+			params: fmt.Sprintf(`["%s", [], 100000000000]`, invokescriptContractAVM),
+			fail:   true,
+		},
+		{
+			name:   "invalid gas budget",
+			params: fmt.Sprintf(`["%s", [], "qwerty"]`, invokescriptContractAVM),
+			fail:   true,
+		},
 		{
 			name:   "no params",
 			params: `[]`,
@@ -1002,6 +1351,33 @@ var rpcTestCases = map[string][]rpcTestCase{
 			},
 		},
 	},
+	"watchtransaction": {
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid hash",
+			params: `["notahash"]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid callback",
+			params: `["0x0000000000000000000000000000000000000000000000000000000000000001", "ftp://localhost/"]`,
+			fail:   true,
+		},
+		{
+			name:   "callback not allowed",
+			params: `["0x0000000000000000000000000000000000000000000000000000000000000001", "http://example.com/"]`,
+			fail:   true,
+		},
+		{
+			name:   "unknown transaction",
+			params: `["0x0000000000000000000000000000000000000000000000000000000000000001", "http://localhost/"]`,
+			fail:   true,
+		},
+	},
 }
 
 func TestRPC(t *testing.T) {
@@ -1047,6 +1423,327 @@ func TestSubmitOracle(t *testing.T) {
 	t.Run("Valid", runCase(t, false, pubStr, `1`, txSigStr, msgSigStr))
 }
 
+func TestInvokeTimeout(t *testing.T) {
+	chain, rpcSrv, httpSrv := initClearServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	rpcSrv.config.MaxInvokeDuration = 10 * time.Millisecond
+	// Endless JMP loop.
+	script := base64.StdEncoding.EncodeToString([]byte{byte(opcode.JMP), 0})
+	req := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "invokescript", "params": ["%s"]}`, script)
+	body := doRPCCallOverHTTP(req, httpSrv.URL, t)
+	res := checkErrGetResult(t, body, false)
+
+	var inv result.Invoke
+	require.NoError(t, json.Unmarshal(res, &inv))
+	require.Equal(t, "FAULT", inv.State)
+	require.Equal(t, "execution time limit of 10ms exceeded", inv.FaultException)
+}
+
+func TestMaxTransfersLimit(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	rpcSrv.config.MaxTransfersLimit = 2
+	addr := testchain.PrivateKeyByID(0).Address()
+	end := time.Now().Unix() * 1000
+	req := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "getnep17transfers", "params": ["%s", 0, %d, 3]}`, addr, end)
+	body := doRPCCallOverHTTP(req, httpSrv.URL, t)
+	checkErrGetResult(t, body, true)
+
+	req = fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "getnep17transfers", "params": ["%s", 0, %d]}`, addr, end)
+	body = doRPCCallOverHTTP(req, httpSrv.URL, t)
+	res := checkErrGetResult(t, body, false)
+	actual := new(result.NEP17Transfers)
+	require.NoError(t, json.Unmarshal(res, actual))
+	require.Equal(t, 2, len(actual.Sent)+len(actual.Received))
+}
+
+func TestBatchLimits(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	makeBatch := func(n int) string {
+		reqs := make([]string, n)
+		for i := range reqs {
+			reqs[i] = fmt.Sprintf(`{"jsonrpc": "2.0", "id": %d, "method": "getblockhash", "params": [%d]}`, i, i)
+		}
+		return "[" + strings.Join(reqs, ",") + "]"
+	}
+	rpcSrv.config.MaxBatchSize = 3
+	rpcSrv.config.BatchConcurrency = 2
+
+	body := doRPCCallOverHTTP(makeBatch(4), httpSrv.URL, t)
+	checkErrGetResult(t, body, true)
+
+	body = doRPCCallOverHTTP(makeBatch(3), httpSrv.URL, t)
+	var responses []response.Raw
+	require.NoError(t, json.Unmarshal(body, &responses))
+	require.Equal(t, 3, len(responses))
+	for i, r := range responses {
+		require.Nil(t, r.Error)
+		require.Equal(t, strconv.Itoa(i), string(r.ID))
+		var h util.Uint256
+		require.NoError(t, json.Unmarshal(r.Result, &h))
+		require.Equal(t, chain.GetHeaderHash(i), h)
+	}
+}
+
+func TestRequestLimits(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	rpc := `{"jsonrpc": "2.0", "id": 1, "method": "getblockcount", "params": []}`
+
+	t.Run("body size", func(t *testing.T) {
+		rpcSrv.config.MaxRequestBodySize = int64(len(rpc) - 1)
+		defer func() { rpcSrv.config.MaxRequestBodySize = 0 }()
+
+		resp, err := http.Post(httpSrv.URL, "application/json", strings.NewReader(rpc))
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+
+		rpcSrv.config.MaxRequestBodySize = int64(len(rpc))
+		checkErrGetResult(t, doRPCCallOverHTTP(rpc, httpSrv.URL, t), false)
+	})
+	t.Run("concurrency", func(t *testing.T) {
+		rpcSrv.inFlight = make(chan struct{}, 1)
+		defer func() { rpcSrv.inFlight = nil }()
+
+		// Occupy the only slot.
+		rpcSrv.inFlight <- struct{}{}
+		body := doRPCCallOverHTTP(rpc, httpSrv.URL, t)
+		var resp response.Raw
+		require.NoError(t, json.Unmarshal(body, &resp))
+		require.NotNil(t, resp.Error)
+		require.Equal(t, response.ErrServerBusy.Code, resp.Error.Code)
+
+		<-rpcSrv.inFlight
+		checkErrGetResult(t, doRPCCallOverHTTP(rpc, httpSrv.URL, t), false)
+		require.Equal(t, 0, len(rpcSrv.inFlight))
+	})
+}
+
+func TestInvokeHistoric(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	acc := testchain.PrivateKeyByID(0).GetScriptHash()
+	gasHash := chain.UtilityTokenHash()
+	w := io.NewBufBinWriter()
+	emit.AppCall(w.BinWriter, gasHash, "balanceOf", callflag.ReadStates, acc)
+	script := base64.StdEncoding.EncodeToString(w.Bytes())
+
+	invoke := func(t *testing.T, method string, params string, fail bool) int64 {
+		rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "%s", "params": %s}`, method, params)
+		body := doRPCCallOverHTTP(rpc, httpSrv.URL, t)
+		raw := checkErrGetResult(t, body, fail)
+		if fail {
+			return 0
+		}
+		res := new(result.Invoke)
+		require.NoError(t, json.Unmarshal(raw, res))
+		require.Equal(t, "HALT", res.State, res.FaultException)
+		require.Equal(t, 1, len(res.Stack))
+		bi, err := res.Stack[0].TryInteger()
+		require.NoError(t, err)
+		return bi.Int64()
+	}
+	height := chain.BlockHeight()
+	current := chain.GetUtilityTokenBalance(acc).Int64()
+	require.True(t, current > 0)
+
+	t.Run("invokescripthistoric", func(t *testing.T) {
+		require.Equal(t, int64(0), invoke(t, "invokescripthistoric", fmt.Sprintf(`[0, "%s"]`, script), false))
+		require.Equal(t, current, invoke(t, "invokescripthistoric", fmt.Sprintf(`[%d, "%s"]`, height, script), false))
+
+		sr, err := chain.GetStateModule().GetStateRoot(0)
+		require.NoError(t, err)
+		require.Equal(t, int64(0), invoke(t, "invokescripthistoric", fmt.Sprintf(`["%s", "%s"]`, sr.Root.StringLE(), script), false))
+	})
+	t.Run("invokefunctionhistoric", func(t *testing.T) {
+		params := fmt.Sprintf(`["%s", "balanceOf", [{"type": "Hash160", "value": "%s"}]]`, gasHash.StringLE(), acc.StringLE())
+		require.Equal(t, int64(0), invoke(t, "invokefunctionhistoric", `[0, `+params[1:], false))
+		require.Equal(t, current, invoke(t, "invokefunctionhistoric", fmt.Sprintf(`[%d, `, height)+params[1:], false))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		invoke(t, "invokescripthistoric", fmt.Sprintf(`[%d, "%s"]`, height+1, script), true)
+		invoke(t, "invokescripthistoric", fmt.Sprintf(`["%s", "%s"]`, util.Uint256{1, 2, 3}.StringLE(), script), true)
+		invoke(t, "invokescripthistoric", fmt.Sprintf(`["notaroot", "%s"]`, script), true)
+		invoke(t, "invokescripthistoric", `[]`, true)
+	})
+}
+
+func TestSimulateScript(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	acc := util.Uint160{1, 2, 3}
+	contract, err := util.Uint160DecodeStringLE(testContractHash)
+	require.NoError(t, err)
+	gasHash := chain.UtilityTokenHash()
+
+	simulate := func(t *testing.T, script []byte, ov string, fail bool) *result.Invoke {
+		rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "simulatescript", "params": ["%s", %s]}`,
+			base64.StdEncoding.EncodeToString(script), ov)
+		body := doRPCCallOverHTTP(rpc, httpSrv.URL, t)
+		raw := checkErrGetResult(t, body, fail)
+		if fail {
+			return nil
+		}
+		res := new(result.Invoke)
+		require.NoError(t, json.Unmarshal(raw, res))
+		require.Equal(t, "HALT", res.State, res.FaultException)
+		require.Equal(t, 1, len(res.Stack))
+		return res
+	}
+	getInt := func(t *testing.T, res *result.Invoke) int64 {
+		bi, err := res.Stack[0].TryInteger()
+		require.NoError(t, err)
+		return bi.Int64()
+	}
+
+	w := io.NewBufBinWriter()
+	emit.AppCall(w.BinWriter, gasHash, "balanceOf", callflag.ReadStates, acc)
+	gasScript := w.Bytes()
+	balanceOv := fmt.Sprintf(`{"balances": [{"account": "%s", "asset": "%s", "amount": "12345"}]}`,
+		acc.StringLE(), gasHash.StringLE())
+
+	t.Run("disabled", func(t *testing.T) {
+		simulate(t, gasScript, balanceOv, true)
+	})
+	rpcSrv.config.EnableStateOverride = true
+
+	t.Run("balance", func(t *testing.T) {
+		require.Equal(t, int64(12345), getInt(t, simulate(t, gasScript, balanceOv, false)))
+		require.Equal(t, int64(0), chain.GetUtilityTokenBalance(acc).Int64())
+	})
+	t.Run("storage", func(t *testing.T) {
+		w := io.NewBufBinWriter()
+		emit.AppCall(w.BinWriter, contract, "balanceOf", callflag.ReadStates, acc)
+		ov := fmt.Sprintf(`{"storage": [{"contract": "%s", "key": "%s", "value": "%s"}]}`, testContractHash,
+			base64.StdEncoding.EncodeToString(acc.BytesBE()), base64.StdEncoding.EncodeToString([]byte{42}))
+		require.Equal(t, int64(42), getInt(t, simulate(t, w.Bytes(), ov, false)))
+	})
+	t.Run("timestamp", func(t *testing.T) {
+		w := io.NewBufBinWriter()
+		emit.Syscall(w.BinWriter, interopnames.SystemRuntimeGetTime)
+		require.Equal(t, int64(123456), getInt(t, simulate(t, w.Bytes(), `{"timestamp": 123456}`, false)))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		simulate(t, gasScript, `{"balances": [{"account": "`+acc.StringLE()+`", "asset": "`+contract.StringLE()+`", "amount": "1"}]}`, true)
+		simulate(t, gasScript, `{"storage": [{"contract": "`+acc.StringLE()+`", "key": "AQ==", "value": null}]}`, true)
+		simulate(t, gasScript, `"notanoverride"`, true)
+	})
+}
+
+func TestRateLimit(t *testing.T) {
+	chain, rpcSrv, httpSrv := initClearServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	rpcSrv.limiter = newRateLimiter(rpc.RateLimitConfig{
+		Enabled:           true,
+		RequestsPerSecond: 0.001,
+		Burst:             2,
+	})
+	req := `{"jsonrpc": "2.0", "id": 1, "method": "getblockcount", "params": []}`
+	for i := 0; i < 2; i++ {
+		checkErrGetResult(t, doRPCCallOverHTTP(req, httpSrv.URL, t), false)
+	}
+	body := doRPCCallOverHTTP(req, httpSrv.URL, t)
+	var resp response.Raw
+	require.NoError(t, json.Unmarshal(body, &resp))
+	require.NotNil(t, resp.Error)
+	require.True(t, errors.Is(resp.Error, response.ErrLimitExceeded))
+}
+
+func TestCalculateNetworkFee(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	const rpc = `{"jsonrpc": "2.0", "id": 1, "method": "calculatenetworkfee", "params": ["%s"]}`
+	priv0 := testchain.PrivateKeyByID(0)
+	dummySig := append([]byte{byte(opcode.PUSHDATA1), 64}, make([]byte, 64)...)
+	newTx := func(acc util.Uint160, w transaction.Witness) *transaction.Transaction {
+		tx := transaction.New(netmode.UnitTestNet, []byte{byte(opcode.PUSH1)}, 0)
+		tx.ValidUntilBlock = 100
+		tx.Signers = []transaction.Signer{{Account: acc}}
+		tx.Scripts = []transaction.Witness{w}
+		return tx
+	}
+	calculate := func(t *testing.T, tx *transaction.Transaction, fail bool) int64 {
+		body := doRPCCallOverHTTP(fmt.Sprintf(rpc, base64.StdEncoding.EncodeToString(tx.Bytes())), httpSrv.URL, t)
+		raw := checkErrGetResult(t, body, fail)
+		if fail {
+			return 0
+		}
+		res := new(result.NetworkFee)
+		require.NoError(t, json.Unmarshal(raw, res))
+		return res.Value
+	}
+	baseSize := func(tx *transaction.Transaction) int {
+		scripts := tx.Scripts
+		tx.Scripts = nil
+		defer func() { tx.Scripts = scripts }()
+		return io.GetVarSize(tx)
+	}
+
+	t.Run("invalid transaction", func(t *testing.T) {
+		body := doRPCCallOverHTTP(fmt.Sprintf(rpc, "not-a-tx"), httpSrv.URL, t)
+		checkErrGetResult(t, body, true)
+	})
+	t.Run("signature", func(t *testing.T) {
+		verif := priv0.PublicKey().GetVerificationScript()
+		tx := newTx(priv0.GetScriptHash(), transaction.Witness{InvocationScript: dummySig, VerificationScript: verif})
+		netFee, sizeDelta := fee.Calculate(chain.GetPolicer().GetBaseExecFee(), verif)
+		expected := netFee + int64(baseSize(tx)+sizeDelta)*chain.FeePerByte()
+		require.Equal(t, expected, calculate(t, tx, false))
+	})
+	t.Run("hash mismatch", func(t *testing.T) {
+		tx := newTx(util.Uint160{1, 2, 3}, transaction.Witness{
+			InvocationScript:   dummySig,
+			VerificationScript: priv0.PublicKey().GetVerificationScript(),
+		})
+		calculate(t, tx, true)
+	})
+	t.Run("contract", func(t *testing.T) {
+		h, err := util.Uint160DecodeStringLE(verifyContractHash)
+		require.NoError(t, err)
+		tx := newTx(h, transaction.Witness{InvocationScript: []byte{}, VerificationScript: []byte{}})
+		sizeFee := int64(baseSize(tx)+2) * chain.FeePerByte()
+		require.Greater(t, calculate(t, tx, false), sizeFee)
+	})
+	t.Run("non-standard script", func(t *testing.T) {
+		verif := []byte{byte(opcode.DROP), byte(opcode.PUSHT)}
+		tx := newTx(hash.Hash160(verif), transaction.Witness{InvocationScript: dummySig, VerificationScript: verif})
+		sizeFee := int64(baseSize(tx)+io.GetVarSize(dummySig)+io.GetVarSize(verif)) * chain.FeePerByte()
+		require.Greater(t, calculate(t, tx, false), sizeFee)
+	})
+	t.Run("failing contract", func(t *testing.T) {
+		h, err := util.Uint160DecodeStringLE(verifyWithArgsContractHash)
+		require.NoError(t, err)
+		tx := newTx(h, transaction.Witness{InvocationScript: []byte{}, VerificationScript: []byte{}})
+		calculate(t, tx, true)
+	})
+	t.Run("gas limit", func(t *testing.T) {
+		rpcSrv.config.MaxVerificationGas = 1
+		defer func() { rpcSrv.config.MaxVerificationGas = 0 }()
+		h, err := util.Uint160DecodeStringLE(verifyContractHash)
+		require.NoError(t, err)
+		tx := newTx(h, transaction.Witness{InvocationScript: []byte{}, VerificationScript: []byte{}})
+		calculate(t, tx, true)
+	})
+}
+
 func TestSubmitNotaryRequest(t *testing.T) {
 	rpc := `{"jsonrpc": "2.0", "id": 1, "method": "submitnotaryrequest", "params": %s}`
 
@@ -1362,6 +2059,123 @@ func testRPCProtocol(t *testing.T, doRPCCall func(string, string, *testing.T) []
 		require.NoError(t, json.Unmarshal(rawRes, vp))
 		require.Equal(t, []byte("testvalue"), vp.Value)
 	})
+	t.Run("getstate and findstates", func(t *testing.T) {
+		r, err := chain.GetStateModule().GetStateRoot(3)
+		require.NoError(t, err)
+		key := base64.StdEncoding.EncodeToString([]byte("testkey"))
+
+		rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "getstate", "params": ["%s", "%s", "%s"]}`,
+			r.Root.StringLE(), testContractHash, key)
+		body := doRPCCall(rpc, httpSrv.URL, t)
+		rawRes := checkErrGetResult(t, body, false)
+		var val []byte
+		require.NoError(t, json.Unmarshal(rawRes, &val))
+		require.Equal(t, []byte("testvalue"), val)
+
+		rpc = fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "getstate", "params": ["%s", "%s", "%s"]}`,
+			r.Root.StringLE(), testContractHash, base64.StdEncoding.EncodeToString([]byte("missingkey")))
+		body = doRPCCall(rpc, httpSrv.URL, t)
+		checkErrGetResult(t, body, true)
+
+		rpc = fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "findstates", "params": ["%s", "%s", "%s"]}`,
+			r.Root.StringLE(), testContractHash, key)
+		body = doRPCCall(rpc, httpSrv.URL, t)
+		rawRes = checkErrGetResult(t, body, false)
+		res := new(result.FindStates)
+		require.NoError(t, json.Unmarshal(rawRes, res))
+		require.True(t, len(res.Results) > 0)
+		require.Equal(t, []byte("testkey"), res.Results[0].Key)
+		require.Equal(t, []byte("testvalue"), res.Results[0].Value)
+		require.NotNil(t, res.FirstProof)
+		proved, ok := mpt.VerifyProof(r.Root, res.FirstProof.Key, res.FirstProof.Proof)
+		require.True(t, ok)
+		require.Equal(t, []byte("testvalue"), proved)
+
+		rpc = fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "findstates", "params": ["%s", "%s", "%s", null, 1]}`,
+			r.Root.StringLE(), testContractHash, "")
+		body = doRPCCall(rpc, httpSrv.URL, t)
+		rawRes = checkErrGetResult(t, body, false)
+		page := new(result.FindStates)
+		require.NoError(t, json.Unmarshal(rawRes, page))
+		// Test contract has more than one storage item.
+		require.True(t, page.Truncated)
+		require.NotEmpty(t, page.Next)
+		rpc = fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "findstates", "params": ["%s", "%s", "%s", null, 1, "%s"]}`,
+			r.Root.StringLE(), testContractHash, "", page.Next)
+		body = doRPCCall(rpc, httpSrv.URL, t)
+		rawRes = checkErrGetResult(t, body, false)
+		next := new(result.FindStates)
+		require.NoError(t, json.Unmarshal(rawRes, next))
+		require.Equal(t, 1, len(next.Results))
+		require.Equal(t, 1, bytes.Compare(next.Results[0].Key, page.Results[0].Key))
+
+		for name, params := range map[string]string{
+			"bad count":            fmt.Sprintf(`["%s", "%s", "%s", "", 0]`, r.Root.StringLE(), testContractHash, key),
+			"start without prefix": fmt.Sprintf(`["%s", "%s", "%s", "YWJj"]`, r.Root.StringLE(), testContractHash, key),
+			"unknown contract":     fmt.Sprintf(`["%s", "0000000000000000000000000000000000000000", "%s"]`, r.Root.StringLE(), key),
+		} {
+			t.Run(name, func(t *testing.T) {
+				rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "findstates", "params": %s}`, params)
+				body := doRPCCall(rpc, httpSrv.URL, t)
+				checkErrGetResult(t, body, true)
+			})
+		}
+	})
+	t.Run("findstorage", func(t *testing.T) {
+		rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "findstorage", "params": ["%s", "%s"]}`,
+			testContractHash, base64.StdEncoding.EncodeToString([]byte("testkey")))
+		body := doRPCCall(rpc, httpSrv.URL, t)
+		rawRes := checkErrGetResult(t, body, false)
+		res := new(result.FindStorage)
+		require.NoError(t, json.Unmarshal(rawRes, res))
+		require.True(t, len(res.Results) > 0)
+		require.Equal(t, []byte("testkey"), res.Results[0].Key)
+		require.Equal(t, []byte("testvalue"), res.Results[0].Value)
+		require.False(t, res.Truncated)
+		require.Empty(t, res.Next)
+
+		var keys [][]byte
+		next := ""
+		for {
+			params := fmt.Sprintf(`["%s", "", 1]`, testContractHash)
+			if next != "" {
+				params = fmt.Sprintf(`["%s", "", 1, "%s"]`, testContractHash, next)
+			}
+			rpc = fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "findstorage", "params": %s}`, params)
+			body = doRPCCall(rpc, httpSrv.URL, t)
+			rawRes = checkErrGetResult(t, body, false)
+			page := new(result.FindStorage)
+			require.NoError(t, json.Unmarshal(rawRes, page))
+			require.Equal(t, 1, len(page.Results))
+			keys = append(keys, page.Results[0].Key)
+			if !page.Truncated {
+				break
+			}
+			next = page.Next
+		}
+		cHash, err := util.Uint160DecodeStringLE(testContractHash)
+		require.NoError(t, err)
+		all, err := chain.GetStorageItems(chain.GetContractState(cHash).ID)
+		require.NoError(t, err)
+		require.Equal(t, len(all), len(keys))
+		for i := 1; i < len(keys); i++ {
+			require.Equal(t, 1, bytes.Compare(keys[i], keys[i-1]))
+		}
+
+		for name, params := range map[string]string{
+			"bad count":          fmt.Sprintf(`["%s", "", 0]`, testContractHash),
+			"bad prefix":         fmt.Sprintf(`["%s", 1]`, testContractHash),
+			"bad cursor":         fmt.Sprintf(`["%s", "", null, "garbage!"]`, testContractHash),
+			"unknown contract":   `["0000000000000000000000000000000000000000", ""]`,
+			"cursor with prefix": fmt.Sprintf(`["%s", "YWJj", null, "%s"]`, testContractHash, (&cursor{Key: []byte("xyz")}).String()),
+		} {
+			t.Run(name, func(t *testing.T) {
+				rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "findstorage", "params": %s}`, params)
+				body := doRPCCall(rpc, httpSrv.URL, t)
+				checkErrGetResult(t, body, true)
+			})
+		}
+	})
 	t.Run("getstateroot", func(t *testing.T) {
 		testRoot := func(t *testing.T, p string) {
 			rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "getstateroot", "params": [%s]}`, p)
@@ -1501,6 +2315,47 @@ func testRPCProtocol(t *testing.T, doRPCCall func(string, string, *testing.T) []
 		require.NoErrorf(t, err, "could not parse response: %s", res)
 
 		assert.ElementsMatch(t, expected, actual)
+
+		rpc = `{"jsonrpc": "2.0", "id": 1, "method": "getrawmempool", "params": [1, 1]}`
+		body = doRPCCall(rpc, httpSrv.URL, t)
+		res = checkErrGetResult(t, body, false)
+
+		var details result.RawMempool
+		require.NoError(t, json.Unmarshal(res, &details))
+		require.Equal(t, chain.BlockHeight(), details.Height)
+		assert.ElementsMatch(t, expected, details.Verified)
+		require.Equal(t, len(expected), len(details.Transactions))
+		for i, d := range details.Transactions {
+			require.Equal(t, details.Verified[i], d.Hash)
+			tx, ok := mp.TryGetValue(d.Hash)
+			require.True(t, ok)
+			require.Equal(t, address.Uint160ToString(tx.Sender()), d.Sender)
+			require.Equal(t, tx.NetworkFee, d.NetworkFee)
+			require.Equal(t, tx.SystemFee, d.SystemFee)
+			require.Equal(t, tx.FeePerByte(), d.FeePerByte)
+		}
+	})
+
+	t.Run("estimatefee", func(t *testing.T) {
+		mp := chain.GetMemPool()
+		check := func(t *testing.T, params string) result.FeeEstimate {
+			rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "estimatefee", "params": [%s]}`, params)
+			body := doRPCCall(rpc, httpSrv.URL, t)
+			res := checkErrGetResult(t, body, false)
+			var actual result.FeeEstimate
+			require.NoError(t, json.Unmarshal(res, &actual))
+			require.Equal(t, mp.Count(), actual.MempoolSize)
+			return actual
+		}
+		require.True(t, check(t, "").FeePerByte >= chain.FeePerByte())
+		// Some pooled transactions pay nothing, so it's the Policy value.
+		require.Equal(t, chain.FeePerByte(), check(t, "0").FeePerByte)
+
+		for _, ps := range []string{`-1`, `101`, `"abc"`} {
+			rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "estimatefee", "params": [%s]}`, ps)
+			body := doRPCCall(rpc, httpSrv.URL, t)
+			checkErrGetResult(t, body, true)
+		}
 	})
 
 	t.Run("getnep17transfers", func(t *testing.T) {
@@ -1546,6 +2401,49 @@ func testRPCProtocol(t *testing.T, doRPCCall func(string, string, *testing.T) []
 		t.Run("limit 2", func(t *testing.T) { testNEP17T(t, 4, 5, 2, 0, []int{6}, []int{1}) })
 		t.Run("limit with page", func(t *testing.T) { testNEP17T(t, 1, 7, 3, 1, []int{5, 6}, []int{1}) })
 		t.Run("limit with page 2", func(t *testing.T) { testNEP17T(t, 1, 7, 3, 2, []int{7, 8}, []int{2}) })
+		t.Run("cursor", func(t *testing.T) {
+			getTransfers := func(t *testing.T, ps string, fail bool) *result.NEP17Transfers {
+				rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "getnep17transfers", "params": ["%s", %s]}`,
+					testchain.PrivateKeyByID(0).Address(), ps)
+				body := doRPCCall(rpc, httpSrv.URL, t)
+				res := checkErrGetResult(t, body, fail)
+				if fail {
+					return nil
+				}
+				actual := new(result.NEP17Transfers)
+				require.NoError(t, json.Unmarshal(res, actual))
+				return actual
+			}
+			start, err := e.chain.GetHeader(e.chain.GetHeaderHash(1))
+			require.NoError(t, err)
+			stop, err := e.chain.GetHeader(e.chain.GetHeaderHash(7))
+			require.NoError(t, err)
+			frame := fmt.Sprintf("%d, %d", start.Timestamp, stop.Timestamp)
+
+			all := getTransfers(t, frame, false)
+			require.Empty(t, all.Next)
+			var sent, rcvd []result.NEP17Transfer
+			res := getTransfers(t, frame+", 2", false)
+			for pages := 1; ; pages++ {
+				require.True(t, len(res.Sent)+len(res.Received) <= 2)
+				sent = append(sent, res.Sent...)
+				rcvd = append(rcvd, res.Received...)
+				if res.Next == "" {
+					require.Equal(t, (len(all.Sent)+len(all.Received)+1)/2, pages)
+					break
+				}
+				res = getTransfers(t, fmt.Sprintf(`%s, 2, null, "%s"`, frame, res.Next), false)
+			}
+			require.Equal(t, all.Sent, sent)
+			require.Equal(t, all.Received, rcvd)
+
+			t.Run("bad cursor", func(t *testing.T) {
+				getTransfers(t, frame+`, 2, null, "bad"`, true)
+			})
+			t.Run("cursor and page", func(t *testing.T) {
+				getTransfers(t, frame+`, 2, 1, "AJYAAAABAAAAAA"`, true)
+			})
+		})
 	})
 }
 
@@ -1909,3 +2807,98 @@ func checkNep17TransfersAux(t *testing.T, e *executor, acc interface{}, sent, rc
 	}
 	require.Equal(t, arr, res.Received)
 }
+
+func TestCanonicalResult(t *testing.T) {
+	inv := &result.Invoke{State: "HALT", GasConsumed: 1, Script: []byte{1}}
+	res, respErr := canonicalResult(inv)
+	require.Nil(t, respErr)
+	expected, err := inv.MarshalCanonicalJSON()
+	require.NoError(t, err)
+	require.Equal(t, json.RawMessage(expected), res)
+
+	res, respErr = canonicalResult(true)
+	require.Nil(t, respErr)
+	require.Equal(t, true, res)
+}
+
+func TestLightWallet(t *testing.T) {
+	watched := testchain.PrivateKeyByID(0).Address()
+	other := testchain.PrivateKeyByID(1).Address()
+
+	t.Run("disabled", func(t *testing.T) {
+		chain, rpcSrv, httpSrv := initClearServerWithInMemoryChain(t)
+		defer chain.Close()
+		defer rpcSrv.Shutdown()
+
+		for _, method := range []string{"getwatched", "addwatched", "removewatched"} {
+			req := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "%s", "params": ["%s"]}`, method, other)
+			checkErrGetResult(t, doRPCCallOverHTTP(req, httpSrv.URL, t), true)
+		}
+	})
+
+	cfg, err := config.Load("../../../config", netmode.UnitTestNet)
+	require.NoError(t, err)
+	cfg.ApplicationConfiguration.P2PNotary.Enabled = false
+	cfg.ProtocolConfiguration.LightWallet = config.LightWallet{Enabled: true, Watched: []string{watched}}
+	logger := zaptest.NewLogger(t)
+	chain, err := core.NewBlockchain(storage.NewMemoryStore(), cfg.ProtocolConfiguration, logger)
+	require.NoError(t, err)
+	go chain.Run()
+	_, rpcSrv, httpSrv := wrapUnitTestChain(t, chain, nil, cfg, logger)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	getWatched := func(t *testing.T) []string {
+		req := `{"jsonrpc": "2.0", "id": 1, "method": "getwatched", "params": []}`
+		res := checkErrGetResult(t, doRPCCallOverHTTP(req, httpSrv.URL, t), false)
+		var actual []string
+		require.NoError(t, json.Unmarshal(res, &actual))
+		return actual
+	}
+	require.Equal(t, []string{watched}, getWatched(t))
+
+	for _, method := range []string{"getnep17balances", "getnep17transfers", "getnep11balances", "getnep11transfers"} {
+		req := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "%s", "params": ["%s"]}`, method, watched)
+		checkErrGetResult(t, doRPCCallOverHTTP(req, httpSrv.URL, t), false)
+		req = fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "%s", "params": ["%s"]}`, method, other)
+		checkErrGetResult(t, doRPCCallOverHTTP(req, httpSrv.URL, t), true)
+	}
+
+	// Watch list can't be changed without authentication.
+	req := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "addwatched", "params": ["%s"]}`, other)
+	checkErrGetResult(t, doRPCCallOverHTTP(req, httpSrv.URL, t), true)
+	require.Equal(t, []string{watched}, getWatched(t))
+
+	rpcSrv.config.Auth = rpc.AuthConfig{
+		Enabled:      true,
+		BearerTokens: []string{"secret"},
+		Methods:      []string{"addwatched", "removewatched"},
+	}
+	doAuthorizedCall := func(t *testing.T, req string) []byte {
+		r, err := http.NewRequest("POST", httpSrv.URL, strings.NewReader(req))
+		require.NoError(t, err)
+		r.Header.Set("Authorization", "Bearer secret")
+		resp, err := http.DefaultClient.Do(r)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return bytes.TrimSpace(body)
+	}
+	checkErrGetResult(t, doRPCCallOverHTTP(req, httpSrv.URL, t), true)
+
+	req = `{"jsonrpc": "2.0", "id": 1, "method": "addwatched", "params": []}`
+	checkErrGetResult(t, doAuthorizedCall(t, req), true)
+	req = `{"jsonrpc": "2.0", "id": 1, "method": "addwatched", "params": ["notanaddress"]}`
+	checkErrGetResult(t, doAuthorizedCall(t, req), true)
+
+	req = fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "addwatched", "params": ["%s"]}`, other)
+	checkErrGetResult(t, doAuthorizedCall(t, req), false)
+	require.ElementsMatch(t, []string{watched, other}, getWatched(t))
+	req = fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "getnep17balances", "params": ["%s"]}`, other)
+	checkErrGetResult(t, doRPCCallOverHTTP(req, httpSrv.URL, t), false)
+
+	req = fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "removewatched", "params": ["%s"]}`, watched)
+	checkErrGetResult(t, doAuthorizedCall(t, req), false)
+	require.Equal(t, []string{other}, getWatched(t))
+}
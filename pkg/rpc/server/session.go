@@ -0,0 +1,194 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+const (
+	// Default time sessions are kept for since the last access.
+	defaultSessionExpirationTime = time.Minute
+
+	// Default maximum number of items returned by traverseiterator.
+	defaultMaxIteratorResultItems = 100
+
+	// Default maximum number of simultaneously opened sessions.
+	defaultMaxSessions = 20
+)
+
+type (
+	// session holds iterators returned by a single test invocation.
+	session struct {
+		// Iterators can't be used concurrently.
+		iteratorsLock sync.Mutex
+		iterators     map[string]iterator
+		timer         *time.Timer
+	}
+
+	// iterator is implemented by all VM iterators.
+	iterator interface {
+		Next() bool
+		Value() stackitem.Item
+	}
+)
+
+// newSessionID returns random hex-encoded session or iterator identifier.
+func newSessionID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// sessionExpirationTime returns configured session expiration time.
+func (s *Server) sessionExpirationTime() time.Duration {
+	if t := s.config.Sessions.ExpirationTime; t > 0 {
+		return t
+	}
+	return defaultSessionExpirationTime
+}
+
+// maxSessions returns configured maximum number of simultaneously opened
+// sessions.
+func (s *Server) maxSessions() int {
+	if max := s.config.Sessions.MaxSessions; max > 0 {
+		return max
+	}
+	return defaultMaxSessions
+}
+
+// registerSession creates a new session for iterators found on the resulting
+// stack of successful invocation and replaces them with references to this
+// session. Results without iterators are left untouched.
+func (s *Server) registerSession(res *result.Invoke) *response.Error {
+	if !s.config.Sessions.Enabled {
+		return nil
+	}
+	sess := &session{iterators: make(map[string]iterator)}
+	for i := range res.Stack {
+		it, ok := res.Stack[i].Value().(iterator)
+		if !ok || res.Stack[i].Type() != stackitem.InteropT {
+			continue
+		}
+		id, err := newSessionID()
+		if err != nil {
+			return response.NewInternalServerError("can't create iterator ID", err)
+		}
+		sess.iterators[id] = it
+		res.Stack[i] = stackitem.NewInterop(result.Iterator{ID: id})
+	}
+	if len(sess.iterators) == 0 {
+		return nil
+	}
+	id, err := newSessionID()
+	if err != nil {
+		return response.NewInternalServerError("can't create session ID", err)
+	}
+
+	s.sessionsLock.Lock()
+	defer s.sessionsLock.Unlock()
+	if len(s.sessions) >= s.maxSessions() {
+		return response.NewRPCError("max number of sessions reached", "", nil)
+	}
+	sess.timer = time.AfterFunc(s.sessionExpirationTime(), func() {
+		s.sessionsLock.Lock()
+		delete(s.sessions, id)
+		s.sessionsLock.Unlock()
+	})
+	s.sessions[id] = sess
+	res.Session = id
+	return nil
+}
+
+// traverseIterator returns the next items of the iterator from the given
+// session, the number of items requested can't exceed MaxIteratorResultItems
+// setting.
+func (s *Server) traverseIterator(ps request.Params) (interface{}, *response.Error) {
+	if !s.config.Sessions.Enabled {
+		return nil, response.NewRPCError("sessions are disabled", "", nil)
+	}
+	sID, err := ps.Value(0).GetString()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	iID, err := ps.Value(1).GetString()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	count, err := ps.Value(2).GetInt()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	max := s.config.Sessions.MaxIteratorResultItems
+	if max <= 0 {
+		max = defaultMaxIteratorResultItems
+	}
+	if count <= 0 || count > max {
+		return nil, response.NewInvalidParamsError("invalid number of items requested", nil)
+	}
+
+	s.sessionsLock.Lock()
+	sess, ok := s.sessions[sID]
+	if ok {
+		sess.timer.Reset(s.sessionExpirationTime())
+	}
+	s.sessionsLock.Unlock()
+	if !ok {
+		return nil, response.NewRPCError("unknown session", "", nil)
+	}
+
+	sess.iteratorsLock.Lock()
+	defer sess.iteratorsLock.Unlock()
+	it, ok := sess.iterators[iID]
+	if !ok {
+		return nil, response.NewRPCError("unknown iterator", "", nil)
+	}
+	var res = []json.RawMessage{}
+	for len(res) < count && it.Next() {
+		data, err := stackitem.ToJSONWithTypes(it.Value())
+		if err != nil {
+			return nil, response.NewInternalServerError("can't marshal iterator value", err)
+		}
+		res = append(res, data)
+	}
+	return res, nil
+}
+
+// terminateSession drops the given session, it returns false if there is no
+// such session.
+func (s *Server) terminateSession(ps request.Params) (interface{}, *response.Error) {
+	if !s.config.Sessions.Enabled {
+		return nil, response.NewRPCError("sessions are disabled", "", nil)
+	}
+	sID, err := ps.Value(0).GetString()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	s.sessionsLock.Lock()
+	defer s.sessionsLock.Unlock()
+	sess, ok := s.sessions[sID]
+	if ok {
+		sess.timer.Stop()
+		delete(s.sessions, sID)
+	}
+	return ok, nil
+}
+
+// dropSessions drops all sessions, it's used on server shutdown.
+func (s *Server) dropSessions() {
+	s.sessionsLock.Lock()
+	defer s.sessionsLock.Unlock()
+	for id, sess := range s.sessions {
+		sess.timer.Stop()
+		delete(s.sessions, id)
+	}
+}
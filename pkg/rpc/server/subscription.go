@@ -1,12 +1,17 @@
 package server
 
 import (
+	"bytes"
+
 	"github.com/gorilla/websocket"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"go.uber.org/atomic"
 )
 
@@ -25,6 +30,10 @@ type (
 	feed struct {
 		event  response.EventID
 		filter interface{}
+		// replayFrom is the index of the block past events are to be
+		// replayed from, the feed doesn't receive live events until
+		// they're replayed.
+		replayFrom *uint32
 	}
 )
 
@@ -56,30 +65,50 @@ func (f *feed) Matches(r *response.Notification) bool {
 		b := r.Payload[0].(*block.Block)
 		return int(b.PrimaryIndex) == filt.Primary
 	case response.TransactionEventID:
-		filt := f.filter.(request.TxFilter)
-		tx := r.Payload[0].(*transaction.Transaction)
-		senderOK := filt.Sender == nil || tx.Sender().Equals(*filt.Sender)
-		signerOK := true
-		if filt.Signer != nil {
-			signerOK = false
-			for i := range tx.Signers {
-				if tx.Signers[i].Account.Equals(*filt.Signer) {
-					signerOK = true
-					break
-				}
-			}
-		}
-		return senderOK && signerOK
+		return txMatches(f.filter.(request.TxFilter), r.Payload[0].(*transaction.Transaction))
+	case response.MempoolEventID:
+		return txMatches(f.filter.(request.TxFilter), r.Payload[0].(*result.MempoolEvent).Transaction)
 	case response.NotificationEventID:
 		filt := f.filter.(request.NotificationFilter)
 		notification := r.Payload[0].(*state.NotificationEvent)
 		hashOk := filt.Contract == nil || notification.ScriptHash.Equals(*filt.Contract)
 		nameOk := filt.Name == nil || notification.Name == *filt.Name
-		return hashOk && nameOk
+		fromOk := filt.From == nil || notificationParamIs(notification, 0, *filt.From)
+		toOk := filt.To == nil || notificationParamIs(notification, 1, *filt.To)
+		return hashOk && nameOk && fromOk && toOk
 	case response.ExecutionEventID:
 		filt := f.filter.(request.ExecutionFilter)
 		applog := r.Payload[0].(*state.AppExecResult)
-		return applog.VMState.String() == filt.State
+		stateOk := filt.State == "" || applog.VMState.String() == filt.State
+		containerOk := filt.Container == nil || applog.Container.Equals(*filt.Container)
+		return stateOk && containerOk
 	}
 	return false
 }
+
+// notificationParamIs checks whether notification parameter with the given
+// index is the specified script hash.
+func notificationParamIs(n *state.NotificationEvent, index int, h util.Uint160) bool {
+	arr, ok := n.Item.Value().([]stackitem.Item)
+	if !ok || len(arr) <= index {
+		return false
+	}
+	b, ok := arr[index].Value().([]byte)
+	return ok && bytes.Equal(b, h.BytesBE())
+}
+
+// txMatches checks whether given transaction passes the filter.
+func txMatches(filt request.TxFilter, tx *transaction.Transaction) bool {
+	senderOK := filt.Sender == nil || tx.Sender().Equals(*filt.Sender)
+	signerOK := true
+	if filt.Signer != nil {
+		signerOK = false
+		for i := range tx.Signers {
+			if tx.Signers[i].Account.Equals(*filt.Signer) {
+				signerOK = true
+				break
+			}
+		}
+	}
+	return senderOK && signerOK
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -10,8 +11,22 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/nspcc-dev/neo-go/internal/testchain"
 	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/blockchainer"
+	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/network"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/nspcc-dev/neo-go/pkg/services/finality"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/atomic"
 )
@@ -207,6 +222,18 @@ func TestFilteredSubscriptions(t *testing.T) {
 				require.Equal(t, "my_pretty_notification", n)
 			},
 		},
+		"notification matching transfer sender": {
+			params: `["notification_from_execution", {"name":"Transfer", "from":"` + goodSender.StringLE() + `"}]`,
+			check: func(t *testing.T, resp *response.Notification) {
+				rmap := resp.Payload[0].(map[string]interface{})
+				require.Equal(t, response.NotificationEventID, resp.Event)
+				require.Equal(t, "Transfer", rmap["name"].(string))
+				st := rmap["state"].(map[string]interface{})
+				params := st["value"].([]interface{})
+				from := params[0].(map[string]interface{})
+				require.Equal(t, base64.StdEncoding.EncodeToString(goodSender.BytesBE()), from["value"].(string))
+			},
+		},
 		"execution matching": {
 			params: `["transaction_executed", {"state":"HALT"}]`,
 			check: func(t *testing.T, resp *response.Notification) {
@@ -234,6 +261,18 @@ func TestFilteredSubscriptions(t *testing.T) {
 				t.Fatal("unexpected match for faulted execution")
 			},
 		},
+		"notification non-matching transfer receiver": {
+			params: `["notification_from_execution", {"to":"00112233445566778899aabbccddeeff00112233"}]`,
+			check: func(t *testing.T, _ *response.Notification) {
+				t.Fatal("unexpected match for receiver 00112233445566778899aabbccddeeff00112233")
+			},
+		},
+		"execution non-matching container": {
+			params: `["transaction_executed", {"container":"0000000000000000000000000000000000000000000000000000000000000000"}]`,
+			check: func(t *testing.T, _ *response.Notification) {
+				t.Fatal("unexpected match for zero container")
+			},
+		},
 	}
 
 	for name, this := range cases {
@@ -344,12 +383,286 @@ func TestMaxSubscriptions(t *testing.T) {
 	c.Close()
 }
 
+func TestMempoolSubscription(t *testing.T) {
+	chain, rpcSrv, c, respMsgs, finishedFlag := initCleanServerAndWSClient(t)
+
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	acc0 := wallet.NewAccountFromPrivateKey(testchain.PrivateKeyByID(0))
+	for _, b := range getTestBlocks(t) {
+		require.NoError(t, chain.AddBlock(b))
+	}
+
+	goodID := callSubscribe(t, c, respMsgs, `["mempool_event", {"sender":"`+acc0.Contract.ScriptHash().StringLE()+`"}]`)
+	badID := callSubscribe(t, c, respMsgs, `["mempool_event", {"sender":"00112233445566778899aabbccddeeff00112233"}]`)
+
+	height := chain.BlockHeight()
+	tx := transaction.New(testchain.Network(), []byte{byte(opcode.PUSH1)}, 0)
+	tx.ValidUntilBlock = height + 10
+	tx.Signers = []transaction.Signer{{Account: acc0.Contract.ScriptHash()}}
+	netFee, sizeDelta := fee.Calculate(chain.GetBaseExecFee(), acc0.Contract.Script)
+	tx.NetworkFee = netFee + int64(io.GetVarSize(tx)+sizeDelta)*chain.FeePerByte()
+	require.NoError(t, acc0.SignTx(tx))
+
+	checkEvent := func(typ mempool.EventType, reason mempool.RemovalReason) {
+		resp := getNotification(t, respMsgs)
+		require.Equal(t, response.MempoolEventID, resp.Event)
+		rmap := resp.Payload[0].(map[string]interface{})
+		require.Equal(t, typ.String(), rmap["type"])
+		if reason != 0 {
+			require.Equal(t, reason.String(), rmap["reason"])
+		} else {
+			require.NotContains(t, rmap, "reason")
+		}
+		txmap := rmap["transaction"].(map[string]interface{})
+		require.Equal(t, "0x"+tx.Hash().StringLE(), txmap["hash"])
+	}
+
+	require.NoError(t, chain.PoolTx(tx))
+	checkEvent(mempool.TransactionAdded, 0)
+
+	require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0, tx)))
+	checkEvent(mempool.TransactionRemoved, mempool.RemovedInBlock)
+
+	callUnsubscribe(t, c, respMsgs, goodID)
+	callUnsubscribe(t, c, respMsgs, badID)
+	finishedFlag.CAS(false, true)
+	c.Close()
+}
+
+func TestSubscriptionReplay(t *testing.T) {
+	chain, rpcSrv, c, respMsgs, finishedFlag := initCleanServerAndWSClient(t)
+
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	for _, b := range getTestBlocks(t) {
+		require.NoError(t, chain.AddBlock(b))
+	}
+	height := chain.BlockHeight()
+	from := height - 2
+
+	blockID := callSubscribe(t, c, respMsgs, fmt.Sprintf(`["block_added", null, %d]`, from))
+	for i := from; i <= height; i++ {
+		resp := getNotification(t, respMsgs)
+		require.Equal(t, response.BlockEventID, resp.Event)
+		rmap := resp.Payload[0].(map[string]interface{})
+		require.Equal(t, float64(i), rmap["index"])
+	}
+
+	execID := callSubscribe(t, c, respMsgs, fmt.Sprintf(`["transaction_executed", {"state":"HALT"}, %d]`, height))
+	b, err := chain.GetBlock(chain.GetHeaderHash(int(height)))
+	require.NoError(t, err)
+	// OnPersist, transactions and PostPersist.
+	containers := []util.Uint256{b.Hash()}
+	for _, tx := range b.Transactions {
+		aers, err := chain.GetAppExecResults(tx.Hash(), trigger.Application)
+		require.NoError(t, err)
+		if aers[0].VMState == vm.HaltState {
+			containers = append(containers, tx.Hash())
+		}
+	}
+	containers = append(containers, b.Hash())
+	for _, h := range containers {
+		resp := getNotification(t, respMsgs)
+		require.Equal(t, response.ExecutionEventID, resp.Event)
+		rmap := resp.Payload[0].(map[string]interface{})
+		require.Equal(t, "0x"+h.StringLE(), rmap["container"])
+	}
+
+	// Live events follow replayed ones.
+	require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0)))
+	for {
+		resp := getNotification(t, respMsgs)
+		if resp.Event == response.BlockEventID {
+			rmap := resp.Payload[0].(map[string]interface{})
+			require.Equal(t, float64(height+1), rmap["index"])
+			break
+		}
+		require.Equal(t, response.ExecutionEventID, resp.Event)
+	}
+
+	callUnsubscribe(t, c, respMsgs, blockID)
+	callUnsubscribe(t, c, respMsgs, execID)
+	finishedFlag.CAS(false, true)
+	c.Close()
+}
+
+// stallingChain blocks GetBlock calls until unblock channel is closed.
+type stallingChain struct {
+	blockchainer.Blockchainer
+	entered chan struct{}
+	unblock chan struct{}
+}
+
+func (c *stallingChain) GetBlock(hash util.Uint256) (*block.Block, error) {
+	select {
+	case c.entered <- struct{}{}:
+	default:
+	}
+	<-c.unblock
+	return c.Blockchainer.GetBlock(hash)
+}
+
+func TestSubscriptionReplayLiveEvents(t *testing.T) {
+	chain, orc, cfg, logger := getUnitTestChain(t, false, false)
+	defer chain.Close()
+	for _, b := range getTestBlocks(t) {
+		require.NoError(t, chain.AddBlock(b))
+	}
+	netSrv, err := network.NewServer(network.NewServerConfig(cfg), chain, logger)
+	require.NoError(t, err)
+	sc := &stallingChain{
+		Blockchainer: chain,
+		entered:      make(chan struct{}, 1),
+		unblock:      make(chan struct{}),
+	}
+	rpcSrv := New(sc, cfg.ApplicationConfiguration.RPC, netSrv, orc, logger)
+	rpcSrv.Start(make(chan error, 2))
+	defer rpcSrv.Shutdown()
+
+	height := chain.BlockHeight()
+	from := height - 2
+	liveCh := make(chan *websocket.PreparedMessage, notificationBufSize)
+	live := &subscriber{writer: liveCh}
+	live.feeds[0].event = response.BlockEventID
+	replayCh := make(chan *websocket.PreparedMessage, notificationBufSize)
+	replayed := &subscriber{writer: replayCh}
+	replayed.feeds[0].event = response.BlockEventID
+	replayed.feeds[0].replayFrom = &from
+
+	rpcSrv.subsLock.Lock()
+	for _, sub := range []*subscriber{live, replayed} {
+		rpcSrv.subscribers[sub] = true
+		rpcSrv.subscribeToChannel(response.BlockEventID)
+	}
+	rpcSrv.subsLock.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		rpcSrv.replayEvents(replayed)
+		close(done)
+	}()
+	<-sc.entered
+
+	// Block events reach other subscribers while replay is stalled.
+	require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0)))
+	select {
+	case <-liveCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no live event during replay")
+	}
+
+	close(sc.unblock)
+	<-done
+	// The new block is replayed too, without duplicates.
+	require.Equal(t, int(height-from+2), len(replayCh))
+	require.Nil(t, replayed.feeds[0].replayFrom)
+}
+
+func TestStateRootSubscription(t *testing.T) {
+	chain, rpcSrv, c, respMsgs, finishedFlag := initCleanServerAndWSClient(t)
+
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	id := callSubscribe(t, c, respMsgs, `["state_root_validated"]`)
+	require.Equal(t, 1, rpcSrv.stateRootSubs)
+
+	// There are no state validators in the test chain, so the event is
+	// emulated.
+	sr := &state.MPTRoot{
+		Index:   1,
+		Root:    util.Uint256{1, 2, 3},
+		Witness: &transaction.Witness{InvocationScript: []byte{1}, VerificationScript: []byte{2}},
+	}
+	rpcSrv.stateRootCh <- sr
+	resp := getNotification(t, respMsgs)
+	require.Equal(t, response.StateRootEventID, resp.Event)
+	rmap := resp.Payload[0].(map[string]interface{})
+	require.Equal(t, float64(sr.Index), rmap["index"])
+	require.Equal(t, "0x"+sr.Root.StringLE(), rmap["stateroot"])
+	require.NotNil(t, rmap["witness"])
+
+	callUnsubscribe(t, c, respMsgs, id)
+	require.Equal(t, 0, rpcSrv.stateRootSubs)
+	finishedFlag.CAS(false, true)
+	c.Close()
+}
+
+func TestWatchTransaction(t *testing.T) {
+	chain, rpcSrv, c, respMsgs, finishedFlag := initCleanServerAndWSClient(t)
+
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	var tx *transaction.Transaction
+	for _, b := range getTestBlocks(t) {
+		require.NoError(t, chain.AddBlock(b))
+		if tx == nil && len(b.Transactions) != 0 {
+			tx = b.Transactions[0]
+		}
+	}
+	require.NotNil(t, tx)
+	_, height, err := chain.GetTransaction(tx.Hash())
+	require.NoError(t, err)
+
+	t.Run("unknown", func(t *testing.T) {
+		resp := callWSGetRaw(t, c, `{"jsonrpc": "2.0", "method": "watchtransaction", "params": ["0x0000000000000000000000000000000000000000000000000000000000000001"], "id": 1}`, respMsgs)
+		require.NotNil(t, resp.Error)
+		require.Equal(t, response.ErrNotFound.Code, resp.Error.Code)
+	})
+	t.Run("included", func(t *testing.T) {
+		c.SetWriteDeadline(time.Now().Add(time.Second))
+		require.NoError(t, c.WriteMessage(websocket.TextMessage,
+			[]byte(fmt.Sprintf(`{"jsonrpc": "2.0", "method": "watchtransaction", "params": ["%s"], "id": 1}`, tx.Hash().StringLE()))))
+
+		// Event is generated immediately, so it can be received either
+		// before or after the response.
+		var gotResp, gotEvent bool
+		for i := 0; i < 2; i++ {
+			var rr struct {
+				Method string            `json:"method"`
+				Params []json.RawMessage `json:"params"`
+				Result json.RawMessage   `json:"result"`
+			}
+			require.NoError(t, json.Unmarshal(<-respMsgs, &rr))
+			if rr.Method == "" {
+				var b bool
+				require.NoError(t, json.Unmarshal(rr.Result, &b))
+				require.True(t, b)
+				gotResp = true
+				continue
+			}
+			require.Equal(t, "transaction_finality", rr.Method)
+			require.Equal(t, 1, len(rr.Params))
+			var ev finality.Event
+			require.NoError(t, json.Unmarshal(rr.Params[0], &ev))
+			require.Equal(t, finality.Event{Hash: tx.Hash(), Status: finality.Included, Height: height}, ev)
+			gotEvent = true
+		}
+		require.True(t, gotResp)
+		require.True(t, gotEvent)
+	})
+	t.Run("unwatch", func(t *testing.T) {
+		resp := callWSGetRaw(t, c, fmt.Sprintf(`{"jsonrpc": "2.0", "method": "unwatchtransaction", "params": ["%s"], "id": 1}`, tx.Hash().StringLE()), respMsgs)
+		require.Nil(t, resp.Error)
+		var b bool
+		require.NoError(t, json.Unmarshal(resp.Result, &b))
+		require.False(t, b)
+	})
+	finishedFlag.CAS(false, true)
+	c.Close()
+}
+
 func TestBadSubUnsub(t *testing.T) {
 	var subCases = map[string]string{
 		"no params":              `{"jsonrpc": "2.0", "method": "subscribe", "params": [], "id": 1}`,
 		"bad (non-string) event": `{"jsonrpc": "2.0", "method": "subscribe", "params": [1], "id": 1}`,
 		"bad (wrong) event":      `{"jsonrpc": "2.0", "method": "subscribe", "params": ["block_removed"], "id": 1}`,
 		"missed event":           `{"jsonrpc": "2.0", "method": "subscribe", "params": ["event_missed"], "id": 1}`,
+		"finality event":         `{"jsonrpc": "2.0", "method": "subscribe", "params": ["transaction_finality"], "id": 1}`,
 		"block invalid filter":   `{"jsonrpc": "2.0", "method": "subscribe", "params": ["block_added", 1], "id": 1}`,
 		"tx filter 1":            `{"jsonrpc": "2.0", "method": "subscribe", "params": ["transaction_added", 1], "id": 1}`,
 		"tx filter 2":            `{"jsonrpc": "2.0", "method": "subscribe", "params": ["transaction_added", {"state": "HALT"}], "id": 1}`,
@@ -357,6 +670,9 @@ func TestBadSubUnsub(t *testing.T) {
 		"notification filter 2":  `{"jsonrpc": "2.0", "method": "subscribe", "params": ["notification_from_execution", "name"], "id": 1}`,
 		"execution filter 1":     `{"jsonrpc": "2.0", "method": "subscribe", "params": ["transaction_executed", "FAULT"], "id": 1}`,
 		"execution filter 2":     `{"jsonrpc": "2.0", "method": "subscribe", "params": ["transaction_executed", {"state": "STOP"}], "id": 1}`,
+		"state root filter":      `{"jsonrpc": "2.0", "method": "subscribe", "params": ["state_root_validated", {"index": 1}], "id": 1}`,
+		"replay bad index":       `{"jsonrpc": "2.0", "method": "subscribe", "params": ["block_added", null, -1], "id": 1}`,
+		"replay mempool":         `{"jsonrpc": "2.0", "method": "subscribe", "params": ["mempool_event", null, 0], "id": 1}`,
 	}
 	var unsubCases = map[string]string{
 		"no params":         `{"jsonrpc": "2.0", "method": "unsubscribe", "params": [], "id": 1}`,
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// certReloader keeps TLS certificate loaded from the given files and allows
+// to reload it without server restart.
+type certReloader struct {
+	certFile string
+	keyFile  string
+
+	lock     sync.RWMutex
+	cert     *tls.Certificate
+	modTimes [2]time.Time
+}
+
+func newCertReloader(certFile, keyFile string) *certReloader {
+	return &certReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+	}
+}
+
+// reload loads certificate and key from files replacing the current
+// certificate. The current certificate is kept if new one can't be loaded.
+func (r *certReloader) reload() error {
+	modTimes, err := r.getModTimes()
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+	r.lock.Lock()
+	r.cert = &cert
+	r.modTimes = modTimes
+	r.lock.Unlock()
+	return nil
+}
+
+// changed checks whether certificate or key file was modified since the last
+// reload.
+func (r *certReloader) changed() bool {
+	modTimes, err := r.getModTimes()
+	if err != nil {
+		return false
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return modTimes != r.modTimes
+}
+
+func (r *certReloader) getModTimes() ([2]time.Time, error) {
+	var res [2]time.Time
+	for i, name := range []string{r.certFile, r.keyFile} {
+		fi, err := os.Stat(name)
+		if err != nil {
+			return res, err
+		}
+		res[i] = fi.ModTime()
+	}
+	return res, nil
+}
+
+// getCertificate implements tls.Config's GetCertificate callback.
+func (r *certReloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.cert, nil
+}
+
+// ReloadTLS reloads TLS certificate and key from files specified in the
+// configuration, new connections use reloaded certificate. It does nothing if
+// TLS is not enabled.
+func (s *Server) ReloadTLS() error {
+	if s.tlsCerts == nil {
+		return nil
+	}
+	err := s.tlsCerts.reload()
+	if err == nil {
+		s.log.Info("TLS certificate reloaded")
+	}
+	return err
+}
+
+// watchTLSFiles checks TLS certificate and key files for changes every
+// interval and reloads them when needed until the server is shut down.
+func (s *Server) watchTLSFiles(interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-s.shutdown:
+			return
+		case <-t.C:
+			if !s.tlsCerts.changed() {
+				continue
+			}
+			if err := s.ReloadTLS(); err != nil {
+				s.log.Warn("failed to reload TLS certificate", zap.Error(err))
+			}
+		}
+	}
+}
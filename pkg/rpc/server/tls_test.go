@@ -0,0 +1,76 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestCert generates self-signed certificate with the given serial
+// number and writes it along with its key to the given files.
+func writeTestCert(t *testing.T, certFile, keyFile string, serial int64) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+}
+
+func TestCertReloader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "neogo.rpctls")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	r := newCertReloader(certFile, keyFile)
+	require.Error(t, r.reload())
+
+	getSerial := func(t *testing.T) int64 {
+		c, err := r.getCertificate(nil)
+		require.NoError(t, err)
+		cert, err := x509.ParseCertificate(c.Certificate[0])
+		require.NoError(t, err)
+		return cert.SerialNumber.Int64()
+	}
+
+	writeTestCert(t, certFile, keyFile, 1)
+	require.NoError(t, r.reload())
+	require.Equal(t, int64(1), getSerial(t))
+	require.False(t, r.changed())
+
+	// Modification time granularity can be too coarse for this test.
+	future := time.Now().Add(time.Minute)
+	writeTestCert(t, certFile, keyFile, 2)
+	require.NoError(t, os.Chtimes(certFile, future, future))
+	require.True(t, r.changed())
+	require.NoError(t, r.reload())
+	require.Equal(t, int64(2), getSerial(t))
+	require.False(t, r.changed())
+
+	t.Run("invalid", func(t *testing.T) {
+		require.NoError(t, ioutil.WriteFile(keyFile, []byte("garbage"), 0600))
+		require.Error(t, r.reload())
+		require.Equal(t, int64(2), getSerial(t))
+	})
+}
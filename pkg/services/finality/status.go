@@ -0,0 +1,60 @@
+package finality
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// Status is a final transaction state.
+type Status byte
+
+const (
+	// Included means that transaction is included into a block.
+	Included Status = iota + 1
+	// Expired means that the chain has reached transaction's ValidUntilBlock
+	// height without including it.
+	Expired
+	// Conflicted means that some transaction conflicting with the watched one
+	// (see Conflicts attribute) is included into a block.
+	Conflicted
+)
+
+// String implements fmt.Stringer interface.
+func (s Status) String() string {
+	switch s {
+	case Included:
+		return "included"
+	case Expired:
+		return "expired"
+	case Conflicted:
+		return "conflicted"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (s Status) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (s *Status) UnmarshalJSON(b []byte) error {
+	var str string
+
+	err := json.Unmarshal(b, &str)
+	if err != nil {
+		return err
+	}
+	switch str {
+	case "included":
+		*s = Included
+	case "expired":
+		*s = Expired
+	case "conflicted":
+		*s = Conflicted
+	default:
+		return errors.New("invalid status")
+	}
+	return nil
+}
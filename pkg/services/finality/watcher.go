@@ -0,0 +1,366 @@
+package finality
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/blockchainer"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"go.uber.org/zap"
+)
+
+type (
+	// Watcher tracks transactions and notifies interested parties when they
+	// reach their final state: get included into a block, become conflicting
+	// with some included transaction or expire. Only persisted blocks are used
+	// to make these decisions, mempool state is not final and can change at
+	// any time.
+	Watcher struct {
+		chain      blockchainer.Blockchainer
+		maxWatches int
+		timeout    time.Duration
+		log        *zap.Logger
+
+		lock    sync.Mutex
+		watches map[util.Uint256][]*watch
+		count   int
+
+		callbacks chan callback
+		workers   sync.WaitGroup
+		blocksCh  chan *block.Block
+		stopCh    chan struct{}
+		done      chan struct{}
+	}
+
+	// Config is a Watcher configuration.
+	Config struct {
+		Chain blockchainer.Blockchainer
+		// MaxWatches limits the number of simultaneously active watches,
+		// DefaultMaxWatches is used if it's not set.
+		MaxWatches int
+		// CallbackTimeout is a timeout for callback URL requests,
+		// DefaultCallbackTimeout is used if it's not set.
+		CallbackTimeout time.Duration
+		// Log is used to log callback delivery failures, it can be nil.
+		Log *zap.Logger
+	}
+
+	// NotifyFunc is a callback for final transaction state notifications.
+	// It's called exactly once per watch and it must not block.
+	NotifyFunc func(Event)
+
+	// Event is a final transaction state notification.
+	Event struct {
+		Hash   util.Uint256 `json:"hash"`
+		Status Status       `json:"status"`
+		// Height is the height of the block that made this decision.
+		Height uint32 `json:"height"`
+		// ConflictedBy is the hash of included transaction that conflicts
+		// with the watched one, it's only set for Conflicted status.
+		ConflictedBy *util.Uint256 `json:"conflictedby,omitempty"`
+	}
+
+	watch struct {
+		vub    uint32
+		owner  interface{}
+		notify NotifyFunc
+	}
+
+	callback struct {
+		url string
+		ev  Event
+	}
+)
+
+const (
+	// DefaultMaxWatches is the default limit of simultaneously active
+	// watches.
+	DefaultMaxWatches = 10000
+	// DefaultCallbackTimeout is the default timeout for callback URL
+	// requests.
+	DefaultCallbackTimeout = 5 * time.Second
+
+	// callbackWorkers is the number of goroutines delivering callbacks.
+	callbackWorkers = 4
+	// callbackQueueSize is the number of callbacks waiting for delivery,
+	// callbacks exceeding it are dropped.
+	callbackQueueSize = 1024
+)
+
+var (
+	// ErrUnknownTransaction is returned from Watch when transaction's
+	// ValidUntilBlock can't be determined.
+	ErrUnknownTransaction = errors.New("unknown transaction")
+	// ErrTooManyWatches is returned from Watch when the limit of active watches
+	// is reached.
+	ErrTooManyWatches = errors.New("too many watches")
+)
+
+// New returns a new Watcher, it should be started with Run.
+func New(cfg Config) *Watcher {
+	if cfg.MaxWatches <= 0 {
+		cfg.MaxWatches = DefaultMaxWatches
+	}
+	if cfg.CallbackTimeout <= 0 {
+		cfg.CallbackTimeout = DefaultCallbackTimeout
+	}
+	return &Watcher{
+		chain:      cfg.Chain,
+		maxWatches: cfg.MaxWatches,
+		timeout:    cfg.CallbackTimeout,
+		log:        cfg.Log,
+		watches:    make(map[util.Uint256][]*watch),
+		callbacks:  make(chan callback, callbackQueueSize),
+		blocksCh:   make(chan *block.Block),
+		stopCh:     make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+}
+
+// Run runs Watcher's block processing loop and callback delivery workers, it
+// should be called in a separate goroutine.
+func (w *Watcher) Run() {
+	w.chain.SubscribeForBlocks(w.blocksCh)
+	defer close(w.done)
+	w.workers.Add(callbackWorkers)
+	for i := 0; i < callbackWorkers; i++ {
+		go w.deliverCallbacks()
+	}
+	for {
+		select {
+		case <-w.stopCh:
+			w.chain.UnsubscribeFromBlocks(w.blocksCh)
+		drainloop:
+			for {
+				select {
+				case <-w.blocksCh:
+				default:
+					break drainloop
+				}
+			}
+			w.workers.Wait()
+			return
+		case b := <-w.blocksCh:
+			w.processBlock(b)
+		}
+	}
+}
+
+// Stop stops Watcher's block processing loop. Active watches and callbacks
+// that are not yet delivered are dropped without notifications.
+func (w *Watcher) Stop() {
+	close(w.stopCh)
+	<-w.done
+}
+
+// Watch starts tracking transaction with the given hash. vub is transaction's
+// ValidUntilBlock, if it's 0 it's taken from the memory pool. If the transaction
+// is already in the chain or already expired notify is called immediately.
+// Watches with non-nil owner can be dropped with Unwatch.
+func (w *Watcher) Watch(h util.Uint256, vub uint32, owner interface{}, notify NotifyFunc) error {
+	w.lock.Lock()
+	// Checking the chain with the lock held guarantees that any block that
+	// hasn't been processed yet either is already visible here or will see
+	// this watch.
+	if _, height, err := w.chain.GetTransaction(h); err == nil {
+		w.lock.Unlock()
+		notify(Event{Hash: h, Status: Included, Height: height})
+		return nil
+	}
+	if vub == 0 {
+		tx, ok := w.chain.GetMemPool().TryGetValue(h)
+		if !ok {
+			w.lock.Unlock()
+			return ErrUnknownTransaction
+		}
+		vub = tx.ValidUntilBlock
+	}
+	if height := w.chain.BlockHeight(); height >= vub {
+		w.lock.Unlock()
+		notify(Event{Hash: h, Status: Expired, Height: height})
+		return nil
+	}
+	if w.count >= w.maxWatches {
+		w.lock.Unlock()
+		return ErrTooManyWatches
+	}
+	w.watches[h] = append(w.watches[h], &watch{vub: vub, owner: owner, notify: notify})
+	w.count++
+	w.lock.Unlock()
+	return nil
+}
+
+// Unwatch drops watches of the given owner without notifications. If h is nil
+// all of its watches are dropped, otherwise only the ones for transaction h.
+// It returns the number of watches dropped.
+func (w *Watcher) Unwatch(owner interface{}, h *util.Uint256) int {
+	if owner == nil {
+		return 0
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	var dropped int
+	for th, ws := range w.watches {
+		if h != nil && !th.Equals(*h) {
+			continue
+		}
+		var rest []*watch
+		for _, wt := range ws {
+			if wt.owner == owner {
+				dropped++
+			} else {
+				rest = append(rest, wt)
+			}
+		}
+		if len(rest) == 0 {
+			delete(w.watches, th)
+		} else {
+			w.watches[th] = rest
+		}
+	}
+	w.count -= dropped
+	return dropped
+}
+
+// Len returns the number of active watches.
+func (w *Watcher) Len() int {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.count
+}
+
+type notification struct {
+	ev     Event
+	notify NotifyFunc
+}
+
+// processBlock makes decisions on watched transactions using the given block.
+func (w *Watcher) processBlock(b *block.Block) {
+	var ns []notification
+
+	w.lock.Lock()
+	if len(w.watches) == 0 {
+		w.lock.Unlock()
+		return
+	}
+	emit := func(h util.Uint256, ev Event) {
+		for _, wt := range w.watches[h] {
+			ns = append(ns, notification{ev: ev, notify: wt.notify})
+		}
+		w.count -= len(w.watches[h])
+		delete(w.watches, h)
+	}
+	for _, tx := range b.Transactions {
+		h := tx.Hash()
+		if _, ok := w.watches[h]; ok {
+			emit(h, Event{Hash: h, Status: Included, Height: b.Index})
+		}
+		for _, attr := range tx.GetAttributes(transaction.ConflictsT) {
+			ch := attr.Value.(*transaction.Conflicts).Hash
+			if _, ok := w.watches[ch]; ok {
+				emit(ch, Event{Hash: ch, Status: Conflicted, Height: b.Index, ConflictedBy: &h})
+			}
+		}
+	}
+	for h, ws := range w.watches {
+		var rest []*watch
+		for _, wt := range ws {
+			if b.Index >= wt.vub {
+				ns = append(ns, notification{
+					ev:     Event{Hash: h, Status: Expired, Height: b.Index},
+					notify: wt.notify,
+				})
+				w.count--
+			} else {
+				rest = append(rest, wt)
+			}
+		}
+		if len(rest) == 0 {
+			delete(w.watches, h)
+		} else {
+			w.watches[h] = rest
+		}
+	}
+	w.lock.Unlock()
+
+	for _, n := range ns {
+		n.notify(n.ev)
+	}
+}
+
+// HTTPNotifier returns a NotifyFunc that POSTs JSON-encoded events to the
+// given URL. Requests are made asynchronously by a fixed number of workers
+// with the configured timeout, events are dropped if too many of them are
+// waiting for delivery, failures are logged and not retried.
+func (w *Watcher) HTTPNotifier(url string) NotifyFunc {
+	return func(ev Event) {
+		select {
+		case w.callbacks <- callback{url: url, ev: ev}:
+		default:
+			w.logCallbackError(url, ev, errors.New("callback queue is full"))
+		}
+	}
+}
+
+// deliverCallbacks sends queued callbacks until Watcher is stopped.
+func (w *Watcher) deliverCallbacks() {
+	defer w.workers.Done()
+	for {
+		select {
+		case <-w.stopCh:
+			return
+		case c := <-w.callbacks:
+			if err := postEvent(c.url, w.timeout, c.ev); err != nil {
+				w.logCallbackError(c.url, c.ev, err)
+			}
+		}
+	}
+}
+
+func (w *Watcher) logCallbackError(url string, ev Event, err error) {
+	if w.log != nil {
+		w.log.Warn("failed to deliver transaction finality event",
+			zap.String("url", url),
+			zap.String("hash", ev.Hash.StringLE()),
+			zap.Error(err))
+	}
+}
+
+// httpClient is used to deliver callbacks, it doesn't follow redirects, so
+// that only the allowed URLs are requested.
+var httpClient = &http.Client{
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+func postEvent(url string, timeout time.Duration, ev Event) error {
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+	return nil
+}
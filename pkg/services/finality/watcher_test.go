@@ -0,0 +1,132 @@
+package finality
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/internal/fakechain"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func newTx(nonce uint32, vub uint32) *transaction.Transaction {
+	tx := transaction.New(0, []byte{byte(opcode.PUSH1)}, 0)
+	tx.Nonce = nonce
+	tx.ValidUntilBlock = vub
+	tx.Signers = []transaction.Signer{{Account: util.Uint160{1, 2, 3}}}
+	tx.Scripts = []transaction.Witness{{}}
+	return tx
+}
+
+func TestWatcher(t *testing.T) {
+	bc := fakechain.NewFakeChain()
+	bc.UtilityTokenBalance = big.NewInt(100_0000_0000)
+	bc.Blockheight = 10
+	w := New(Config{Chain: bc, MaxWatches: 4})
+
+	var events []Event
+	notify := func(ev Event) { events = append(events, ev) }
+
+	t.Run("unknown", func(t *testing.T) {
+		require.True(t, errors.Is(w.Watch(util.Uint256{1}, 0, nil, notify), ErrUnknownTransaction))
+	})
+	t.Run("already included", func(t *testing.T) {
+		tx := newTx(1, 20)
+		bc.PutTx(tx)
+		require.NoError(t, w.Watch(tx.Hash(), 0, nil, notify))
+		require.Equal(t, []Event{{Hash: tx.Hash(), Status: Included, Height: 1}}, events)
+		require.Equal(t, 0, w.Len())
+		events = nil
+	})
+	t.Run("already expired", func(t *testing.T) {
+		require.NoError(t, w.Watch(util.Uint256{2}, 10, nil, notify))
+		require.Equal(t, []Event{{Hash: util.Uint256{2}, Status: Expired, Height: 10}}, events)
+		require.Equal(t, 0, w.Len())
+		events = nil
+	})
+
+	included, expired, conflicted, pending := newTx(2, 12), newTx(3, 11), newTx(4, 12), newTx(5, 12)
+	require.NoError(t, bc.GetMemPool().Add(included, bc))
+	require.NoError(t, w.Watch(included.Hash(), 0, nil, notify))
+	require.NoError(t, w.Watch(expired.Hash(), 11, nil, notify))
+	require.NoError(t, w.Watch(conflicted.Hash(), 12, nil, notify))
+	require.NoError(t, w.Watch(pending.Hash(), 12, nil, notify))
+	require.True(t, errors.Is(w.Watch(util.Uint256{3}, 12, nil, notify), ErrTooManyWatches))
+	require.Equal(t, 4, w.Len())
+
+	conflicting := newTx(6, 12)
+	conflicting.Attributes = []transaction.Attribute{{
+		Type:  transaction.ConflictsT,
+		Value: &transaction.Conflicts{Hash: conflicted.Hash()},
+	}}
+	b := block.New(0, false)
+	b.Index = 11
+	b.Transactions = []*transaction.Transaction{included, conflicting}
+	w.processBlock(b)
+
+	ch := conflicting.Hash()
+	require.ElementsMatch(t, []Event{
+		{Hash: included.Hash(), Status: Included, Height: 11},
+		{Hash: conflicted.Hash(), Status: Conflicted, Height: 11, ConflictedBy: &ch},
+		{Hash: expired.Hash(), Status: Expired, Height: 11},
+	}, events)
+	require.Equal(t, 1, w.Len())
+}
+
+func TestWatcher_Unwatch(t *testing.T) {
+	bc := fakechain.NewFakeChain()
+	w := New(Config{Chain: bc})
+	require.Equal(t, DefaultMaxWatches, w.maxWatches)
+
+	var notified bool
+	notify := func(Event) { notified = true }
+	owner1, owner2 := new(int), new(int)
+	require.NoError(t, w.Watch(util.Uint256{1}, 12, owner1, notify))
+	require.NoError(t, w.Watch(util.Uint256{2}, 12, owner1, notify))
+	require.NoError(t, w.Watch(util.Uint256{1}, 12, owner2, notify))
+	require.NoError(t, w.Watch(util.Uint256{1}, 12, nil, notify))
+
+	require.Equal(t, 0, w.Unwatch(nil, nil))
+	h := util.Uint256{1}
+	require.Equal(t, 1, w.Unwatch(owner1, &h))
+	require.Equal(t, 3, w.Len())
+	require.Equal(t, 1, w.Unwatch(owner1, nil))
+	require.Equal(t, 2, w.Len())
+	require.Equal(t, 2, len(w.watches[h]))
+	require.False(t, notified)
+}
+
+func TestHTTPNotifier(t *testing.T) {
+	evCh := make(chan Event, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		require.Equal(t, http.MethodPost, req.Method)
+		data, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		var ev Event
+		require.NoError(t, json.Unmarshal(data, &ev))
+		evCh <- ev
+	}))
+	t.Cleanup(srv.Close)
+
+	w := New(Config{Chain: fakechain.NewFakeChain(), CallbackTimeout: time.Second})
+	go w.Run()
+	t.Cleanup(w.Stop)
+
+	ev := Event{Hash: util.Uint256{1, 2, 3}, Status: Included, Height: 42}
+	w.HTTPNotifier(srv.URL)(ev)
+	select {
+	case actual := <-evCh:
+		require.Equal(t, ev, actual)
+	case <-time.After(time.Second):
+		t.Fatal("no event delivered")
+	}
+}
@@ -134,7 +134,7 @@ func (o *Oracle) CreateResponseTx(gasForResponse int64, height uint32, resp *tra
 }
 
 func (o *Oracle) testVerify(tx *transaction.Transaction) (int64, bool) {
-	v := o.Chain.GetTestVM(trigger.Verification, tx, nil)
+	v, _ := o.Chain.GetTestVM(trigger.Verification, tx, nil)
 	v.GasLimit = o.Chain.GetPolicer().GetMaxVerificationGAS()
 	v.LoadScriptWithHash(o.oracleScript, o.oracleHash, callflag.ReadOnly)
 	v.Jump(v.Context(), o.verifyOffset)
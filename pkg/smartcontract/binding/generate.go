@@ -0,0 +1,383 @@
+package binding
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// Config contains parameters for the wrapper generator.
+type Config struct {
+	Manifest *manifest.Manifest
+	// Hash is the contract hash, it's exported as Hash variable if it's
+	// not zero.
+	Hash util.Uint160
+	// Package is the name of the generated package, it's derived from the
+	// contract name if empty.
+	Package string
+	Output  io.Writer
+}
+
+type (
+	contractTmpl struct {
+		Package    string
+		Contract   string
+		StdImports []string
+		Imports    []string
+		Hash       string
+		Methods    []methodTmpl
+		Events     []eventTmpl
+	}
+
+	methodTmpl struct {
+		Name    string
+		NameABI string
+		Safe    bool
+		Params  []paramTmpl
+		Return  typeTmpl
+	}
+
+	paramTmpl struct {
+		Name string
+		Type string
+		Arg  string
+	}
+
+	eventTmpl struct {
+		Name    string
+		NameABI string
+		Fields  []fieldTmpl
+	}
+
+	fieldTmpl struct {
+		Name string
+		typeTmpl
+	}
+
+	typeTmpl struct {
+		// Type is a Go type, empty for Void.
+		Type string
+		// Convert is a function converting stack item to Type, empty if
+		// stack item is returned as is.
+		Convert string
+		// Zero is a zero value of Type.
+		Zero string
+	}
+)
+
+const srcTmpl = `// Code generated by neo-go contract generate-rpcwrapper; DO NOT EDIT.
+
+// Package {{.Package}} contains RPC wrappers for {{.Contract}} contract.
+package {{.Package}}
+
+import (
+{{range .StdImports}}	"{{.}}"
+{{end}}{{if .StdImports}}
+{{end}}{{range .Imports}}	"{{.}}"
+{{end}})
+{{if .Hash}}
+// Hash contains contract hash.
+var Hash = {{.Hash}}
+{{end}}
+// Contract is a {{.Contract}} contract wrapper.
+type Contract struct {
+	inv *client.ContractInvoker
+}
+
+// New returns a new contract wrapper using the given invoker.
+func New(inv *client.ContractInvoker) *Contract {
+	return &Contract{inv: inv}
+}
+{{range $m := .Methods}}{{if .Safe}}
+// {{.Name}} performs test invocation of ` + "`{{.NameABI}}`" + ` method.
+func (c *Contract) {{.Name}}({{template "params" .Params}}) {{if .Return.Type}}({{.Return.Type}}, error){{else}}error{{end}} {
+{{- if not .Return.Type}}
+	_, err := c.inv.Call("{{.NameABI}}"{{template "args" .Params}})
+	return err
+{{- else if not .Return.Convert}}
+	return c.inv.Call("{{.NameABI}}"{{template "args" .Params}})
+{{- else}}
+	item, err := c.inv.Call("{{.NameABI}}"{{template "args" .Params}})
+	if err != nil {
+		return {{.Return.Zero}}, err
+	}
+	return {{.Return.Convert}}(item)
+{{- end}}
+}
+{{else}}
+// {{.Name}} creates a transaction invoking ` + "`{{.NameABI}}`" + ` method, signs it
+// and sends it to the network returning its hash.
+func (c *Contract) {{.Name}}({{template "params" .Params}}) (util.Uint256, error) {
+	return c.inv.Invoke("{{.NameABI}}"{{template "args" .Params}})
+}
+
+// {{.Name}}Test performs test invocation of ` + "`{{.NameABI}}`" + ` method.
+func (c *Contract) {{.Name}}Test({{template "params" .Params}}) (*result.Invoke, error) {
+	return c.inv.TestInvoke("{{.NameABI}}"{{template "args" .Params}})
+}
+{{end}}{{end}}{{range $e := .Events}}
+// {{.Name}} represents ` + "`{{.NameABI}}`" + ` event.
+type {{.Name}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}}
+{{- end}}
+}
+
+// Parse{{.Name}} converts notification to {{.Name}}.
+func Parse{{.Name}}(ev *state.NotificationEvent) (*{{.Name}}, error) {
+	{{if .Fields}}params{{else}}_{{end}}, err := client.CheckNotification(ev, "{{.NameABI}}", {{len .Fields}})
+	if err != nil {
+		return nil, err
+	}
+	res := new({{.Name}})
+{{- range $i, $f := .Fields}}
+{{- if .Convert}}
+	res.{{.Name}}, err = {{.Convert}}(params[{{$i}}])
+	if err != nil {
+		return nil, fmt.Errorf("invalid {{.Name}}: %w", err)
+	}
+{{- else}}
+	res.{{.Name}} = params[{{$i}}]
+{{- end}}
+{{- end}}
+	return res, nil
+}
+{{end}}
+{{- define "params"}}{{range $i, $p := .}}{{if $i}}, {{end}}{{.Name}} {{.Type}}{{end}}{{end}}
+{{- define "args"}}{{range .}}, {{.Arg}}{{end}}{{end}}`
+
+var tmpl = template.Must(template.New("wrapper").Parse(srcTmpl))
+
+// Generate writes Go source code of a typed RPC wrapper for the contract
+// described by the given manifest. The wrapper has a method per ABI method
+// (except the ones starting with '_'): safe methods are test-invoked with
+// results converted to Go types, other methods create, sign and send
+// transactions (Test-suffixed variants perform test invocations). Every event
+// gets a Go structure with a parsing function.
+func Generate(cfg Config) error {
+	if cfg.Manifest == nil {
+		return errors.New("no manifest")
+	}
+	ctr := contractTmpl{
+		Package:  cfg.Package,
+		Contract: cfg.Manifest.Name,
+	}
+	if ctr.Package == "" {
+		ctr.Package = strings.ToLower(goName(cfg.Manifest.Name, false))
+	}
+	if !token.IsIdentifier(ctr.Package) || token.IsKeyword(ctr.Package) {
+		return fmt.Errorf("invalid package name: %q", ctr.Package)
+	}
+
+	imports := map[string]bool{"github.com/nspcc-dev/neo-go/pkg/rpc/client": true}
+	if !cfg.Hash.Equals(util.Uint160{}) {
+		ctr.Hash = goBytes("util.Uint160", cfg.Hash.BytesBE())
+		imports["github.com/nspcc-dev/neo-go/pkg/util"] = true
+	}
+
+	names := make(map[string]bool)
+	for _, m := range cfg.Manifest.ABI.Methods {
+		if strings.HasPrefix(m.Name, "_") {
+			continue
+		}
+		mt := methodTmpl{
+			Name:    uniqueName(names, goName(m.Name, true), len(m.Parameters)),
+			NameABI: m.Name,
+			Safe:    m.Safe,
+			Params:  goParams(m.Parameters, imports),
+		}
+		if m.Safe {
+			mt.Return = goType(m.ReturnType, imports)
+		} else {
+			names[mt.Name+"Test"] = true
+			imports["github.com/nspcc-dev/neo-go/pkg/rpc/response/result"] = true
+			imports["github.com/nspcc-dev/neo-go/pkg/util"] = true
+		}
+		ctr.Methods = append(ctr.Methods, mt)
+	}
+
+	for _, e := range cfg.Manifest.ABI.Events {
+		et := eventTmpl{
+			NameABI: e.Name,
+			Name:    uniqueName(names, goName(e.Name, true)+"Event", 1),
+		}
+		fields := make(map[string]bool)
+		for _, p := range e.Parameters {
+			ft := fieldTmpl{
+				Name:     uniqueName(fields, goName(p.Name, true), 1),
+				typeTmpl: goType(p.Type, imports),
+			}
+			if ft.Convert != "" {
+				imports["fmt"] = true
+			}
+			et.Fields = append(et.Fields, ft)
+		}
+		imports["github.com/nspcc-dev/neo-go/pkg/core/state"] = true
+		ctr.Events = append(ctr.Events, et)
+	}
+
+	for imp := range imports {
+		if strings.Contains(imp, ".") {
+			ctr.Imports = append(ctr.Imports, imp)
+		} else {
+			ctr.StdImports = append(ctr.StdImports, imp)
+		}
+	}
+	sort.Strings(ctr.StdImports)
+	sort.Strings(ctr.Imports)
+
+	buf := new(bytes.Buffer)
+	if err := tmpl.Execute(buf, ctr); err != nil {
+		return fmt.Errorf("failed to generate wrapper: %w", err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format generated wrapper: %w", err)
+	}
+	_, err = cfg.Output.Write(src)
+	return err
+}
+
+// goType returns Go type data for the given contract parameter type.
+func goType(typ smartcontract.ParamType, imports map[string]bool) typeTmpl {
+	switch typ {
+	case smartcontract.BoolType:
+		return typeTmpl{Type: "bool", Convert: "client.ItemToBool", Zero: "false"}
+	case smartcontract.IntegerType:
+		imports["math/big"] = true
+		return typeTmpl{Type: "*big.Int", Convert: "client.ItemToBigInt", Zero: "nil"}
+	case smartcontract.ByteArrayType, smartcontract.SignatureType:
+		return typeTmpl{Type: "[]byte", Convert: "client.ItemToBytes", Zero: "nil"}
+	case smartcontract.StringType:
+		return typeTmpl{Type: "string", Convert: "client.ItemToString", Zero: `""`}
+	case smartcontract.Hash160Type:
+		imports["github.com/nspcc-dev/neo-go/pkg/util"] = true
+		return typeTmpl{Type: "util.Uint160", Convert: "client.ItemToUint160", Zero: "util.Uint160{}"}
+	case smartcontract.Hash256Type:
+		imports["github.com/nspcc-dev/neo-go/pkg/util"] = true
+		return typeTmpl{Type: "util.Uint256", Convert: "client.ItemToUint256", Zero: "util.Uint256{}"}
+	case smartcontract.PublicKeyType:
+		imports["github.com/nspcc-dev/neo-go/pkg/crypto/keys"] = true
+		return typeTmpl{Type: "*keys.PublicKey", Convert: "client.ItemToPublicKey", Zero: "nil"}
+	case smartcontract.ArrayType:
+		imports["github.com/nspcc-dev/neo-go/pkg/vm/stackitem"] = true
+		return typeTmpl{Type: "[]stackitem.Item", Convert: "client.ItemToArray", Zero: "nil"}
+	case smartcontract.MapType:
+		imports["github.com/nspcc-dev/neo-go/pkg/vm/stackitem"] = true
+		return typeTmpl{Type: "*stackitem.Map", Convert: "client.ItemToMap", Zero: "nil"}
+	case smartcontract.VoidType:
+		return typeTmpl{}
+	default:
+		imports["github.com/nspcc-dev/neo-go/pkg/vm/stackitem"] = true
+		return typeTmpl{Type: "stackitem.Item", Zero: "nil"}
+	}
+}
+
+// goParams returns Go method parameters for the given contract parameters.
+func goParams(ps []manifest.Parameter, imports map[string]bool) []paramTmpl {
+	res := make([]paramTmpl, 0, len(ps))
+	// Receiver and local variable names are reserved.
+	names := map[string]bool{"c": true, "item": true, "err": true}
+	for _, p := range ps {
+		name := goName(p.Name, false)
+		if token.IsKeyword(name) {
+			name += "Arg"
+		}
+		pt := paramTmpl{Name: uniqueName(names, name, 1)}
+		pt.Arg = pt.Name
+		switch p.Type {
+		case smartcontract.BoolType:
+			pt.Type = "bool"
+		case smartcontract.IntegerType:
+			imports["math/big"] = true
+			pt.Type = "*big.Int"
+		case smartcontract.ByteArrayType, smartcontract.SignatureType:
+			pt.Type = "[]byte"
+		case smartcontract.StringType:
+			pt.Type = "string"
+		case smartcontract.Hash160Type:
+			imports["github.com/nspcc-dev/neo-go/pkg/util"] = true
+			pt.Type = "util.Uint160"
+		case smartcontract.Hash256Type:
+			imports["github.com/nspcc-dev/neo-go/pkg/util"] = true
+			pt.Type = "util.Uint256"
+		case smartcontract.PublicKeyType:
+			imports["github.com/nspcc-dev/neo-go/pkg/crypto/keys"] = true
+			pt.Type = "*keys.PublicKey"
+			pt.Arg = pt.Name + ".Bytes()"
+		case smartcontract.ArrayType:
+			pt.Type = "[]interface{}"
+		default:
+			pt.Type = "interface{}"
+		}
+		res = append(res, pt)
+	}
+	return res
+}
+
+// goName converts the given manifest name to Go identifier.
+func goName(name string, exported bool) string {
+	var (
+		res   []rune
+		upper = exported
+	)
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = exported || len(res) != 0
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		} else if len(res) == 0 {
+			r = unicode.ToLower(r)
+		}
+		res = append(res, r)
+	}
+	if len(res) == 0 || unicode.IsDigit(res[0]) {
+		prefix := []rune("v")
+		if exported {
+			prefix = []rune("V")
+		}
+		res = append(prefix, res...)
+	}
+	return string(res)
+}
+
+// uniqueName returns the given name (with suffix appended if it's already used)
+// and marks it as used.
+func uniqueName(used map[string]bool, name string, suffix int) string {
+	res := name
+	for used[res] {
+		res = fmt.Sprintf("%s%d", name, suffix)
+		suffix++
+	}
+	used[res] = true
+	return res
+}
+
+// goBytes returns Go composite literal of the given byte array type.
+func goBytes(typ string, b []byte) string {
+	var sb strings.Builder
+	sb.WriteString(typ)
+	sb.WriteString("{")
+	for i := range b {
+		if i != 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "0x%02x", b[i])
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
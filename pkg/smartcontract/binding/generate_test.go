@@ -0,0 +1,109 @@
+package binding
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	m := manifest.DefaultManifest("My-Token")
+	m.ABI.Methods = []manifest.Method{
+		{Name: "_deploy", ReturnType: smartcontract.VoidType, Parameters: []manifest.Parameter{
+			manifest.NewParameter("data", smartcontract.AnyType),
+			manifest.NewParameter("isUpdate", smartcontract.BoolType),
+		}},
+		{Name: "symbol", ReturnType: smartcontract.StringType, Safe: true},
+		{Name: "balanceOf", ReturnType: smartcontract.IntegerType, Safe: true, Parameters: []manifest.Parameter{
+			manifest.NewParameter("account", smartcontract.Hash160Type),
+		}},
+		{Name: "getOwner", ReturnType: smartcontract.PublicKeyType, Safe: true},
+		{Name: "check", ReturnType: smartcontract.VoidType, Safe: true, Parameters: []manifest.Parameter{
+			manifest.NewParameter("type", smartcontract.Hash256Type),
+			manifest.NewParameter("err", smartcontract.AnyType),
+		}},
+		{Name: "raw", ReturnType: smartcontract.AnyType, Safe: true},
+		{Name: "transfer", ReturnType: smartcontract.BoolType, Parameters: []manifest.Parameter{
+			manifest.NewParameter("from", smartcontract.Hash160Type),
+			manifest.NewParameter("to", smartcontract.Hash160Type),
+			manifest.NewParameter("amount", smartcontract.IntegerType),
+			manifest.NewParameter("data", smartcontract.AnyType),
+		}},
+		{Name: "transfer", ReturnType: smartcontract.BoolType, Parameters: []manifest.Parameter{
+			manifest.NewParameter("to", smartcontract.Hash160Type),
+			manifest.NewParameter("amount", smartcontract.IntegerType),
+		}},
+		{Name: "setOwner", ReturnType: smartcontract.VoidType, Parameters: []manifest.Parameter{
+			manifest.NewParameter("owner", smartcontract.PublicKeyType),
+			manifest.NewParameter("list", smartcontract.ArrayType),
+		}},
+	}
+	m.ABI.Events = []manifest.Event{
+		{Name: "Transfer", Parameters: []manifest.Parameter{
+			manifest.NewParameter("from", smartcontract.Hash160Type),
+			manifest.NewParameter("to", smartcontract.Hash160Type),
+			manifest.NewParameter("amount", smartcontract.IntegerType),
+		}},
+		{Name: "Empty"},
+		{Name: "raw_event", Parameters: []manifest.Parameter{
+			manifest.NewParameter("data", smartcontract.AnyType),
+		}},
+	}
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, Generate(Config{
+		Manifest: m,
+		Hash:     util.Uint160{1, 2, 3},
+		Output:   buf,
+	}))
+	src := buf.String()
+
+	f, err := parser.ParseFile(token.NewFileSet(), "wrapper.go", src, 0)
+	require.NoError(t, err, src)
+	require.Equal(t, "mytoken", f.Name.Name)
+
+	for _, s := range []string{
+		"var Hash = util.Uint160{0x01, 0x02, 0x03,",
+		"func (c *Contract) Symbol() (string, error) {",
+		"func (c *Contract) BalanceOf(account util.Uint160) (*big.Int, error) {",
+		"return client.ItemToBigInt(item)",
+		"func (c *Contract) GetOwner() (*keys.PublicKey, error) {",
+		"func (c *Contract) Check(typeArg util.Uint256, err1 interface{}) error {",
+		"func (c *Contract) Raw() (stackitem.Item, error) {",
+		"func (c *Contract) Transfer(from util.Uint160, to util.Uint160, amount *big.Int, data interface{}) (util.Uint256, error) {",
+		"func (c *Contract) TransferTest(from util.Uint160, to util.Uint160, amount *big.Int, data interface{}) (*result.Invoke, error) {",
+		"func (c *Contract) Transfer2(to util.Uint160, amount *big.Int) (util.Uint256, error) {",
+		`return c.inv.Invoke("setOwner", owner.Bytes(), list)`,
+		"type TransferEvent struct {",
+		"func ParseTransferEvent(ev *state.NotificationEvent) (*TransferEvent, error) {",
+		`_, err := client.CheckNotification(ev, "Empty", 0)`,
+		"func ParseRawEventEvent(ev *state.NotificationEvent) (*RawEventEvent, error) {",
+		"res.Data = params[0]",
+	} {
+		require.Contains(t, src, s)
+	}
+	require.NotContains(t, src, "_deploy")
+
+	t.Run("bad package", func(t *testing.T) {
+		require.Error(t, Generate(Config{Manifest: m, Package: "func", Output: buf}))
+	})
+}
+
+func TestGoName(t *testing.T) {
+	for in, out := range map[string]string{
+		"balanceOf":    "BalanceOf",
+		"total_supply": "TotalSupply",
+		"_internal":    "Internal",
+		"1st":          "V1st",
+		"":             "V",
+	} {
+		require.Equal(t, out, goName(in, true), in)
+	}
+	require.Equal(t, "myToken", goName("My-Token", false))
+}
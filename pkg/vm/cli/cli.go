@@ -321,6 +321,7 @@ func handleLoadBase64(c *ishell.Context) {
 	}
 	v.Load(b)
 	c.Printf("READY: loaded %d instructions\n", v.Context().LenInstr())
+	setManifestInContext(c, new(manifest.Manifest))
 	changePrompt(c, v)
 }
 
@@ -337,6 +338,7 @@ func handleLoadHex(c *ishell.Context) {
 	}
 	v.Load(b)
 	c.Printf("READY: loaded %d instructions\n", v.Context().LenInstr())
+	setManifestInContext(c, new(manifest.Manifest))
 	changePrompt(c, v)
 }
 
@@ -513,8 +515,13 @@ func handleOps(c *ishell.Context) {
 		return
 	}
 	v := getVMFromContext(c)
+	m := getManifestFromContext(c)
+	methods := make(map[int]string, len(m.ABI.Methods))
+	for _, md := range m.ABI.Methods {
+		methods[md.Offset] = md.Name
+	}
 	out := bytes.NewBuffer(nil)
-	v.PrintOps(out)
+	v.PrintOpsWithMethods(out, methods)
 	c.Println(out.String())
 }
 
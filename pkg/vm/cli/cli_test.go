@@ -317,6 +317,27 @@ func TestPrintOps(t *testing.T) {
 	e.checkNextLine(t, "10.*PUSHDATA1.*010203")
 }
 
+func TestPrintOpsLabels(t *testing.T) {
+	script := []byte{
+		byte(opcode.PUSH1),
+		byte(opcode.JMPIF), 3,
+		byte(opcode.PUSH2),
+		byte(opcode.RET),
+	}
+	e := newTestVMCLI(t)
+	e.runProg(t,
+		"loadhex "+hex.EncodeToString(script),
+		"ops")
+
+	e.checkNextLine(t, fmt.Sprintf("READY: loaded %d instructions", len(script)))
+	e.checkNextLine(t, "INDEX.*OPCODE.*PARAMETER")
+	e.checkNextLine(t, "0.*PUSH1")
+	e.checkNextLine(t, "1.*JMPIF.*L1, 4 \\(3/03\\)")
+	e.checkNextLine(t, "3.*PUSH2")
+	e.checkNextLine(t, "L1:")
+	e.checkNextLine(t, "4.*RET")
+}
+
 func TestLoadAbort(t *testing.T) {
 	e := newTestVMCLI(t)
 	e.runProg(t,
@@ -53,6 +53,9 @@ type Context struct {
 	RetCount int
 	// NEF represents NEF file for the current contract.
 	NEF *nef.File
+	// invTree is the invocation tree node of this context, it's only
+	// set if invocation tree tracking is enabled.
+	invTree *InvocationTree
 }
 
 // CheckReturnState represents possible states of stack after opcode.RET was processed.
@@ -10,6 +10,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
 	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/stretchr/testify/assert"
@@ -173,6 +174,22 @@ func TestEmitArray(t *testing.T) {
 		assert.EqualValues(t, opcode.PUSH0, res[35])
 	})
 
+	t.Run("hashes", func(t *testing.T) {
+		buf := io.NewBufBinWriter()
+		u160 := util.Uint160{1, 2, 3}
+		u256 := util.Uint256{4, 5, 6}
+		Array(buf.BinWriter, u160, u256)
+		require.NoError(t, buf.Err)
+
+		res := buf.Bytes()
+		assert.EqualValues(t, opcode.PUSHDATA1, res[0])
+		assert.EqualValues(t, 32, res[1])
+		assert.EqualValues(t, u256.BytesBE(), res[2:34])
+		assert.EqualValues(t, opcode.PUSHDATA1, res[34])
+		assert.EqualValues(t, 20, res[35])
+		assert.EqualValues(t, u160.BytesBE(), res[36:56])
+	})
+
 	t.Run("empty", func(t *testing.T) {
 		buf := io.NewBufBinWriter()
 		Array(buf.BinWriter)
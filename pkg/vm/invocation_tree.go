@@ -0,0 +1,45 @@
+package vm
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// InvocationTree represents a tree of contract calls made during script
+// execution, every node is a script loaded with the given call flags and
+// its children are the calls made from it.
+type InvocationTree struct {
+	Current util.Uint160      `json:"hash"`
+	Flags   callflag.CallFlag `json:"callflags"`
+	Calls   []*InvocationTree `json:"call,omitempty"`
+}
+
+// EnableInvocationTree enables invocation tree tracking, it must be called
+// before any script is loaded into the VM.
+func (v *VM) EnableInvocationTree() {
+	v.invTree = &InvocationTree{}
+}
+
+// GetInvocationTree returns the root of the invocation tree (its children
+// are the scripts loaded into the VM directly) or nil if tracking is not
+// enabled.
+func (v *VM) GetInvocationTree() *InvocationTree {
+	return v.invTree
+}
+
+// addInvocation adds a node for the new context to the invocation tree, it's
+// a child of the node of the calling context.
+func (v *VM) addInvocation(parent, ctx *Context) {
+	if v.invTree == nil {
+		return
+	}
+	tree := v.invTree
+	if parent != nil && parent.invTree != nil {
+		tree = parent.invTree
+	}
+	ctx.invTree = &InvocationTree{
+		Current: ctx.ScriptHash(),
+		Flags:   ctx.callFlag,
+	}
+	tree.Calls = append(tree.Calls, ctx.invTree)
+}
@@ -0,0 +1,64 @@
+package vm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvocationTree(t *testing.T) {
+	buf := io.NewBufBinWriter()
+	emit.Syscall(buf.BinWriter, "call")
+	emit.Opcodes(buf.BinWriter, opcode.RET)
+	callScript := buf.Bytes()
+
+	// Contract call is made from the internal function, it doesn't create
+	// a separate invocation tree node.
+	mainScript := append([]byte{byte(opcode.CALL), 3, byte(opcode.RET)}, callScript...)
+	mainHash := hash.Hash160(mainScript)
+	firstHash := util.Uint160{1, 2, 3}
+	secondHash := util.Uint160{4, 5, 6}
+
+	v := newTestVM()
+	v.SyscallHandler = func(v *VM, id uint32) error {
+		if id != interopnames.ToID([]byte("call")) {
+			return errors.New("syscall not found")
+		}
+		caller := v.GetCurrentScriptHash()
+		switch caller {
+		case mainHash:
+			v.LoadScriptWithCallingHash(caller, callScript, firstHash, callflag.ReadOnly, false, 0)
+		case firstHash:
+			v.LoadScriptWithCallingHash(caller, []byte{byte(opcode.RET)}, secondHash, callflag.NoneFlag, false, 0)
+		}
+		return nil
+	}
+	require.Nil(t, v.GetInvocationTree())
+	v.EnableInvocationTree()
+	v.LoadScriptWithFlags(mainScript, callflag.All)
+	runVM(t, v)
+
+	expected := &InvocationTree{
+		Calls: []*InvocationTree{{
+			Current: mainHash,
+			Flags:   callflag.All,
+			Calls: []*InvocationTree{{
+				Current: firstHash,
+				Flags:   callflag.ReadOnly,
+				Calls: []*InvocationTree{{
+					Current: secondHash,
+					Flags:   callflag.NoneFlag,
+				}},
+			}},
+		}},
+	}
+	require.Equal(t, expected, v.GetInvocationTree())
+}
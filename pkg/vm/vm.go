@@ -11,6 +11,8 @@ import (
 	"math"
 	"math/big"
 	"os"
+	"sort"
+	"sync/atomic"
 	"text/tabwriter"
 	"unicode/utf8"
 
@@ -39,6 +41,10 @@ func newError(ip int, op opcode.Opcode, err interface{}) *errorAtInstruct {
 	return &errorAtInstruct{ip: ip, op: op, err: err}
 }
 
+// ErrInterrupted is returned from Run when VM execution is stopped via
+// Interrupt.
+var ErrInterrupted = errors.New("execution interrupted")
+
 // StateMessage is a vm state message which could be used as additional info for example by cli.
 type StateMessage string
 
@@ -87,6 +93,13 @@ type VM struct {
 
 	// Invocations is a script invocation counter.
 	Invocations map[util.Uint160]int
+
+	// invTree is the root of the invocation tree, it's nil unless
+	// tracking is enabled with EnableInvocationTree.
+	invTree *InvocationTree
+
+	// interrupted is set to non-zero value by Interrupt.
+	interrupted uint32
 }
 
 // New returns a new VM object ready to load AVM bytecode scripts.
@@ -156,12 +169,22 @@ func (v *VM) LoadArgs(method []byte, args []stackitem.Item) {
 
 // PrintOps prints the opcodes of the current loaded program to stdout.
 func (v *VM) PrintOps(out io.Writer) {
+	v.PrintOpsWithMethods(out, nil)
+}
+
+// PrintOpsWithMethods prints the opcodes of the current loaded program to
+// stdout just like PrintOps does, but additionally splits the listing into
+// methods using the given method offset to name map (usually taken from
+// contract manifest ABI). Jump, call and try targets are labeled with method
+// names where possible and with generated Ln labels otherwise.
+func (v *VM) PrintOpsWithMethods(out io.Writer, methods map[int]string) {
 	if out == nil {
 		out = os.Stdout
 	}
 	w := tabwriter.NewWriter(out, 0, 0, 4, ' ', 0)
 	fmt.Fprintln(w, "INDEX\tOPCODE\tPARAMETER\t")
 	realctx := v.Context()
+	labels := getJumpLabels(realctx.Copy(), methods)
 	ctx := realctx.Copy()
 	ctx.ip = 0
 	ctx.nextip = 0
@@ -171,6 +194,14 @@ func (v *VM) PrintOps(out io.Writer) {
 		if ctx.ip == realctx.ip {
 			cursor = "<<"
 		}
+		if name, ok := methods[ctx.ip]; ok {
+			if ctx.ip != 0 {
+				fmt.Fprintln(w, "\t\t\t")
+			}
+			fmt.Fprintf(w, "%s:\tmethod\t\t\n", name)
+		} else if label, ok := labels[ctx.ip]; ok {
+			fmt.Fprintf(w, "%s:\t\t\t\n", label)
+		}
 		if err != nil {
 			fmt.Fprintf(w, "%d\t%s\tERROR: %s\t%s\n", ctx.ip, instr, err, cursor)
 			break
@@ -185,11 +216,11 @@ func (v *VM) PrintOps(out io.Writer) {
 				opcode.JMPEQL, opcode.JMPNEL,
 				opcode.JMPGTL, opcode.JMPGEL, opcode.JMPLEL, opcode.JMPLTL,
 				opcode.PUSHA, opcode.ENDTRY, opcode.ENDTRYL:
-				desc = getOffsetDesc(ctx, parameter)
+				desc = getOffsetDesc(ctx, parameter, labels)
 			case opcode.TRY, opcode.TRYL:
 				catchP, finallyP := getTryParams(instr, parameter)
 				desc = fmt.Sprintf("catch %s, finally %s",
-					getOffsetDesc(ctx, catchP), getOffsetDesc(ctx, finallyP))
+					getOffsetDesc(ctx, catchP, labels), getOffsetDesc(ctx, finallyP, labels))
 			case opcode.INITSSLOT:
 				desc = fmt.Sprint(parameter[0])
 			case opcode.CONVERT, opcode.ISTYPE:
@@ -226,11 +257,65 @@ func (v *VM) PrintOps(out io.Writer) {
 	w.Flush()
 }
 
-func getOffsetDesc(ctx *Context, parameter []byte) string {
+// getJumpLabels walks through the program in ctx and returns offset to label
+// map for all jump, call and try targets. Targets matching method offsets are
+// labeled with method names, others get sequential Ln labels.
+func getJumpLabels(ctx *Context, methods map[int]string) map[int]string {
+	var targets []int
+	ctx.ip = 0
+	ctx.nextip = 0
+	for ctx.nextip < len(ctx.prog) {
+		instr, parameter, err := ctx.Next()
+		if err != nil {
+			break
+		}
+		var params [][]byte
+		switch instr {
+		case opcode.JMP, opcode.JMPIF, opcode.JMPIFNOT, opcode.CALL,
+			opcode.JMPEQ, opcode.JMPNE,
+			opcode.JMPGT, opcode.JMPGE, opcode.JMPLE, opcode.JMPLT,
+			opcode.JMPL, opcode.JMPIFL, opcode.JMPIFNOTL, opcode.CALLL,
+			opcode.JMPEQL, opcode.JMPNEL,
+			opcode.JMPGTL, opcode.JMPGEL, opcode.JMPLEL, opcode.JMPLTL,
+			opcode.PUSHA, opcode.ENDTRY, opcode.ENDTRYL:
+			params = [][]byte{parameter}
+		case opcode.TRY, opcode.TRYL:
+			catchP, finallyP := getTryParams(instr, parameter)
+			params = [][]byte{catchP, finallyP}
+		}
+		for _, p := range params {
+			offset, rOffset, err := calcJumpOffset(ctx, p)
+			// Zero TRY offsets mean there is no catch/finally block.
+			if err != nil || (rOffset == 0 && (instr == opcode.TRY || instr == opcode.TRYL)) {
+				continue
+			}
+			targets = append(targets, offset)
+		}
+	}
+	sort.Ints(targets)
+
+	labels := make(map[int]string)
+	for _, offset := range targets {
+		if _, ok := labels[offset]; ok {
+			continue
+		}
+		if name, ok := methods[offset]; ok {
+			labels[offset] = name
+		} else {
+			labels[offset] = fmt.Sprintf("L%d", len(labels)+1)
+		}
+	}
+	return labels
+}
+
+func getOffsetDesc(ctx *Context, parameter []byte, labels map[int]string) string {
 	offset, rOffset, err := calcJumpOffset(ctx, parameter)
 	if err != nil {
 		return fmt.Sprintf("ERROR: %v", err)
 	}
+	if label, ok := labels[offset]; ok {
+		return fmt.Sprintf("%s, %d (%d/%x)", label, offset, rOffset, parameter)
+	}
 	return fmt.Sprintf("%d (%d/%x)", offset, rOffset, parameter)
 }
 
@@ -288,6 +373,7 @@ func (v *VM) LoadScriptWithFlags(b []byte, f callflag.CallFlag) {
 	ctx.callFlag = f
 	ctx.static = newSlot(v.refs)
 	ctx.callingScriptHash = v.GetCurrentScriptHash()
+	v.addInvocation(v.Context(), ctx)
 	v.istack.PushVal(ctx)
 }
 
@@ -310,6 +396,9 @@ func (v *VM) LoadScriptWithCallingHash(caller util.Uint160, b []byte, hash util.
 	ctx := v.Context()
 	ctx.scriptHash = hash
 	ctx.callingScriptHash = caller
+	if ctx.invTree != nil {
+		ctx.invTree.Current = hash
+	}
 	if hasReturn {
 		ctx.RetCount = 1
 	} else {
@@ -385,6 +474,10 @@ func (v *VM) Run() error {
 			// Normal exit from this loop.
 			return nil
 		case v.state == NoneState:
+			if atomic.LoadUint32(&v.interrupted) != 0 {
+				v.state = FaultState
+				return ErrInterrupted
+			}
 			if err := v.Step(); err != nil {
 				return err
 			}
@@ -400,6 +493,13 @@ func (v *VM) Run() error {
 	}
 }
 
+// Interrupt makes Run stop before executing the next instruction and return
+// ErrInterrupted leaving VM in FAULT state. It's safe to call it from another
+// goroutine, once called it affects all subsequent Run calls.
+func (v *VM) Interrupt() {
+	atomic.StoreUint32(&v.interrupted, 1)
+}
+
 // Step 1 instruction in the program.
 func (v *VM) Step() error {
 	ctx := v.Context()
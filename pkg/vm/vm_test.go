@@ -9,6 +9,7 @@ import (
 	"math/big"
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/nspcc-dev/neo-go/internal/random"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
@@ -46,6 +47,14 @@ func TestInteropHook(t *testing.T) {
 	assert.Equal(t, big.NewInt(1), v.estack.Pop().value.Value())
 }
 
+func TestVM_Interrupt(t *testing.T) {
+	v := newTestVM()
+	v.Load([]byte{byte(opcode.JMP), 0}) // endless loop
+	time.AfterFunc(10*time.Millisecond, v.Interrupt)
+	require.True(t, errors.Is(v.Run(), ErrInterrupted))
+	require.Equal(t, FaultState, v.State())
+}
+
 func TestVM_SetPriceGetter(t *testing.T) {
 	v := newTestVM()
 	prog := []byte{
@@ -138,6 +147,19 @@ func checkVMFailed(t *testing.T, vm *VM) {
 	assert.Equal(t, true, vm.HasFailed())
 }
 
+func TestPrintOpsWithMethods(t *testing.T) {
+	prog := []byte{byte(opcode.CALL), 3, byte(opcode.RET), byte(opcode.PUSH1), byte(opcode.RET)}
+	v := load(prog)
+	out := bytes.NewBuffer(nil)
+	v.PrintOpsWithMethods(out, map[int]string{0: "main", 3: "sum"})
+
+	res := out.String()
+	require.Contains(t, res, "main:")
+	require.Contains(t, res, "sum:")
+	require.Contains(t, res, "sum, 3 (3/03)")
+	require.NotContains(t, res, "L1")
+}
+
 func TestStackLimitPUSH1Good(t *testing.T) {
 	prog := make([]byte, MaxStackSize*2)
 	for i := 0; i < MaxStackSize; i++ {
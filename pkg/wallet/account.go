@@ -2,6 +2,7 @@ package wallet
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -29,7 +30,8 @@ type Account struct {
 	// NEO public address.
 	Address string `json:"address"`
 
-	// Encrypted WIF of the account also known as the key.
+	// Encrypted WIF of the account also known as the key. It's empty for
+	// watch-only accounts.
 	EncryptedWIF string `json:"key"`
 
 	// Label is a label the user had made for this account.
@@ -93,8 +95,18 @@ func NewAccount() (*Account, error) {
 	return NewAccountFromPrivateKey(priv), nil
 }
 
-// SignTx signs transaction t and updates it's Witnesses.
+// SignTx signs transaction t and updates it's Witnesses. Deployed contract
+// accounts without a key (see NewContractAccount) get an empty witness if
+// contract's `verify` method has no parameters, otherwise invocation script
+// depends on the contract and can't be created here.
 func (a *Account) SignTx(t *transaction.Transaction) error {
+	if a.privateKey == nil && a.Contract != nil && a.Contract.Deployed {
+		if len(a.Contract.Parameters) != 0 {
+			return errors.New("deployed contract account requires `verify` arguments")
+		}
+		t.Scripts = append(t.Scripts, transaction.Witness{})
+		return nil
+	}
 	if a.privateKey == nil {
 		return errors.New("account is not unlocked")
 	}
@@ -124,14 +136,47 @@ func (a *Account) SignTx(t *transaction.Transaction) error {
 	return nil
 }
 
-// GetVerificationScript returns account's verification script.
+// GetVerificationScript returns account's verification script, it's nil for
+// watch-only accounts without contract.
 func (a *Account) GetVerificationScript() []byte {
 	if a.Contract != nil {
 		return a.Contract.Script
 	}
+	if a.privateKey == nil {
+		return nil
+	}
 	return a.PrivateKey().PublicKey().GetVerificationScript()
 }
 
+// IsWatchOnly returns true if the account has no key, so it can't be used to
+// sign anything.
+func (a *Account) IsWatchOnly() bool {
+	return a.EncryptedWIF == "" && a.privateKey == nil
+}
+
+// MarshalJSON implements json.Marshaler interface. Watch-only accounts have
+// null key as required by NEP-6.
+func (a *Account) MarshalJSON() ([]byte, error) {
+	aux := struct {
+		Address      string    `json:"address"`
+		EncryptedWIF *string   `json:"key"`
+		Label        string    `json:"label"`
+		Contract     *Contract `json:"contract"`
+		Locked       bool      `json:"lock"`
+		Default      bool      `json:"isdefault"`
+	}{
+		Address:  a.Address,
+		Label:    a.Label,
+		Contract: a.Contract,
+		Locked:   a.Locked,
+		Default:  a.Default,
+	}
+	if a.EncryptedWIF != "" {
+		aux.EncryptedWIF = &a.EncryptedWIF
+	}
+	return json.Marshal(aux)
+}
+
 // Decrypt decrypts the EncryptedWIF with the given passphrase returning error
 // if anything goes wrong.
 func (a *Account) Decrypt(passphrase string) error {
@@ -237,6 +282,42 @@ func NewAccountFromPrivateKey(p *keys.PrivateKey) *Account {
 	return a
 }
 
+// NewWatchOnlyAccount creates a watch-only account for the given script hash.
+// Its verification script is not known, so it can't be used as a transaction
+// signer, but its balances can be tracked.
+func NewWatchOnlyAccount(h util.Uint160) *Account {
+	return &Account{Address: address.Uint160ToString(h)}
+}
+
+// NewContractAccount creates an account for the deployed contract with the
+// given hash and parameters of its `verify` method. Such account has empty
+// verification script (contract's `verify` method is used instead) and can be
+// used as a transaction signer.
+func NewContractAccount(h util.Uint160, params ...ContractParam) *Account {
+	return &Account{
+		Address: address.Uint160ToString(h),
+		Contract: &Contract{
+			Script:     []byte{},
+			Parameters: params,
+			Deployed:   true,
+		},
+	}
+}
+
+// NewWatchOnlyAccountFromPublicKey creates a watch-only account with standard
+// signature contract for the given public key. It can be used to create
+// transactions that are to be signed elsewhere.
+func NewWatchOnlyAccountFromPublicKey(pub *keys.PublicKey) *Account {
+	return &Account{
+		publicKey: pub.Bytes(),
+		Address:   pub.Address(),
+		Contract: &Contract{
+			Script:     pub.GetVerificationScript(),
+			Parameters: getContractParams(1),
+		},
+	}
+}
+
 func getContractParams(n int) []ContractParam {
 	params := make([]ContractParam, n)
 	for i := range params {
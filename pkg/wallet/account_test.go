@@ -6,8 +6,12 @@ import (
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/keytestcases"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -81,6 +85,55 @@ func TestContract_MarshalJSON(t *testing.T) {
 	require.Error(t, json.Unmarshal(data, &c))
 }
 
+func TestWatchOnlyAccount(t *testing.T) {
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	pub := priv.PublicKey()
+
+	acc := NewWatchOnlyAccount(pub.GetScriptHash())
+	require.True(t, acc.IsWatchOnly())
+	require.Equal(t, pub.Address(), acc.Address)
+	require.Nil(t, acc.GetVerificationScript())
+
+	acc = NewWatchOnlyAccountFromPublicKey(pub)
+	require.True(t, acc.IsWatchOnly())
+	require.Equal(t, pub.Address(), acc.Address)
+	require.Equal(t, pub.GetVerificationScript(), acc.GetVerificationScript())
+	require.Error(t, acc.SignTx(&transaction.Transaction{}))
+
+	data, err := json.Marshal(acc)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"key":null`)
+	actual := new(Account)
+	require.NoError(t, json.Unmarshal(data, actual))
+	require.True(t, actual.IsWatchOnly())
+	require.Equal(t, acc.Contract, actual.Contract)
+
+	require.False(t, NewAccountFromPrivateKey(priv).IsWatchOnly())
+}
+
+func TestContractAccount(t *testing.T) {
+	h := util.Uint160{1, 2, 3}
+	acc := NewContractAccount(h)
+	require.True(t, acc.IsWatchOnly())
+	require.True(t, acc.Contract.Deployed)
+	require.Equal(t, address.Uint160ToString(h), acc.Address)
+	require.Equal(t, []byte{}, acc.GetVerificationScript())
+
+	tx := &transaction.Transaction{}
+	require.NoError(t, acc.SignTx(tx))
+	require.Equal(t, []transaction.Witness{{}}, tx.Scripts)
+
+	acc = NewContractAccount(h, ContractParam{Name: "sig", Type: smartcontract.SignatureType})
+	require.Error(t, acc.SignTx(tx))
+
+	data, err := json.Marshal(acc)
+	require.NoError(t, err)
+	actual := new(Account)
+	require.NoError(t, json.Unmarshal(data, actual))
+	require.Equal(t, acc.Contract, actual.Contract)
+}
+
 func TestContract_ScriptHash(t *testing.T) {
 	script := []byte{0, 1, 2, 3}
 	c := &Contract{Script: script}